@@ -0,0 +1,239 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/downloader"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+const (
+	// syncInterval 守护模式下将 CertificateDownloaderMgr 中的证书同步到磁盘的间隔
+	// 证书的下载由 CertificateDownloaderMgr 按 downloadInterval 调度，同步本身只在内容变化时写盘
+	syncInterval = time.Minute
+
+	certificateFilePrefix = "wechatpay_"
+	certificateFileSuffix = ".pem"
+)
+
+// certificateExpiringError 最新的平台证书即将过期
+type certificateExpiringError struct {
+	serialNo string
+	notAfter time.Time
+}
+
+// Error 输出 certificateExpiringError
+func (e certificateExpiringError) Error() string {
+	return fmt.Sprintf(
+		"最新平台证书`%v`将于 %v 过期，剩余 %v", e.serialNo, chinatime.Format(e.notAfter),
+		time.Until(e.notAfter).Truncate(time.Minute),
+	)
+}
+
+// runDaemon 以守护模式运行：使用 CertificateDownloaderMgr 定期更新平台证书，并持续同步到输出目录，
+// 直到收到 SIGINT/SIGTERM 或 ctx 结束
+//
+// 返回值为进程退出码
+func runDaemon(ctx context.Context, client *core.Client) int {
+	mgr := downloader.NewCertificateDownloaderMgrWithInterval(ctx, downloadInterval)
+	defer mgr.Stop()
+
+	if err := mgr.RegisterDownloaderWithClient(ctx, client, mchID, mchAPIv3Key); err != nil {
+		reportError("下载证书失败：", err)
+		return errCodeRunError
+	}
+	visitor := mgr.GetCertificateVisitor(mchID)
+
+	if code := syncAndCheck(ctx, visitor); code != 0 {
+		return code
+	}
+
+	serverErr := make(chan error, 1)
+	if listenAddr != "" {
+		server := &http.Server{Addr: listenAddr, Handler: newCertificateHandler(visitor)}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				serverErr <- err
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+		log.Printf("证书服务已监听 http://%v/certificates", listenAddr)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case sig := <-signals:
+			log.Printf("收到信号 %v，退出", sig)
+			return 0
+		case <-ctx.Done():
+			return 0
+		case err := <-serverErr:
+			reportError("证书服务异常：", err)
+			return errCodeRunError
+		case <-ticker.C:
+			if code := syncAndCheck(ctx, visitor); code != 0 {
+				return code
+			}
+		}
+	}
+}
+
+// syncAndCheck 同步证书到磁盘并检查最新证书的有效期，返回非 0 值表示需要以该退出码退出
+func syncAndCheck(ctx context.Context, visitor core.CertificateVisitor) int {
+	if err := syncCertificates(ctx, visitor, time.Now()); err != nil {
+		// 写盘失败可能只是暂时的，记录后等待下次同步
+		log.Printf("同步证书失败：%v", err)
+	}
+
+	if err := checkNewestCertificate(ctx, visitor, time.Now()); err != nil {
+		log.Printf("警告：%v", err)
+		if exitOnExpiring {
+			return errCodeCertificateExpiring
+		}
+	}
+	return 0
+}
+
+// syncCertificates 将证书以 wechatpay_<序列号>.pem 的文件名原子写入输出目录，并清理已过期的证书文件
+func syncCertificates(ctx context.Context, visitor core.CertificateExporter, now time.Time) error {
+	for serialNo, certContent := range visitor.ExportAll(ctx) {
+		certificate, err := utils.LoadCertificate(certContent)
+		if err != nil || utils.IsCertificateExpired(*certificate, now) {
+			// 已过期的证书不再写入，避免与下方的清理相互抵消
+			continue
+		}
+
+		outputFilePath := certificateFilePath(serialNo)
+		content := []byte(certContent + "\n")
+		if existing, err := ioutil.ReadFile(outputFilePath); err == nil && string(existing) == string(content) {
+			continue
+		}
+
+		if err := writeFileAtomic(outputFilePath, content); err != nil {
+			return fmt.Errorf("写入证书到`%v`失败: %v", outputFilePath, err)
+		}
+		log.Printf("写入证书到`%v`成功", outputFilePath)
+	}
+
+	return pruneExpiredCertificates(now)
+}
+
+// pruneExpiredCertificates 删除输出目录中已经过期的平台证书文件
+func pruneExpiredCertificates(now time.Time) error {
+	matches, err := filepath.Glob(filepath.Join(outputPath, certificateFilePrefix+"*"+certificateFileSuffix))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range matches {
+		certificate, err := utils.LoadCertificateWithPath(path)
+		if err != nil {
+			// 不是本工具写入的证书文件，不做处理
+			continue
+		}
+		if !utils.IsCertificateExpired(*certificate, now) {
+			continue
+		}
+
+		if err = os.Remove(path); err != nil {
+			return fmt.Errorf("删除过期证书`%v`失败：%v", path, err)
+		}
+		log.Printf("删除过期证书`%v`成功", path)
+	}
+	return nil
+}
+
+// checkNewestCertificate 检查最新的平台证书是否即将在 expiryThreshold 内过期
+func checkNewestCertificate(ctx context.Context, visitor core.CertificateGetter, now time.Time) error {
+	serialNo := visitor.GetNewestSerial(ctx)
+	certificate, ok := visitor.Get(ctx, serialNo)
+	if !ok {
+		return fmt.Errorf("未找到可用的平台证书")
+	}
+
+	if certificate.NotAfter.Sub(now) < expiryThreshold {
+		return certificateExpiringError{serialNo: serialNo, notAfter: certificate.NotAfter}
+	}
+	return nil
+}
+
+// newCertificateHandler 以 JSON（证书序列号->证书PEM内容）的形式对外提供平台证书
+func newCertificateHandler(visitor core.CertificateExporter) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(visitor.ExportAll(r.Context()))
+	})
+	return mux
+}
+
+func certificateFilePath(serialNo string) string {
+	return filepath.Join(outputPath, certificateFilePrefix+serialNo+certificateFileSuffix)
+}
+
+// writeFileAtomic 先写入同目录下的临时文件再重命名，避免读取方读到写了一半的证书
+func writeFileAtomic(path string, content []byte) error {
+	dir, name := filepath.Split(path)
+	f, err := ioutil.TempFile(dir, "."+strings.TrimSuffix(name, certificateFileSuffix)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+
+	if _, err = f.Write(content); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = os.Chmod(tmpPath, 0644); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+const testAPIv3Key = "testAPIv3Key0123456789abcdefghij"
+
+var testKey *rsa.PrivateKey
+
+func init() {
+	var err error
+	if testKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+}
+
+// testCertificate 自签名的平台证书
+type testCertificate struct {
+	serialNo string
+	content  string
+	cert     *x509.Certificate
+}
+
+func newTestCertificate(t *testing.T, serial int64, notAfter time.Time) testCertificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "Tenpay.com Root CA"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &testKey.PublicKey, testKey)
+	require.NoError(t, err)
+	content := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	cert, err := utils.LoadCertificate(content)
+	require.NoError(t, err)
+	return testCertificate{serialNo: utils.GetCertificateSerialNumber(*cert), content: content, cert: cert}
+}
+
+// fakeVisitor 使用固定证书列表的 core.CertificateVisitor
+type fakeVisitor map[string]testCertificate
+
+func (v fakeVisitor) Get(_ context.Context, serialNo string) (*x509.Certificate, bool) {
+	c, ok := v[serialNo]
+	return c.cert, ok
+}
+
+func (v fakeVisitor) GetAll(_ context.Context) map[string]*x509.Certificate {
+	ret := make(map[string]*x509.Certificate)
+	for serialNo, c := range v {
+		ret[serialNo] = c.cert
+	}
+	return ret
+}
+
+func (v fakeVisitor) GetNewestSerial(_ context.Context) string {
+	var newest testCertificate
+	for _, c := range v {
+		if newest.cert == nil || c.cert.NotAfter.After(newest.cert.NotAfter) {
+			newest = c
+		}
+	}
+	return newest.serialNo
+}
+
+func (v fakeVisitor) Export(_ context.Context, serialNo string) (string, bool) {
+	c, ok := v[serialNo]
+	return c.content, ok
+}
+
+func (v fakeVisitor) ExportAll(_ context.Context) map[string]string {
+	ret := make(map[string]string)
+	for serialNo, c := range v {
+		ret[serialNo] = c.content
+	}
+	return ret
+}
+
+func newFakeVisitor(certs ...testCertificate) fakeVisitor {
+	v := make(fakeVisitor)
+	for _, c := range certs {
+		v[c.serialNo] = c
+	}
+	return v
+}
+
+// setFlags 设置守护模式使用的命令行参数，测试结束后还原
+func setFlags(t *testing.T, dir string, threshold time.Duration, exit bool) {
+	saved := []interface{}{mchID, mchAPIv3Key, outputPath, downloadInterval, listenAddr, expiryThreshold, exitOnExpiring}
+	t.Cleanup(func() {
+		mchID, mchAPIv3Key, outputPath = saved[0].(string), saved[1].(string), saved[2].(string)
+		downloadInterval, listenAddr = saved[3].(time.Duration), saved[4].(string)
+		expiryThreshold, exitOnExpiring = saved[5].(time.Duration), saved[6].(bool)
+	})
+	mchID, mchAPIv3Key, outputPath = "1900000109", testAPIv3Key, dir
+	downloadInterval, listenAddr = time.Hour, ""
+	expiryThreshold, exitOnExpiring = threshold, exit
+}
+
+func writeCertificateFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		content  string
+		dir      string
+		wantErr  bool
+	}{
+		{name: "new file", content: "certificate"},
+		{name: "replace existing file", existing: "old", content: "new"},
+		{name: "directory not exist", content: "certificate", dir: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.dir, "wechatpay_ABC.pem")
+			if tt.existing != "" {
+				writeCertificateFile(t, dir, "wechatpay_ABC.pem", tt.existing)
+			}
+
+			err := writeFileAtomic(path, []byte(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, listFiles(t, dir))
+				return
+			}
+			require.NoError(t, err)
+			content, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(content))
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+			// 不残留临时文件
+			assert.Equal(t, []string{"wechatpay_ABC.pem"}, listFiles(t, dir))
+		})
+	}
+}
+
+func TestPruneExpiredCertificates(t *testing.T) {
+	now := time.Now()
+	valid := newTestCertificate(t, 1, now.Add(24*time.Hour))
+	expired := newTestCertificate(t, 2, now.Add(-time.Hour))
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "remove expired certificate",
+			files: map[string]string{"wechatpay_1.pem": valid.content, "wechatpay_2.pem": expired.content},
+			want:  []string{"wechatpay_1.pem"},
+		},
+		{
+			name:  "keep files not written by this tool",
+			files: map[string]string{"other.pem": expired.content, "wechatpay_notes.pem": "not a certificate"},
+			want:  []string{"other.pem", "wechatpay_notes.pem"},
+		},
+		{name: "empty directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			setFlags(t, dir, 0, false)
+			for name, content := range tt.files {
+				writeCertificateFile(t, dir, name, content)
+			}
+
+			require.NoError(t, pruneExpiredCertificates(now))
+			assert.ElementsMatch(t, tt.want, listFiles(t, dir))
+		})
+	}
+}
+
+func TestSyncAndCheck(t *testing.T) {
+	now := time.Now()
+	valid := newTestCertificate(t, 1, now.Add(365*24*time.Hour))
+	expiring := newTestCertificate(t, 2, now.Add(24*time.Hour))
+	expired := newTestCertificate(t, 3, now.Add(-time.Hour))
+
+	tests := []struct {
+		name     string
+		visitor  fakeVisitor
+		existing map[string]string
+		exit     bool
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "write valid certificates",
+			visitor:  newFakeVisitor(valid, expiring),
+			wantCode: 0,
+			want:     []string{certificateFilePrefix + valid.serialNo + ".pem", certificateFilePrefix + expiring.serialNo + ".pem"},
+		},
+		{
+			name:     "skip and prune expired certificates",
+			visitor:  newFakeVisitor(valid, expired),
+			existing: map[string]string{certificateFilePrefix + expired.serialNo + ".pem": expired.content},
+			wantCode: 0,
+			want:     []string{certificateFilePrefix + valid.serialNo + ".pem"},
+		},
+		{
+			name:     "newest certificate expiring",
+			visitor:  newFakeVisitor(expiring),
+			exit:     true,
+			wantCode: errCodeCertificateExpiring,
+			want:     []string{certificateFilePrefix + expiring.serialNo + ".pem"},
+		},
+		{
+			name:     "newest certificate expiring without exit",
+			visitor:  newFakeVisitor(expiring),
+			wantCode: 0,
+			want:     []string{certificateFilePrefix + expiring.serialNo + ".pem"},
+		},
+		{
+			name:     "no certificate",
+			visitor:  newFakeVisitor(),
+			exit:     true,
+			wantCode: errCodeCertificateExpiring,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			setFlags(t, dir, 30*24*time.Hour, tt.exit)
+			for name, content := range tt.existing {
+				writeCertificateFile(t, dir, name, content)
+			}
+
+			assert.Equal(t, tt.wantCode, syncAndCheck(context.Background(), tt.visitor))
+			assert.ElementsMatch(t, tt.want, listFiles(t, dir))
+			for _, name := range tt.want {
+				content, err := ioutil.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, tt.visitor[name[len(certificateFilePrefix):len(name)-len(certificateFileSuffix)]].content+"\n",
+					string(content))
+			}
+		})
+	}
+}
+
+func TestSyncCertificates_Unchanged(t *testing.T) {
+	dir := t.TempDir()
+	setFlags(t, dir, 0, false)
+	valid := newTestCertificate(t, 1, time.Now().Add(24*time.Hour))
+	visitor := newFakeVisitor(valid)
+
+	require.NoError(t, syncCertificates(context.Background(), visitor, time.Now()))
+	path := certificateFilePath(valid.serialNo)
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	// 内容未变化时不重新写入
+	require.NoError(t, syncCertificates(context.Background(), visitor, time.Now()))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old))
+}
+
+func TestNewCertificateHandler(t *testing.T) {
+	valid := newTestCertificate(t, 1, time.Now().Add(24*time.Hour))
+	server := httptest.NewServer(newCertificateHandler(newFakeVisitor(valid)))
+	defer server.Close()
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+		wantBody   bool
+	}{
+		{http.MethodGet, "/certificates", http.StatusOK, true},
+		{http.MethodHead, "/certificates", http.StatusOK, false},
+		{http.MethodPost, "/certificates", http.StatusMethodNotAllowed, false},
+		{http.MethodGet, "/", http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, "GET, HEAD", resp.Header.Get("Allow"))
+			}
+			if !tt.wantBody {
+				return
+			}
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, map[string]string{valid.serialNo: valid.content}, body)
+		})
+	}
+}
+
+// newDownloadServer 模拟平台证书下载接口，status 非 200 时返回错误应答
+func newDownloadServer(t *testing.T, status int, certs ...testCertificate) *httptest.Server {
+	block, err := aes.NewCipher([]byte(testAPIv3Key))
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	var data []map[string]interface{}
+	for i, c := range certs {
+		nonce := fmt.Sprintf("nonce%07d", i) // AEAD_AES_256_GCM 的 nonce 为 12 字节
+		ciphertext := gcm.Seal(nil, []byte(nonce), []byte(c.content), []byte("certificate"))
+		data = append(data, map[string]interface{}{
+			"serial_no":      c.serialNo,
+			"effective_time": c.cert.NotBefore.Format(time.RFC3339),
+			"expire_time":    c.cert.NotAfter.Format(time.RFC3339),
+			"encrypt_certificate": map[string]string{
+				"algorithm":       "AEAD_AES_256_GCM",
+				"associated_data": "certificate",
+				"nonce":           nonce,
+				"ciphertext":      base64.StdEncoding.EncodeToString(ciphertext),
+			},
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"data": data})
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"code":"SYSTEM_ERROR","message":"系统错误"}`))
+			return
+		}
+		assert.Equal(t, "/v3/certificates", r.URL.Path)
+		_, _ = w.Write(body)
+	}))
+}
+
+// newRedirectClient 创建将所有请求发往 server 的 Client
+func newRedirectClient(t *testing.T, server *httptest.Server) *core.Client {
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client, err := core.NewClient(
+		context.Background(),
+		option.WithMerchantCredential("1900000109", "SERIAL", testKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRunDaemon(t *testing.T) {
+	now := time.Now()
+	valid := newTestCertificate(t, 1, now.Add(365*24*time.Hour))
+	expiring := newTestCertificate(t, 2, now.Add(24*time.Hour))
+
+	tests := []struct {
+		name     string
+		status   int
+		certs    []testCertificate
+		exit     bool
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "download failed",
+			status:   http.StatusInternalServerError,
+			wantCode: errCodeRunError,
+		},
+		{
+			name:     "sync until context done",
+			status:   http.StatusOK,
+			certs:    []testCertificate{valid},
+			wantCode: 0,
+			want:     []string{certificateFilePrefix + valid.serialNo + ".pem"},
+		},
+		{
+			name:     "exit when certificate expiring",
+			status:   http.StatusOK,
+			certs:    []testCertificate{expiring},
+			exit:     true,
+			wantCode: errCodeCertificateExpiring,
+			want:     []string{certificateFilePrefix + expiring.serialNo + ".pem"},
+		},
+		{
+			name:     "warn only when certificate expiring",
+			status:   http.StatusOK,
+			certs:    []testCertificate{expiring},
+			wantCode: 0,
+			want:     []string{certificateFilePrefix + expiring.serialNo + ".pem"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			setFlags(t, dir, 30*24*time.Hour, tt.exit)
+			server := newDownloadServer(t, tt.status, tt.certs...)
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			assert.Equal(t, tt.wantCode, runDaemon(ctx, newRedirectClient(t, server)))
+			assert.ElementsMatch(t, tt.want, listFiles(t, dir))
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/jemuri/wechatpay-go/core"
 	"github.com/jemuri/wechatpay-go/core/downloader"
@@ -24,10 +24,17 @@ var (
 
 	wechatPayCertificatePath string
 	outputPath               string
+
+	daemonMode       bool
+	downloadInterval time.Duration
+	listenAddr       string
+	expiryThreshold  time.Duration
+	exitOnExpiring   bool
 )
 
 const errCodeParamError = 1
 const errCodeRunError = 2
+const errCodeCertificateExpiring = 3
 
 func init() {
 	flag.StringVar(&mchID, "m", "", "【必传】`商户号`")
@@ -37,6 +44,16 @@ func init() {
 
 	flag.StringVar(&wechatPayCertificatePath, "c", "", "【可选】`商户平台证书路径`，用于验签。省略则跳过验签")
 	flag.StringVar(&outputPath, "o", "./", "【可选】`证书下载保存目录`")
+
+	flag.BoolVar(&daemonMode, "d", false, "【可选】以守护模式运行，定期更新证书并同步到保存目录，同时清理已过期的证书文件")
+	flag.DurationVar(
+		&downloadInterval, "i", downloader.DefaultDownloadInterval, "【可选】守护模式下的`证书更新间隔`",
+	)
+	flag.StringVar(&listenAddr, "a", "", "【可选】守护模式下提供证书查询的 HTTP `监听地址`，如 127.0.0.1:8080。省略则不启动")
+	flag.DurationVar(
+		&expiryThreshold, "e", 30*24*time.Hour, "【可选】守护模式下最新证书的`过期预警时间`，剩余有效期不足时输出告警",
+	)
+	flag.BoolVar(&exitOnExpiring, "x", false, "【可选】守护模式下最新证书即将过期时以非零退出码（3）退出")
 }
 
 func main() {
@@ -55,6 +72,10 @@ func main() {
 		os.Exit(errCodeRunError)
 	}
 
+	if daemonMode {
+		os.Exit(runDaemon(ctx, client))
+	}
+
 	d, err := downloader.NewCertificateDownloaderWithClient(ctx, client, mchAPIv3Key)
 	if err != nil {
 		reportError("下载证书失败：", err)
@@ -130,6 +151,10 @@ func checkArgs() error {
 		}
 	}
 
+	if daemonMode && downloadInterval <= 0 {
+		return paramError{"证书更新间隔", downloadInterval.String(), "必须大于 0"}
+	}
+
 	err = os.MkdirAll(outputPath, os.ModePerm)
 	if err != nil {
 		return paramError{"证书下载保存目录", outputPath, fmt.Sprintf("创建失败：%v", err)}
@@ -142,15 +167,9 @@ func checkArgs() error {
 
 func saveCertificates(ctx context.Context, d *downloader.CertificateDownloader) error {
 	for serialNo, certContent := range d.ExportAll(ctx) {
-		outputFilePath := filepath.Join(outputPath, fmt.Sprintf("wechatpay_%v.pem", serialNo))
+		outputFilePath := certificateFilePath(serialNo)
 
-		f, err := os.Create(outputFilePath)
-		if err != nil {
-			return fmt.Errorf("创建证书文件`%v`失败：%v", outputFilePath, err)
-		}
-
-		_, err = f.WriteString(certContent + "\n")
-		if err != nil {
+		if err := writeFileAtomic(outputFilePath, []byte(certContent+"\n")); err != nil {
 			return fmt.Errorf("写入证书到`%v`失败: %v", outputFilePath, err)
 		}
 
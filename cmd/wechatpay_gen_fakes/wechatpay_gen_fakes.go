@@ -0,0 +1,547 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// wechatpay_gen_fakes 为 services 下的每个 ApiService 生成接口与 Fake 实现
+//
+// 对于每个包含 ApiService 的服务包，生成：
+//   - <包目录>/interfaces.go：每个 XxxApiService 对应的 XxxAPI 接口，及编译期实现检查
+//   - <包目录>/<包名>test/fakes.go：每个 XxxAPI 接口的 FakeXxxAPI 实现
+//
+// 在 services 目录下执行 go generate 即可重新生成
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath      = "github.com/jemuri/wechatpay-go"
+	serviceSuffix   = "ApiService"
+	apiResultType   = "*core.APIResult"
+	contextType     = "context.Context"
+	fileHeader      = "// Copyright 2021 Tencent Inc. All rights reserved.\n\n// Code generated by wechatpay_gen_fakes; DO NOT EDIT.\n\n"
+	interfaceFile   = "interfaces.go"
+	fakeFile        = "fakes.go"
+	servicetestPath = modulePath + "/services/servicetest"
+)
+
+var servicesDir string
+
+func init() {
+	flag.StringVar(&servicesDir, "d", ".", "services 目录")
+}
+
+func main() {
+	flag.Parse()
+
+	var dirs []string
+	err := filepath.Walk(servicesDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		exitWith(err)
+	}
+
+	for _, dir := range dirs {
+		pkg, err := parsePackage(dir)
+		if err != nil {
+			exitWith(err)
+		}
+		if pkg == nil {
+			continue
+		}
+		if err = pkg.generate(); err != nil {
+			exitWith(err)
+		}
+	}
+}
+
+func exitWith(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// param 参数或返回值
+type param struct {
+	name      string
+	typ       string // 包内引用的类型
+	extType   string // 包外引用的类型
+	synthetic bool   // 源码中未命名，name 为生成的名称
+}
+
+type method struct {
+	name    string
+	doc     string
+	params  []param
+	results []param
+}
+
+type service struct {
+	name    string
+	methods []*method
+}
+
+type servicePackage struct {
+	dir        string
+	name       string
+	importPath string
+	imports    map[string]string // 包名 -> 导入路径
+	services   []*service
+}
+
+func parsePackage(dir string) (*servicePackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != interfaceFile
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, astPkg := range pkgs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		absServices, err := filepath.Abs(servicesDir)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(absServices, absDir)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg := &servicePackage{
+			dir:        dir,
+			name:       name,
+			importPath: path.Join(modulePath, "services", filepath.ToSlash(rel)),
+			imports:    make(map[string]string),
+		}
+		if err = pkg.collect(astPkg); err != nil {
+			return nil, err
+		}
+		if len(pkg.services) > 0 {
+			return pkg, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *servicePackage) collect(astPkg *ast.Package) error {
+	localTypes := make(map[string]bool)
+	services := make(map[string]*service)
+	for _, file := range astPkg.Files {
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					name := spec.(*ast.TypeSpec).Name.Name
+					localTypes[name] = true
+					if strings.HasSuffix(name, serviceSuffix) && ast.IsExported(name) {
+						services[name] = &service{name: name}
+					}
+				}
+			}
+		}
+	}
+
+	files := make([]string, 0, len(astPkg.Files))
+	for filename := range astPkg.Files {
+		files = append(files, filename)
+	}
+	sort.Strings(files)
+
+	for _, filename := range files {
+		file := astPkg.Files[filename]
+		fileImports := make(map[string]string)
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			fileImports[name] = importPath
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			svc, ok := services[star.X.(*ast.Ident).Name]
+			if !ok {
+				continue
+			}
+
+			r := &typeRenderer{pkg: p.name, localTypes: localTypes, fileImports: fileImports, used: p.imports}
+			m := &method{name: fn.Name.Name, doc: firstLine(fn.Doc)}
+			m.params = r.fields(fn.Type.Params, "arg")
+			m.results = r.fields(fn.Type.Results, "r")
+			if r.err != nil {
+				return fmt.Errorf("%s.%s: %v", svc.name, m.name, r.err)
+			}
+			if err := m.check(); err != nil {
+				return fmt.Errorf("%s.%s: %v", svc.name, m.name, err)
+			}
+			svc.methods = append(svc.methods, m)
+		}
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if svc := services[name]; len(svc.methods) > 0 {
+			sort.Slice(svc.methods, func(i, j int) bool { return svc.methods[i].name < svc.methods[j].name })
+			p.services = append(p.services, svc)
+		}
+	}
+	return nil
+}
+
+func firstLine(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(doc.Text(), "\n", 2)[0])
+}
+
+// check 确保接口的返回值以 error 结尾
+func (m *method) check() error {
+	if len(m.results) == 0 || m.results[len(m.results)-1].typ != "error" {
+		return fmt.Errorf("last result must be error")
+	}
+	return nil
+}
+
+func (m *method) requestParams() []param {
+	var params []param
+	for _, p := range m.params {
+		if p.typ != contextType {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// returnParams 返回 XxxReturns 的参数，*core.APIResult 由 servicetest.NewResult 生成，不需要传入
+func (m *method) returnParams() []param {
+	var params []param
+	for _, p := range m.results {
+		if p.typ != apiResultType {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+type typeRenderer struct {
+	pkg         string
+	localTypes  map[string]bool
+	fileImports map[string]string
+	used        map[string]string
+	err         error
+}
+
+func (r *typeRenderer) fields(list *ast.FieldList, prefix string) []param {
+	if list == nil {
+		return nil
+	}
+
+	var params []param
+	for _, field := range list.List {
+		typ, extType := r.render(field.Type, false), r.render(field.Type, true)
+		if len(field.Names) == 0 {
+			params = append(params, param{typ: typ, extType: extType})
+			continue
+		}
+		for _, name := range field.Names {
+			params = append(params, param{name: name.Name, typ: typ, extType: extType})
+		}
+	}
+
+	for i := range params {
+		if params[i].name == "" || params[i].name == "_" {
+			params[i].synthetic = true
+			params[i].name = fmt.Sprintf("%s%d", prefix, i)
+			if params[i].typ == "error" {
+				params[i].name = "err"
+			}
+		}
+	}
+	return params
+}
+
+// render 输出类型表达式，qualify 为 true 时为包内类型加上包名
+func (r *typeRenderer) render(expr ast.Expr, qualify bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if qualify && r.localTypes[t.Name] {
+			return r.pkg + "." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + r.render(t.X, qualify)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			r.err = fmt.Errorf("array type is not supported")
+			return ""
+		}
+		return "[]" + r.render(t.Elt, qualify)
+	case *ast.MapType:
+		return "map[" + r.render(t.Key, qualify) + "]" + r.render(t.Value, qualify)
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	case *ast.SelectorExpr:
+		name := t.X.(*ast.Ident).Name
+		importPath, ok := r.fileImports[name]
+		if !ok {
+			r.err = fmt.Errorf("unknown package %s", name)
+			return ""
+		}
+		// 统一使用导入路径的最后一段作为包名，避免不同文件中的别名冲突
+		base := path.Base(importPath)
+		r.used[base] = importPath
+		return base + "." + t.Sel.Name
+	}
+	r.err = fmt.Errorf("unsupported type %T", expr)
+	return ""
+}
+
+func interfaceName(serviceName string) string {
+	return strings.TrimSuffix(serviceName, serviceSuffix) + "API"
+}
+
+func (p *servicePackage) generate() error {
+	if err := p.writeFile(filepath.Join(p.dir, interfaceFile), p.interfaces()); err != nil {
+		return err
+	}
+	testDir := filepath.Join(p.dir, p.name+"test")
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		return err
+	}
+	return p.writeFile(filepath.Join(testDir, fakeFile), p.fakes())
+}
+
+func (p *servicePackage) writeFile(filename string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("format %s: %v\n%s", filename, err, src)
+	}
+	return ioutil.WriteFile(filename, formatted, 0o644)
+}
+
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	var std, module []string
+	for _, importPath := range imports {
+		if strings.HasPrefix(importPath, modulePath) {
+			module = append(module, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(module)
+
+	buf.WriteString("import (\n")
+	for _, importPath := range std {
+		fmt.Fprintf(buf, "\t%q\n", importPath)
+	}
+	if len(std) > 0 && len(module) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, importPath := range module {
+		fmt.Fprintf(buf, "\t%q\n", importPath)
+	}
+	buf.WriteString(")\n\n")
+}
+
+// paramList 输出带名称的参数列表，external 为 true 时使用包外引用的类型
+func paramList(list []param, external bool) string {
+	parts := make([]string, 0, len(list))
+	for _, p := range list {
+		typ := p.typ
+		if external {
+			typ = p.extType
+		}
+		parts = append(parts, p.name+" "+typ)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sourceParamList 按源码输出参数列表，源码中未命名的参数不输出名称
+func sourceParamList(list []param) string {
+	for _, p := range list {
+		if !p.synthetic {
+			return paramList(list, false)
+		}
+	}
+	types := make([]string, 0, len(list))
+	for _, p := range list {
+		types = append(types, p.typ)
+	}
+	return strings.Join(types, ", ")
+}
+
+func signature(params, results []param, external bool) string {
+	return "(" + paramList(params, external) + ") (" + paramList(results, external) + ")"
+}
+
+func funcType(params, results []param) string {
+	parts := func(list []param) string {
+		types := make([]string, 0, len(list))
+		for _, p := range list {
+			types = append(types, p.extType)
+		}
+		return strings.Join(types, ", ")
+	}
+	return "func(" + parts(params) + ") (" + parts(results) + ")"
+}
+
+func (p *servicePackage) interfaces() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader)
+	fmt.Fprintf(&buf, "package %s\n\n", p.name)
+	writeImports(&buf, p.imports)
+
+	for _, svc := range p.services {
+		name := interfaceName(svc.name)
+		fmt.Fprintf(&buf, "// %s %s 提供的接口\n//\n", name, svc.name)
+		fmt.Fprintf(&buf, "// 业务代码依赖该接口而非 *%s，即可在测试中使用 %stest.Fake%s 替代\n", svc.name, p.name, name)
+		fmt.Fprintf(&buf, "type %s interface {\n", name)
+		for i, m := range svc.methods {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if m.doc != "" {
+				fmt.Fprintf(&buf, "\t// %s\n", m.doc)
+			}
+			fmt.Fprintf(&buf, "\t%s(%s) (%s)\n", m.name, sourceParamList(m.params), sourceParamList(m.results))
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	if len(p.services) == 1 {
+		fmt.Fprintf(&buf, "var _ %s = (*%s)(nil)\n", interfaceName(p.services[0].name), p.services[0].name)
+		return buf.Bytes()
+	}
+	buf.WriteString("var (\n")
+	for _, svc := range p.services {
+		fmt.Fprintf(&buf, "\t_ %s = (*%s)(nil)\n", interfaceName(svc.name), svc.name)
+	}
+	buf.WriteString(")\n")
+	return buf.Bytes()
+}
+
+func (p *servicePackage) fakes() []byte {
+	imports := map[string]string{p.name: p.importPath, "servicetest": servicetestPath}
+	for name, importPath := range p.imports {
+		imports[name] = importPath
+	}
+	usesAPIResult := false
+	for _, svc := range p.services {
+		for _, m := range svc.methods {
+			for _, r := range m.results {
+				usesAPIResult = usesAPIResult || r.typ == apiResultType
+			}
+		}
+	}
+	if !usesAPIResult {
+		delete(imports, "core")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader)
+	fmt.Fprintf(&buf, "// Package %stest 提供 %s 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码\n", p.name, p.name)
+	fmt.Fprintf(&buf, "package %stest\n\n", p.name)
+	writeImports(&buf, imports)
+
+	for _, svc := range p.services {
+		iface := interfaceName(svc.name)
+		fake := "Fake" + iface
+
+		fmt.Fprintf(&buf, "// %s %s.%s 的 Fake 实现\n//\n", fake, p.name, iface)
+		buf.WriteString("// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；\n")
+		buf.WriteString("// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查\n")
+		fmt.Fprintf(&buf, "type %s struct {\n\tservicetest.Recorder\n\n", fake)
+		for _, m := range svc.methods {
+			fmt.Fprintf(&buf, "\t%sFunc %s\n", m.name, funcType(m.params, m.results))
+		}
+		buf.WriteString("}\n\n")
+		fmt.Fprintf(&buf, "var _ %s.%s = (*%s)(nil)\n\n", p.name, iface, fake)
+
+		for _, m := range svc.methods {
+			p.writeFakeMethod(&buf, iface, fake, m)
+		}
+	}
+	return buf.Bytes()
+}
+
+func (p *servicePackage) writeFakeMethod(buf *bytes.Buffer, iface, fake string, m *method) {
+	var request = "nil"
+	if params := m.requestParams(); len(params) == 1 {
+		request = params[0].name
+	} else if len(params) > 1 {
+		names := make([]string, 0, len(params))
+		for _, param := range params {
+			names = append(names, param.name)
+		}
+		request = "[]interface{}{" + strings.Join(names, ", ") + "}"
+	}
+	args := make([]string, 0, len(m.params))
+	for _, param := range m.params {
+		args = append(args, param.name)
+	}
+	errName := m.results[len(m.results)-1].name
+
+	fmt.Fprintf(buf, "// %s 记录调用并返回 %sFunc 的结果\n", m.name, m.name)
+	fmt.Fprintf(buf, "func (f *%s) %s%s {\n", fake, m.name, signature(m.params, m.results, true))
+	fmt.Fprintf(buf, "\tf.Record(%q, %s)\n", m.name, request)
+	fmt.Fprintf(buf, "\tif f.%sFunc == nil {\n", m.name)
+	fmt.Fprintf(buf, "\t\t%s = servicetest.NotStubbed(%q, %q)\n\t\treturn\n\t}\n", errName, iface, m.name)
+	fmt.Fprintf(buf, "\treturn f.%sFunc(%s)\n}\n\n", m.name, strings.Join(args, ", "))
+
+	returnParams := m.returnParams()
+	values := make([]string, 0, len(m.results))
+	for _, r := range m.results {
+		if r.typ == apiResultType {
+			values = append(values, "servicetest.NewResult("+errName+")")
+		} else {
+			values = append(values, r.name)
+		}
+	}
+	fmt.Fprintf(buf, "// %sReturns 设置 %s 的返回值", m.name, m.name)
+	if len(returnParams) != len(m.results) {
+		buf.WriteString("，*core.APIResult 由 servicetest.NewResult 根据 err 生成")
+	}
+	buf.WriteString("\n")
+	fmt.Fprintf(buf, "func (f *%s) %sReturns(%s) *%s {\n", fake, m.name, paramList(returnParams, true), fake)
+	fmt.Fprintf(buf, "\tf.%sFunc = %s {\n", m.name, funcType(m.params, m.results))
+	fmt.Fprintf(buf, "\t\treturn %s\n\t}\n\treturn f\n}\n\n", strings.Join(values, ", "))
+}
@@ -0,0 +1,639 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// wechatpay_gen_validators 为 services 下的请求模型生成 Validate 方法
+//
+// 对于每个包含 ApiService 的服务包，以 ApiService 方法的 req 参数类型及其引用的包内模型为对象，
+// 生成 <包目录>/validators.go，其中的 Validate 方法校验：
+//   - 必填字段：JSON 标签不含 omitempty 的字段
+//   - 枚举字段：取值为枚举类型的常量之一
+//   - 嵌套模型：递归调用 Validate
+//   - constraints.yaml 中按接口文档整理的长度、取值范围、格式、时间窗口与互斥约束
+//
+// 使用 XML 的 v2 接口模型以值类型表示字段，零值即视为未设置；其中 appid、mch_id、sign 等必填字段由服务在发送前填充，
+// 因此这类模型只校验已设置字段的约束，不校验必填。
+//
+// 在 services 目录下执行 go generate 即可重新生成
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	modulePath     = "github.com/jemuri/wechatpay-go"
+	serviceSuffix  = "ApiService"
+	requestParam   = "req"
+	fileHeader     = "// Copyright 2021 Tencent Inc. All rights reserved.\n\n// Code generated by wechatpay_gen_validators; DO NOT EDIT.\n\n"
+	validatorFile  = "validators.go"
+	validationPath = modulePath + "/core/validation"
+	chinatimePath  = modulePath + "/core/chinatime"
+	idgenPath      = modulePath + "/utils/idgen"
+)
+
+//go:embed constraints.yaml
+var constraintsYAML []byte
+
+var servicesDir string
+
+func init() {
+	flag.StringVar(&servicesDir, "d", ".", "services 目录")
+}
+
+func main() {
+	flag.Parse()
+
+	var c constraints
+	if err := yaml.Unmarshal(constraintsYAML, &c); err != nil {
+		exitWith(fmt.Errorf("parse constraints.yaml: %v", err))
+	}
+
+	var dirs []string
+	err := filepath.Walk(servicesDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		exitWith(err)
+	}
+
+	used := make(map[string]bool)
+	for _, dir := range dirs {
+		pkg, err := parsePackage(dir)
+		if err != nil {
+			exitWith(err)
+		}
+		if pkg == nil {
+			continue
+		}
+		if err = pkg.generate(&c, used); err != nil {
+			exitWith(err)
+		}
+	}
+
+	// 类型约束的键需要与生成的类型对应，避免模型改名后约束静默失效
+	for key := range c.Types {
+		if !used[key] {
+			exitWith(fmt.Errorf("constraints.yaml: type %s is not a request model", key))
+		}
+	}
+	for key := range c.Fields {
+		if strings.Contains(key, ".") && !used[key] {
+			exitWith(fmt.Errorf("constraints.yaml: field %s does not belong to a request model", key))
+		}
+	}
+}
+
+func exitWith(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// rule 单个字段的约束
+type rule struct {
+	MinBytes int    `yaml:"min_bytes"` // 字符串的最小字节数
+	MaxBytes int    `yaml:"max_bytes"` // 字符串的最大字节数
+	Pattern  string `yaml:"pattern"`   // 字符串需匹配的正则表达式
+	ID       string `yaml:"id"`        // 商户侧单号，取值为 idgen 中的 Field 变量名
+	Min      *int64 `yaml:"min"`       // 整数的最小值
+	Max      int64  `yaml:"max"`       // 整数的最大值，0 表示不限制
+	MinAhead string `yaml:"min_ahead"` // 时间与当前时间的最小间隔，如 1m
+	MaxAhead string `yaml:"max_ahead"` // 时间与当前时间的最大间隔，如 2h
+}
+
+// typeConstraints 单个请求模型的约束
+type typeConstraints struct {
+	Fields     map[string]rule `yaml:"fields"`      // 覆盖同名的通用字段约束
+	ExactlyOne [][]string      `yaml:"exactly_one"` // 有且只有一个需要设置的字段组
+	AtMostOne  [][]string      `yaml:"at_most_one"` // 至多设置一个的字段组
+}
+
+// constraints constraints.yaml 的内容
+type constraints struct {
+	Fields map[string]rule             `yaml:"fields"` // 按 JSON 字段名或 类型名.JSON 字段名 生效的约束
+	Types  map[string]*typeConstraints `yaml:"types"`  // 按 服务包路径.类型名 生效的约束
+}
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindInt
+	kindOther
+	kindEnum
+	kindStruct
+	kindTime
+)
+
+type field struct {
+	name     string // Go 字段名
+	json     string // JSON 字段名，v2 接口模型为 XML 字段名
+	required bool
+	slice    bool
+	value    bool   // 以值类型表示的 XML 字段，零值视为未设置
+	goType   string // value 为 true 时的 Go 类型
+	kind     fieldKind
+	typeName string // kindEnum 与 kindStruct 的包内类型名
+}
+
+type model struct {
+	name   string
+	fields []field
+}
+
+type servicePackage struct {
+	dir    string
+	name   string
+	rel    string // 相对于 services 目录的路径
+	models map[string]*model
+	enums  map[string][]string // 枚举类型名 -> 取值
+	roots  []string            // ApiService 方法的 req 参数类型
+}
+
+func parsePackage(dir string) (*servicePackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != validatorFile
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, astPkg := range pkgs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		absServices, err := filepath.Abs(servicesDir)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(absServices, absDir)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg := &servicePackage{
+			dir:    dir,
+			name:   name,
+			rel:    filepath.ToSlash(rel),
+			models: make(map[string]*model),
+			enums:  make(map[string][]string),
+		}
+		if err = pkg.collect(astPkg); err != nil {
+			return nil, err
+		}
+		if len(pkg.roots) > 0 {
+			return pkg, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *servicePackage) collect(astPkg *ast.Package) error {
+	files := make([]string, 0, len(astPkg.Files))
+	for filename := range astPkg.Files {
+		files = append(files, filename)
+	}
+	sort.Strings(files)
+
+	structs := make(map[string]*ast.StructType)
+	for _, filename := range files {
+		for _, decl := range astPkg.Files[filename].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					structs[ts.Name.Name] = t
+				case *ast.Ident:
+					if t.Name == "string" {
+						p.enums[ts.Name.Name] = nil
+					}
+				}
+			}
+		}
+	}
+
+	roots := make(map[string]bool)
+	for _, filename := range files {
+		for _, decl := range astPkg.Files[filename].Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Tok == token.CONST {
+					p.collectEnumValues(d)
+				}
+			case *ast.FuncDecl:
+				if name, ok := requestType(d); ok {
+					if _, isStruct := structs[name]; isStruct {
+						roots[name] = true
+					}
+				}
+			}
+		}
+	}
+	for name := range roots {
+		p.roots = append(p.roots, name)
+	}
+	sort.Strings(p.roots)
+
+	// 从 req 参数类型出发，收集其引用的包内模型
+	queue := append([]string(nil), p.roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, ok := p.models[name]; ok {
+			continue
+		}
+		m, err := p.parseModel(name, structs[name], structs)
+		if err != nil {
+			return err
+		}
+		p.models[name] = m
+		for _, f := range m.fields {
+			if f.kind == kindStruct {
+				queue = append(queue, f.typeName)
+			}
+		}
+	}
+	return nil
+}
+
+// requestType 返回 ApiService 方法的 req 参数的包内类型名，参数可以是模型或模型的指针
+func requestType(fn *ast.FuncDecl) (string, bool) {
+	if fn.Recv == nil || !fn.Name.IsExported() {
+		return "", false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return "", false
+	}
+	if recv, ok := star.X.(*ast.Ident); !ok || !strings.HasSuffix(recv.Name, serviceSuffix) {
+		return "", false
+	}
+	for _, param := range fn.Type.Params.List {
+		for _, name := range param.Names {
+			typ := param.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if ident, ok := typ.(*ast.Ident); ok && name.Name == requestParam {
+				return ident.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *servicePackage) collectEnumValues(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		vs := spec.(*ast.ValueSpec)
+		ident, ok := vs.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if _, ok := p.enums[ident.Name]; !ok {
+			continue
+		}
+		for _, value := range vs.Values {
+			if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				s, _ := strconv.Unquote(lit.Value)
+				p.enums[ident.Name] = append(p.enums[ident.Name], s)
+			}
+		}
+	}
+}
+
+func (p *servicePackage) parseModel(name string, st *ast.StructType, structs map[string]*ast.StructType) (*model, error) {
+	m := &model{name: name}
+	for _, astField := range st.Fields.List {
+		if astField.Tag == nil || len(astField.Names) != 1 {
+			continue
+		}
+		tag, _ := strconv.Unquote(astField.Tag.Value)
+		jsonTag, isJSON := reflect.StructTag(tag).Lookup("json")
+		if !isJSON {
+			jsonTag = reflect.StructTag(tag).Get("xml")
+		}
+		jsonName := strings.Split(jsonTag, ",")[0]
+		if jsonName == "" || jsonName == "-" {
+			continue
+		}
+
+		f := field{
+			name:     astField.Names[0].Name,
+			json:     jsonName,
+			required: !strings.Contains(jsonTag, ",omitempty"),
+			kind:     kindOther,
+		}
+		typ := astField.Type
+		if arr, ok := typ.(*ast.ArrayType); ok {
+			f.slice = true
+			typ = arr.Elt
+		} else if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		} else if ident, ok := typ.(*ast.Ident); ok && !isJSON && isValueType(ident.Name) {
+			f.value, f.goType, f.required = true, ident.Name, false
+		} else {
+			return nil, fmt.Errorf("%s.%s: field %s must be a pointer or slice", p.name, name, f.name)
+		}
+		if sel, ok := typ.(*ast.SelectorExpr); ok && sel.Sel.Name == "Time" {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "time" {
+				f.kind = kindTime
+			}
+		}
+		if ident, ok := typ.(*ast.Ident); ok {
+			switch {
+			case ident.Name == "string":
+				f.kind = kindString
+			case ident.Name == "int64", f.value && ident.Name == "int":
+				f.kind = kindInt
+			case p.enums[ident.Name] != nil:
+				f.kind, f.typeName = kindEnum, ident.Name
+			case structs[ident.Name] != nil:
+				f.kind, f.typeName = kindStruct, ident.Name
+			}
+		}
+		m.fields = append(m.fields, f)
+	}
+	return m, nil
+}
+
+// isValueType 判断 XML 模型字段的类型是否可以校验
+func isValueType(name string) bool {
+	return name == "string" || name == "int" || name == "int64"
+}
+
+// ref 返回传给 Collector 的字段指针表达式，值类型字段的零值对应 nil
+func (f field) ref() string {
+	switch {
+	case !f.value:
+		return "o." + f.name
+	case f.goType == "string":
+		return "validation.String(o." + f.name + ")"
+	case f.goType == "int64":
+		return "validation.Int64(o." + f.name + ")"
+	default:
+		return "validation.Int64(int64(o." + f.name + "))"
+	}
+}
+
+// isSet 返回判断字段已设置的表达式
+func (f field) isSet() string {
+	switch {
+	case !f.value:
+		return "o." + f.name + " != nil"
+	case f.goType == "string":
+		return "o." + f.name + ` != ""`
+	default:
+		return "o." + f.name + " != 0"
+	}
+}
+
+func (p *servicePackage) generate(c *constraints, used map[string]bool) error {
+	names := make([]string, 0, len(p.models))
+	for name := range p.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body bytes.Buffer
+	imports := map[string]string{"validation": validationPath}
+	for _, name := range names {
+		key := p.rel + "." + name
+		tc := c.Types[key]
+		if tc != nil {
+			used[key] = true
+		}
+		if err := p.writeValidate(&body, p.models[name], c.Fields, tc, imports, used); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader)
+	fmt.Fprintf(&buf, "package %s\n\n", p.name)
+	writeImports(&buf, imports)
+	buf.Write(body.Bytes())
+
+	filename := filepath.Join(p.dir, validatorFile)
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %v\n%s", filename, err, buf.Bytes())
+	}
+	return ioutil.WriteFile(filename, formatted, 0o644)
+}
+
+func (p *servicePackage) writeValidate(
+	buf *bytes.Buffer, m *model, common map[string]rule, tc *typeConstraints, imports map[string]string,
+	used map[string]bool,
+) error {
+	byJSON := make(map[string]field, len(m.fields))
+	for _, f := range m.fields {
+		byJSON[f.json] = f
+	}
+	rules := make(map[string]rule, len(common))
+	for name, r := range common {
+		if !strings.Contains(name, ".") {
+			rules[name] = r
+		}
+	}
+	// 类型名.字段名 形式的约束对所有服务包中含该字段的同名模型生效，优先于通用约束
+	for name, r := range common {
+		typeName, jsonName, ok := strings.Cut(name, ".")
+		if _, exists := byJSON[jsonName]; ok && exists && typeName == m.name {
+			rules[jsonName] = r
+			used[name] = true
+		}
+	}
+	if tc != nil {
+		for name, r := range tc.Fields {
+			if _, ok := byJSON[name]; !ok {
+				return fmt.Errorf("unknown field %s", name)
+			}
+			rules[name] = r
+		}
+	}
+
+	fmt.Fprintf(buf, "// Validate 按接口文档中的约束校验 %s 的字段\n", m.name)
+	fmt.Fprintf(buf, "func (o %s) Validate(opts ...validation.Option) error {\n", m.name)
+	fmt.Fprintf(buf, "\tc := validation.NewCollector(%q, opts...)\n", m.name)
+	for _, f := range m.fields {
+		if f.required {
+			fmt.Fprintf(buf, "\tc.Required(%q, o.%s != nil)\n", f.json, f.name)
+		}
+		if err := writeFieldChecks(buf, f, rules, p.enums, tc != nil, imports); err != nil {
+			return err
+		}
+	}
+	if tc != nil {
+		groups := []struct {
+			method string
+			fields [][]string
+		}{{"ExactlyOne", tc.ExactlyOne}, {"AtMostOne", tc.AtMostOne}}
+		for _, g := range groups {
+			for _, names := range g.fields {
+				quoted := make([]string, 0, len(names))
+				set := make([]string, 0, len(names))
+				for _, name := range names {
+					f, ok := byJSON[name]
+					if !ok {
+						return fmt.Errorf("unknown field %s", name)
+					}
+					quoted = append(quoted, strconv.Quote(name))
+					set = append(set, f.isSet())
+				}
+				fmt.Fprintf(buf, "\tc.%s([]string{%s}, %s)\n", g.method, strings.Join(quoted, ", "), strings.Join(set, ", "))
+			}
+		}
+	}
+	buf.WriteString("\treturn c.Err()\n}\n\n")
+	return nil
+}
+
+// writeFieldChecks 输出字段的约束与枚举校验，strict 为 true 时约束与字段类型不符将返回错误
+func writeFieldChecks(
+	buf *bytes.Buffer, f field, rules map[string]rule, enums map[string][]string, strict bool, imports map[string]string,
+) error {
+	switch f.kind {
+	case kindEnum:
+		quoted := make([]string, 0, len(enums[f.typeName]))
+		for _, v := range enums[f.typeName] {
+			quoted = append(quoted, strconv.Quote(v))
+		}
+		if f.slice {
+			fmt.Fprintf(buf, "\tfor i := range o.%s {\n", f.name)
+			fmt.Fprintf(buf, "\t\tc.Enum(validation.Index(%q, i), (*string)(&o.%s[i]), %s)\n\t}\n", f.json, f.name, strings.Join(quoted, ", "))
+		} else {
+			fmt.Fprintf(buf, "\tc.Enum(%q, (*string)(o.%s), %s)\n", f.json, f.name, strings.Join(quoted, ", "))
+		}
+		return nil
+	case kindStruct:
+		if f.slice {
+			fmt.Fprintf(buf, "\tfor i, item := range o.%s {\n", f.name)
+			fmt.Fprintf(buf, "\t\tc.Nested(validation.Index(%q, i), item.Validate(opts...))\n\t}\n", f.json)
+		} else {
+			fmt.Fprintf(buf, "\tif o.%s != nil {\n\t\tc.Nested(%q, o.%s.Validate(opts...))\n\t}\n", f.name, f.json, f.name)
+		}
+		return nil
+	}
+
+	r, ok := rules[f.json]
+	if !ok {
+		return nil
+	}
+	isString := r.MinBytes > 0 || r.MaxBytes > 0 || r.Pattern != "" || r.ID != ""
+	isInt := r.Min != nil || r.Max > 0
+	isTime := r.MinAhead != "" || r.MaxAhead != ""
+	if f.slice || (isString && f.kind != kindString) || (isInt && f.kind != kindInt) || (isTime && f.kind != kindTime) {
+		if strict {
+			return fmt.Errorf("constraint of field %s does not match its type", f.json)
+		}
+		return nil
+	}
+
+	if r.MinBytes > 0 || r.MaxBytes > 0 {
+		fmt.Fprintf(buf, "\tc.Length(%q, %s, %d, %d)\n", f.json, f.ref(), r.MinBytes, r.MaxBytes)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("pattern of field %s: %v", f.json, err)
+		}
+		fmt.Fprintf(buf, "\tc.Pattern(%q, %s, %q)\n", f.json, f.ref(), r.Pattern)
+	}
+	if r.ID != "" {
+		fmt.Fprintf(buf, "\tc.ID(%q, %s, idgen.%s)\n", f.json, f.ref(), r.ID)
+		imports["idgen"] = idgenPath
+	}
+	if isInt {
+		min := int64(0)
+		if r.Min != nil {
+			min = *r.Min
+		}
+		fmt.Fprintf(buf, "\tc.Range(%q, %s, %d, %d)\n", f.json, f.ref(), min, r.Max)
+	}
+	if isTime {
+		var window []string
+		for _, bound := range []struct{ name, value string }{{"Min", r.MinAhead}, {"Max", r.MaxAhead}} {
+			if bound.value == "" {
+				continue
+			}
+			d, err := time.ParseDuration(bound.value)
+			if err != nil {
+				return fmt.Errorf("window of field %s: %v", f.json, err)
+			}
+			window = append(window, bound.name+": "+durationExpr(d))
+		}
+		fmt.Fprintf(buf, "\tc.Window(%q, %s, chinatime.Window{%s})\n", f.json, f.ref(), strings.Join(window, ", "))
+		imports["chinatime"] = chinatimePath
+		imports["time"] = "time"
+	}
+	return nil
+}
+
+// durationExpr 返回时长的 Go 表达式，如 2 * time.Hour
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		name string
+		unit time.Duration
+	}{{"time.Hour", time.Hour}, {"time.Minute", time.Minute}, {"time.Second", time.Second}}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			if d == u.unit {
+				return u.name
+			}
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	var std, module []string
+	for _, importPath := range imports {
+		if strings.HasPrefix(importPath, modulePath) {
+			module = append(module, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(module)
+
+	buf.WriteString("import (\n")
+	for _, importPath := range std {
+		fmt.Fprintf(buf, "\t%q\n", importPath)
+	}
+	if len(std) > 0 && len(module) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, importPath := range module {
+		fmt.Fprintf(buf, "\t%q\n", importPath)
+	}
+	buf.WriteString(")\n\n")
+}
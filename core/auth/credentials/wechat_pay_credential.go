@@ -6,9 +6,9 @@ package credentials
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/clock"
 	"github.com/jemuri/wechatpay-go/core/consts"
 	"github.com/jemuri/wechatpay-go/utils"
 )
@@ -16,6 +16,7 @@ import (
 // WechatPayCredentials 微信支付请求报文头 Authorization 信息生成器
 type WechatPayCredentials struct {
 	Signer auth.Signer // 数字签名生成器
+	Clock  clock.Clock // 生成时间戳所用的时钟，为 nil 时使用本机系统时钟
 }
 
 // GenerateAuthorizationHeader 生成请求报文头中的 Authorization 信息，详见：
@@ -30,7 +31,7 @@ func (c *WechatPayCredentials) GenerateAuthorizationHeader(
 	if err != nil {
 		return "", err
 	}
-	timestamp := time.Now().Unix()
+	timestamp := clock.OrSystem(c.Clock).Now().Unix()
 	message := fmt.Sprintf(consts.SignatureMessageFormat, method, canonicalURL, timestamp, nonce, signBody)
 	signatureResult, err := c.Signer.Sign(ctx, message)
 	if err != nil {
@@ -0,0 +1,240 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package keyring 微信支付 API v3 Go SDK 可热更新的商户私钥
+//
+// 商户轮换 API 证书时，KeyRing 可以在不重建 core.Client 的情况下原子地切换商户私钥与证书序列号。
+// 切换后的一段重叠期内，旧私钥仍可用于解密（微信支付可能仍在使用旧证书加密应答或通知中的敏感信息）。
+package keyring
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/utils"
+	"github.com/jemuri/wechatpay-go/utils/task"
+)
+
+// DefaultOverlap 默认的新旧私钥重叠期
+const DefaultOverlap = 24 * time.Hour
+
+// Key 商户私钥及其对应的商户证书序列号
+type Key struct {
+	MchID               string          // 商户号
+	CertificateSerialNo string          // 商户证书序列号
+	PrivateKey          *rsa.PrivateKey // 商户私钥
+}
+
+// Validate 校验 Key 是否完整
+func (k *Key) Validate() error {
+	if k == nil {
+		return fmt.Errorf("key is nil")
+	}
+	if strings.TrimSpace(k.MchID) == "" {
+		return fmt.Errorf("mchid is required in key")
+	}
+	if strings.TrimSpace(k.CertificateSerialNo) == "" {
+		return fmt.Errorf("certificate serial no is required in key")
+	}
+	if k.PrivateKey == nil {
+		return fmt.Errorf("private key is required in key")
+	}
+	return nil
+}
+
+func (k *Key) equal(other *Key) bool {
+	return k.MchID == other.MchID && k.CertificateSerialNo == other.CertificateSerialNo &&
+		k.PrivateKey.Equal(other.PrivateKey)
+}
+
+// Loader 商户私钥加载器
+type Loader interface {
+	Load(ctx context.Context) (*Key, error)
+}
+
+// LoaderFunc 使用函数实现 Loader，可用于从配置中心、KMS 等来源加载私钥
+type LoaderFunc func(ctx context.Context) (*Key, error)
+
+// Load 加载商户私钥
+func (f LoaderFunc) Load(ctx context.Context) (*Key, error) {
+	return f(ctx)
+}
+
+// NewFileLoader 创建一个从文件加载商户私钥的 Loader，证书序列号从商户证书文件中读取
+func NewFileLoader(mchID, privateKeyPath, certificatePath string) Loader {
+	return LoaderFunc(func(context.Context) (*Key, error) {
+		privateKey, err := utils.LoadPrivateKeyWithPath(privateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load private key from %s err:%w", privateKeyPath, err)
+		}
+		certificate, err := utils.LoadCertificateWithPath(certificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("load certificate from %s err:%w", certificatePath, err)
+		}
+		if !privateKey.PublicKey.Equal(certificate.PublicKey) {
+			return nil, fmt.Errorf("private key %s does not match certificate %s", privateKeyPath, certificatePath)
+		}
+
+		return &Key{
+			MchID:               mchID,
+			CertificateSerialNo: utils.GetCertificateSerialNumber(*certificate),
+			PrivateKey:          privateKey,
+		}, nil
+	})
+}
+
+// keyState KeyRing 的不可变状态，整体原子替换
+type keyState struct {
+	current           *Key
+	previous          *Key
+	previousExpiresAt time.Time
+}
+
+// KeyRing 可热更新的商户私钥环，可以在多个 goroutine 中并发使用
+//
+// KeyRing 启动自动更新后不会被 GoGC 自动回收，不再使用时应调用 Stop 方法
+type KeyRing struct {
+	state   atomic.Value // *keyState
+	loader  Loader
+	overlap time.Duration
+	clock   clock.Clock
+
+	reloadLock sync.Mutex
+	task       *task.RepeatedTask
+}
+
+// Current 获取当前用于签名的商户私钥
+func (r *KeyRing) Current() *Key {
+	return r.loadState().current
+}
+
+// Keys 获取当前仍然有效的全部商户私钥，当前私钥在前，处于重叠期内的旧私钥在后
+func (r *KeyRing) Keys() []*Key {
+	state := r.loadState()
+	if state.previous == nil || !r.clock.Now().Before(state.previousExpiresAt) {
+		return []*Key{state.current}
+	}
+	return []*Key{state.current, state.previous}
+}
+
+// Get 获取证书序列号对应的商户私钥，重叠期内旧的证书序列号仍然可以获取
+func (r *KeyRing) Get(certificateSerialNo string) (*Key, bool) {
+	for _, key := range r.Keys() {
+		if key.CertificateSerialNo == certificateSerialNo {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// Set 原子地切换为新的商户私钥，原私钥将在重叠期内继续用于解密
+//
+// 新私钥与当前私钥相同时不做任何处理；新私钥的商户号与当前私钥不同时返回错误，KeyRing 只用于同一商户的证书轮换
+func (r *KeyRing) Set(key *Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	state := r.loadState()
+	if key.MchID != state.current.MchID {
+		return fmt.Errorf("key mchid [%s] differs from current mchid [%s]", key.MchID, state.current.MchID)
+	}
+	if state.current.equal(key) {
+		return nil
+	}
+
+	r.state.Store(&keyState{
+		current:           key,
+		previous:          state.current,
+		previousExpiresAt: r.clock.Now().Add(r.overlap),
+	})
+	return nil
+}
+
+// Reload 使用 Loader 重新加载商户私钥，私钥发生变化时切换，校验规则与 Set 相同
+func (r *KeyRing) Reload(ctx context.Context) error {
+	if r.loader == nil {
+		return fmt.Errorf("KeyRing has no loader")
+	}
+
+	key, err := r.loader.Load(ctx)
+	if err != nil {
+		return err
+	}
+	return r.Set(key)
+}
+
+// StartAutoReload 以 interval 为间隔自动调用 Reload，加载失败时调用 onError（可以为 nil）并保留当前私钥
+func (r *KeyRing) StartAutoReload(interval time.Duration, onError func(error)) {
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	if r.task != nil {
+		return
+	}
+
+	r.task = task.NewRepeatedTask(interval, func(time.Time) {
+		if err := r.Reload(context.Background()); err != nil && onError != nil {
+			onError(err)
+		}
+	})
+	r.task.Start()
+}
+
+// Stop 停止自动更新
+func (r *KeyRing) Stop() {
+	r.reloadLock.Lock()
+	t := r.task
+	r.task = nil
+	r.reloadLock.Unlock()
+
+	if t != nil {
+		t.Stop()
+	}
+}
+
+func (r *KeyRing) loadState() *keyState {
+	return r.state.Load().(*keyState)
+}
+
+// NewKeyRing 使用初始私钥创建 KeyRing
+//
+// loader 用于 Reload，可以为 nil；overlap 为切换后旧私钥仍可用于解密的时长，<= 0 时使用 DefaultOverlap
+func NewKeyRing(initial *Key, loader Loader, overlap time.Duration) (*KeyRing, error) {
+	return NewKeyRingWithClock(initial, loader, overlap, clock.System)
+}
+
+// NewKeyRingWithClock 使用初始私钥与指定时钟创建 KeyRing
+func NewKeyRingWithClock(initial *Key, loader Loader, overlap time.Duration, c clock.Clock) (*KeyRing, error) {
+	if err := initial.Validate(); err != nil {
+		return nil, err
+	}
+	if overlap <= 0 {
+		overlap = DefaultOverlap
+	}
+
+	r := &KeyRing{loader: loader, overlap: overlap, clock: clock.OrSystem(c)}
+	r.state.Store(&keyState{current: initial})
+	return r, nil
+}
+
+// NewKeyRingWithLoader 使用 Loader 加载初始私钥并创建 KeyRing
+func NewKeyRingWithLoader(ctx context.Context, loader Loader, overlap time.Duration) (*KeyRing, error) {
+	if loader == nil {
+		return nil, fmt.Errorf("loader is required")
+	}
+
+	initial, err := loader.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyRing(initial, loader, overlap)
+}
@@ -0,0 +1,254 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package keyring_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
+	"github.com/jemuri/wechatpay-go/core/auth/signers"
+	"github.com/jemuri/wechatpay-go/core/cipher/decryptors"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+const testMchID = "1900009191"
+
+type mockClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *mockClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestKey(t *testing.T, serialNo string) *keyring.Key {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &keyring.Key{MchID: testMchID, CertificateSerialNo: serialNo, PrivateKey: privateKey}
+}
+
+func TestKeyRing_SetWithOverlap(t *testing.T) {
+	c := &mockClock{now: time.Unix(1624523846, 0)}
+	oldKey, newKey := newTestKey(t, "OLD"), newTestKey(t, "NEW")
+
+	ring, err := keyring.NewKeyRingWithClock(oldKey, nil, time.Hour, c)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey, ring.Current())
+	assert.Equal(t, []*keyring.Key{oldKey}, ring.Keys())
+
+	require.NoError(t, ring.Set(newKey))
+	assert.Equal(t, newKey, ring.Current())
+	assert.Equal(t, []*keyring.Key{newKey, oldKey}, ring.Keys())
+
+	key, ok := ring.Get("OLD")
+	assert.True(t, ok)
+	assert.Equal(t, oldKey, key)
+
+	// 再次设置相同的私钥不应重置重叠期
+	c.Advance(30 * time.Minute)
+	require.NoError(t, ring.Set(&keyring.Key{MchID: testMchID, CertificateSerialNo: "NEW", PrivateKey: newKey.PrivateKey}))
+	c.Advance(30 * time.Minute)
+
+	assert.Equal(t, []*keyring.Key{newKey}, ring.Keys())
+	_, ok = ring.Get("OLD")
+	assert.False(t, ok)
+}
+
+func TestKeyRing_SetInvalidKey(t *testing.T) {
+	ring, err := keyring.NewKeyRing(newTestKey(t, "OLD"), nil, 0)
+	require.NoError(t, err)
+
+	assert.Error(t, ring.Set(nil))
+	assert.Error(t, ring.Set(&keyring.Key{MchID: testMchID, CertificateSerialNo: "NEW"}))
+
+	newKey := newTestKey(t, "NEW")
+	newKey.MchID = ""
+	assert.EqualError(t, ring.Set(newKey), "mchid is required in key")
+	newKey.MchID = "1900000109"
+	assert.EqualError(t, ring.Set(newKey), "key mchid [1900000109] differs from current mchid [1900009191]")
+	assert.Equal(t, "OLD", ring.Current().CertificateSerialNo)
+	assert.Equal(t, testMchID, ring.Current().MchID)
+
+	_, err = keyring.NewKeyRing(&keyring.Key{MchID: testMchID}, nil, 0)
+	assert.Error(t, err)
+	_, err = keyring.NewKeyRing(&keyring.Key{CertificateSerialNo: "OLD", PrivateKey: newKey.PrivateKey}, nil, 0)
+	assert.EqualError(t, err, "mchid is required in key")
+}
+
+func TestKeyRing_ReloadDifferentMchID(t *testing.T) {
+	newKey := newTestKey(t, "NEW")
+	newKey.MchID = "1900000109"
+	loader := keyring.LoaderFunc(func(context.Context) (*keyring.Key, error) { return newKey, nil })
+
+	ring, err := keyring.NewKeyRing(newTestKey(t, "OLD"), loader, 0)
+	require.NoError(t, err)
+
+	assert.Error(t, ring.Reload(context.Background()))
+	assert.Equal(t, "OLD", ring.Current().CertificateSerialNo)
+	assert.Len(t, ring.Keys(), 1)
+}
+
+func TestKeyRing_Reload(t *testing.T) {
+	ctx := context.Background()
+	keys := []*keyring.Key{newTestKey(t, "1"), newTestKey(t, "2")}
+	index := 0
+	loader := keyring.LoaderFunc(func(context.Context) (*keyring.Key, error) {
+		if index >= len(keys) {
+			return nil, fmt.Errorf("no more keys")
+		}
+		key := keys[index]
+		index++
+		return key, nil
+	})
+
+	ring, err := keyring.NewKeyRingWithLoader(ctx, loader, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "1", ring.Current().CertificateSerialNo)
+
+	require.NoError(t, ring.Reload(ctx))
+	assert.Equal(t, "2", ring.Current().CertificateSerialNo)
+
+	// 加载失败时保留当前私钥
+	assert.Error(t, ring.Reload(ctx))
+	assert.Equal(t, "2", ring.Current().CertificateSerialNo)
+
+	ring, err = keyring.NewKeyRing(keys[0], nil, 0)
+	require.NoError(t, err)
+	assert.Error(t, ring.Reload(ctx))
+}
+
+func writeTestKeyPair(t *testing.T, dir string, serialNo int64) (keyPath, certPath string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNo),
+		Subject:      pkix.Name{CommonName: testMchID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	keyPath, certPath = filepath.Join(dir, "apiclient_key.pem"), filepath.Join(dir, "apiclient_cert.pem")
+	require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0600))
+	require.NoError(t, ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return keyPath, certPath
+}
+
+func TestNewFileLoader(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keyPath, certPath := writeTestKeyPair(t, dir, 0x1A2B)
+	ring, err := keyring.NewKeyRingWithLoader(ctx, keyring.NewFileLoader(testMchID, keyPath, certPath), 0)
+	require.NoError(t, err)
+	assert.Equal(t, testMchID, ring.Current().MchID)
+	assert.Equal(t, "1A2B", ring.Current().CertificateSerialNo)
+
+	// 轮换后的文件被重新加载
+	writeTestKeyPair(t, dir, 0x3C4D)
+	require.NoError(t, ring.Reload(ctx))
+	assert.Equal(t, "3C4D", ring.Current().CertificateSerialNo)
+	_, ok := ring.Get("1A2B")
+	assert.True(t, ok)
+
+	// 私钥与证书不匹配
+	otherDir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(otherDir)
+	_, otherCertPath := writeTestKeyPair(t, otherDir, 0x5E6F)
+	_, err = keyring.NewFileLoader(testMchID, keyPath, otherCertPath).Load(ctx)
+	assert.Error(t, err)
+}
+
+func TestKeyRingSigner(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey := newTestKey(t, "OLD"), newTestKey(t, "NEW")
+	ring, err := keyring.NewKeyRing(oldKey, nil, time.Hour)
+	require.NoError(t, err)
+
+	signer := signers.NewKeyRingSigner(ring)
+	assert.Equal(t, "SHA256-RSA2048", signer.Algorithm())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				result, err := signer.Sign(ctx, "message")
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, testMchID, result.MchID)
+				assert.Contains(t, []string{"OLD", "NEW"}, result.CertificateSerialNo)
+			}
+		}()
+	}
+	require.NoError(t, ring.Set(newKey))
+	wg.Wait()
+
+	result, err := signer.Sign(ctx, "message")
+	require.NoError(t, err)
+	assert.Equal(t, "NEW", result.CertificateSerialNo)
+}
+
+func TestKeyRingDecryptor(t *testing.T) {
+	ctx := context.Background()
+	c := &mockClock{now: time.Unix(1624523846, 0)}
+	oldKey, newKey := newTestKey(t, "OLD"), newTestKey(t, "NEW")
+	ring, err := keyring.NewKeyRingWithClock(oldKey, nil, time.Hour, c)
+	require.NoError(t, err)
+	require.NoError(t, ring.Set(newKey))
+
+	decryptor := decryptors.NewKeyRingDecryptor(ring)
+	for _, key := range []*keyring.Key{oldKey, newKey} {
+		ciphertext, err := utils.EncryptOAEPWithPublicKey("plaintext", &key.PrivateKey.PublicKey)
+		require.NoError(t, err)
+
+		plaintext, err := decryptor.Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "plaintext", plaintext)
+	}
+
+	// 重叠期结束后旧私钥不再可用
+	c.Advance(time.Hour)
+	ciphertext, err := utils.EncryptOAEPWithPublicKey("plaintext", &oldKey.PrivateKey.PublicKey)
+	require.NoError(t, err)
+	_, err = decryptor.Decrypt(ctx, ciphertext)
+	assert.Error(t, err)
+
+	plaintext, err := decryptor.Decrypt(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, plaintext)
+}
@@ -0,0 +1,15 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package auth 微信支付 API v3 Go SDK 安全验证相关接口
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// ReplayCache 报文防重放缓存，用于记录已经处理过的 Wechatpay-Nonce
+type ReplayCache interface {
+	// Add 记录 nonce 并在 ttl 后过期。nonce 尚未被记录（或已过期）时返回 true，否则返回 false
+	Add(ctx context.Context, nonce string, ttl time.Duration) (added bool, err error)
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package replaycaches 微信支付 API v3 Go SDK 报文防重放缓存
+package replaycaches
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+)
+
+// DefaultSweepInterval 默认的过期记录清理间隔
+const DefaultSweepInterval = time.Minute
+
+// MemoryReplayCache 进程内的报文防重放缓存，记录在 TTL 到期后自动失效
+//
+// 多实例部署时，同一个报文可能被重放到不同的实例上，此时请基于 Redis 等共享存储自行实现 auth.ReplayCache
+type MemoryReplayCache struct {
+	clock         clock.Clock
+	sweepInterval time.Duration
+
+	lock      sync.Mutex
+	entries   map[string]time.Time // nonce -> 过期时间
+	nextSweep time.Time
+}
+
+// Add 记录 nonce 并在 ttl 后过期。nonce 尚未被记录（或已过期）时返回 true，否则返回 false
+func (c *MemoryReplayCache) Add(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := c.clock.Now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sweep(now)
+
+	if expiresAt, ok := c.entries[nonce]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	c.entries[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// Len 获取当前记录数（含尚未清理的过期记录）
+func (c *MemoryReplayCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.entries)
+}
+
+// sweep 按 sweepInterval 清理过期记录，调用方需持有锁
+func (c *MemoryReplayCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+
+	for nonce, expiresAt := range c.entries {
+		if !now.Before(expiresAt) {
+			delete(c.entries, nonce)
+		}
+	}
+	c.nextSweep = now.Add(c.sweepInterval)
+}
+
+// NewMemoryReplayCache 使用本机系统时钟初始化 MemoryReplayCache
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return NewMemoryReplayCacheWithClock(clock.System, DefaultSweepInterval)
+}
+
+// NewMemoryReplayCacheWithClock 使用指定时钟与清理间隔初始化 MemoryReplayCache，sweepInterval <= 0 时使用 DefaultSweepInterval
+func NewMemoryReplayCacheWithClock(c clock.Clock, sweepInterval time.Duration) *MemoryReplayCache {
+	if sweepInterval <= 0 {
+		sweepInterval = DefaultSweepInterval
+	}
+	return &MemoryReplayCache{
+		clock:         clock.OrSystem(c),
+		sweepInterval: sweepInterval,
+		entries:       make(map[string]time.Time),
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package replaycaches
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/clock"
+)
+
+var _ auth.ReplayCache = (*MemoryReplayCache)(nil)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
+
+func TestMemoryReplayCache_Add(t *testing.T) {
+	ctx := context.Background()
+	c := &mockClock{now: time.Unix(1624523846, 0)}
+	cache := NewMemoryReplayCacheWithClock(c, time.Minute)
+
+	added, err := cache.Add(ctx, "nonce-1", 5*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = cache.Add(ctx, "nonce-1", 5*time.Minute)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	added, err = cache.Add(ctx, "nonce-2", 5*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	c.now = c.now.Add(5 * time.Minute)
+	added, err = cache.Add(ctx, "nonce-1", 5*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added, "expired nonce should be accepted again")
+}
+
+func TestMemoryReplayCache_Sweep(t *testing.T) {
+	ctx := context.Background()
+	c := &mockClock{now: time.Unix(1624523846, 0)}
+	cache := NewMemoryReplayCacheWithClock(c, time.Minute)
+
+	for i := 0; i < 100; i++ {
+		_, err := cache.Add(ctx, fmt.Sprintf("nonce-%d", i), 30*time.Second)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 100, cache.Len())
+
+	c.now = c.now.Add(time.Minute)
+	_, err := cache.Add(ctx, "another-nonce", 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMemoryReplayCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryReplayCacheWithClock(clock.System, 0)
+
+	var addedCount int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			added, err := cache.Add(ctx, "same-nonce", time.Minute)
+			assert.NoError(t, err)
+			if added {
+				atomic.AddInt32(&addedCount, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), addedCount)
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+// CryptoSigner 使用 crypto.Signer 的 SHA256WithRSA 数字签名生成器
+//
+// 商户私钥无需出现在进程内存中，可以保存在 KMS/HSM 等外部服务中，只需提供实现了 crypto.Signer 的 RSA 私钥句柄
+type CryptoSigner struct {
+	MchID               string        // 商户号
+	CertificateSerialNo string        // 商户证书序列号
+	Signer              crypto.Signer // 商户私钥句柄
+}
+
+// Sign 对信息使用 SHA256WithRSA 算法进行签名
+func (s *CryptoSigner) Sign(_ context.Context, message string) (*auth.SignatureResult, error) {
+	if s.Signer == nil {
+		return nil, fmt.Errorf("you must set signer to use CryptoSigner")
+	}
+	if strings.TrimSpace(s.CertificateSerialNo) == "" {
+		return nil, fmt.Errorf("you must set mch certificate serial no to use CryptoSigner")
+	}
+	signature, err := utils.SignSHA256WithSigner(message, s.Signer)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.SignatureResult{MchID: s.MchID, CertificateSerialNo: s.CertificateSerialNo, Signature: signature}, nil
+}
+
+// GetMchID 返回签名所使用的商户号
+func (s *CryptoSigner) GetMchID() string {
+	return s.MchID
+}
+
+// Algorithm 返回使用的签名算法：SHA256-RSA2048
+func (s *CryptoSigner) Algorithm() string {
+	return "SHA256-RSA2048"
+}
+
+// NewCryptoSigner 使用 crypto.Signer 初始化 CryptoSigner
+func NewCryptoSigner(mchID, certificateSerialNo string, signer crypto.Signer) *CryptoSigner {
+	return &CryptoSigner{MchID: mchID, CertificateSerialNo: certificateSerialNo, Signer: signer}
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+func TestCryptoSigner_Sign(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKeyStr))
+	require.NoError(t, err)
+
+	signer := NewCryptoSigner(testMchID, testCertificateSerial, privateKey)
+	assert.Equal(t, "SHA256-RSA2048", signer.Algorithm())
+
+	result, err := signer.Sign(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, &auth.SignatureResult{
+		MchID:               testMchID,
+		CertificateSerialNo: testCertificateSerial,
+		Signature:           testExpectedSignature,
+	}, result)
+}
+
+func TestCryptoSigner_SignWithoutSigner(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKeyStr))
+	require.NoError(t, err)
+
+	_, err = NewCryptoSigner(testMchID, testCertificateSerial, nil).Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+
+	_, err = NewCryptoSigner(testMchID, "", privateKey).Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+// HTTPRemoteSigningClient 基于 HTTP/JSON 的远程签名服务客户端
+//
+// 远程服务需要提供以下接口：
+//
+//	POST {Endpoint}/sign        请求 {"key_id":"...","algorithm":"SHA256withRSA","digest":"<base64>"}，应答 {"signature":"<base64>"}
+//	GET  {Endpoint}/public-key?key_id=...  应答 {"public_key":"<PEM>"}
+//
+// 使用 gRPC 或云厂商 KMS 时，请自行实现 RemoteSigningClient
+type HTTPRemoteSigningClient struct {
+	Endpoint   string       // 远程签名服务地址，如 https://signer.internal
+	HTTPClient *http.Client // 为 nil 时使用默认 HTTPClient
+	Header     http.Header  // 附加到每个请求的 Header，如鉴权信息
+}
+
+type remoteSignRequest struct {
+	KeyID     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	Digest    string `json:"digest"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+type remotePublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// SignDigest 请求远程签名服务对 SHA256 摘要签名
+func (c *HTTPRemoteSigningClient) SignDigest(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	body, err := json.Marshal(remoteSignRequest{
+		KeyID:     keyID,
+		Algorithm: "SHA256withRSA",
+		Digest:    base64.StdEncoding.EncodeToString(digest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url("/sign"), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(consts.ContentType, consts.ApplicationJSON)
+
+	resp := remoteSignResponse{}
+	if err = c.do(request, &resp); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode signature failed, error=%s", err.Error())
+	}
+	return signature, nil
+}
+
+// PublicKey 从远程签名服务获取公钥
+func (c *HTTPRemoteSigningClient) PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	request, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, c.url("/public-key")+"?key_id="+url.QueryEscape(keyID), nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := remotePublicKeyResponse{}
+	if err = c.do(request, &resp); err != nil {
+		return nil, err
+	}
+	return utils.LoadPublicKey(resp.PublicKey)
+}
+
+func (c *HTTPRemoteSigningClient) url(path string) string {
+	return strings.TrimSuffix(c.Endpoint, "/") + path
+}
+
+func (c *HTTPRemoteSigningClient) do(request *http.Request, result interface{}) error {
+	for key, values := range c.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	request.Header.Set(consts.Accept, "*/*")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: consts.DefaultTimeout}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("remote signer %s returns %d: %s", request.URL.Path, response.StatusCode, string(body))
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unmarshal remote signer response err:%v", err)
+	}
+	return nil
+}
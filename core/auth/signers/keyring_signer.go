@@ -0,0 +1,51 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
+)
+
+// KeyRingSigner 使用 keyring.KeyRing 中当前私钥签名的 SHA256WithRSA 数字签名生成器
+//
+// 私钥轮换后无需重建 core.Client，下一次签名即使用新的私钥与证书序列号
+type KeyRingSigner struct {
+	ring *keyring.KeyRing
+}
+
+// Sign 对信息使用 KeyRing 中的当前私钥进行 SHA256WithRSA 签名
+func (s *KeyRingSigner) Sign(ctx context.Context, message string) (*auth.SignatureResult, error) {
+	if s.ring == nil {
+		return nil, fmt.Errorf("you must set keyring to use KeyRingSigner")
+	}
+
+	key := s.ring.Current()
+	signer := SHA256WithRSASigner{
+		MchID:               key.MchID,
+		CertificateSerialNo: key.CertificateSerialNo,
+		PrivateKey:          key.PrivateKey,
+	}
+	return signer.Sign(ctx, message)
+}
+
+// GetMchID 返回 KeyRing 当前私钥所属的商户号
+func (s *KeyRingSigner) GetMchID() string {
+	if s.ring == nil {
+		return ""
+	}
+	return s.ring.Current().MchID
+}
+
+// Algorithm 返回使用的签名算法：SHA256-RSA2048
+func (s *KeyRingSigner) Algorithm() string {
+	return "SHA256-RSA2048"
+}
+
+// NewKeyRingSigner 使用 keyring.KeyRing 初始化 KeyRingSigner
+func NewKeyRingSigner(ring *keyring.KeyRing) *KeyRingSigner {
+	return &KeyRingSigner{ring: ring}
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+)
+
+// LocalRemoteSigningClient 使用进程内私钥模拟远程签名服务，可用于测试或本地开发
+type LocalRemoteSigningClient struct {
+	lock sync.RWMutex
+	keys map[string]*rsa.PrivateKey
+}
+
+// SignDigest 使用 keyID 对应的私钥对 SHA256 摘要签名
+func (c *LocalRemoteSigningClient) SignDigest(_ context.Context, keyID string, digest []byte) ([]byte, error) {
+	key, err := c.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+}
+
+// PublicKey 获取 keyID 对应的公钥
+func (c *LocalRemoteSigningClient) PublicKey(_ context.Context, keyID string) (*rsa.PublicKey, error) {
+	key, err := c.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &key.PublicKey, nil
+}
+
+// Set 设置 keyID 对应的私钥，可用于模拟远程服务中的密钥轮换
+func (c *LocalRemoteSigningClient) Set(keyID string, privateKey *rsa.PrivateKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.keys[keyID] = privateKey
+}
+
+func (c *LocalRemoteSigningClient) get(keyID string) (*rsa.PrivateKey, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	key, ok := c.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+	return key, nil
+}
+
+// NewLocalRemoteSigningClient 使用 keyID 到私钥的映射初始化 LocalRemoteSigningClient
+func NewLocalRemoteSigningClient(keys map[string]*rsa.PrivateKey) *LocalRemoteSigningClient {
+	c := &LocalRemoteSigningClient{keys: make(map[string]*rsa.PrivateKey, len(keys))}
+	for keyID, key := range keys {
+		c.keys[keyID] = key
+	}
+	return c
+}
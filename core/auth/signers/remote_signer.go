@@ -0,0 +1,131 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jemuri/wechatpay-go/core/auth"
+)
+
+// RemoteSigningClient 远程签名服务客户端
+//
+// 商户私钥保存在远程签名服务（KMS/HSM 或自建签名服务）中，SDK 只将待签名信息的 SHA256 摘要发送给远程服务。
+// 你可以基于 HTTP、gRPC 或云厂商 KMS SDK 实现本接口。
+type RemoteSigningClient interface {
+	// SignDigest 使用 keyID 对应的 RSA 私钥，以 PKCS #1 v1.5 方式对 SHA256 摘要签名，返回原始签名字节
+	SignDigest(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+	// PublicKey 获取 keyID 对应的 RSA 公钥
+	PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error)
+}
+
+// RemoteSigner 使用远程签名服务的 SHA256WithRSA 数字签名生成器
+//
+// 默认会使用远程服务的公钥校验每一次签名结果（公钥会被缓存），以尽早发现 keyID 配置错误或远程服务异常。
+// 校验失败时会重新获取一次公钥，以兼容远程服务中的密钥轮换。
+type RemoteSigner struct {
+	MchID               string              // 商户号
+	CertificateSerialNo string              // 商户证书序列号
+	KeyID               string              // 远程签名服务中的私钥标识
+	Client              RemoteSigningClient // 远程签名服务客户端
+	SkipSelfCheck       bool                // 跳过签名结果校验
+
+	lock      sync.Mutex
+	publicKey *rsa.PublicKey
+}
+
+// Sign 对信息使用远程签名服务进行 SHA256WithRSA 签名
+func (s *RemoteSigner) Sign(ctx context.Context, message string) (*auth.SignatureResult, error) {
+	if s.Client == nil {
+		return nil, fmt.Errorf("you must set client to use RemoteSigner")
+	}
+	if strings.TrimSpace(s.CertificateSerialNo) == "" {
+		return nil, fmt.Errorf("you must set mch certificate serial no to use RemoteSigner")
+	}
+
+	digest := sha256.Sum256([]byte(message))
+	signature, err := s.Client.SignDigest(ctx, s.KeyID, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign with remote key %s err:%w", s.KeyID, err)
+	}
+
+	if !s.SkipSelfCheck {
+		if err = s.selfCheck(ctx, digest[:], signature); err != nil {
+			return nil, err
+		}
+	}
+
+	return &auth.SignatureResult{
+		MchID:               s.MchID,
+		CertificateSerialNo: s.CertificateSerialNo,
+		Signature:           base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// GetMchID 返回签名所使用的商户号
+func (s *RemoteSigner) GetMchID() string {
+	return s.MchID
+}
+
+// Algorithm 返回使用的签名算法：SHA256-RSA2048
+func (s *RemoteSigner) Algorithm() string {
+	return "SHA256-RSA2048"
+}
+
+// PublicKey 获取远程私钥对应的公钥，获取成功后会被缓存
+func (s *RemoteSigner) PublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	s.lock.Lock()
+	publicKey := s.publicKey
+	s.lock.Unlock()
+
+	if publicKey != nil {
+		return publicKey, nil
+	}
+	return s.refreshPublicKey(ctx)
+}
+
+func (s *RemoteSigner) refreshPublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	publicKey, err := s.Client.PublicKey(ctx, s.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("get public key of remote key %s err:%w", s.KeyID, err)
+	}
+	if publicKey == nil {
+		return nil, fmt.Errorf("remote key %s has no public key", s.KeyID)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.publicKey = publicKey
+	return publicKey, nil
+}
+
+// selfCheck 使用缓存的公钥校验签名，失败时刷新公钥后再校验一次
+func (s *RemoteSigner) selfCheck(ctx context.Context, digest, signature []byte) error {
+	publicKey, err := s.PublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature) == nil {
+		return nil
+	}
+
+	if publicKey, err = s.refreshPublicKey(ctx); err != nil {
+		return err
+	}
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature); err != nil {
+		return fmt.Errorf("self check signature of remote key %s failed: %v", s.KeyID, err)
+	}
+	return nil
+}
+
+// NewRemoteSigner 使用远程签名服务客户端初始化 RemoteSigner
+func NewRemoteSigner(mchID, certificateSerialNo, keyID string, client RemoteSigningClient) *RemoteSigner {
+	return &RemoteSigner{MchID: mchID, CertificateSerialNo: certificateSerialNo, KeyID: keyID, Client: client}
+}
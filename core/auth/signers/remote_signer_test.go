@@ -0,0 +1,171 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package signers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+const testKeyID = "merchant-key"
+
+// countingSigningClient 统计公钥获取次数的 RemoteSigningClient
+type countingSigningClient struct {
+	*LocalRemoteSigningClient
+	publicKeyCalls int32
+}
+
+func (c *countingSigningClient) PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	atomic.AddInt32(&c.publicKeyCalls, 1)
+	return c.LocalRemoteSigningClient.PublicKey(ctx, keyID)
+}
+
+func TestRemoteSigner_Sign(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKeyStr))
+	require.NoError(t, err)
+
+	client := &countingSigningClient{
+		LocalRemoteSigningClient: NewLocalRemoteSigningClient(map[string]*rsa.PrivateKey{testKeyID: privateKey}),
+	}
+	signer := NewRemoteSigner(testMchID, testCertificateSerial, testKeyID, client)
+
+	for i := 0; i < 3; i++ {
+		result, err := signer.Sign(context.Background(), testMessage)
+		require.NoError(t, err)
+		assert.Equal(t, testMchID, result.MchID)
+		assert.Equal(t, testCertificateSerial, result.CertificateSerialNo)
+		assert.Equal(t, testExpectedSignature, result.Signature)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&client.publicKeyCalls), "public key should be cached")
+
+	// 远程服务轮换密钥后，自检失败会刷新一次公钥
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	client.Set(testKeyID, newKey)
+
+	_, err = signer.Sign(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&client.publicKeyCalls))
+
+	publicKey, err := signer.PublicKey(context.Background())
+	require.NoError(t, err)
+	assert.True(t, newKey.PublicKey.Equal(publicKey))
+}
+
+// mismatchedSigningClient 签名私钥与返回的公钥不匹配，模拟 keyID 配置错误
+type mismatchedSigningClient struct {
+	*LocalRemoteSigningClient
+	publicKey *rsa.PublicKey
+}
+
+func (c *mismatchedSigningClient) PublicKey(context.Context, string) (*rsa.PublicKey, error) {
+	return c.publicKey, nil
+}
+
+func TestRemoteSigner_SelfCheckFailed(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKeyStr))
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	client := &mismatchedSigningClient{
+		LocalRemoteSigningClient: NewLocalRemoteSigningClient(map[string]*rsa.PrivateKey{testKeyID: privateKey}),
+		publicKey:                &otherKey.PublicKey,
+	}
+	signer := NewRemoteSigner(testMchID, testCertificateSerial, testKeyID, client)
+
+	_, err = signer.Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+
+	signer.SkipSelfCheck = true
+	result, err := signer.Sign(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, testExpectedSignature, result.Signature)
+}
+
+func TestRemoteSigner_SignWithUnknownKey(t *testing.T) {
+	signer := NewRemoteSigner(
+		testMchID, testCertificateSerial, "unknown", NewLocalRemoteSigningClient(nil),
+	)
+	_, err := signer.Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+
+	_, err = NewRemoteSigner(testMchID, testCertificateSerial, testKeyID, nil).Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+}
+
+func newTestSigningServer(t *testing.T, privateKey *rsa.PrivateKey) *httptest.Server {
+	local := NewLocalRemoteSigningClient(map[string]*rsa.PrivateKey{testKeyID: privateKey})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		req := remoteSignRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "SHA256withRSA", req.Algorithm)
+
+		digest, err := base64.StdEncoding.DecodeString(req.Digest)
+		require.NoError(t, err)
+		signature, err := local.SignDigest(r.Context(), req.KeyID, digest)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(remoteSignResponse{Signature: base64.StdEncoding.EncodeToString(signature)})
+	})
+	mux.HandleFunc("/public-key", func(w http.ResponseWriter, r *http.Request) {
+		publicKey, err := local.PublicKey(r.Context(), r.URL.Query().Get("key_id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(remotePublicKeyResponse{
+			PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestHTTPRemoteSigningClient(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKeyStr))
+	require.NoError(t, err)
+
+	server := newTestSigningServer(t, privateKey)
+	defer server.Close()
+
+	client := &HTTPRemoteSigningClient{
+		Endpoint: server.URL + "/",
+		Header:   http.Header{"Authorization": []string{"Bearer token"}},
+	}
+	signer := NewRemoteSigner(testMchID, testCertificateSerial, testKeyID, client)
+
+	result, err := signer.Sign(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, testExpectedSignature, result.Signature)
+
+	_, err = NewRemoteSigner(testMchID, testCertificateSerial, "unknown", client).Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+
+	_, err = NewRemoteSigner(
+		testMchID, testCertificateSerial, testKeyID, &HTTPRemoteSigningClient{Endpoint: server.URL},
+	).Sign(context.Background(), testMessage)
+	assert.Error(t, err)
+}
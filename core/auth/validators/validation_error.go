@@ -0,0 +1,50 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package validators
+
+import "errors"
+
+// 验签失败原因，可用作监控指标的标签
+const (
+	FailureReasonMissingHeader    = "missing_header"     // 缺少 Wechatpay-* Header
+	FailureReasonInvalidHeader    = "invalid_header"     // Wechatpay-* Header 格式错误
+	FailureReasonTimestampExpired = "timestamp_expired"  // 时间戳超出容差
+	FailureReasonSignature        = "signature_mismatch" // 签名校验失败
+	FailureReasonReplay           = "replay"             // 报文重放
+	FailureReasonReplayCache      = "replay_cache_error" // 防重放缓存异常
+	FailureReasonReadBody         = "read_body"          // 读取报文 Body 失败
+	FailureReasonUnknown          = "unknown"            // 其他原因
+)
+
+// ValidationError 验签失败错误，Reason 为失败原因
+type ValidationError struct {
+	Reason string
+	Err    error
+}
+
+// Error 输出 ValidationError，与被包装的错误一致
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap 返回被包装的错误
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// FailureReason 获取验签失败的原因，err 不是验签错误时返回 FailureReasonUnknown
+func FailureReason(err error) string {
+	if IsReplayError(err) {
+		return FailureReasonReplay
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Reason
+	}
+	return FailureReasonUnknown
+}
+
+func newValidationError(reason string, err error) error {
+	return &ValidationError{Reason: reason, Err: err}
+}
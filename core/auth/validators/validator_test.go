@@ -16,6 +16,8 @@ import (
 	"github.com/agiledragon/gomonkey"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/jemuri/wechatpay-go/core/auth/replaycaches"
+	"github.com/jemuri/wechatpay-go/core/clock"
 	"github.com/jemuri/wechatpay-go/core/consts"
 )
 
@@ -350,3 +352,154 @@ func TestWechatPayNotifyValidator_ValidateReadBodyError(t *testing.T) {
 	err := validator.Validate(context.Background(), request)
 	assert.Error(t, err)
 }
+
+func TestWechatPayResponseValidator_ValidateWithClock(t *testing.T) {
+	const mockTimestamp = 1624523846
+	mockTimestampStr := fmt.Sprintf("%d", mockTimestamp)
+
+	newResponse := func() *http.Response {
+		return &http.Response{
+			Header: http.Header{
+				consts.WechatPaySignature: {"[SERIAL1234567890-" + mockTimestampStr + "\nNONCE1234567890\nBODY\n]"},
+				consts.WechatPaySerial:    {"SERIAL1234567890"},
+				consts.WechatPayTimestamp: {mockTimestampStr},
+				consts.WechatPayNonce:     {"NONCE1234567890"},
+				consts.RequestID:          {"any-request-id"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBuffer([]byte("BODY"))),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		tolerance time.Duration
+		wantErr   bool
+	}{
+		{name: "within default tolerance", now: time.Unix(mockTimestamp+299, 0), wantErr: false},
+		{name: "beyond default tolerance", now: time.Unix(mockTimestamp+300, 0), wantErr: true},
+		{name: "beyond default tolerance in the past", now: time.Unix(mockTimestamp-300, 0), wantErr: true},
+		{
+			name: "within custom tolerance", now: time.Unix(mockTimestamp+599, 0), tolerance: 10 * time.Minute,
+			wantErr: false,
+		},
+		{
+			name: "beyond custom tolerance", now: time.Unix(mockTimestamp+60, 0), tolerance: time.Minute,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewWechatPayResponseValidator(&mockVerifier{})
+			validator.SetClock(clock.Fixed(tt.now))
+			validator.SetTimestampTolerance(tt.tolerance)
+
+			err := validator.Validate(context.Background(), newResponse())
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "expires")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWechatPayResponseValidator_VerifyTimestamp(t *testing.T) {
+	const mockTimestamp = 1624523846
+	mockTimestampStr := fmt.Sprintf("%d", mockTimestamp)
+
+	newResponse := func(signature string) *http.Response {
+		return &http.Response{
+			Header: http.Header{
+				consts.WechatPaySignature: {signature},
+				consts.WechatPaySerial:    {"SERIAL1234567890"},
+				consts.WechatPayTimestamp: {mockTimestampStr},
+				consts.WechatPayNonce:     {"NONCE1234567890"},
+				consts.RequestID:          {"any-request-id"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBuffer([]byte("BODY"))),
+		}
+	}
+
+	validator := NewWechatPayResponseValidator(&mockVerifier{})
+	validator.SetClock(clock.Fixed(time.Unix(mockTimestamp+3600, 0)))
+	validator.SetReplayCache(replaycaches.NewMemoryReplayCache())
+
+	// 时间戳过期的应答不能通过完整校验，但签名有效时仍可以取得其时间戳
+	response := newResponse("[SERIAL1234567890-" + mockTimestampStr + "\nNONCE1234567890\nBODY\n]")
+	timestamp, err := validator.VerifyTimestamp(context.Background(), response)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(mockTimestamp, 0), timestamp)
+	body, err := ioutil.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "BODY", string(body))
+
+	_, err = validator.VerifyTimestamp(context.Background(), newResponse("FORGED"))
+	assert.Error(t, err)
+	assert.Equal(t, FailureReasonSignature, FailureReason(err))
+}
+
+func TestWechatPayResponseValidator_ValidateWithReplayCache(t *testing.T) {
+	mockTimestampStr := fmt.Sprintf("%d", time.Now().Unix())
+
+	newResponse := func(nonce, signatureNonce string) *http.Response {
+		return &http.Response{
+			Header: http.Header{
+				consts.WechatPaySignature: {"[SERIAL1234567890-" + mockTimestampStr + "\n" + signatureNonce + "\nBODY\n]"},
+				consts.WechatPaySerial:    {"SERIAL1234567890"},
+				consts.WechatPayTimestamp: {mockTimestampStr},
+				consts.WechatPayNonce:     {nonce},
+				consts.RequestID:          {"any-request-id"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBuffer([]byte("BODY"))),
+		}
+	}
+
+	validator := NewWechatPayResponseValidator(&mockVerifier{})
+	validator.SetReplayCache(replaycaches.NewMemoryReplayCache())
+
+	// 验签失败的报文不应占用 nonce
+	err := validator.Validate(context.Background(), newResponse("NONCE1234567890", "FORGED"))
+	assert.Error(t, err)
+	assert.False(t, IsReplayError(err))
+
+	err = validator.Validate(context.Background(), newResponse("NONCE1234567890", "NONCE1234567890"))
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), newResponse("NONCE1234567890", "NONCE1234567890"))
+	assert.Error(t, err)
+	assert.True(t, IsReplayError(err))
+	replayErr, ok := err.(*ReplayError)
+	assert.True(t, ok)
+	assert.Equal(t, "NONCE1234567890", replayErr.Nonce)
+	assert.Equal(t, "any-request-id", replayErr.RequestID)
+
+	err = validator.Validate(context.Background(), newResponse("NONCE0987654321", "NONCE0987654321"))
+	assert.NoError(t, err)
+}
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"replay", &ReplayError{Nonce: "nonce"}, FailureReasonReplay},
+		{"validation error", newValidationError(FailureReasonSignature, fmt.Errorf("verify fail")), FailureReasonSignature},
+		{
+			"wrapped validation error",
+			fmt.Errorf("wrapped: %w", newValidationError(FailureReasonMissingHeader, fmt.Errorf("empty"))),
+			FailureReasonMissingHeader,
+		},
+		{"other error", fmt.Errorf("other"), FailureReasonUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FailureReason(tt.err))
+		})
+	}
+
+	err := newValidationError(FailureReasonSignature, fmt.Errorf("verify fail"))
+	assert.Equal(t, "verify fail", err.Error())
+}
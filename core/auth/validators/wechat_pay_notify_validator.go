@@ -21,7 +21,7 @@ type WechatPayNotifyValidator struct {
 func (v *WechatPayNotifyValidator) Validate(ctx context.Context, request *http.Request) error {
 	body, err := ioutil.ReadAll(request.Body)
 	if err != nil {
-		return fmt.Errorf("read request body err: %v", err)
+		return newValidationError(FailureReasonReadBody, fmt.Errorf("read request body err: %v", err))
 	}
 
 	_ = request.Body.Close()
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/jemuri/wechatpay-go/core/auth"
 )
@@ -18,17 +19,39 @@ type WechatPayResponseValidator struct {
 	wechatPayValidator
 }
 
+// Clone 返回验证器的副本，副本与原验证器共享 Verifier 与防重放缓存，但可以独立设置时钟、容差与 Logger
+//
+// Client 在副本上应用自身的配置，因此同一个验证器可以被多个配置不同的 Client 共享
+func (v *WechatPayResponseValidator) Clone() auth.Validator {
+	clone := *v
+	return &clone
+}
+
 // Validate 使用验证器对微信支付应答报文进行验证
 func (v *WechatPayResponseValidator) Validate(ctx context.Context, response *http.Response) error {
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return fmt.Errorf("read response body err:[%s]", err.Error())
+		return newValidationError(FailureReasonReadBody, fmt.Errorf("read response body err:[%s]", err.Error()))
 	}
 	response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
 	return v.validateHTTPMessage(ctx, response.Header, body)
 }
 
+// VerifyTimestamp 仅校验应答签名，返回受签名保护的应答时间戳
+//
+// 与 Validate 不同，VerifyTimestamp 不检查时间戳是否过期，也不做防重放检查，
+// 因此只能用于估计服务器时间（如补偿本机时钟偏差），不能用于判断应答是否可信
+func (v *WechatPayResponseValidator) VerifyTimestamp(ctx context.Context, response *http.Response) (time.Time, error) {
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return time.Time{}, newValidationError(FailureReasonReadBody, fmt.Errorf("read response body err:[%s]", err.Error()))
+	}
+	response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+	return v.verifyTimestamp(ctx, response.Header, body)
+}
+
 // GetAcceptSerial 客户端可以处理的证书或者公钥序列号
 func (v *WechatPayResponseValidator) GetAcceptSerial(ctx context.Context) (string, error) {
 	return v.getAcceptSerial(ctx)
@@ -4,7 +4,9 @@ package validators
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"net/http"
 	"strconv"
@@ -12,11 +14,41 @@ import (
 	"time"
 
 	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/clock"
 	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/logging"
 )
 
+// DefaultTimestampTolerance 默认允许的微信支付时间戳与本机时间之差
+const DefaultTimestampTolerance = consts.FiveMinute * time.Second
+
 type wechatPayValidator struct {
-	verifier auth.Verifier
+	verifier    auth.Verifier
+	clock       clock.Clock
+	tolerance   time.Duration
+	replayCache auth.ReplayCache
+	logger      *slog.Logger
+}
+
+// ReplayError 报文重放错误，同一个 Wechatpay-Nonce 在时间戳有效期内重复出现
+type ReplayError struct {
+	Nonce     string // 重复出现的 Wechatpay-Nonce
+	Timestamp int64  // 报文的 Wechatpay-Timestamp
+	RequestID string // 报文的 Request-Id
+}
+
+// Error 输出 ReplayError
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf(
+		"nonce=[%s] timestamp=[%d] has been seen before, possible replay, request-id=[%s]",
+		e.Nonce, e.Timestamp, e.RequestID,
+	)
+}
+
+// IsReplayError 判断当前 error 是否为 *ReplayError（包括被包装的情况）
+func IsReplayError(err error) bool {
+	var replayErr *ReplayError
+	return errors.As(err, &replayErr)
 }
 
 type wechatPayHeader struct {
@@ -28,6 +60,31 @@ type wechatPayHeader struct {
 }
 
 func (v *wechatPayValidator) validateHTTPMessage(ctx context.Context, header http.Header, body []byte) error {
+	err := v.doValidateHTTPMessage(ctx, header, body)
+	v.logResult(ctx, header, err)
+	return err
+}
+
+// logResult 输出验签结果，通过时为 Debug，失败时为 Warn
+func (v *wechatPayValidator) logResult(ctx context.Context, header http.Header, err error) {
+	logger := logging.OrDiscard(v.logger)
+
+	attrs := []slog.Attr{
+		slog.String(logging.KeyRequestID, strings.TrimSpace(header.Get(consts.RequestID))),
+		slog.String(logging.KeySerial, strings.TrimSpace(header.Get(consts.WechatPaySerial))),
+	}
+	if err == nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, "wechatpay signature verified", attrs...)
+		return
+	}
+
+	attrs = append(
+		attrs, slog.String(logging.KeyReason, FailureReason(err)), slog.String(logging.KeyError, err.Error()),
+	)
+	logger.LogAttrs(ctx, slog.LevelWarn, "wechatpay signature validation failed", attrs...)
+}
+
+func (v *wechatPayValidator) doValidateHTTPMessage(ctx context.Context, header http.Header, body []byte) error {
 	if v.verifier == nil {
 		return fmt.Errorf("you must init Validator with auth.Verifier")
 	}
@@ -37,18 +94,70 @@ func (v *wechatPayValidator) validateHTTPMessage(ctx context.Context, header htt
 		return err
 	}
 
-	if err := checkWechatPayHeader(ctx, headerArgs); err != nil {
+	if err := checkWechatPayHeader(ctx, headerArgs, v.now(), v.timestampTolerance()); err != nil {
 		return err
 	}
 
+	if err := v.verifySignature(ctx, headerArgs, body); err != nil {
+		return err
+	}
+
+	// 仅记录验签通过的报文，避免伪造的报文占用合法的 nonce
+	return v.checkReplay(ctx, headerArgs)
+}
+
+// verifyTimestamp 仅校验报文签名，返回受签名保护的 Wechatpay-Timestamp
+//
+// 不检查时间戳是否过期，也不记录 nonce，因此不能代替完整的校验，仅可用于估计服务器时间
+func (v *wechatPayValidator) verifyTimestamp(ctx context.Context, header http.Header, body []byte) (time.Time, error) {
+	if v.verifier == nil {
+		return time.Time{}, fmt.Errorf("you must init Validator with auth.Verifier")
+	}
+
+	headerArgs, err := getWechatPayHeader(ctx, header)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := v.verifySignature(ctx, headerArgs, body); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(headerArgs.Timestamp, 0), nil
+}
+
+func (v *wechatPayValidator) verifySignature(ctx context.Context, headerArgs wechatPayHeader, body []byte) error {
 	message := buildMessage(ctx, headerArgs, body)
 
 	if err := v.verifier.Verify(ctx, headerArgs.Serial, message, headerArgs.Signature); err != nil {
-		return fmt.Errorf(
+		return newValidationError(FailureReasonSignature, fmt.Errorf(
 			"validate verify fail serial=[%s] request-id=[%s] err=%w",
 			headerArgs.Serial, headerArgs.RequestID, err,
+		))
+	}
+	return nil
+}
+
+// checkReplay 使用 replayCache 检查报文是否为重放报文，未设置 replayCache 时跳过检查
+//
+// nonce 只需要记录到报文时间戳超出容差为止，此后该报文会因时间戳过期而被拒绝
+func (v *wechatPayValidator) checkReplay(ctx context.Context, args wechatPayHeader) error {
+	if v.replayCache == nil {
+		return nil
+	}
+
+	ttl := time.Unix(args.Timestamp, 0).Add(v.timestampTolerance()).Sub(v.now())
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+
+	added, err := v.replayCache.Add(ctx, args.Nonce, ttl)
+	if err != nil {
+		return newValidationError(
+			FailureReasonReplayCache, fmt.Errorf("check replay failed, request-id=[%s] err=%w", args.RequestID, err),
 		)
 	}
+	if !added {
+		return &ReplayError{Nonce: args.Nonce, Timestamp: args.Timestamp, RequestID: args.RequestID}
+	}
 	return nil
 }
 
@@ -56,6 +165,49 @@ func (v *wechatPayValidator) getAcceptSerial(ctx context.Context) (string, error
 	return v.verifier.GetSerial(ctx)
 }
 
+// SetClock 设置校验时间戳时使用的时钟，未设置时使用本机系统时钟
+//
+// 请在验证器投入使用前完成设置
+func (v *wechatPayValidator) SetClock(c clock.Clock) {
+	v.clock = c
+}
+
+// SetTimestampTolerance 设置允许的微信支付时间戳与当前时间之差，tolerance <= 0 时使用 DefaultTimestampTolerance
+//
+// 请在验证器投入使用前完成设置
+func (v *wechatPayValidator) SetTimestampTolerance(tolerance time.Duration) {
+	v.tolerance = tolerance
+}
+
+// SetReplayCache 设置报文防重放缓存，设置后同一个 Wechatpay-Nonce 在时间戳有效期内重复出现时将返回 *ReplayError
+//
+// 请在验证器投入使用前完成设置
+func (v *wechatPayValidator) SetReplayCache(c auth.ReplayCache) {
+	v.replayCache = c
+}
+
+// SetLogger 设置结构化日志 Logger，验签通过时输出 Debug 日志，失败时输出包含失败原因的 Warn 日志
+//
+// 请在验证器投入使用前完成设置
+func (v *wechatPayValidator) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		v.logger = nil
+		return
+	}
+	v.logger = logging.Redacting(logger)
+}
+
+func (v *wechatPayValidator) now() time.Time {
+	return clock.OrSystem(v.clock).Now()
+}
+
+func (v *wechatPayValidator) timestampTolerance() time.Duration {
+	if v.tolerance <= 0 {
+		return DefaultTimestampTolerance
+	}
+	return v.tolerance
+}
+
 // getWechatPayHeader 从 http.Header 中获取 wechatPayHeader 信息
 func getWechatPayHeader(ctx context.Context, header http.Header) (wechatPayHeader, error) {
 	_ = ctx // Suppressing warnings
@@ -65,7 +217,9 @@ func getWechatPayHeader(ctx context.Context, header http.Header) (wechatPayHeade
 	getHeaderString := func(key string) (string, error) {
 		val := strings.TrimSpace(header.Get(key))
 		if val == "" {
-			return "", fmt.Errorf("key `%s` is empty in header, request-id=[%s]", key, requestID)
+			return "", newValidationError(
+				FailureReasonMissingHeader, fmt.Errorf("key `%s` is empty in header, request-id=[%s]", key, requestID),
+			)
 		}
 		return val, nil
 	}
@@ -77,7 +231,10 @@ func getWechatPayHeader(ctx context.Context, header http.Header) (wechatPayHeade
 		}
 		ret, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
-			return 0, fmt.Errorf("invalid `%s` in header, request-id=[%s], err:%w", key, requestID, err)
+			return 0, newValidationError(
+				FailureReasonInvalidHeader,
+				fmt.Errorf("invalid `%s` in header, request-id=[%s], err:%w", key, requestID, err),
+			)
 		}
 		return ret, nil
 	}
@@ -109,13 +266,16 @@ func getWechatPayHeader(ctx context.Context, header http.Header) (wechatPayHeade
 // checkWechatPayHeader 对 wechatPayHeader 内容进行检查，看是否符合要求
 //
 // 检查项：
-//   - Timestamp 与当前时间之差不得超过 FiveMinute;
-func checkWechatPayHeader(ctx context.Context, args wechatPayHeader) error {
+//   - Timestamp 与当前时间之差不得超过 tolerance（默认为 FiveMinute）;
+func checkWechatPayHeader(ctx context.Context, args wechatPayHeader, now time.Time, tolerance time.Duration) error {
 	// Suppressing warnings
 	_ = ctx
 
-	if math.Abs(float64(time.Now().Unix()-args.Timestamp)) >= consts.FiveMinute {
-		return fmt.Errorf("timestamp=[%d] expires, request-id=[%s]", args.Timestamp, args.RequestID)
+	if math.Abs(float64(now.Unix()-args.Timestamp)) >= tolerance.Seconds() {
+		return newValidationError(
+			FailureReasonTimestampExpired,
+			fmt.Errorf("timestamp=[%d] expires, request-id=[%s]", args.Timestamp, args.RequestID),
+		)
 	}
 	return nil
 }
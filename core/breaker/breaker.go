@@ -0,0 +1,277 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package breaker 微信支付 API v3 Go SDK 熔断器
+//
+// 微信支付接口异常时，大量请求会一直等待到超时（默认 consts.DefaultTimeout），导致调用方堆积 goroutine。
+// 在 core.Client 中设置 CircuitBreaker 后，某个 域名 + 接口 连续失败达到阈值即熔断，熔断期间的请求立即返回
+// *OpenError；熔断一段时间后进入半开状态，放行少量探测请求，探测成功则恢复。
+// 配合 option.WithBackupHost，熔断期间的幂等查询请求可以改为发往微信支付备份域名。
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+)
+
+// State 熔断器状态
+type State int
+
+const (
+	StateClosed   State = iota // 关闭：请求正常放行
+	StateOpen                  // 打开：请求立即失败
+	StateHalfOpen              // 半开：放行少量探测请求
+)
+
+// String 输出 State
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+// 默认熔断配置
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+	DefaultHalfOpenProbes   = 1
+	DefaultSuccessThreshold = 1
+)
+
+// Key 熔断的维度：域名与接口
+type Key struct {
+	Host      string // 请求的域名，如 api.mch.weixin.qq.com
+	Operation string // 接口，见 metrics.Operation
+}
+
+// String 输出 Key
+func (k Key) String() string {
+	return fmt.Sprintf("host=[%s] operation=[%s]", k.Host, k.Operation)
+}
+
+// Config 熔断配置，字段为零值时使用对应的默认值
+type Config struct {
+	FailureThreshold int           // 关闭状态下连续失败多少次后熔断
+	OpenTimeout      time.Duration // 熔断持续多久后进入半开状态
+	HalfOpenProbes   int           // 半开状态下允许同时进行的探测请求数
+	SuccessThreshold int           // 半开状态下探测成功多少次后恢复
+}
+
+func (c Config) withDefaults() Config {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultFailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = DefaultOpenTimeout
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = DefaultHalfOpenProbes
+	}
+	if c.SuccessThreshold <= 0 {
+		c.SuccessThreshold = DefaultSuccessThreshold
+	}
+	return c
+}
+
+// OpenError 熔断错误，请求未被发出
+type OpenError struct {
+	Key        Key           // 被熔断的域名与接口
+	State      State         // 熔断器状态，半开状态下探测请求已满时同样返回本错误
+	RetryAfter time.Duration // 预计进入半开状态的等待时间
+}
+
+// Error 输出 OpenError
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is %s, %s retry after %s", e.State, e.Key, e.RetryAfter)
+}
+
+// IsOpenError 判断当前 error 是否为 *OpenError（包括被包装的情况）
+func IsOpenError(err error) bool {
+	var openErr *OpenError
+	return errors.As(err, &openErr)
+}
+
+// StateChangeFunc 熔断器状态变化回调
+type StateChangeFunc func(key Key, from, to State)
+
+// circuit 单个 域名 + 接口 的熔断状态
+type circuit struct {
+	state      State
+	generation uint64 // 每次状态变化加一，用于丢弃过期的请求结果
+	failures   int
+	successes  int
+	probes     int
+	openedAt   time.Time
+}
+
+type stateChange struct {
+	key      Key
+	from, to State
+}
+
+// CircuitBreaker 按 域名 + 接口 熔断的熔断器，可以在多个 goroutine 及多个 core.Client 中并发使用
+type CircuitBreaker struct {
+	config   Config
+	clock    clock.Clock
+	onChange StateChangeFunc
+
+	lock     sync.Mutex
+	circuits map[Key]*circuit
+}
+
+// SetClock 设置熔断器使用的时钟，未设置时使用本机系统时钟
+//
+// 请在熔断器投入使用前完成设置
+func (b *CircuitBreaker) SetClock(c clock.Clock) *CircuitBreaker {
+	b.clock = clock.OrSystem(c)
+	return b
+}
+
+// OnStateChange 设置状态变化回调，回调在熔断器的锁之外同步执行，请勿在回调中执行耗时操作
+//
+// 请在熔断器投入使用前完成设置
+func (b *CircuitBreaker) OnStateChange(fn StateChangeFunc) *CircuitBreaker {
+	b.onChange = fn
+	return b
+}
+
+// State 获取 Key 当前的熔断状态
+func (b *CircuitBreaker) State(key Key) State {
+	b.lock.Lock()
+	c, ok := b.circuits[key]
+	if !ok {
+		b.lock.Unlock()
+		return StateClosed
+	}
+	change := b.refresh(key, c, b.clock.Now())
+	state := c.state
+	b.lock.Unlock()
+
+	b.notify(change)
+	return state
+}
+
+// Allow 判断请求能否发出，可以发出时返回 done，请求结束后必须调用 done 报告请求是否成功
+//
+// 熔断中或半开状态下探测请求已满时返回 *OpenError
+func (b *CircuitBreaker) Allow(key Key) (done func(success bool), err error) {
+	b.lock.Lock()
+	now := b.clock.Now()
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	change := b.refresh(key, c, now)
+
+	switch c.state {
+	case StateOpen:
+		err = &OpenError{Key: key, State: StateOpen, RetryAfter: c.openedAt.Add(b.config.OpenTimeout).Sub(now)}
+	case StateHalfOpen:
+		if c.probes >= b.config.HalfOpenProbes {
+			err = &OpenError{Key: key, State: StateHalfOpen}
+		} else {
+			c.probes++
+		}
+	}
+	generation := c.generation
+	b.lock.Unlock()
+
+	b.notify(change)
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() { b.report(key, generation, success) })
+	}, nil
+}
+
+// refresh 熔断超时后进入半开状态，调用方需持有锁
+func (b *CircuitBreaker) refresh(key Key, c *circuit, now time.Time) *stateChange {
+	if c.state == StateOpen && !now.Before(c.openedAt.Add(b.config.OpenTimeout)) {
+		return b.setState(key, c, StateHalfOpen, now)
+	}
+	return nil
+}
+
+func (b *CircuitBreaker) report(key Key, generation uint64, success bool) {
+	b.lock.Lock()
+	c, ok := b.circuits[key]
+	if !ok || c.generation != generation {
+		b.lock.Unlock()
+		return
+	}
+
+	var change *stateChange
+	now := b.clock.Now()
+	switch c.state {
+	case StateClosed:
+		if success {
+			c.failures = 0
+		} else if c.failures++; c.failures >= b.config.FailureThreshold {
+			change = b.setState(key, c, StateOpen, now)
+		}
+	case StateHalfOpen:
+		c.probes--
+		if !success {
+			change = b.setState(key, c, StateOpen, now)
+		} else if c.successes++; c.successes >= b.config.SuccessThreshold {
+			change = b.setState(key, c, StateClosed, now)
+		}
+	}
+	b.lock.Unlock()
+
+	b.notify(change)
+}
+
+// setState 切换状态并重置计数，调用方需持有锁
+func (b *CircuitBreaker) setState(key Key, c *circuit, state State, now time.Time) *stateChange {
+	change := &stateChange{key: key, from: c.state, to: state}
+	*c = circuit{state: state, generation: c.generation + 1}
+	if state == StateOpen {
+		c.openedAt = now
+	}
+	return change
+}
+
+func (b *CircuitBreaker) notify(change *stateChange) {
+	if change != nil && b.onChange != nil {
+		b.onChange(change.key, change.from, change.to)
+	}
+}
+
+// NewCircuitBreaker 使用 config 创建熔断器
+func NewCircuitBreaker(config Config) *CircuitBreaker {
+	return &CircuitBreaker{
+		config:   config.withDefaults(),
+		clock:    clock.System,
+		circuits: make(map[Key]*circuit),
+	}
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent 将请求标记为幂等请求，熔断时可以改为发往备份域名
+//
+// GET 与 HEAD 请求默认视为幂等请求；重复调用不会产生副作用的 POST 请求（如关闭订单）可以使用本方法标记
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// IsIdempotent 判断 ctx 是否被 WithIdempotent 标记为幂等请求
+func IsIdempotent(ctx context.Context) bool {
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package breaker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/breaker"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
+
+var testKey = breaker.Key{Host: "api.mch.weixin.qq.com", Operation: "/v3/pay/transactions/id/{transaction_id}"}
+
+func TestCircuitBreaker(t *testing.T) {
+	c := &mockClock{now: time.Unix(1700000000, 0)}
+	var changes []string
+	b := breaker.NewCircuitBreaker(breaker.Config{FailureThreshold: 2, OpenTimeout: 10 * time.Second}).
+		SetClock(c).
+		OnStateChange(func(key breaker.Key, from, to breaker.State) {
+			changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+		})
+
+	report := func(success bool) {
+		done, err := b.Allow(testKey)
+		require.NoError(t, err)
+		done(success)
+	}
+
+	// 成功请求会重置连续失败次数
+	report(false)
+	report(true)
+	report(false)
+	assert.Equal(t, breaker.StateClosed, b.State(testKey))
+	report(false)
+	assert.Equal(t, breaker.StateOpen, b.State(testKey))
+
+	c.now = c.now.Add(4 * time.Second)
+	_, err := b.Allow(testKey)
+	require.Error(t, err)
+	assert.True(t, breaker.IsOpenError(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, 6*time.Second, err.(*breaker.OpenError).RetryAfter)
+
+	// 其他接口不受影响
+	_, err = b.Allow(breaker.Key{Host: testKey.Host, Operation: "/v3/certificates"})
+	assert.NoError(t, err)
+
+	// 半开状态只放行一个探测请求，探测失败则重新熔断
+	c.now = c.now.Add(6 * time.Second)
+	probe, err := b.Allow(testKey)
+	require.NoError(t, err)
+	_, err = b.Allow(testKey)
+	require.Error(t, err)
+	assert.Equal(t, breaker.StateHalfOpen, err.(*breaker.OpenError).State)
+	probe(false)
+	assert.Equal(t, breaker.StateOpen, b.State(testKey))
+
+	// 探测成功则恢复
+	c.now = c.now.Add(10 * time.Second)
+	report(true)
+	assert.Equal(t, breaker.StateClosed, b.State(testKey))
+
+	assert.Equal(t, []string{
+		"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+	}, changes)
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	c := &mockClock{now: time.Unix(1700000000, 0)}
+	b := breaker.NewCircuitBreaker(breaker.Config{FailureThreshold: 1}).SetClock(c)
+
+	slow, err := b.Allow(testKey)
+	require.NoError(t, err)
+	failed, err := b.Allow(testKey)
+	require.NoError(t, err)
+
+	failed(false)
+	assert.Equal(t, breaker.StateOpen, b.State(testKey))
+
+	// 熔断前发出的请求的结果不影响熔断状态，done 重复调用无效
+	slow(true)
+	slow(true)
+	assert.Equal(t, breaker.StateOpen, b.State(testKey))
+}
+
+func TestWithIdempotent(t *testing.T) {
+	assert.False(t, breaker.IsIdempotent(context.Background()))
+	assert.True(t, breaker.IsIdempotent(breaker.WithIdempotent(context.Background())))
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package chinatime 微信支付 API v3 使用的北京时间
+//
+// 微信支付要求请求中的时间（如下单的 time_expire、转账批次与代金券批次的起止时间）为 RFC3339 格式的北京时间，
+// 形如 2018-06-08T10:34:56+08:00。time.Time.Format 使用时间自身的时区，在时区为 UTC 的主机上会输出
+// 2018-06-08T02:34:56Z，部分接口会因此返回 "time_expire 格式错误"。
+// 本包提供与主机时区无关的格式化与解析，SDK 中的模型与请求参数均使用本包格式化时间。
+package chinatime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Location 北京时间（UTC+8，无夏令时），使用固定时区以避免依赖主机的时区数据库
+var Location = time.FixedZone("CST", 8*60*60)
+
+// 时间格式
+const (
+	Layout     = time.RFC3339          // 接口中的时间格式
+	DateLayout = "2006-01-02"          // 接口中的日期格式，如账单日期
+	BillLayout = "2006-01-02 15:04:05" // 账单中的时间格式
+)
+
+// In 返回 t 对应的北京时间
+func In(t time.Time) time.Time {
+	return t.In(Location)
+}
+
+// Date 返回北京时间的指定时刻
+func Date(year int, month time.Month, day, hour, min, sec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, 0, Location)
+}
+
+// Format 将 t 格式化为 RFC3339 格式的北京时间，秒以下的部分被舍去
+func Format(t time.Time) string {
+	return t.In(Location).Format(Layout)
+}
+
+// FormatDate 将 t 格式化为北京时间的日期，如 "2019-06-11"
+func FormatDate(t time.Time) string {
+	return t.In(Location).Format(DateLayout)
+}
+
+// Parse 解析 RFC3339 格式的时间，或不带时区的账单时间（视为北京时间），返回北京时间
+func Parse(s string) (time.Time, error) {
+	if t, err := time.Parse(Layout, s); err == nil {
+		return t.In(Location), nil
+	}
+	if t, err := time.ParseInLocation(BillLayout, s, Location); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(DateLayout, s, Location); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected format %s", s, Layout)
+}
+
+// Window 以当前时间为基准的时间窗口，如订单失效时间需在当前时间 1 分钟之后
+type Window struct {
+	Min time.Duration // 与当前时间的最小间隔
+	Max time.Duration // 与当前时间的最大间隔，0 表示不限制
+}
+
+// Validate 校验 t 是否在 now 之后的 [Min, Max] 窗口内，name 为字段名，用于错误信息
+func (w Window) Validate(name string, t, now time.Time) error {
+	ahead := t.Sub(now)
+	if ahead < w.Min {
+		return fmt.Errorf(
+			"%s %s must be at least %s after %s", name, Format(t), w.Min, Format(now),
+		)
+	}
+	if w.Max > 0 && ahead > w.Max {
+		return fmt.Errorf(
+			"%s %s must be at most %s after %s", name, Format(t), w.Max, Format(now),
+		)
+	}
+	return nil
+}
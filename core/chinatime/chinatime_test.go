@@ -0,0 +1,58 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package chinatime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	utc := time.Date(2018, 6, 8, 2, 34, 56, 789, time.UTC)
+	assert.Equal(t, "2018-06-08T10:34:56+08:00", Format(utc))
+	assert.Equal(t, "2018-06-08T10:34:56+08:00", Format(utc.In(time.FixedZone("PST", -8*3600))))
+	assert.Equal(t, "2018-06-08", FormatDate(utc))
+
+	// 跨日期的时间以北京时间的日期为准
+	assert.Equal(t, "2018-06-09", FormatDate(time.Date(2018, 6, 8, 16, 0, 0, 0, time.UTC)))
+	assert.Equal(t, Date(2018, 6, 8, 10, 34, 56), In(utc.Truncate(time.Second)))
+}
+
+func TestParse(t *testing.T) {
+	want := Date(2018, 6, 8, 10, 34, 56)
+	for _, s := range []string{
+		"2018-06-08T10:34:56+08:00",
+		"2018-06-08T02:34:56Z",
+		"2018-06-08 10:34:56",
+	} {
+		got, err := Parse(s)
+		require.NoError(t, err, s)
+		assert.True(t, want.Equal(got), s)
+		assert.Equal(t, Location, got.Location(), s)
+	}
+
+	day, err := Parse("2018-06-08")
+	require.NoError(t, err)
+	assert.Equal(t, Date(2018, 6, 8, 0, 0, 0), day)
+
+	_, err = Parse("2018/06/08 10:34:56")
+	assert.Error(t, err)
+}
+
+func TestWindow_Validate(t *testing.T) {
+	now := Date(2018, 6, 8, 10, 0, 0)
+	w := Window{Min: time.Minute, Max: 2 * time.Hour}
+
+	assert.NoError(t, w.Validate("time_expire", now.Add(time.Minute), now))
+	assert.NoError(t, w.Validate("time_expire", now.Add(2*time.Hour), now))
+	assert.EqualError(t, w.Validate("time_expire", now.Add(30*time.Second), now),
+		"time_expire 2018-06-08T10:00:30+08:00 must be at least 1m0s after 2018-06-08T10:00:00+08:00")
+	assert.EqualError(t, w.Validate("time_expire", now.Add(3*time.Hour), now),
+		"time_expire 2018-06-08T13:00:00+08:00 must be at most 2h0m0s after 2018-06-08T10:00:00+08:00")
+
+	// Max 为 0 时不限制上限
+	assert.NoError(t, Window{Min: time.Minute}.Validate("time_expire", now.AddDate(1, 0, 0), now))
+}
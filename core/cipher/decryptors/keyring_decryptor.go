@@ -0,0 +1,40 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package decryptors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+// KeyRingDecryptor 使用 keyring.KeyRing 中私钥解密的微信支付字符串解密器
+//
+// 优先使用当前私钥解密，失败时依次尝试重叠期内的旧私钥
+type KeyRingDecryptor struct {
+	ring *keyring.KeyRing
+}
+
+// Decrypt 使用 KeyRing 中的商户私钥对字符串进行解密
+func (d *KeyRingDecryptor) Decrypt(_ context.Context, ciphertext string) (plaintext string, err error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	if d.ring == nil {
+		return "", fmt.Errorf("you must set keyring to use KeyRingDecryptor")
+	}
+
+	for _, key := range d.ring.Keys() {
+		if plaintext, err = utils.DecryptOAEP(ciphertext, key.PrivateKey); err == nil {
+			return plaintext, nil
+		}
+	}
+	return "", err
+}
+
+// NewKeyRingDecryptor 使用 keyring.KeyRing 初始化 KeyRingDecryptor
+func NewKeyRingDecryptor(ring *keyring.KeyRing) *KeyRingDecryptor {
+	return &KeyRingDecryptor{ring: ring}
+}
@@ -4,6 +4,7 @@ package decryptors
 
 import (
 	"context"
+	"crypto"
 	"crypto/rsa"
 
 	"github.com/jemuri/wechatpay-go/utils"
@@ -13,6 +14,8 @@ import (
 type WechatPayDecryptor struct {
 	// 商户私钥
 	privateKey *rsa.PrivateKey
+	// 商户私钥句柄，私钥保存在 KMS/HSM 等外部服务中时使用
+	decrypter crypto.Decrypter
 }
 
 // Decrypt 使用商户私钥对字符串进行解密
@@ -20,6 +23,9 @@ func (d *WechatPayDecryptor) Decrypt(_ context.Context, ciphertext string) (plai
 	if ciphertext == "" {
 		return "", nil
 	}
+	if d.decrypter != nil {
+		return utils.DecryptOAEPWithDecrypter(ciphertext, d.decrypter)
+	}
 	return utils.DecryptOAEP(ciphertext, d.privateKey)
 }
 
@@ -27,3 +33,10 @@ func (d *WechatPayDecryptor) Decrypt(_ context.Context, ciphertext string) (plai
 func NewWechatPayDecryptor(privateKey *rsa.PrivateKey) *WechatPayDecryptor {
 	return &WechatPayDecryptor{privateKey: privateKey}
 }
+
+// NewWechatPayDecryptorWithDecrypter 使用 crypto.Decrypter 初始化一个 WechatPayDecryptor
+//
+// 商户私钥保存在 KMS/HSM 等外部服务中时，只需提供实现了 crypto.Decrypter 的 RSA 私钥句柄
+func NewWechatPayDecryptorWithDecrypter(decrypter crypto.Decrypter) *WechatPayDecryptor {
+	return &WechatPayDecryptor{decrypter: decrypter}
+}
@@ -69,3 +69,16 @@ func TestWechatPayDecryptor_DecryptEmpty(t *testing.T) {
 }
 
 func testingKey(s string) string { return strings.ReplaceAll(s, "TESTING KEY", "PRIVATE KEY") }
+
+func TestWechatPayDecryptor_DecryptWithDecrypter(t *testing.T) {
+	privateKey, err := utils.LoadPrivateKey(testingKey(testPrivateKey))
+	require.NoError(t, err)
+	decryptor := NewWechatPayDecryptorWithDecrypter(privateKey)
+
+	plaintext, err := decryptor.Decrypt(context.Background(), testCipherText)
+	require.NoError(t, err)
+	assert.Equal(t, testPlainText, plaintext)
+
+	_, err = NewWechatPayDecryptorWithDecrypter(nil).Decrypt(context.Background(), testCipherText)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
@@ -28,8 +29,16 @@ import (
 
 	"github.com/jemuri/wechatpay-go/core/auth"
 	"github.com/jemuri/wechatpay-go/core/auth/credentials"
+	"github.com/jemuri/wechatpay-go/core/auth/validators"
+	"github.com/jemuri/wechatpay-go/core/breaker"
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 	"github.com/jemuri/wechatpay-go/core/cipher"
+	"github.com/jemuri/wechatpay-go/core/clock"
 	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+	"github.com/jemuri/wechatpay-go/core/ratelimit"
+	"github.com/jemuri/wechatpay-go/core/validation"
 )
 
 var (
@@ -66,6 +75,49 @@ type Client struct {
 	validator  auth.Validator
 	signer     auth.Signer
 	cipher     cipher.Cipher
+
+	clock              clock.Clock
+	skewClock          *clock.SkewClock
+	timestampTolerance time.Duration
+	replayCache        auth.ReplayCache
+
+	metrics metrics.Recorder
+	logger  *slog.Logger
+
+	mchID   string
+	limiter ratelimit.Limiter
+
+	breaker     *breaker.CircuitBreaker
+	backupHosts map[string]*url.URL
+
+	dryRun bool
+
+	requestValidation bool
+}
+
+// clockSetter 可以设置时钟的 auth.Validator，如 validators.WechatPayResponseValidator
+type clockSetter interface {
+	SetClock(c clock.Clock)
+}
+
+// timestampToleranceSetter 可以设置时间戳容差的 auth.Validator，如 validators.WechatPayResponseValidator
+type timestampToleranceSetter interface {
+	SetTimestampTolerance(tolerance time.Duration)
+}
+
+// replayCacheSetter 可以设置防重放缓存的 auth.Validator，如 validators.WechatPayResponseValidator
+type replayCacheSetter interface {
+	SetReplayCache(c auth.ReplayCache)
+}
+
+// loggerSetter 可以设置结构化日志 Logger 的 auth.Validator，如 validators.WechatPayResponseValidator
+type loggerSetter interface {
+	SetLogger(logger *slog.Logger)
+}
+
+// mchIDGetter 可以提供商户号的 auth.Signer，如 signers.SHA256WithRSASigner
+type mchIDGetter interface {
+	GetMchID() string
 }
 
 // NewClient 初始化一个微信支付API v3 HTTPClient
@@ -94,23 +146,56 @@ func NewClientWithDialSettings(ctx context.Context, settings *DialSettings) (*Cl
 // NewClientWithValidator 使用原 Client 复制一个新的 Client，并设置新 Client 的 validator。
 // 原 Client 不受任何影响
 func NewClientWithValidator(client *Client, validator auth.Validator) *Client {
-	return &Client{
-		httpClient: client.httpClient,
-		credential: client.credential,
-		signer:     client.signer,
-		validator:  validator,
-		cipher:     client.cipher,
-	}
+	newClient := &Client{
+		httpClient:         client.httpClient,
+		credential:         client.credential,
+		signer:             client.signer,
+		validator:          validator,
+		cipher:             client.cipher,
+		clock:              client.clock,
+		skewClock:          client.skewClock,
+		timestampTolerance: client.timestampTolerance,
+		replayCache:        client.replayCache,
+		metrics:            client.metrics,
+		logger:             client.logger,
+		mchID:              client.mchID,
+		limiter:            client.limiter,
+		breaker:            client.breaker,
+		backupHosts:        client.backupHosts,
+		dryRun:             client.dryRun,
+		requestValidation:  client.requestValidation,
+	}
+	newClient.configureValidator()
+	return newClient
 }
 
 func initClientWithSettings(_ context.Context, settings *DialSettings) *Client {
 	client := &Client{
-		signer:     settings.Signer,
-		validator:  settings.Validator,
-		credential: &credentials.WechatPayCredentials{Signer: settings.Signer},
-		httpClient: settings.HTTPClient,
-		cipher:     settings.Cipher,
-	}
+		signer:             settings.Signer,
+		validator:          settings.Validator,
+		httpClient:         settings.HTTPClient,
+		cipher:             settings.Cipher,
+		clock:              clock.OrSystem(settings.Clock),
+		timestampTolerance: settings.TimestampTolerance,
+		replayCache:        settings.ReplayCache,
+		metrics:            metrics.OrNop(settings.MetricsRecorder),
+		logger:             logging.Redacting(settings.Logger),
+		limiter:            settings.RateLimiter,
+		breaker:            settings.CircuitBreaker,
+		backupHosts:        settings.BackupHosts,
+		dryRun:             settings.DryRun,
+		requestValidation:  settings.RequestValidation,
+	}
+	if getter, ok := settings.Signer.(mchIDGetter); ok {
+		client.mchID = getter.GetMchID()
+	}
+
+	if settings.ClockSkewDetection {
+		client.skewClock = clock.NewSkewClock(client.clock)
+		client.clock = client.skewClock
+	}
+	client.credential = &credentials.WechatPayCredentials{Signer: settings.Signer, Clock: client.clock}
+	client.configureValidator()
 
 	if client.httpClient == nil {
 		client.httpClient = &http.Client{
@@ -120,6 +205,46 @@ func initClientWithSettings(_ context.Context, settings *DialSettings) *Client {
 	return client
 }
 
+// validatorCloner 可以复制自身的 validator，如 validators.WechatPayResponseValidator
+type validatorCloner interface {
+	Clone() auth.Validator
+}
+
+// configureValidator 将 Client 的时钟、时间戳容差、防重放缓存与 Logger 同步到 validator 中
+//
+// 仅在使用了自定义时钟、时钟偏差补偿、自定义容差、防重放缓存或 Logger 时才需要配置。validator 实现了 Clone 时
+// 配置其副本，调用方传入的实例不受影响，可以被多个 Client 共享；否则直接修改 validator
+func (client *Client) configureValidator() {
+	if client.clock == clock.System && client.timestampTolerance <= 0 && client.replayCache == nil &&
+		client.logger == logging.Discard {
+		return
+	}
+	if cloner, ok := client.validator.(validatorCloner); ok {
+		client.validator = cloner.Clone()
+	}
+
+	if client.clock != clock.System {
+		if v, ok := client.validator.(clockSetter); ok {
+			v.SetClock(client.clock)
+		}
+	}
+	if client.timestampTolerance > 0 {
+		if v, ok := client.validator.(timestampToleranceSetter); ok {
+			v.SetTimestampTolerance(client.timestampTolerance)
+		}
+	}
+	if client.replayCache != nil {
+		if v, ok := client.validator.(replayCacheSetter); ok {
+			v.SetReplayCache(client.replayCache)
+		}
+	}
+	if client.logger != logging.Discard {
+		if v, ok := client.validator.(loggerSetter); ok {
+			v.SetLogger(client.logger)
+		}
+	}
+}
+
 func initSettings(opts []ClientOption) (*DialSettings, error) {
 	var (
 		o   DialSettings
@@ -169,6 +294,27 @@ func (client *Client) Upload(ctx context.Context, requestURL, meta, reqBody, for
 	return client.doRequest(ctx, http.MethodPost, requestURL, nil, formContentType, strings.NewReader(reqBody), meta)
 }
 
+// UploadStream 以流式 Body 向微信支付上传文件，避免将整个文件读入内存
+//
+// body 为完整的 multipart/form-data 内容，contentLength 为其字节数，meta 为参与签名的 meta JSON。
+// 请求结束后如果 body 实现了 io.Closer 将被关闭。推荐使用 services/fileuploader 中各上传接口的实现
+func (client *Client) UploadStream(
+	ctx context.Context, requestURL, meta string, body io.Reader, contentLength int64, formContentType string,
+) (*APIResult, error) {
+	if closer, ok := body.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+	return client.doRequest(
+		ctx, http.MethodPost, requestURL, nil, formContentType, &sizedReader{Reader: body, size: contentLength}, meta,
+	)
+}
+
+// sizedReader 已知长度的请求 Body，用于设置请求的 Content-Length
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
 func (client *Client) requestWithJSONBody(ctx context.Context, method, requestURL string, body interface{}) (
 	*APIResult, error,
 ) {
@@ -191,15 +337,17 @@ func (client *Client) doRequest(
 ) (*APIResult, error) {
 
 	var (
-		err           error
-		authorization string
-		request       *http.Request
+		err     error
+		request *http.Request
 	)
 
 	// Construct Request
 	if request, err = http.NewRequestWithContext(ctx, method, requestURL, reqBody); err != nil {
 		return nil, err
 	}
+	if sized, ok := reqBody.(*sizedReader); ok {
+		request.ContentLength = sized.size
+	}
 
 	// Header Setting Priority:
 	// Fixed Headers > Per-Request Header Parameters
@@ -218,36 +366,201 @@ func (client *Client) doRequest(
 	ua := fmt.Sprintf(consts.UserAgentFormat, consts.Version, runtime.GOOS, runtime.Version())
 	request.Header.Set(consts.UserAgent, ua)
 
-	// Set Authentication
-	if authorization, err = client.credential.GenerateAuthorizationHeader(
-		ctx, method, request.URL.RequestURI(),
-		signBody,
-	); err != nil {
-		return nil, fmt.Errorf("generate authorization err:%s", err.Error())
+	dryRun := client.dryRun || IsDryRun(ctx)
+
+	// Acquire Rate Limit Permit before signing, so that waiting does not age the Authorization timestamp
+	limitKey := ratelimit.Key{MchID: client.mchID, Operation: operationOf(ctx, request)}
+	if client.limiter != nil && !dryRun {
+		if err = client.limiter.Wait(ctx, limitKey); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set Authentication, the signer is kept in the request context so that retries can be re-signed
+	sign := func(r *http.Request) error {
+		authorization, err := client.credential.GenerateAuthorizationHeader(
+			r.Context(), r.Method, r.URL.RequestURI(),
+			signBody,
+		)
+		if err != nil {
+			return fmt.Errorf("generate authorization err:%s", err.Error())
+		}
+		r.Header.Set(consts.Authorization, authorization)
+		return nil
+	}
+	request = request.WithContext(withSigner(ctx, sign))
+	if err = sign(request); err != nil {
+		return nil, err
 	}
-	request.Header.Set(consts.Authorization, authorization)
 
 	// indicate Wechatpay-Serial that client can verify
 	if serial, err := client.validator.GetAcceptSerial(ctx); err == nil {
 		request.Header.Set(consts.WechatPaySerial, serial)
 	}
 
+	client.logger.DebugContext(
+		ctx, "wechatpay request sending",
+		slog.String(logging.KeyMethod, request.Method), slog.String(logging.KeyPath, request.URL.Path),
+		slog.Any(logging.KeyHeader, request.Header),
+	)
+
+	// Dry Run: return the signed request without sending it
+	if dryRun {
+		dryRunErr, err := newDryRunError(request, signBody)
+		if err != nil {
+			return nil, err
+		}
+		return &APIResult{Request: request}, dryRunErr
+	}
+
+	// Check Circuit Breaker, idempotent requests may be routed to the backup host
+	done, err := client.allowRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
 	// Send HTTP Request
+	start := time.Now()
 	result, err := client.doHTTP(request)
+	done(err == nil && result.Response.StatusCode < http.StatusInternalServerError)
 	if err != nil {
+		client.observeRequest(ctx, request, nil, metrics.ResultNetworkError, start, err)
 		return result, err
 	}
 	// Check if Success
-	if err = CheckResponse(result.Response); err != nil {
+	err = CheckResponse(result.Response)
+	client.feedbackRateLimit(limitKey, result.Response, err)
+	if err != nil {
+		// 本机时钟偏差过大时请求会被拒绝，从验签通过的错误应答中学习服务器时间以便后续请求恢复
+		client.observeServerTime(ctx, result.Response)
+		client.observeRequest(ctx, request, result.Response, metrics.ResultAPIError, start, err)
 		return result, err
 	}
 	// Validate WechatPay Signature
-	if err = client.validator.Validate(ctx, result.Response); err != nil {
+	err = client.validator.Validate(ctx, result.Response)
+	// Compensate Clock Skew
+	client.observeServerTime(ctx, result.Response)
+	if err != nil {
+		client.metrics.IncValidationFailure(ctx, metrics.SourceResponse, validators.FailureReason(err))
+		client.observeRequest(ctx, request, result.Response, metrics.ResultValidationError, start, err)
 		return result, err
 	}
+	client.observeRequest(ctx, request, result.Response, metrics.ResultSuccess, start, nil)
 	return result, nil
 }
 
+// observeRequest 记录一次请求的监控指标并输出日志
+func (client *Client) observeRequest(
+	ctx context.Context, request *http.Request, response *http.Response, result string, start time.Time, err error,
+) {
+	m := metrics.RequestMetric{
+		Method:   request.Method,
+		Result:   result,
+		Duration: time.Since(start),
+	}
+	m.Operation = operationOf(ctx, request)
+	if response != nil {
+		m.StatusCode = response.StatusCode
+	}
+	if apiErr, ok := err.(*APIError); ok {
+		m.ErrorCode = apiErr.Code
+	}
+
+	client.metrics.ObserveRequest(ctx, m)
+	client.logRequest(ctx, m, request, response, err)
+}
+
+// allowRequest 使用熔断器判断请求能否发出，请求结束后需调用返回的 done 报告请求是否成功
+//
+// 主域名的接口熔断且配置了备份地址时，幂等请求将改为发往备份地址
+func (client *Client) allowRequest(ctx context.Context, request *http.Request) (done func(success bool), err error) {
+	if client.breaker == nil {
+		return func(bool) {}, nil
+	}
+
+	operation := operationOf(ctx, request)
+	if done, err = client.breaker.Allow(breaker.Key{Host: request.URL.Host, Operation: operation}); err == nil {
+		return done, nil
+	}
+
+	backup, ok := client.backupHosts[request.URL.Host]
+	if !ok || !IsIdempotent(request) {
+		return nil, err
+	}
+	backupDone, backupErr := client.breaker.Allow(breaker.Key{Host: backup.Host, Operation: operation})
+	if backupErr != nil {
+		return nil, err
+	}
+
+	client.logger.LogAttrs(
+		ctx, slog.LevelWarn, "wechatpay request failover to backup host",
+		slog.String(logging.KeyMethod, request.Method), slog.String(logging.KeyPath, request.URL.Path),
+		slog.String(logging.KeyHost, request.URL.Host), slog.String(logging.KeyBackupHost, backup.Host),
+	)
+	request.URL.Scheme, request.URL.Host = backup.Scheme, backup.Host
+	request.Host = backup.Host
+	return backupDone, nil
+}
+
+// operationOf 获取请求的接口名，优先使用 metrics.WithOperation 指定的接口名，否则使用请求路径模板
+func operationOf(ctx context.Context, request *http.Request) string {
+	if operation, ok := metrics.OperationFromContext(ctx); ok {
+		return operation
+	}
+	return metrics.Operation(request.URL.Path)
+}
+
+// feedbackRateLimit 将请求是否被微信支付限频（HTTP 429 或限频错误码）反馈给限流器
+func (client *Client) feedbackRateLimit(key ratelimit.Key, response *http.Response, err error) {
+	if client.limiter == nil {
+		return
+	}
+
+	limited := response.StatusCode == http.StatusTooManyRequests
+	if apiErr, ok := err.(*APIError); ok && ratelimit.IsRateLimitCode(apiErr.Code) {
+		limited = true
+	}
+	client.limiter.Feedback(key, limited)
+}
+
+// logRequest 输出一次请求的日志，成功时为 Info，APIError 与验签失败为 Warn，网络错误为 Error
+func (client *Client) logRequest(
+	ctx context.Context, m metrics.RequestMetric, request *http.Request, response *http.Response, err error,
+) {
+	level := slog.LevelInfo
+	switch m.Result {
+	case metrics.ResultAPIError, metrics.ResultValidationError:
+		level = slog.LevelWarn
+	case metrics.ResultNetworkError:
+		level = slog.LevelError
+	}
+	if !client.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String(logging.KeyMethod, request.Method),
+		slog.String(logging.KeyPath, request.URL.Path),
+		slog.String(logging.KeyResult, m.Result),
+		slog.Duration(logging.KeyLatency, m.Duration),
+	}
+	if response != nil {
+		attrs = append(
+			attrs,
+			slog.String(logging.KeyRequestID, response.Header.Get(consts.RequestID)),
+			slog.Int(logging.KeyStatus, m.StatusCode),
+		)
+	}
+	if m.ErrorCode != "" {
+		attrs = append(attrs, slog.String(logging.KeyErrorCode, m.ErrorCode))
+	}
+	if err != nil {
+		// *APIError 通过 LogValue 输出，避免应答 Header 被写入日志
+		attrs = append(attrs, slog.Any(logging.KeyError, err))
+	}
+	client.logger.LogAttrs(ctx, level, "wechatpay request completed", attrs...)
+}
+
 // Request 向微信支付发送请求
 //
 // 相比于 Get / Post / Put / Patch / Delete 方法，本方法可以设置更多内容
@@ -303,6 +616,36 @@ func (client *Client) doHTTP(req *http.Request) (result *APIResult, err error) {
 	return result, err
 }
 
+// timestampVerifier 可以只校验应答签名并返回应答时间戳的验证器，如 validators.WechatPayResponseValidator
+type timestampVerifier interface {
+	VerifyTimestamp(ctx context.Context, response *http.Response) (time.Time, error)
+}
+
+// observeServerTime 启用时钟偏差补偿时，根据应答报文中验签通过的 Wechatpay-Timestamp 更新时钟偏移量
+//
+// 未经签名保护的时间（如 Date 头部）不会被采用；验证器不支持单独验签（如 WithoutValidator）时不做补偿。
+// 重放的旧报文与超出 clock.MaxSkewOffset 的偏移量由 clock.SkewClock.Observe 拒绝。
+func (client *Client) observeServerTime(ctx context.Context, response *http.Response) {
+	if client.skewClock == nil {
+		return
+	}
+
+	verifier, ok := client.validator.(timestampVerifier)
+	if !ok {
+		return
+	}
+	serverTime, err := verifier.VerifyTimestamp(ctx, response)
+	if err != nil {
+		return
+	}
+	client.skewClock.Observe(serverTime)
+}
+
+// Clock 获取 Client 所使用的时钟，启用时钟偏差补偿时返回补偿后的时钟
+func (client *Client) Clock() clock.Clock {
+	return clock.OrSystem(client.clock)
+}
+
 // EncryptRequest 使用 cipher 对请求结构进行原地加密，并返回加密所用的平台证书的序列号。
 // 未设置 cipher 时将跳过加密，并返回空序列号。
 //
@@ -314,6 +657,29 @@ func (client *Client) EncryptRequest(ctx context.Context, req interface{}) (stri
 	return client.cipher.Encrypt(ctx, req)
 }
 
+// RequestValidator 可以在本地校验参数的请求，服务包中的请求模型均实现了该接口
+type RequestValidator interface {
+	Validate(opts ...validation.Option) error
+}
+
+// ValidateRequest 开启请求校验（见 option.WithRequestValidation）时，使用 req 的 Validate 方法校验请求参数，
+// 未开启或 req 没有 Validate 方法时将跳过校验
+//
+// 服务接口在加密敏感字段与签名前调用本方法，校验失败时不会发出请求。时间窗口使用 Client 的时钟校验，见 Clock
+func (client *Client) ValidateRequest(req interface{}) error {
+	if !client.requestValidation {
+		return nil
+	}
+	switch v := req.(type) {
+	case RequestValidator:
+		return v.Validate(validation.WithClock(client.clock))
+	case interface{ Validate() error }:
+		// 兼容未接受校验选项的自定义请求
+		return v.Validate()
+	}
+	return nil
+}
+
 // DecryptResponse 使用 cipher 对应答结构进行原地解密，未设置 cipher 时将跳过解密
 //
 // 本方法会对结构中的敏感字段进行原地解密，因此需要传入结构体的指针。
@@ -406,6 +772,11 @@ func CreateFormField(w *multipart.Writer, fieldName, contentType string, fieldVa
 //
 // 如果要设置上述内容，则CreateFormFile(w, "file_test.mp4", "video/mp4", pic1)
 func CreateFormFile(w *multipart.Writer, filename, contentType string, file []byte) error {
+	return CreateFormFileWithReader(w, filename, contentType, bytes.NewReader(file))
+}
+
+// CreateFormFileWithReader 设置form-data中的文件，文件内容从 file 中流式读取
+func CreateFormFileWithReader(w *multipart.Writer, filename, contentType string, file io.Reader) error {
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, "file", filename))
 	h.Set("Content-Type", contentType)
@@ -413,7 +784,7 @@ func CreateFormFile(w *multipart.Writer, filename, contentType string, file []by
 	if err != nil {
 		return err
 	}
-	_, err = part.Write(file)
+	_, err = io.Copy(part, file)
 	return err
 }
 
@@ -491,7 +862,7 @@ func ParameterToString(obj interface{}, collectionFormat string) string {
 	if reflect.TypeOf(obj).Kind() == reflect.Slice {
 		return strings.Trim(strings.Replace(fmt.Sprint(obj), " ", delimiter, -1), "[]")
 	} else if t, ok := obj.(time.Time); ok {
-		return t.Format(time.RFC3339)
+		return chinatime.Format(t)
 	}
 
 	return fmt.Sprintf("%v", obj)
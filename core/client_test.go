@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"math/rand"
 	"mime/multipart"
 	"net/http"
@@ -29,8 +30,17 @@ import (
 	"github.com/jemuri/wechatpay-go/core"
 	"github.com/jemuri/wechatpay-go/core/auth"
 	"github.com/jemuri/wechatpay-go/core/auth/signers"
+	"github.com/jemuri/wechatpay-go/core/auth/validators"
 	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
+	"github.com/jemuri/wechatpay-go/core/breaker"
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/jemuri/wechatpay-go/core/metrics/prometheus"
 	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/core/ratelimit"
+	"github.com/jemuri/wechatpay-go/core/validation"
 	"github.com/jemuri/wechatpay-go/utils"
 )
 
@@ -335,6 +345,323 @@ func TestClientVerifyFail(t *testing.T) {
 	assert.Contains(t, err.Error(), "verify fail")
 }
 
+func TestClientWithMetricsRecorder(t *testing.T) {
+	exporter := prometheus.NewExporter("", nil)
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithMetricsRecorder(exporter),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/pay/transactions/id/4200000001202106243137":
+			writeResponse(w)
+		case "/v3/pay/transactions/id/4200000001202106249999":
+			w.Header().Set("Request-Id", "0")
+			w.Header().Set("Wechatpay-Serial", utils.GetCertificateSerialNumber(*wechatPayCertificate))
+			w.Header().Set("Wechatpay-Nonce", "this-is-a-nonce")
+			w.Header().Set("Wechatpay-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+			w.Header().Set("Wechatpay-Signature", "AABB")
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"ORDER_NOT_EXIST","message":"订单不存在"}`))
+		}
+	}))
+	defer ts.Close()
+
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/id/4200000001202106243137?mchid=1900009191")
+	require.NoError(t, err)
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/id/4200000001202106249999?mchid=1900009191")
+	require.Error(t, err)
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/out-trade-no/not-exist?mchid=1900009191")
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	_, err = exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, `wechatpay_client_requests_total{method="GET",`+
+		`operation="/v3/pay/transactions/id/{transaction_id}",status_code="200",error_code="",result="success"} 1`)
+	assert.Contains(t, output, `wechatpay_client_requests_total{method="GET",`+
+		`operation="/v3/pay/transactions/id/{transaction_id}",status_code="200",error_code="",`+
+		`result="validation_error"} 1`)
+	assert.Contains(t, output, `wechatpay_client_requests_total{method="GET",`+
+		`operation="/v3/pay/transactions/out-trade-no/{out_trade_no}",status_code="404",`+
+		`error_code="ORDER_NOT_EXIST",result="api_error"} 1`)
+	assert.Contains(t, output, `wechatpay_validation_failures_total{source="response",reason="signature_mismatch"} 1`)
+}
+
+func TestClientWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/pay/transactions/id/4200000001202106243137" {
+			writeResponse(w)
+			return
+		}
+		w.Header().Set("Request-Id", "request-id-404")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"ORDER_NOT_EXIST","message":"订单不存在"}`))
+	}))
+	defer ts.Close()
+
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/id/4200000001202106243137?mchid=1900009191")
+	require.NoError(t, err)
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/out-trade-no/not-exist?mchid=1900009191")
+	require.Error(t, err)
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		record := map[string]interface{}{}
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 5)
+
+	assert.Equal(t, "wechatpay request sending", records[0]["msg"])
+	assert.Equal(
+		t, []interface{}{logging.RedactedValue}, records[0]["header"].(map[string]interface{})["Authorization"],
+	)
+	assert.Equal(t, "DEBUG", records[0]["level"])
+
+	assert.Equal(t, "wechatpay signature verified", records[1]["msg"])
+	assert.Equal(t, "DEBUG", records[1]["level"])
+
+	assert.Equal(t, "wechatpay request completed", records[2]["msg"])
+	assert.Equal(t, "INFO", records[2]["level"])
+	assert.Equal(t, "GET", records[2][logging.KeyMethod])
+	assert.Equal(t, "/v3/pay/transactions/id/4200000001202106243137", records[2][logging.KeyPath])
+	assert.Equal(t, float64(http.StatusOK), records[2][logging.KeyStatus])
+	assert.Contains(t, records[2], logging.KeyLatency)
+
+	assert.Equal(t, "WARN", records[4]["level"])
+	assert.Equal(t, "request-id-404", records[4][logging.KeyRequestID])
+	assert.Equal(t, "ORDER_NOT_EXIST", records[4][logging.KeyErrorCode])
+}
+
+func TestClientLogOmitsAPIErrorHeader(t *testing.T) {
+	const signature = "c2lnbmF0dXJlLW9mLWFuLWVycm9yLXJlc3BvbnNl"
+
+	var buf bytes.Buffer
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "request-id-400")
+		w.Header().Set("Wechatpay-Signature", signature)
+		w.Header().Set("Wechatpay-Nonce", "nonce-of-an-error-response")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"PARAM_ERROR","message":"参数错误"}`))
+	}))
+	defer ts.Close()
+
+	_, err = client.Get(ctx, ts.URL+"/v3/pay/transactions/out-trade-no/1217752501201407033233368018?mchid=1900009191")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), signature)
+
+	assert.NotContains(t, buf.String(), signature)
+	assert.NotContains(t, buf.String(), "nonce-of-an-error-response")
+
+	record := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, map[string]interface{}{
+		logging.KeyStatus:    float64(http.StatusBadRequest),
+		logging.KeyErrorCode: "PARAM_ERROR",
+		logging.KeyErrorMsg:  "参数错误",
+	}, record[logging.KeyError])
+}
+
+type recordingLimiter struct {
+	ratelimit.Limiter
+	waits     []ratelimit.Key
+	feedbacks []bool
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context, key ratelimit.Key) error {
+	l.waits = append(l.waits, key)
+	return l.Limiter.Wait(ctx, key)
+}
+
+func (l *recordingLimiter) Feedback(key ratelimit.Key, limited bool) {
+	l.feedbacks = append(l.feedbacks, limited)
+	l.Limiter.Feedback(key, limited)
+}
+
+func TestClientWithRateLimiter(t *testing.T) {
+	limiter := &recordingLimiter{
+		Limiter: ratelimit.NewTokenBucketLimiter(ratelimit.Rule{Rate: 1, Burst: 2}).SetMode(ratelimit.ModeFailFast),
+	}
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithRateLimiter(limiter),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			writeResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":"RATELIMIT_EXCEED","message":"达到调用速率限制"}`))
+	}))
+	defer ts.Close()
+
+	requestURL := ts.URL + "/v3/pay/transactions/id/4200000001202106243137"
+	_, err = client.Get(ctx, requestURL)
+	require.NoError(t, err)
+	_, err = client.Get(ctx, requestURL)
+	assert.True(t, core.IsAPIError(err, ratelimit.CodeRateLimitExceed))
+
+	// 收到限频应答后令牌被清空，请求不会发出
+	_, err = client.Get(ctx, requestURL)
+	assert.True(t, ratelimit.IsRateLimitedError(err))
+	assert.Equal(t, 2, requests)
+
+	assert.Equal(
+		t, ratelimit.Key{MchID: testMchID, Operation: "/v3/pay/transactions/id/{transaction_id}"}, limiter.waits[0],
+	)
+	assert.Equal(t, []bool{false, true}, limiter.feedbacks)
+}
+
+func TestClientWithCircuitBreaker(t *testing.T) {
+	primaryRequests, backupRequests := 0, 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests++
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code":"SYSTEM_ERROR","message":"系统错误"}`))
+	}))
+	defer primary.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backupRequests++
+		writeResponse(w)
+	}))
+	defer backup.Close()
+
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithCircuitBreaker(breaker.NewCircuitBreaker(breaker.Config{FailureThreshold: 2})),
+		option.WithBackupHost(primary.URL, backup.URL),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	requestURL := primary.URL + "/v3/pay/transactions/out-trade-no/1217752501201407033233368018"
+	for i := 0; i < 2; i++ {
+		_, err = client.Get(ctx, requestURL)
+		assert.True(t, core.IsAPIError(err, "SYSTEM_ERROR"))
+	}
+
+	// 熔断后查询请求改为发往备份地址
+	result, err := client.Get(ctx, requestURL)
+	require.NoError(t, err)
+	assert.Equal(t, backup.URL, "http://"+result.Request.URL.Host)
+
+	// 其他接口不受影响
+	_, err = client.Post(ctx, requestURL+"/close", map[string]string{"mchid": testMchID})
+	assert.True(t, core.IsAPIError(err, "SYSTEM_ERROR"))
+
+	// 非幂等请求立即失败
+	_, err = client.Post(ctx, requestURL, map[string]string{"mchid": testMchID})
+	assert.True(t, breaker.IsOpenError(err))
+
+	// 标记为幂等的请求同样可以改为发往备份地址
+	_, err = client.Post(breaker.WithIdempotent(ctx), requestURL, map[string]string{"mchid": testMchID})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, primaryRequests)
+	assert.Equal(t, 2, backupRequests)
+}
+
+func TestClientDryRun(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeResponse(w)
+	}))
+	defer ts.Close()
+
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	data := testData{StockID: "it's-a-stock", AppID: "wxd678efh567hg6787"}
+	result, err := client.Post(core.WithDryRun(ctx), ts.URL+testRequestUri, data)
+	require.Error(t, err)
+	assert.Equal(t, 0, requests)
+	assert.Nil(t, result.Response)
+
+	dryRunErr, ok := core.AsDryRunError(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	assert.Same(t, result.Request, dryRunErr.Request)
+
+	// 签名原文与 Authorization 中的签名一致
+	bodyReader, err := dryRunErr.Request.GetBody()
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(bodyReader)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"stock_id":"it's-a-stock"`)
+	schema, params := parseAuthorization(t, dryRunErr.Request.Header.Get(consts.Authorization))
+	assertAuthorization(t, schema, http.MethodPost, testRequestUri, params, body)
+	assert.Equal(t, fmt.Sprintf("POST\n%s\n%s\n%s\n%s\n",
+		testRequestUri, params["timestamp"], params["nonce_str"], body), dryRunErr.SignMessage)
+
+	curl, err := dryRunErr.Curl()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(curl, "curl -X POST '"+ts.URL+testRequestUri+"'"))
+	assert.Contains(t, curl, "-H 'Authorization: WECHATPAY2-SHA256-RSA2048 mchid=")
+	assert.Contains(t, curl, "-H 'Wechatpay-Serial: ")
+	assert.Contains(t, curl, `--data-binary '{"stock_id":"it'\''s-a-stock"`)
+
+	// 通过 option 开启后所有请求均为试运行
+	client, err = core.NewClient(ctx, append(opts, option.WithDryRun())...)
+	require.NoError(t, err)
+	_, err = client.Get(ctx, ts.URL+testRequestUri)
+	assert.True(t, core.IsDryRunError(err))
+	assert.Equal(t, 0, requests)
+}
+
+func TestCurlCommand(t *testing.T) {
+	request, err := http.NewRequest(http.MethodPost, "https://api.mch.weixin.qq.com/v3/merchant/media/upload",
+		bytes.NewReader([]byte("meta\x00\xff'")))
+	require.NoError(t, err)
+	request.Header.Set(consts.ContentType, "multipart/form-data")
+	request.Header.Set(consts.Accept, "*/*")
+
+	curl, err := core.CurlCommand(request)
+	require.NoError(t, err)
+	assert.Equal(t, "curl -X POST 'https://api.mch.weixin.qq.com/v3/merchant/media/upload' \\\n"+
+		"  -H 'Accept: */*' \\\n"+
+		"  -H 'Content-Type: multipart/form-data' \\\n"+
+		`  --data-binary $'meta\x00\xff\''`, curl)
+}
+
 func TestClientNoAuth(t *testing.T) {
 	opts := []core.ClientOption{
 		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
@@ -446,4 +773,247 @@ func TestClient_Upload(t *testing.T) {
 	assert.Equal(t, string(body), responseBody)
 }
 
+// writeSignedResponse 以微信支付平台私钥签名并写出应答，timestamp 为应答的 Wechatpay-Timestamp
+func writeSignedResponse(w http.ResponseWriter, timestamp time.Time, status int, body string) {
+	w.Header().Set("Request-Id", "0")
+	w.Header().Set("Wechatpay-Serial", utils.GetCertificateSerialNumber(*wechatPayCertificate))
+	nonce := "nonce-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	w.Header().Set("Wechatpay-Nonce", nonce)
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	w.Header().Set("Wechatpay-Timestamp", ts)
+	signature, _ := utils.SignSHA256WithRSA(fmt.Sprintf("%s\n%s\n%s\n", ts, nonce, body), wechatPayPrivateKey)
+	w.Header().Set("Wechatpay-Signature", signature)
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+func TestClientClockSkewDetection(t *testing.T) {
+	const (
+		skew      = 10 * time.Minute
+		signError = `{"code":"SIGN_ERROR","message":"timestamp expired"}`
+	)
+
+	newClient := func(t *testing.T) *core.Client {
+		client, err := core.NewClient(ctx,
+			option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+			option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+			option.WithClockSkewDetection(),
+		)
+		require.NoError(t, err)
+		return client
+	}
+	requestTimestamp := func(r *http.Request) int64 {
+		_, params := parseAuthorization(t, r.Header.Get("Authorization"))
+		timestamp, _ := strconv.ParseInt(params["timestamp"], 10, 64)
+		return timestamp
+	}
+
+	t.Run("recover from signed error response", func(t *testing.T) {
+		client := newClient(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serverNow := time.Now().Add(skew)
+			if serverNow.Unix()-requestTimestamp(r) >= 60 {
+				writeSignedResponse(w, serverNow, http.StatusUnauthorized, signError)
+				return
+			}
+			writeSignedResponse(w, serverNow, http.StatusOK, responseBody)
+		}))
+		defer ts.Close()
+
+		// 首次请求因本机时钟落后而失败，但 Client 已从验签通过的应答时间戳中学习到偏差
+		_, err := client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		assert.InDelta(t, skew.Seconds(), time.Until(client.Clock().Now()).Seconds(), 2)
+
+		result, err := client.Get(ctx, ts.URL+testRequestUri)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(result.Response.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, responseBody, string(body))
+	})
+
+	t.Run("ignore unsigned Date header", func(t *testing.T) {
+		client := newClient(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, signError)
+		}))
+		defer ts.Close()
+
+		_, err := client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		assert.InDelta(t, 0, time.Until(client.Clock().Now()).Seconds(), 2)
+	})
+
+	t.Run("ignore forged signature", func(t *testing.T) {
+		client := newClient(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Request-Id", "0")
+			w.Header().Set("Wechatpay-Serial", utils.GetCertificateSerialNumber(*wechatPayCertificate))
+			w.Header().Set("Wechatpay-Nonce", "forged-nonce")
+			w.Header().Set("Wechatpay-Timestamp", strconv.FormatInt(time.Now().Add(skew).Unix(), 10))
+			w.Header().Set("Wechatpay-Signature", "Zm9yZ2Vk")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, signError)
+		}))
+		defer ts.Close()
+
+		_, err := client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		assert.InDelta(t, 0, time.Until(client.Clock().Now()).Seconds(), 2)
+	})
+
+	t.Run("ignore offset beyond max", func(t *testing.T) {
+		client := newClient(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeSignedResponse(w, time.Now().Add(clock.MaxSkewOffset+time.Minute), http.StatusUnauthorized, signError)
+		}))
+		defer ts.Close()
+
+		_, err := client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		assert.InDelta(t, 0, time.Until(client.Clock().Now()).Seconds(), 2)
+	})
+
+	t.Run("ignore replayed older response", func(t *testing.T) {
+		client := newClient(t)
+		serverTimes := []time.Time{time.Now().Add(skew), time.Now().Add(-skew)}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serverTime := serverTimes[0]
+			serverTimes = serverTimes[1:]
+			writeSignedResponse(w, serverTime, http.StatusUnauthorized, signError)
+		}))
+		defer ts.Close()
+
+		_, err := client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		_, err = client.Get(ctx, ts.URL+testRequestUri)
+		assert.True(t, core.IsAPIError(err, "SIGN_ERROR"))
+		assert.InDelta(t, skew.Seconds(), time.Until(client.Clock().Now()).Seconds(), 2)
+	})
+}
+
+func TestClientWithClock(t *testing.T) {
+	fixedNow := time.Unix(1624523846, 0)
+
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithClock(clock.Fixed(fixedNow)),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params := parseAuthorization(t, r.Header.Get("Authorization"))
+		assert.Equal(t, strconv.FormatInt(fixedNow.Unix(), 10), params["timestamp"])
+
+		// 应答时间戳取自真实时间，与 Client 的时钟相差过大
+		writeResponse(w)
+	}))
+	defer ts.Close()
+
+	_, err = client.Get(ctx, ts.URL+testRequestUri)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expires")
+}
+
+func TestClientSharedValidator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w)
+	}))
+	defer ts.Close()
+
+	// 同一个 validator 被时钟不同的两个 Client 共享
+	shared := validators.NewWechatPayResponseValidator(
+		verifiers.NewSHA256WithRSAVerifier(core.NewCertificateMapWithList([]*x509.Certificate{wechatPayCertificate})),
+	)
+	fixedClient, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithoutValidator(),
+		option.WithClock(clock.Fixed(time.Unix(1624523846, 0))),
+	)
+	require.NoError(t, err)
+	systemClient, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithoutValidator(),
+	)
+	require.NoError(t, err)
+
+	fixedClient = core.NewClientWithValidator(fixedClient, shared)
+	systemClient = core.NewClientWithValidator(systemClient, shared)
+
+	// 各 Client 使用自己的时钟，shared 本身不被修改
+	_, err = fixedClient.Get(ctx, ts.URL+testRequestUri)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expires")
+	_, err = systemClient.Get(ctx, ts.URL+testRequestUri)
+	require.NoError(t, err)
+
+	resp, err := http.Get(ts.URL + testRequestUri)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.NoError(t, shared.Validate(ctx, resp))
+}
+
 func testingKey(s string) string { return strings.ReplaceAll(s, "TESTING KEY", "PRIVATE KEY") }
+
+func TestParameterToString(t *testing.T) {
+	// 时间参数与主机时区无关，均格式化为北京时间
+	utc := time.Date(2018, 6, 8, 2, 34, 56, 0, time.UTC)
+	assert.Equal(t, "2018-06-08T10:34:56+08:00", core.ParameterToString(utc, ""))
+	assert.Equal(t, "1900000109", core.ParameterToString("1900000109", ""))
+	assert.Equal(t, "a,b", core.ParameterToString([]string{"a", "b"}, "csv"))
+}
+
+type validatedRequest struct{ err error }
+
+func (r validatedRequest) Validate() error { return r.err }
+
+func TestClientValidateRequest(t *testing.T) {
+	opts := []core.ClientOption{
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+	}
+	invalid := validatedRequest{err: fmt.Errorf("invalid request")}
+
+	// 默认不校验
+	client, err := core.NewClient(ctx, opts...)
+	require.NoError(t, err)
+	assert.NoError(t, client.ValidateRequest(invalid))
+
+	client, err = core.NewClient(ctx, append(opts, option.WithRequestValidation())...)
+	require.NoError(t, err)
+	assert.EqualError(t, client.ValidateRequest(invalid), "invalid request")
+	assert.NoError(t, client.ValidateRequest(validatedRequest{}))
+	assert.NoError(t, client.ValidateRequest(testData{StockID: "stock"}))
+
+	// 复制的 Client 保留配置
+	assert.Error(t, core.NewClientWithValidator(client, nil).ValidateRequest(invalid))
+}
+
+// expiringRequest 使用校验选项中的时钟校验失效时间的请求
+type expiringRequest struct{ TimeExpire *time.Time }
+
+func (r expiringRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("expiringRequest", opts...)
+	c.Window("time_expire", r.TimeExpire, chinatime.Window{Min: time.Minute, Max: 2 * time.Hour})
+	return c.Err()
+}
+
+func TestClientValidateRequestWithClock(t *testing.T) {
+	now := time.Now().Add(24 * time.Hour)
+	client, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithClock(clock.Fixed(now)),
+		option.WithRequestValidation(),
+	)
+	require.NoError(t, err)
+
+	// 时间窗口相对于 Client 的时钟，而不是本机时间
+	assert.NoError(t, client.ValidateRequest(expiringRequest{TimeExpire: core.Time(now.Add(time.Hour))}))
+	err = client.ValidateRequest(expiringRequest{TimeExpire: core.Time(time.Now().Add(time.Hour))})
+	assert.True(t, validation.IsError(err))
+}
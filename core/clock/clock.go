@@ -0,0 +1,108 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package clock 微信支付 API v3 Go SDK 时钟
+//
+// SDK 中所有与当前时间相关的逻辑（请求签名的时间戳、应答与通知的时间戳校验等）均通过 Clock 获取时间，
+// 你可以注入自定义的 Clock 来模拟时间，或使用 SkewClock 补偿本机与微信支付服务器之间的时钟偏差。
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock 时钟
+type Clock interface {
+	Now() time.Time // 获取当前时间
+}
+
+// Func 使用函数实现 Clock，如 clock.Func(time.Now)
+type Func func() time.Time
+
+// Now 获取当前时间
+func (f Func) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+// Now 获取本机当前时间
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// System 本机系统时钟
+var System Clock = systemClock{}
+
+// Fixed 返回一个始终停在 t 的时钟，可用于测试
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
+
+// OrSystem 当 c 为 nil 时返回 System，否则返回 c
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
+
+// MaxSkewOffset Observe 所能接受的最大偏移量，超出该范围的服务器时间会被忽略
+const MaxSkewOffset = time.Hour
+
+// SkewClock 可补偿时钟偏差的时钟
+//
+// SkewClock 在基础时钟上叠加一个偏移量，偏移量可以手动设置，也可以通过 Observe 根据服务器时间自动计算。
+// SkewClock 可以在多个 goroutine 中并发使用。
+type SkewClock struct {
+	base     Clock
+	offset   int64 // 偏移量（纳秒），原子读写
+	observed int64 // 最近一次被采用的服务器时间（纳秒），原子读写
+}
+
+// Now 获取补偿后的当前时间
+func (c *SkewClock) Now() time.Time {
+	return c.base.Now().Add(c.Offset())
+}
+
+// Offset 获取当前的偏移量
+func (c *SkewClock) Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.offset))
+}
+
+// SetOffset 设置偏移量
+func (c *SkewClock) SetOffset(offset time.Duration) {
+	atomic.StoreInt64(&c.offset, int64(offset))
+}
+
+// Observe 根据观测到的服务器时间更新偏移量，返回该服务器时间是否被采用
+//
+// 服务器时间通常只精确到秒，因此偏差小于 1 秒时视为没有偏差。
+// 为避免被重放的旧报文拨动时钟，早于上一次被采用的服务器时间、或偏移量超出 MaxSkewOffset 的服务器时间都会被忽略。
+// 调用方应只传入经过签名校验的服务器时间。
+func (c *SkewClock) Observe(serverTime time.Time) bool {
+	offset := serverTime.Sub(c.base.Now())
+	if offset > MaxSkewOffset || offset < -MaxSkewOffset {
+		return false
+	}
+	if offset > -time.Second && offset < time.Second {
+		offset = 0
+	}
+
+	for {
+		observed := atomic.LoadInt64(&c.observed)
+		if serverTime.UnixNano() < observed {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&c.observed, observed, serverTime.UnixNano()) {
+			break
+		}
+	}
+	c.SetOffset(offset)
+	return true
+}
+
+// NewSkewClock 使用基础时钟初始化 SkewClock，base 为 nil 时使用 System
+func NewSkewClock(base Clock) *SkewClock {
+	return &SkewClock{base: OrSystem(base)}
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrSystem(t *testing.T) {
+	assert.Equal(t, System, OrSystem(nil))
+
+	fixed := Fixed(time.Unix(1624523846, 0))
+	assert.Equal(t, time.Unix(1624523846, 0), OrSystem(fixed).Now())
+}
+
+func TestSkewClock_Observe(t *testing.T) {
+	base := time.Unix(1624523846, 0)
+	c := NewSkewClock(Fixed(base))
+	assert.Equal(t, base, c.Now())
+
+	tests := []struct {
+		name       string
+		serverTime time.Time
+		wantOK     bool
+		wantOffset time.Duration
+	}{
+		{name: "sub-second difference is ignored", serverTime: base.Add(900 * time.Millisecond), wantOK: true},
+		{name: "server ahead", serverTime: base.Add(10 * time.Minute), wantOK: true, wantOffset: 10 * time.Minute},
+		{
+			name: "older than the last observation", serverTime: base.Add(5 * time.Minute), wantOK: false,
+			wantOffset: 10 * time.Minute,
+		},
+		{
+			name: "beyond max offset", serverTime: base.Add(MaxSkewOffset + time.Second), wantOK: false,
+			wantOffset: 10 * time.Minute,
+		},
+		{name: "server further ahead", serverTime: base.Add(MaxSkewOffset), wantOK: true, wantOffset: MaxSkewOffset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantOK, c.Observe(tt.serverTime))
+			assert.Equal(t, tt.wantOffset, c.Offset())
+			assert.Equal(t, base.Add(tt.wantOffset), c.Now())
+		})
+	}
+}
+
+func TestSkewClock_ObserveBehind(t *testing.T) {
+	base := time.Unix(1624523846, 0)
+	c := NewSkewClock(Fixed(base))
+
+	assert.False(t, c.Observe(base.Add(-MaxSkewOffset-time.Second)))
+	assert.Equal(t, time.Duration(0), c.Offset())
+
+	assert.True(t, c.Observe(base.Add(-7*time.Minute)))
+	assert.Equal(t, -7*time.Minute, c.Offset())
+}
+
+func TestSkewClock_Concurrent(t *testing.T) {
+	c := NewSkewClock(nil)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.SetOffset(time.Duration(i) * time.Second)
+			_ = c.Now()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.True(t, c.Offset() >= 0 && c.Offset() < 10*time.Second)
+}
@@ -0,0 +1,225 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/downloader"
+	"github.com/jemuri/wechatpay-go/core/notify"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/core/registry"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+// Credential 加载商户配置中的私钥、APIv3 密钥与微信支付公钥
+func (m *MerchantConfig) Credential() (*registry.MerchantCredential, error) {
+	credential := &registry.MerchantCredential{MchID: m.MchID, CertificateSerialNo: m.CertificateSerialNo}
+
+	var err error
+	if m.PrivateKeyPath != "" {
+		credential.PrivateKey, err = utils.LoadPrivateKeyWithPath(m.PrivateKeyPath)
+	} else {
+		credential.PrivateKey, err = utils.LoadPrivateKey(m.PrivateKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load private key of mchid=[%s] err:%v", m.MchID, err)
+	}
+
+	if credential.MchAPIv3Key, err = resolveSecret(m.MchAPIv3Key); err != nil {
+		return nil, fmt.Errorf("resolve mch apiv3 key of mchid=[%s] err:%v", m.MchID, err)
+	}
+
+	if m.EffectiveMode() == ModePublicKey {
+		credential.WechatPayPublicKeyID = m.WechatPayPublicKeyID
+		if m.WechatPayPublicKeyPath != "" {
+			credential.WechatPayPublicKey, err = utils.LoadPublicKeyWithPath(m.WechatPayPublicKeyPath)
+		} else {
+			credential.WechatPayPublicKey, err = utils.LoadPublicKey(m.WechatPayPublicKey)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("load wechatpay public key of mchid=[%s] err:%v", m.MchID, err)
+		}
+	}
+
+	return credential, nil
+}
+
+// NewHTTPClient 按商户的 HTTP 配置（代理、超时与重试）构建 HTTPClient
+func (m *MerchantConfig) NewHTTPClient() (*http.Client, error) {
+	httpConfig := m.EffectiveHTTP()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if httpConfig.Proxy != "" {
+		proxyURL, err := url.Parse(httpConfig.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy of mchid=[%s] err:%v", m.MchID, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if retry := httpConfig.Retry; retry != nil && retry.MaxAttempts > 1 {
+		roundTripper = &retryTransport{
+			next:        transport,
+			maxAttempts: retry.MaxAttempts,
+			backoff:     time.Duration(retry.Backoff),
+		}
+	}
+
+	timeout := time.Duration(httpConfig.Timeout)
+	if timeout == 0 {
+		timeout = consts.DefaultTimeout
+	}
+	return &http.Client{Transport: roundTripper, Timeout: timeout}, nil
+}
+
+// NewClient 构建商户的 core.Client，平台证书模式下使用 downloader.MgrInstance() 自动更新平台证书
+//
+// opts 会追加到配置生成的初始化参数之后，可用于设置时钟、防重放缓存等
+func (m *MerchantConfig) NewClient(ctx context.Context, opts ...core.ClientOption) (*core.Client, error) {
+	return m.NewClientWithDownloaderMgr(ctx, downloader.MgrInstance(), opts...)
+}
+
+// NewClientWithDownloaderMgr 构建商户的 core.Client，平台证书模式下使用指定的 mgr 自动更新平台证书
+func (m *MerchantConfig) NewClientWithDownloaderMgr(
+	ctx context.Context, mgr *downloader.CertificateDownloaderMgr, opts ...core.ClientOption,
+) (*core.Client, error) {
+	credential, httpClient, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts := []core.ClientOption{option.WithHTTPClient(httpClient)}
+	if credential.UsePublicKey() {
+		clientOpts = append(
+			clientOpts, option.WithWechatPayPublicKeyAuthCipher(
+				credential.MchID, credential.CertificateSerialNo, credential.PrivateKey,
+				credential.WechatPayPublicKeyID, credential.WechatPayPublicKey,
+			),
+		)
+	} else {
+		if err = ensureDownloader(ctx, mgr, credential, httpClient); err != nil {
+			return nil, err
+		}
+		clientOpts = append(
+			clientOpts, option.WithWechatPayAutoAuthCipherUsingDownloaderMgr(
+				credential.MchID, credential.CertificateSerialNo, credential.PrivateKey, mgr,
+			),
+		)
+	}
+
+	return core.NewClient(ctx, append(clientOpts, opts...)...)
+}
+
+// NewNotifyHandler 构建商户的回调通知处理器，平台证书模式下使用 downloader.MgrInstance() 获取平台证书
+func (m *MerchantConfig) NewNotifyHandler(ctx context.Context) (*notify.Handler, error) {
+	return m.NewNotifyHandlerWithDownloaderMgr(ctx, downloader.MgrInstance())
+}
+
+// NewNotifyHandlerWithDownloaderMgr 构建商户的回调通知处理器，平台证书模式下使用指定的 mgr 获取平台证书
+func (m *MerchantConfig) NewNotifyHandlerWithDownloaderMgr(
+	ctx context.Context, mgr *downloader.CertificateDownloaderMgr,
+) (*notify.Handler, error) {
+	credential, httpClient, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.UsePublicKey() {
+		return notify.NewRSANotifyHandler(
+			credential.MchAPIv3Key,
+			verifiers.NewSHA256WithRSAPubkeyVerifier(credential.WechatPayPublicKeyID, *credential.WechatPayPublicKey),
+		)
+	}
+
+	if err = ensureDownloader(ctx, mgr, credential, httpClient); err != nil {
+		return nil, err
+	}
+	return notify.NewRSANotifyHandler(
+		credential.MchAPIv3Key, verifiers.NewSHA256WithRSAVerifier(mgr.GetCertificateVisitor(credential.MchID)),
+	)
+}
+
+func (m *MerchantConfig) load() (*registry.MerchantCredential, *http.Client, error) {
+	credential, err := m.Credential()
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClient, err := m.NewHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credential, httpClient, nil
+}
+
+// ensureDownloader 商户尚未注册平台证书下载器时，使用商户的 HTTPClient 注册
+func ensureDownloader(
+	ctx context.Context, mgr *downloader.CertificateDownloaderMgr, credential *registry.MerchantCredential,
+	httpClient *http.Client,
+) error {
+	if mgr.HasDownloader(ctx, credential.MchID) {
+		return nil
+	}
+
+	client, err := core.NewClient(
+		ctx,
+		option.WithMerchantCredential(credential.MchID, credential.CertificateSerialNo, credential.PrivateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(httpClient),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = mgr.RegisterDownloaderWithClient(ctx, client, credential.MchID, credential.MchAPIv3Key); err != nil {
+		return fmt.Errorf("register certificate downloader of mchid=[%s] err:%w", credential.MchID, err)
+	}
+	return nil
+}
+
+// NewClients 构建配置中所有商户的 core.Client，以商户号为键
+func (c *Config) NewClients(ctx context.Context, opts ...core.ClientOption) (map[string]*core.Client, error) {
+	clients := make(map[string]*core.Client, len(c.Merchants))
+	for _, m := range c.Merchants {
+		client, err := m.NewClient(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		clients[m.MchID] = client
+	}
+	return clients, nil
+}
+
+// NewNotifyHandlers 构建配置中所有商户的回调通知处理器，以商户号为键
+func (c *Config) NewNotifyHandlers(ctx context.Context) (map[string]*notify.Handler, error) {
+	handlers := make(map[string]*notify.Handler, len(c.Merchants))
+	for _, m := range c.Merchants {
+		handler, err := m.NewNotifyHandler(ctx)
+		if err != nil {
+			return nil, err
+		}
+		handlers[m.MchID] = handler
+	}
+	return handlers, nil
+}
+
+// CredentialProvider 加载配置中所有商户的凭据，返回可用于 registry.NewClientRegistry 的 CredentialProvider
+func (c *Config) CredentialProvider() (*registry.StaticCredentialProvider, error) {
+	credentials := make([]*registry.MerchantCredential, 0, len(c.Merchants))
+	for _, m := range c.Merchants {
+		credential, err := m.Credential()
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+	return registry.NewStaticCredentialProvider(credentials...), nil
+}
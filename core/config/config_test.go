@@ -0,0 +1,418 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package config
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/breaker"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"gopkg.in/yaml.v3"
+)
+
+const testMchAPIv3Key = "testMchAPIv3Key0testMchAPIv3Key0"
+
+// writeTestKeys 生成商户私钥与微信支付公钥文件，返回私钥 PEM 内容
+func writeTestKeys(t *testing.T, dir string) (privateKeyPEM string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "apiclient_key.pem"), []byte(privateKeyPEM), 0600))
+
+	wechatPayKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pubDer, err := x509.MarshalPKIXPublicKey(&wechatPayKey.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "pub_key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}), 0600,
+	))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "apiv3_key"), []byte(testMchAPIv3Key+"\n"), 0600))
+	return privateKeyPEM
+}
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "wechatpay-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func TestLoadYAML(t *testing.T) {
+	dir := newTestDir(t)
+	writeTestKeys(t, dir)
+	require.NoError(t, os.Setenv("TEST_WECHATPAY_APIV3_KEY", testMchAPIv3Key))
+	defer os.Unsetenv("TEST_WECHATPAY_APIV3_KEY")
+
+	content := fmt.Sprintf(`
+http:
+  timeout: 10s
+  retry:
+    max_attempts: 3
+    backoff: 100ms
+merchants:
+  - mch_id: "1900009191"
+    certificate_serial_no: SERIAL1
+    private_key_path: %[1]s/apiclient_key.pem
+    mch_api_v3_key: env:TEST_WECHATPAY_APIV3_KEY
+    wechatpay_public_key_id: PUB_KEY_ID_1
+    wechatpay_public_key_path: %[1]s/pub_key.pem
+  - mch_id: "1900009192"
+    certificate_serial_no: SERIAL2
+    private_key_path: %[1]s/apiclient_key.pem
+    mch_api_v3_key: file:%[1]s/apiv3_key
+    http:
+      proxy: http://proxy.internal:8080
+      timeout: 5s
+`, dir)
+	path := filepath.Join(dir, "wechatpay.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	c, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, c.Merchants, 2)
+
+	m1, ok := c.Merchant("1900009191")
+	require.True(t, ok)
+	assert.Equal(t, ModePublicKey, m1.EffectiveMode())
+	assert.Equal(t, Duration(10*time.Second), m1.EffectiveHTTP().Timeout)
+	assert.Equal(t, 3, m1.EffectiveHTTP().Retry.MaxAttempts)
+
+	m2, ok := c.Merchant("1900009192")
+	require.True(t, ok)
+	assert.Equal(t, ModeCertificate, m2.EffectiveMode())
+	assert.Equal(t, Duration(5*time.Second), m2.EffectiveHTTP().Timeout)
+	assert.Equal(t, "http://proxy.internal:8080", m2.EffectiveHTTP().Proxy)
+	assert.Equal(t, Duration(100*time.Millisecond), m2.EffectiveHTTP().Retry.Backoff)
+
+	credential, err := m2.Credential()
+	require.NoError(t, err)
+	assert.Equal(t, testMchAPIv3Key, credential.MchAPIv3Key)
+	assert.False(t, credential.UsePublicKey())
+
+	_, ok = c.Merchant("1900009193")
+	assert.False(t, ok)
+}
+
+func TestParseJSON(t *testing.T) {
+	dir := newTestDir(t)
+	privateKeyPEM := writeTestKeys(t, dir)
+
+	content := fmt.Sprintf(`{
+  "merchants": [{
+    "mch_id": "1900009191",
+    "certificate_serial_no": "SERIAL1",
+    "private_key": %q,
+    "mch_api_v3_key": %q,
+    "mode": "public_key",
+    "wechatpay_public_key_id": "PUB_KEY_ID_1",
+    "wechatpay_public_key_path": %q,
+    "http": {"timeout": "3s"}
+  }]
+}`, privateKeyPEM, testMchAPIv3Key, filepath.Join(dir, "pub_key.pem"))
+
+	c, err := ParseJSON([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, Duration(3*time.Second), c.Merchants[0].EffectiveHTTP().Timeout)
+
+	ctx := context.Background()
+	clients, err := c.NewClients(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, clients["1900009191"])
+
+	handlers, err := c.NewNotifyHandlers(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, handlers["1900009191"])
+
+	provider, err := c.CredentialProvider()
+	require.NoError(t, err)
+	credential, err := provider.GetCredential(ctx, "1900009191")
+	require.NoError(t, err)
+	assert.True(t, credential.UsePublicKey())
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string
+	}{
+		{
+			name:    "empty config",
+			content: ``,
+			wantErr: []string{"merchants: at least one merchant is required"},
+		},
+		{
+			name:    "unknown field",
+			content: "merchants:\n  - mchid: \"1900009191\"\n",
+			wantErr: []string{"field mchid not found"},
+		},
+		{
+			name: "missing fields",
+			content: `
+merchants:
+  - mode: unknown
+`,
+			wantErr: []string{
+				"merchants[0].mch_id: is required",
+				"merchants[0].certificate_serial_no: is required",
+				"merchants[0].private_key_path: one of private_key_path or private_key is required",
+				"merchants[0].mch_api_v3_key: is required",
+				`merchants[0].mode: "unknown" is not supported`,
+			},
+		},
+		{
+			name: "invalid values",
+			content: `
+http:
+  proxy: "not a url"
+  timeout: 1x
+merchants:
+  - mch_id: "1900009191"
+    certificate_serial_no: SERIAL
+    private_key_path: key.pem
+    mch_api_v3_key: too-short
+`,
+			wantErr: []string{`invalid duration "1x"`},
+		},
+		{
+			name: "mode conflicts",
+			content: `
+http:
+  proxy: "not a url"
+merchants:
+  - mch_id: "1900009191"
+    certificate_serial_no: SERIAL
+    private_key_path: key.pem
+    private_key: inline
+    mch_api_v3_key: too-short
+    mode: certificate
+    wechatpay_public_key_id: PUB_KEY_ID
+  - mch_id: "1900009191"
+    certificate_serial_no: SERIAL
+    private_key_path: key.pem
+    mch_api_v3_key: env:TEST_WECHATPAY_NOT_SET
+    mode: public_key
+`,
+			wantErr: []string{
+				`http.proxy: "not a url" is not a valid proxy URL`,
+				"merchants[0].private_key_path: private_key_path and private_key are mutually exclusive",
+				"merchants[0].mch_api_v3_key: must be 32 bytes, got 9",
+				`merchants[0].mode: wechatpay public key is configured but mode is "certificate"`,
+				"merchants[1].mch_api_v3_key: environment variable TEST_WECHATPAY_NOT_SET is not set",
+				"merchants[1].wechatpay_public_key_id: is required in public_key mode",
+				"merchants[1].wechatpay_public_key_path: one of wechatpay_public_key_path or wechatpay_public_key",
+				"merchants[1].mch_id: duplicates merchants[0]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(tt.content))
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestLoadUnsupportedFile(t *testing.T) {
+	_, err := Load("wechatpay.toml")
+	assert.Error(t, err)
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	dir := newTestDir(t)
+	writeTestKeys(t, dir)
+
+	env := map[string]string{
+		"TEST_WECHATPAY_MCH_ID":                "1900009191",
+		"TEST_WECHATPAY_CERTIFICATE_SERIAL_NO": "SERIAL1",
+		"TEST_WECHATPAY_PRIVATE_KEY_PATH":      filepath.Join(dir, "apiclient_key.pem"),
+		"TEST_WECHATPAY_MCH_API_V3_KEY":        testMchAPIv3Key,
+		"TEST_WECHATPAY_PUBLIC_KEY_ID":         "PUB_KEY_ID_1",
+		"TEST_WECHATPAY_PUBLIC_KEY_PATH":       filepath.Join(dir, "pub_key.pem"),
+		"TEST_WECHATPAY_TIMEOUT":               "7s",
+		"TEST_WECHATPAY_RETRY_MAX_ATTEMPTS":    "2",
+	}
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+		defer os.Unsetenv(k)
+	}
+
+	c, err := LoadFromEnv("TEST_WECHATPAY_")
+	require.NoError(t, err)
+	m := c.Merchants[0]
+	assert.Equal(t, "1900009191", m.MchID)
+	assert.Equal(t, ModePublicKey, m.EffectiveMode())
+	assert.Equal(t, Duration(7*time.Second), m.EffectiveHTTP().Timeout)
+	assert.Equal(t, 2, m.EffectiveHTTP().Retry.MaxAttempts)
+
+	_, err = m.NewClient(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, os.Setenv("TEST_WECHATPAY_RETRY_MAX_ATTEMPTS", "two"))
+	_, err = LoadFromEnv("TEST_WECHATPAY_")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `TEST_WECHATPAY_RETRY_MAX_ATTEMPTS: "two" is not an integer`)
+
+	require.NoError(t, os.Unsetenv("TEST_WECHATPAY_RETRY_MAX_ATTEMPTS"))
+	require.NoError(t, os.Unsetenv("TEST_WECHATPAY_MCH_ID"))
+	_, err = LoadFromEnv("TEST_WECHATPAY_")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "merchants[0].mch_id: is required"))
+}
+
+func TestRetryTransport(t *testing.T) {
+	m := &MerchantConfig{
+		HTTP: &HTTPConfig{Retry: &RetryConfig{MaxAttempts: 3, Backoff: Duration(time.Millisecond)}},
+	}
+	client, err := m.NewHTTPClient()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		failures   int32 // 前 failures 次请求返回 failStatus
+		failStatus int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"幂等请求在 5xx 后重试", context.Background(), http.MethodGet, 2, http.StatusServiceUnavailable, http.StatusOK, 3},
+		{"幂等请求在 429 后重试", context.Background(), http.MethodHead, 1, http.StatusTooManyRequests, http.StatusOK, 2},
+		{"达到最大尝试次数后返回最后一次的应答", context.Background(), http.MethodGet, 10,
+			http.StatusServiceUnavailable, http.StatusServiceUnavailable, 3},
+		{"4xx 不重试", context.Background(), http.MethodGet, 10, http.StatusBadRequest, http.StatusBadRequest, 1},
+		{"POST 不重试", context.Background(), http.MethodPost, 2, http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable, 1},
+		{"标记为幂等的 POST 重试", breaker.WithIdempotent(context.Background()), http.MethodPost, 2,
+			http.StatusServiceUnavailable, http.StatusOK, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					body, _ := ioutil.ReadAll(r.Body)
+					assert.Equal(t, "payload", string(body))
+				}
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(tt.ctx, tt.method, server.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestRetryTransport_Resign(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if len(authorizations) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := &MerchantConfig{
+		HTTP: &HTTPConfig{Retry: &RetryConfig{MaxAttempts: 3, Backoff: Duration(time.Millisecond)}},
+	}
+	httpClient, err := m.NewHTTPClient()
+	require.NoError(t, err)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	client, err := core.NewClient(context.Background(),
+		option.WithMerchantCredential("1900009191", "SERIAL1", privateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(httpClient),
+	)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), server.URL+"/v3/certificates")
+	require.NoError(t, err)
+	// 每次重试都重新签名，随机串与时间戳不会被重复使用
+	require.Len(t, authorizations, 3)
+	for i, authorization := range authorizations {
+		assert.True(t, strings.HasPrefix(authorization, "WECHATPAY2-SHA256-RSA2048 "), authorization)
+		for _, previous := range authorizations[:i] {
+			assert.NotEqual(t, previous, authorization)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		yaml    string
+		want    Duration
+		wantErr bool
+	}{
+		{"字符串", `"30s"`, `30s`, Duration(30 * time.Second), false},
+		{"毫秒", `"200ms"`, `200ms`, Duration(200 * time.Millisecond), false},
+		{"不带单位的数字", `30`, `30`, 0, true},
+		{"不带单位的字符串", `"30"`, `"30"`, 0, true},
+		{"无效的字符串", `"thirty"`, `thirty`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.json), &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `use a value like "30s"`)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, d)
+			}
+
+			var config struct {
+				Timeout Duration `yaml:"timeout"`
+			}
+			err = yaml.Unmarshal([]byte("timeout: "+tt.yaml), &config)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `use a value like "30s"`)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, config.Timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEnvPrefix 环境变量的默认前缀
+const DefaultEnvPrefix = "WECHATPAY_"
+
+// LoadFromEnv 从环境变量加载并校验单个商户的配置，prefix 为空时使用 DefaultEnvPrefix
+//
+// 支持的环境变量（以默认前缀为例）：
+//
+//	WECHATPAY_MCH_ID                      商户号
+//	WECHATPAY_CERTIFICATE_SERIAL_NO       商户证书序列号
+//	WECHATPAY_PRIVATE_KEY_PATH            商户私钥文件路径
+//	WECHATPAY_PRIVATE_KEY                 商户私钥 PEM 内容
+//	WECHATPAY_MCH_API_V3_KEY              商户 APIv3 密钥（支持 env:/file: 引用）
+//	WECHATPAY_MODE                        验签模式：certificate 或 public_key
+//	WECHATPAY_PUBLIC_KEY_ID               微信支付公钥 ID
+//	WECHATPAY_PUBLIC_KEY_PATH             微信支付公钥文件路径
+//	WECHATPAY_PUBLIC_KEY                  微信支付公钥 PEM 内容
+//	WECHATPAY_PROXY                       代理地址
+//	WECHATPAY_TIMEOUT                     超时时长，如 30s
+//	WECHATPAY_RETRY_MAX_ATTEMPTS          最大尝试次数
+//	WECHATPAY_RETRY_BACKOFF               首次重试前的等待时长，如 200ms
+func LoadFromEnv(prefix string) (*Config, error) {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	env := func(name string) string {
+		return strings.TrimSpace(os.Getenv(prefix + name))
+	}
+
+	errs := validationErrors{}
+	httpConfig := &HTTPConfig{Proxy: env("PROXY")}
+
+	if v := env("TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			errs.add(prefix+"TIMEOUT", "%q is not a valid duration, use a value like \"30s\"", v)
+		}
+		httpConfig.Timeout = Duration(d)
+	}
+
+	maxAttempts, backoff := env("RETRY_MAX_ATTEMPTS"), env("RETRY_BACKOFF")
+	if maxAttempts != "" || backoff != "" {
+		httpConfig.Retry = &RetryConfig{}
+		if maxAttempts != "" {
+			n, err := strconv.Atoi(maxAttempts)
+			if err != nil {
+				errs.add(prefix+"RETRY_MAX_ATTEMPTS", "%q is not an integer", maxAttempts)
+			}
+			httpConfig.Retry.MaxAttempts = n
+		}
+		if backoff != "" {
+			d, err := time.ParseDuration(backoff)
+			if err != nil {
+				errs.add(prefix+"RETRY_BACKOFF", "%q is not a valid duration, use a value like \"200ms\"", backoff)
+			}
+			httpConfig.Retry.Backoff = Duration(d)
+		}
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	c := &Config{
+		HTTP: httpConfig,
+		Merchants: []*MerchantConfig{
+			{
+				MchID:                  env("MCH_ID"),
+				CertificateSerialNo:    env("CERTIFICATE_SERIAL_NO"),
+				PrivateKeyPath:         env("PRIVATE_KEY_PATH"),
+				PrivateKey:             os.Getenv(prefix + "PRIVATE_KEY"),
+				MchAPIv3Key:            env("MCH_API_V3_KEY"),
+				Mode:                   env("MODE"),
+				WechatPayPublicKeyID:   env("PUBLIC_KEY_ID"),
+				WechatPayPublicKeyPath: env("PUBLIC_KEY_PATH"),
+				WechatPayPublicKey:     os.Getenv(prefix + "PUBLIC_KEY"),
+			},
+		},
+	}
+
+	c.init()
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%v\n(merchants[0] is read from environment variables with prefix %s)", err, prefix)
+	}
+	return c, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package config
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// retryTransport 在网络错误或应答状态码为 429/5xx 时重试幂等请求的 http.RoundTripper
+//
+// 只重试 core.IsIdempotent 的请求（GET、HEAD 与使用 breaker.WithIdempotent 标记的请求），
+// 请求体必须可以重新读取（http.Request.GetBody 不为 nil）。重试前使用 core.Resign 重新签名，
+// 不会重复使用已发送过的随机串与时间戳
+type retryTransport struct {
+	next        http.RoundTripper
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// RoundTrip 发送请求，必要时重试
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxAttempts || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		if err := core.Resign(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !core.IsIdempotent(req) {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -24,6 +24,7 @@ const (
 	ContentType   = "Content-Type"   // Header 中的 ContentType 字段
 	ContentLength = "Content-Length" // Header 中的 ContentLength 字段
 	UserAgent     = "User-Agent"     // Header 中的 UserAgent 字段
+	Date          = "Date"           // Header 中的 Date 字段
 )
 
 // 常用 ContentType
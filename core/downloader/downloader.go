@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
 	"github.com/jemuri/wechatpay-go/core/auth/signers"
 	"github.com/jemuri/wechatpay-go/core/auth/validators"
 	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
@@ -192,6 +193,24 @@ func NewCertificateDownloader(
 	return NewCertificateDownloaderWithClient(ctx, client, mchAPIv3Key)
 }
 
+// NewCertificateDownloaderWithKeyRing 使用可热更新的商户私钥初始化商户的平台证书下载器 CertificateDownloader
+// 商户私钥轮换后，下载器会自动使用新的私钥签名。初始化完成后会立即发起一次下载，确保下载器被正确初始化。
+func NewCertificateDownloaderWithKeyRing(
+	ctx context.Context, ring *keyring.KeyRing, mchAPIv3Key string,
+) (*CertificateDownloader, error) {
+	settings := core.DialSettings{
+		Signer:    signers.NewKeyRingSigner(ring),
+		Validator: &validators.NullValidator{},
+	}
+
+	client, err := core.NewClientWithDialSettings(ctx, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("create downloader failed, create client err:%v", err)
+	}
+
+	return NewCertificateDownloaderWithClient(ctx, client, mchAPIv3Key)
+}
+
 // NewCertificateDownloaderWithClient 使用 core.Client 初始化商户的平台证书下载器 CertificateDownloader
 // 初始化完成后会立即发起一次下载，确保下载器被正确初始化。
 func NewCertificateDownloaderWithClient(
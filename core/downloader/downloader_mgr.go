@@ -6,10 +6,15 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/jemuri/wechatpay-go/core/metrics"
 	"github.com/jemuri/wechatpay-go/utils/task"
 )
 
@@ -57,6 +62,8 @@ type CertificateDownloaderMgr struct {
 	task          *task.RepeatedTask
 	downloaderMap map[string]*CertificateDownloader
 	lock          sync.RWMutex
+	metrics       metrics.Recorder
+	logger        *slog.Logger
 }
 
 // Stop 停止 CertificateDownloaderMgr 的自动下载 Goroutine
@@ -154,11 +161,93 @@ func (mgr *CertificateDownloaderMgr) DownloadCertificates(ctx context.Context) {
 	}
 	mgr.lock.RUnlock()
 
-	for _, downloader := range tmpDownloaderMap {
-		_ = downloader.DownloadCertificates(ctx)
+	for mchID, downloader := range tmpDownloaderMap {
+		err := downloader.DownloadCertificates(ctx)
+		mgr.observe(ctx, mchID, downloader, err)
 	}
 }
 
+// register 注册商户的平台证书下载器，下载器在创建时已完成首次下载
+func (mgr *CertificateDownloaderMgr) register(mchID string, downloader *CertificateDownloader) {
+	mgr.lock.Lock()
+	mgr.downloaderMap[mchID] = downloader
+	mgr.lock.Unlock()
+
+	mgr.observe(mgr.ctx, mchID, downloader, nil)
+}
+
+// SetMetricsRecorder 设置监控指标记录器，Mgr 将上报各商户的平台证书下载结果与平台证书过期时间
+func (mgr *CertificateDownloaderMgr) SetMetricsRecorder(recorder metrics.Recorder) {
+	mgr.lock.Lock()
+	mgr.metrics = recorder
+	downloaders := make(map[string]*CertificateDownloader, len(mgr.downloaderMap))
+	for mchID, downloader := range mgr.downloaderMap {
+		downloaders[mchID] = downloader
+	}
+	mgr.lock.Unlock()
+
+	// 已注册的下载器立即上报当前的平台证书
+	for mchID, downloader := range downloaders {
+		recorder.SetCertificateExpiries(mchID, certificateExpiries(mgr.ctx, downloader))
+	}
+}
+
+// SetLogger 设置结构化日志 Logger，Mgr 将输出各商户平台证书的下载结果，下载成功为 Info，失败为 Error
+func (mgr *CertificateDownloaderMgr) SetLogger(logger *slog.Logger) {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	if logger == nil {
+		mgr.logger = nil
+		return
+	}
+	mgr.logger = logging.Redacting(logger)
+}
+
+// observe 记录一次平台证书下载的监控指标并输出日志
+func (mgr *CertificateDownloaderMgr) observe(
+	ctx context.Context, mchID string, downloader *CertificateDownloader, err error,
+) {
+	mgr.lock.RLock()
+	recorder := mgr.metrics
+	logger := logging.OrDiscard(mgr.logger)
+	mgr.lock.RUnlock()
+
+	if recorder != nil {
+		recorder.ObserveCertificateDownload(mchID, err == nil, time.Now())
+		if err == nil {
+			recorder.SetCertificateExpiries(mchID, certificateExpiries(ctx, downloader))
+		}
+	}
+
+	if err != nil {
+		logger.LogAttrs(
+			ctx, slog.LevelError, "wechatpay certificate download failed",
+			slog.String(logging.KeyMchID, mchID), slog.Any(logging.KeyError, err),
+		)
+		return
+	}
+	if logger.Enabled(ctx, slog.LevelInfo) {
+		serials := make([]string, 0)
+		for serialNo := range downloader.GetAll(ctx) {
+			serials = append(serials, serialNo)
+		}
+		sort.Strings(serials)
+		logger.LogAttrs(
+			ctx, slog.LevelInfo, "wechatpay certificate downloaded",
+			slog.String(logging.KeyMchID, mchID), slog.Any(logging.KeyCertSerial, serials),
+		)
+	}
+}
+
+func certificateExpiries(ctx context.Context, downloader *CertificateDownloader) map[string]time.Time {
+	expiries := make(map[string]time.Time)
+	for serialNo, certificate := range downloader.GetAll(ctx) {
+		expiries[serialNo] = certificate.NotAfter
+	}
+	return expiries
+}
+
 // RegisterDownloaderWithPrivateKey 向 Mgr 注册商户的平台证书下载器
 func (mgr *CertificateDownloaderMgr) RegisterDownloaderWithPrivateKey(
 	ctx context.Context, privateKey *rsa.PrivateKey,
@@ -169,10 +258,7 @@ func (mgr *CertificateDownloaderMgr) RegisterDownloaderWithPrivateKey(
 		return err
 	}
 
-	mgr.lock.Lock()
-	defer mgr.lock.Unlock()
-
-	mgr.downloaderMap[mchID] = downloader
+	mgr.register(mchID, downloader)
 	return nil
 }
 
@@ -185,10 +271,20 @@ func (mgr *CertificateDownloaderMgr) RegisterDownloaderWithClient(
 		return err
 	}
 
-	mgr.lock.Lock()
-	defer mgr.lock.Unlock()
+	mgr.register(mchID, downloader)
+	return nil
+}
 
-	mgr.downloaderMap[mchID] = downloader
+// RegisterDownloaderWithKeyRing 使用可热更新的商户私钥向 Mgr 注册商户的平台证书下载器，商户号取自 KeyRing 的当前私钥
+func (mgr *CertificateDownloaderMgr) RegisterDownloaderWithKeyRing(
+	ctx context.Context, ring *keyring.KeyRing, mchAPIv3Key string,
+) error {
+	downloader, err := NewCertificateDownloaderWithKeyRing(ctx, ring, mchAPIv3Key)
+	if err != nil {
+		return err
+	}
+
+	mgr.register(ring.Current().MchID, downloader)
 	return nil
 }
 
@@ -205,6 +301,9 @@ func (mgr *CertificateDownloaderMgr) RemoveDownloader(_ context.Context, mchID s
 	}
 
 	delete(mgr.downloaderMap, mchID)
+	if mgr.metrics != nil {
+		mgr.metrics.SetCertificateExpiries(mchID, nil)
+	}
 	return downloader
 }
 
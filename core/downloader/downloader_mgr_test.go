@@ -3,6 +3,7 @@
 package downloader_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/jemuri/wechatpay-go/core"
 	"github.com/jemuri/wechatpay-go/core/downloader"
+	"github.com/jemuri/wechatpay-go/core/metrics/prometheus"
 	"github.com/jemuri/wechatpay-go/core/option"
 	"github.com/jemuri/wechatpay-go/utils"
 )
@@ -53,3 +55,34 @@ func TestAutoCertificateDownloader(t *testing.T) {
 	mgr.RemoveDownloader(ctx, mockMchID)
 	assert.Empty(t, provider.GetAll(ctx))
 }
+
+func TestCertificateDownloaderMgr_SetMetricsRecorder(t *testing.T) {
+	patches := mockDownloadServer(t)
+	defer patches.Reset()
+
+	ctx := context.Background()
+
+	mgr := downloader.NewCertificateDownloaderMgr(ctx)
+	defer mgr.Stop()
+
+	privateKey, err := utils.LoadPrivateKey(testingKey(mockMchPrivateKey))
+	require.NoError(t, err)
+	err = mgr.RegisterDownloaderWithPrivateKey(ctx, privateKey, mockMchCertificateSerial, mockMchID, mockAPIv3Key)
+	require.NoError(t, err)
+
+	exporter := prometheus.NewExporter("", nil)
+	mgr.SetMetricsRecorder(exporter)
+	mgr.DownloadCertificates(ctx)
+
+	var buf bytes.Buffer
+	_, err = exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `wechatpay_certificate_downloads_total{mchid="`+mockMchID+`",result="success"} 1`)
+	assert.Contains(t, buf.String(), `wechatpay_certificate_expiry_timestamp_seconds{mchid="`+mockMchID+`"`)
+
+	mgr.RemoveDownloader(ctx, mockMchID)
+	buf.Reset()
+	_, err = exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), `wechatpay_certificate_expiry_timestamp_seconds{mchid="`+mockMchID+`"`)
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // rawCertificate 微信支付平台证书信息
@@ -32,12 +34,12 @@ func (o rawCertificate) MarshalJSON() ([]byte, error) {
 	if o.EffectiveTime == nil {
 		return nil, fmt.Errorf("field `EffectiveTime` is required and must be specified in rawCertificate")
 	}
-	toSerialize["effective_time"] = o.EffectiveTime.Format(time.RFC3339)
+	toSerialize["effective_time"] = chinatime.Format(*o.EffectiveTime)
 
 	if o.ExpireTime == nil {
 		return nil, fmt.Errorf("field `ExpireTime` is required and must be specified in rawCertificate")
 	}
-	toSerialize["expire_time"] = o.ExpireTime.Format(time.RFC3339)
+	toSerialize["expire_time"] = chinatime.Format(*o.ExpireTime)
 
 	if o.EncryptCertificate == nil {
 		return nil, fmt.Errorf("field `encryptCertificate` is required and must be specified in rawCertificate")
@@ -0,0 +1,185 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jemuri/wechatpay-go/core/consts"
+)
+
+var regAuthorizationParams = regexp.MustCompile(`nonce_str="([^"]*)",timestamp="(\d+)"`)
+
+type dryRunKey struct{}
+
+// WithDryRun 返回开启试运行模式的 Context
+//
+// 试运行模式下，Client 会完整地构建并签名请求，但不会发出请求，而是返回 *DryRunError。
+// 所有服务接口均会将该错误原样返回，可用于排查 SIGN_ERROR 或在审批流程中预览将要发出的请求
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun 判断 ctx 是否开启了试运行模式
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// DryRunError 试运行结果，请求已构建并签名但未发出
+type DryRunError struct {
+	Request     *http.Request // 已签名的请求，Body 可以通过 Request.GetBody 重复读取
+	SignMessage string        // 参与签名的原文，格式见 consts.SignatureMessageFormat；APIv2 接口为空
+}
+
+// Error 输出 DryRunError
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run, request is not sent: %s %s", e.Request.Method, e.Request.URL)
+}
+
+// Curl 将请求导出为可以直接执行的 curl 命令
+//
+// 命令中包含 Authorization 等全部请求头，请勿将其输出到日志等不安全的位置
+func (e *DryRunError) Curl() (string, error) {
+	return CurlCommand(e.Request)
+}
+
+// AsDryRunError 判断当前 error 是否为 *DryRunError（包括被包装的情况），是则返回该错误
+func AsDryRunError(err error) (*DryRunError, bool) {
+	var dryRunErr *DryRunError
+	if errors.As(err, &dryRunErr) {
+		return dryRunErr, true
+	}
+	return nil, false
+}
+
+// IsDryRunError 判断当前 error 是否为 *DryRunError（包括被包装的情况）
+func IsDryRunError(err error) bool {
+	_, ok := AsDryRunError(err)
+	return ok
+}
+
+// newDryRunError 将请求 Body 读入内存使其可以重复读取，并还原参与签名的原文
+func newDryRunError(request *http.Request, signBody string) (*DryRunError, error) {
+	if request.Body != nil && request.GetBody == nil {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body err:%v", err)
+		}
+		_ = request.Body.Close()
+		request.ContentLength = int64(len(body))
+		request.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		request.Body, _ = request.GetBody()
+	}
+
+	dryRunErr := &DryRunError{Request: request}
+	if matches := regAuthorizationParams.FindStringSubmatch(request.Header.Get(consts.Authorization)); matches != nil {
+		timestamp, _ := strconv.ParseInt(matches[2], 10, 64)
+		dryRunErr.SignMessage = fmt.Sprintf(
+			consts.SignatureMessageFormat, request.Method, request.URL.RequestURI(), timestamp, matches[1], signBody,
+		)
+	}
+	return dryRunErr, nil
+}
+
+// CurlCommand 将 HTTP 请求导出为可以直接执行的 curl 命令
+//
+// 请求 Body 通过 Request.GetBody 读取，不会消耗 Request.Body；Body 不是可打印的 UTF-8 文本时（如上传的文件），
+// 使用 bash 的 $'...' 语法以转义形式输出
+func CurlCommand(request *http.Request) (string, error) {
+	var buf strings.Builder
+	buf.WriteString("curl -X ")
+	buf.WriteString(request.Method)
+	buf.WriteString(" ")
+	buf.WriteString(shellQuote(request.URL.String()))
+
+	keys := make([]string, 0, len(request.Header))
+	for key := range request.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range request.Header[key] {
+			buf.WriteString(" \\\n  -H ")
+			buf.WriteString(shellQuote(key + ": " + value))
+		}
+	}
+
+	body, err := requestBody(request)
+	if err != nil {
+		return "", err
+	}
+	if len(body) > 0 {
+		buf.WriteString(" \\\n  --data-binary ")
+		if isPrintable(body) {
+			buf.WriteString(shellQuote(string(body)))
+		} else {
+			buf.WriteString(ansiCQuote(body))
+		}
+	}
+	return buf.String(), nil
+}
+
+func requestBody(request *http.Request) ([]byte, error) {
+	if request.GetBody == nil {
+		if request.Body == nil || request.Body == http.NoBody {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("request body cannot be read repeatedly")
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+	return ioutil.ReadAll(body)
+}
+
+func isPrintable(body []byte) bool {
+	if !utf8.Valid(body) {
+		return false
+	}
+	for _, r := range string(body) {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// shellQuote 使用单引号包裹字符串，字符串中的单引号先结束引用、转义后再重新开始引用
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ansiCQuote 使用 $'...' 语法输出任意字节，不可打印字节转义为 \xHH
+func ansiCQuote(body []byte) string {
+	var buf strings.Builder
+	buf.WriteString("$'")
+	for _, b := range body {
+		switch {
+		case b == '\'' || b == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(b)
+		case b >= 0x20 && b < 0x7f:
+			buf.WriteByte(b)
+		default:
+			_, _ = fmt.Fprintf(&buf, `\x%02x`, b)
+		}
+	}
+	buf.WriteString("'")
+	return buf.String()
+}
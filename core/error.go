@@ -6,8 +6,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+
+	"github.com/jemuri/wechatpay-go/core/logging"
 )
 
 // APIError 微信支付 API v3 标准错误结构
@@ -46,6 +49,17 @@ func (e *APIError) Error() string {
 	return buf.String()
 }
 
+// LogValue 实现 slog.LogValuer，日志中仅输出 HTTP 状态码、错误码与说明
+//
+// Error() 的结果包含应答的全部 Header（如 Wechatpay-Signature），不应直接写入日志
+func (e *APIError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int(logging.KeyStatus, e.StatusCode),
+		slog.String(logging.KeyErrorCode, e.Code),
+		slog.String(logging.KeyErrorMsg, e.Message),
+	)
+}
+
 // IsAPIError 判断当前 error 是否为特定 Code 的 *APIError
 //
 // 类型为其他 error 或 Code 不匹配时均返回 false
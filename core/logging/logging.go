@@ -0,0 +1,81 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package logging 微信支付 API v3 Go SDK 结构化日志
+//
+// SDK 通过 log/slog 输出日志，默认不输出任何日志。core.Client、validators、CertificateDownloaderMgr
+// 与 notify.Handler 均可设置 *slog.Logger，设置的 Logger 会被 RedactingHandler 包装，
+// Authorization、签名、APIv3 密钥以及 EM_APIV3 敏感字段等内容在输出前会被自动脱敏。
+//
+// 日志级别约定如下，可通过 slog.Handler 的 Level 配置需要输出的级别：
+//   - Debug：请求 Header、验签通过等详细过程
+//   - Info：请求完成、通知处理成功、平台证书下载成功
+//   - Warn：微信支付返回错误码（APIError）、验签失败
+//   - Error：网络错误、平台证书下载失败
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// 日志中使用的属性名
+const (
+	KeyMethod     = "method"      // 请求方法
+	KeyPath       = "path"        // 请求路径，不包含查询参数
+	KeyRequestID  = "request_id"  // 微信支付应答中的 Request-Id
+	KeyStatus     = "status"      // HTTP 状态码
+	KeyLatency    = "latency"     // 请求耗时
+	KeyErrorCode  = "error_code"  // APIError 错误码
+	KeyErrorMsg   = "error_msg"   // APIError 错误说明
+	KeyResult     = "result"      // 请求或通知的处理结果
+	KeyReason     = "reason"      // 验签失败原因
+	KeySerial     = "serial"      // 证书或公钥序列号
+	KeyMchID      = "mchid"       // 商户号
+	KeyEventType  = "event_type"  // 通知的事件类型
+	KeyNotifyID   = "notify_id"   // 通知 ID
+	KeyHeader     = "header"      // HTTP Header
+	KeyError      = "error"       // 错误信息
+	KeyCertSerial = "cert_serial" // 下载得到的平台证书序列号
+	KeyHost       = "host"        // 请求的域名
+	KeyBackupHost = "backup_host" // 熔断时改用的备份域名
+)
+
+// Discard 不输出任何日志的 Logger
+var Discard = slog.New(discardHandler{})
+
+// OrDiscard 返回 logger，logger 为 nil 时返回 Discard
+func OrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return Discard
+	}
+	return logger
+}
+
+// New 使用 handler 创建一个会自动脱敏的 Logger
+func New(handler slog.Handler) *slog.Logger {
+	return slog.New(NewRedactingHandler(handler))
+}
+
+// NewJSONLogger 创建一个以 JSON 格式向 w 输出 level 及以上级别日志的 Logger，输出的日志会自动脱敏
+func NewJSONLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// Redacting 确保 logger 会对日志进行脱敏，logger 为 nil 时返回 Discard
+func Redacting(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return Discard
+	}
+	if _, ok := logger.Handler().(*RedactingHandler); ok {
+		return logger
+	}
+	return New(logger.Handler())
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
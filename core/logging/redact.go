@@ -0,0 +1,299 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedactedValue 敏感内容脱敏后的值
+const RedactedValue = "[REDACTED]"
+
+// encryptionTag 需要使用 APIv3 加密的敏感字段标签，这类字段通常为姓名、证件号等个人信息
+const (
+	encryptionTagKey   = "encryption"
+	encryptionTagValue = "EM_APIV3"
+)
+
+// authorizationSchemaPrefix Authorization Header 的认证类型前缀，以此开头的字符串值会被脱敏
+const authorizationSchemaPrefix = "WECHATPAY2-"
+
+// maxRedactDepth Redact 递归处理的最大深度，超出后输出 RedactedValue
+const maxRedactDepth = 32
+
+var sensitiveKeys = struct {
+	sync.RWMutex
+	keys map[string]bool
+}{keys: map[string]bool{}}
+
+func init() {
+	for _, key := range []string{
+		// 认证与签名
+		"Authorization", "Wechatpay-Signature", "signature", "paySign", "sign",
+		// 密钥
+		"mch_api_v3_key", "mchAPIv3Key", "apiv3_key", "api_key", "private_key",
+		// 通知解密后的明文
+		"plaintext",
+		// EM_APIV3 敏感字段
+		"name", "user_name", "user_id_card", "id_card_number",
+	} {
+		RegisterSensitiveKey(key)
+	}
+}
+
+// normalizeKey 忽略大小写以及 `-`、`_` 的差异，使 Wechatpay-Signature 与 wechatpay_signature 视为同一个键
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+}
+
+// RegisterSensitiveKey 注册一个需要脱敏的属性名、Header 名或 JSON 字段名，比较时忽略大小写以及 `-`、`_`
+func RegisterSensitiveKey(key string) {
+	sensitiveKeys.Lock()
+	defer sensitiveKeys.Unlock()
+	sensitiveKeys.keys[normalizeKey(key)] = true
+}
+
+// IsSensitiveKey 判断 key 对应的内容是否需要脱敏
+func IsSensitiveKey(key string) bool {
+	sensitiveKeys.RLock()
+	defer sensitiveKeys.RUnlock()
+	return sensitiveKeys.keys[normalizeKey(key)]
+}
+
+// RedactHeader 返回脱敏后的 http.Header 副本，敏感 Header 的值被替换为 RedactedValue
+func RedactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	ret := make(http.Header, len(header))
+	for key, values := range header {
+		if IsSensitiveKey(key) {
+			ret[key] = []string{RedactedValue}
+			continue
+		}
+		ret[key] = append([]string(nil), values...)
+	}
+	return ret
+}
+
+// Redact 返回 v 脱敏后的副本，用于输出请求、应答或通知的数据结构
+//
+// 结构体将被转换为以 JSON 字段名为键的 map，带有 `encryption:"EM_APIV3"` 标签的字段以及
+// 名称为敏感键（见 RegisterSensitiveKey）的字段与 map 元素会被替换为 RedactedValue
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if header, ok := v.(http.Header); ok {
+		return RedactHeader(header)
+	}
+	return redactValue(reflect.ValueOf(v), 0)
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func redactValue(v reflect.Value, depth int) interface{} {
+	if depth > maxRedactDepth {
+		return RedactedValue
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Implements(errorType) {
+			return v.Interface()
+		}
+		return redactValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		ret := map[string]interface{}{}
+		redactStruct(v, ret, depth)
+		return ret
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		ret := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if IsSensitiveKey(key) {
+				ret[key] = RedactedValue
+				continue
+			}
+			ret[key] = redactValue(iter.Value(), depth+1)
+		}
+		return ret
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		ret := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret[i] = redactValue(v.Index(i), depth+1)
+		}
+		return ret
+	case reflect.String:
+		return redactString(v.String())
+	case reflect.Invalid:
+		return nil
+	default:
+		if !v.CanInterface() {
+			return nil
+		}
+		return v.Interface()
+	}
+}
+
+func redactStruct(v reflect.Value, ret map[string]interface{}, depth int) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		if field.Anonymous && name == "" {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				redactStruct(fv, ret, depth+1)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if field.Tag.Get(encryptionTagKey) == encryptionTagValue || IsSensitiveKey(name) {
+			ret[name] = RedactedValue
+			continue
+		}
+		ret[name] = redactValue(fv, depth+1)
+	}
+}
+
+// jsonFieldName 解析字段的 json 标签，返回 JSON 字段名、是否 omitempty 以及是否忽略该字段
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+func redactString(s string) string {
+	if strings.HasPrefix(s, authorizationSchemaPrefix) {
+		return RedactedValue
+	}
+	return s
+}
+
+// RedactingHandler 在输出前对日志属性进行脱敏的 slog.Handler
+//
+// 属性名为敏感键时值被替换为 RedactedValue；值为 http.Header、结构体、map 或 slice 时使用 Redact 脱敏；
+// 以微信支付认证类型开头的字符串（Authorization Header 的值）同样会被脱敏
+type RedactingHandler struct {
+	next slog.Handler
+}
+
+// NewRedactingHandler 使用 next 创建 RedactingHandler，脱敏后的日志交给 next 输出
+func NewRedactingHandler(next slog.Handler) *RedactingHandler {
+	if h, ok := next.(*RedactingHandler); ok {
+		return h
+	}
+	return &RedactingHandler{next: next}
+}
+
+// Enabled 判断是否需要输出 level 级别的日志
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle 对日志属性脱敏后交给下一个 Handler 输出
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs 返回附加了脱敏后属性的 Handler
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(redacted)}
+}
+
+// WithGroup 返回使用属性组 name 的 Handler
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	if attr.Key != "" && IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(redactString(attr.Value.String()))
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, a := range group {
+			redacted[i] = redactAttr(a)
+		}
+		attr.Value = slog.GroupValue(redacted...)
+	case slog.KindAny:
+		attr.Value = slog.AnyValue(Redact(attr.Value.Any()))
+	}
+	return attr
+}
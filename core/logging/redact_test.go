@@ -0,0 +1,108 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReceiver struct {
+	Openid   *string `json:"openid,omitempty"`
+	UserName *string `json:"user_name,omitempty" encryption:"EM_APIV3"`
+	Phone    *string `json:"phone,omitempty" encryption:"EM_APIV3"`
+}
+
+type testRequest struct {
+	OutTradeNo *string           `json:"out_trade_no,omitempty"`
+	Amount     *int64            `json:"amount,omitempty"`
+	Receivers  []testReceiver    `json:"receivers,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
+	Ignored    string            `json:"-"`
+}
+
+func TestRedact(t *testing.T) {
+	str := func(s string) *string { return &s }
+	amount := int64(100)
+
+	req := &testRequest{
+		OutTradeNo: str("1217752501201407033233368018"),
+		Amount:     &amount,
+		Receivers:  []testReceiver{{Openid: str("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"), UserName: str("张三")}},
+		Extra:      map[string]string{"sign": "ABC", "note": "hello"},
+		Ignored:    "ignored",
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"out_trade_no": "1217752501201407033233368018",
+		"amount":       int64(100),
+		"receivers": []interface{}{
+			map[string]interface{}{"openid": "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", "user_name": logging.RedactedValue},
+		},
+		"extra": map[string]interface{}{"sign": logging.RedactedValue, "note": "hello"},
+	}, logging.Redact(req))
+	assert.Equal(t, "张三", *req.Receivers[0].UserName)
+}
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", `WECHATPAY2-SHA256-RSA2048 mchid="1900009191",signature="abc"`)
+	header.Set("Wechatpay-Signature", "abc")
+	header.Set("Wechatpay-Serial", "SERIAL")
+
+	redacted := logging.RedactHeader(header)
+	assert.Equal(t, logging.RedactedValue, redacted.Get("Authorization"))
+	assert.Equal(t, logging.RedactedValue, redacted.Get("Wechatpay-Signature"))
+	assert.Equal(t, "SERIAL", redacted.Get("Wechatpay-Serial"))
+	assert.Equal(t, "abc", header.Get("Wechatpay-Signature"))
+}
+
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, slog.LevelInfo).With(slog.String("mch_api_v3_key", "secret-key"))
+
+	header := http.Header{}
+	header.Set("Authorization", "WECHATPAY2-SHA256-RSA2048 signature")
+
+	logger.Debug("hidden")
+	logger.Info(
+		"message",
+		slog.Any("header", header),
+		slog.String("auth", "WECHATPAY2-SHA256-RSA2048 signature"),
+		slog.Group("notify", slog.String("plaintext", `{"openid":"o"}`), slog.String("event_type", "TRANSACTION.SUCCESS")),
+		slog.String("serial", "SERIAL"),
+	)
+
+	output := buf.String()
+	assert.NotContains(t, output, "hidden")
+	assert.NotContains(t, output, "secret-key")
+	assert.NotContains(t, output, "WECHATPAY2-SHA256-RSA2048")
+	assert.NotContains(t, output, "openid")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, logging.RedactedValue, record["mch_api_v3_key"])
+	assert.Equal(t, logging.RedactedValue, record["auth"])
+	assert.Equal(t, "SERIAL", record["serial"])
+	assert.Equal(
+		t, map[string]interface{}{"plaintext": logging.RedactedValue, "event_type": "TRANSACTION.SUCCESS"},
+		record["notify"],
+	)
+}
+
+func TestRedacting(t *testing.T) {
+	assert.Equal(t, logging.Discard, logging.Redacting(nil))
+	assert.False(t, logging.Discard.Enabled(context.Background(), slog.LevelError))
+
+	logger := logging.NewJSONLogger(&bytes.Buffer{}, slog.LevelInfo)
+	assert.Same(t, logger, logging.Redacting(logger))
+	assert.IsType(t, &logging.RedactingHandler{}, logging.Redacting(slog.Default()).Handler())
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package metrics 微信支付 API v3 Go SDK 监控指标
+//
+// SDK 通过 Recorder 上报以下指标：
+//   - API 请求的次数与耗时，按请求方法、模板化的请求路径（如 /v3/pay/transactions/id/{transaction_id}）、
+//     HTTP 状态码与 APIError 错误码区分；
+//   - 应答与回调通知的验签失败次数，按失败原因区分；
+//   - 回调通知的解析结果，按通知类型区分；
+//   - 平台证书下载器最近一次下载成功的时间与各平台证书的过期时间。
+//
+// 你可以自行实现 Recorder 接入已有的监控系统，或使用子包 prometheus 提供的 Prometheus 兼容实现。
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// 请求结果
+const (
+	ResultSuccess         = "success"          // 请求成功
+	ResultAPIError        = "api_error"        // 微信支付返回了非 2xx 的应答
+	ResultNetworkError    = "network_error"    // 网络错误，未收到应答
+	ResultValidationError = "validation_error" // 应答验签失败
+)
+
+// 验签来源
+const (
+	SourceResponse     = "response"     // API 应答
+	SourceNotification = "notification" // 回调通知
+)
+
+// 回调通知解析结果
+const (
+	NotificationSuccess          = "success"           // 解析成功
+	NotificationValidationFailed = "validation_failed" // 验签失败
+	NotificationInvalidBody      = "invalid_body"      // 报文格式错误或算法套件不匹配
+	NotificationDecryptFailed    = "decrypt_failed"    // 解密失败
+)
+
+// RequestMetric 一次 API 请求的指标
+type RequestMetric struct {
+	Method     string        // 请求方法
+	Operation  string        // 模板化的请求路径，如 /v3/pay/transactions/id/{transaction_id}
+	StatusCode int           // HTTP 状态码，网络错误时为 0
+	ErrorCode  string        // APIError 的错误码，请求成功或非 API 错误时为空
+	Result     string        // 请求结果，如 ResultSuccess
+	Duration   time.Duration // 请求耗时（含验签）
+}
+
+// Recorder 监控指标记录器，实现需要可以在多个 goroutine 中并发使用
+type Recorder interface {
+	// ObserveRequest 记录一次 API 请求
+	ObserveRequest(ctx context.Context, m RequestMetric)
+	// IncValidationFailure 记录一次验签失败，source 为 SourceResponse 或 SourceNotification
+	IncValidationFailure(ctx context.Context, source, reason string)
+	// ObserveNotification 记录一次回调通知的解析结果，无法获取通知类型时 eventType 为空
+	ObserveNotification(ctx context.Context, eventType, outcome string)
+	// ObserveCertificateDownload 记录商户的一次平台证书下载结果
+	ObserveCertificateDownload(mchID string, success bool, at time.Time)
+	// SetCertificateExpiries 设置商户当前全部平台证书（证书序列号->过期时间），会替换该商户之前设置的证书；
+	// expiries 为 nil 表示商户的下载器已被移除，应同时清除该商户的下载指标
+	SetCertificateExpiries(mchID string, expiries map[string]time.Time)
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) ObserveRequest(context.Context, RequestMetric)        {}
+func (nopRecorder) IncValidationFailure(context.Context, string, string) {}
+func (nopRecorder) ObserveNotification(context.Context, string, string)  {}
+func (nopRecorder) ObserveCertificateDownload(string, bool, time.Time)   {}
+func (nopRecorder) SetCertificateExpiries(string, map[string]time.Time)  {}
+
+// Nop 不记录任何指标的 Recorder
+var Nop Recorder = nopRecorder{}
+
+// OrNop 当 r 为 nil 时返回 Nop，否则返回 r
+func OrNop(r Recorder) Recorder {
+	if r == nil {
+		return Nop
+	}
+	return r
+}
+
+type operationKey struct{}
+
+// WithOperation 为本次请求指定监控指标中的 Operation，优先于根据请求路径推断的结果
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext 获取通过 WithOperation 指定的 Operation
+func OperationFromContext(ctx context.Context) (string, bool) {
+	operation, ok := ctx.Value(operationKey{}).(string)
+	return operation, ok && operation != ""
+}
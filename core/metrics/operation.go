@@ -0,0 +1,219 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// knownOperations SDK 内置 API 的请求路径模板
+var knownOperations = []string{
+	"/v3/certificates",
+	"/v3/combine-transactions/app",
+	"/v3/combine-transactions/h5",
+	"/v3/combine-transactions/jsapi",
+	"/v3/combine-transactions/native",
+	"/v3/combine-transactions/out-trade-no/{combine_out_trade_no}",
+	"/v3/combine-transactions/out-trade-no/{combine_out_trade_no}/close",
+	"/v3/goldplan/merchants/changecustompagestatus",
+	"/v3/goldplan/merchants/changegoldplanstatus",
+	"/v3/goldplan/merchants/close-advertising-show",
+	"/v3/goldplan/merchants/open-advertising-show",
+	"/v3/goldplan/merchants/set-advertising-industry-filter",
+	"/v3/lovefeast/brands/{brand_id}",
+	"/v3/lovefeast/users/{openid}/orders/brand-id/{brand_id}",
+	"/v3/lovefeast/users/{openid}/orders/out-trade-no/{out_trade_no}",
+	"/v3/marketing/busifavor/callbacks",
+	"/v3/marketing/busifavor/coupons/associate",
+	"/v3/marketing/busifavor/coupons/deactivate",
+	"/v3/marketing/busifavor/coupons/disassociate",
+	"/v3/marketing/busifavor/coupons/return",
+	"/v3/marketing/busifavor/coupons/send",
+	"/v3/marketing/busifavor/coupons/use",
+	"/v3/marketing/busifavor/coupons/{card_id}/send",
+	"/v3/marketing/busifavor/stocks",
+	"/v3/marketing/busifavor/stocks/{stock_id}",
+	"/v3/marketing/busifavor/stocks/{stock_id}/budget",
+	"/v3/marketing/busifavor/stocks/{stock_id}/couponcodes",
+	"/v3/marketing/busifavor/stocks/{stock_id}/couponcodes/{coupon_code}",
+	"/v3/marketing/busifavor/subsidy/pay-receipts",
+	"/v3/marketing/busifavor/subsidy/pay-receipts/{subsidy_receipt_id}",
+	"/v3/marketing/busifavor/subsidy/return-receipts",
+	"/v3/marketing/busifavor/subsidy/return-receipts/{subsidy_return_receipt_id}",
+	"/v3/marketing/busifavor/users/{openid}/coupons",
+	"/v3/marketing/busifavor/users/{openid}/coupons/{coupon_code}/appids/{appid}",
+	"/v3/marketing/favor/callbacks",
+	"/v3/marketing/favor/coupon-stocks",
+	"/v3/marketing/favor/stocks",
+	"/v3/marketing/favor/stocks/{stock_id}",
+	"/v3/marketing/favor/stocks/{stock_id}/items",
+	"/v3/marketing/favor/stocks/{stock_id}/merchants",
+	"/v3/marketing/favor/stocks/{stock_id}/pause",
+	"/v3/marketing/favor/stocks/{stock_id}/refund-flow",
+	"/v3/marketing/favor/stocks/{stock_id}/restart",
+	"/v3/marketing/favor/stocks/{stock_id}/start",
+	"/v3/marketing/favor/stocks/{stock_id}/stop",
+	"/v3/marketing/favor/stocks/{stock_id}/use-flow",
+	"/v3/marketing/favor/users/{openid}/coupons",
+	"/v3/marketing/favor/users/{openid}/coupons/{coupon_id}",
+	"/v3/marketing/goods-subsidy-activity/activities",
+	"/v3/marketing/goods-subsidy-activity/activity/{activity_id}/apply",
+	"/v3/marketing/goods-subsidy-activity/qualification/lock",
+	"/v3/marketing/goods-subsidy-activity/qualification/unlock",
+	"/v3/marketing/goods-subsidy-activity/retail-store-act/{activity_id}/representative",
+	"/v3/marketing/goods-subsidy-activity/retail-store-act/{activity_id}/representatives",
+	"/v3/marketing/goods-subsidy-activity/retail-store-act/{brand_id}/materials",
+	"/v3/marketing/goods-subsidy-activity/retail-store-act/{brand_id}/stores",
+	"/v3/marketing/goods-subsidy-activity/retail-store-act/{brand_id}/stores/{store_code}",
+	"/v3/marketing/paygiftactivity/activities",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}/goods",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}/merchants",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}/merchants/add",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}/merchants/delete",
+	"/v3/marketing/paygiftactivity/activities/{activity_id}/terminate",
+	"/v3/marketing/paygiftactivity/unique-threshold-activity",
+	"/v3/partner-transfer/batches",
+	"/v3/partner-transfer/batches/batch-id/{batch_id}",
+	"/v3/partner-transfer/batches/batch-id/{batch_id}/details/detail-id/{detail_id}",
+	"/v3/partner-transfer/batches/out-batch-no/{out_batch_no}",
+	"/v3/partner-transfer/batches/out-batch-no/{out_batch_no}/details/out-detail-no/{out_detail_no}",
+	"/v3/pay/partner/transactions/app",
+	"/v3/pay/partner/transactions/h5",
+	"/v3/pay/partner/transactions/id/{transaction_id}",
+	"/v3/pay/partner/transactions/jsapi",
+	"/v3/pay/partner/transactions/native",
+	"/v3/pay/partner/transactions/out-trade-no/{out_trade_no}",
+	"/v3/pay/partner/transactions/out-trade-no/{out_trade_no}/close",
+	"/v3/pay/transactions/app",
+	"/v3/pay/transactions/h5",
+	"/v3/pay/transactions/id/{transaction_id}",
+	"/v3/pay/transactions/jsapi",
+	"/v3/pay/transactions/native",
+	"/v3/pay/transactions/out-trade-no/{out_trade_no}",
+	"/v3/pay/transactions/out-trade-no/{out_trade_no}/close",
+	"/v3/payroll-card/authentications",
+	"/v3/payroll-card/authentications/pre-order",
+	"/v3/payroll-card/authentications/pre-order-with-auth",
+	"/v3/payroll-card/authentications/{authenticate_number}",
+	"/v3/payroll-card/relations/{openid}",
+	"/v3/payroll-card/tokens",
+	"/v3/payroll-card/transfer-batches",
+	"/v3/profitsharing/bills",
+	"/v3/profitsharing/merchant-configs/{sub_mchid}",
+	"/v3/profitsharing/orders",
+	"/v3/profitsharing/orders/unfreeze",
+	"/v3/profitsharing/orders/{out_order_no}",
+	"/v3/profitsharing/receivers/add",
+	"/v3/profitsharing/receivers/delete",
+	"/v3/profitsharing/return-orders",
+	"/v3/profitsharing/return-orders/{out_return_no}",
+	"/v3/profitsharing/transactions/{transaction_id}/amounts",
+	"/v3/qrcode/transactions",
+	"/v3/qrcode/transactions/out-trade-no/{out_trade_no}",
+	"/v3/qrcode/user-services/contract-id/{contract_id}",
+	"/v3/refund/domestic/refunds",
+	"/v3/refund/domestic/refunds/{out_refund_no}",
+	"/v3/refund/domestic/refunds/{refund_id}/apply-abnormal-refund",
+	"/v3/transfer/batches",
+	"/v3/transfer/batches/batch-id/{batch_id}",
+	"/v3/transfer/batches/batch-id/{batch_id}/details/detail-id/{detail_id}",
+	"/v3/transfer/batches/out-batch-no/{out_batch_no}",
+	"/v3/transfer/batches/out-batch-no/{out_batch_no}/details/out-detail-no/{out_detail_no}",
+	"/v3/vehicle/parking/parkings",
+	"/v3/vehicle/parking/services/find",
+	"/v3/vehicle/transactions/out-trade-no/{out_trade_no}",
+	"/v3/vehicle/transactions/parking",
+}
+
+var operations = newOperationTable(knownOperations)
+
+// RegisterOperation 注册自定义的请求路径模板（如 /v3/ecommerce/profitsharing/orders/{out_order_no}），
+// 路径参数使用 {} 包裹。SDK 未内置的 API 注册后即可在指标中得到准确的 Operation
+func RegisterOperation(template string) {
+	operations.add(template)
+}
+
+// Operation 将请求路径转换为路径模板，用于降低监控指标的基数
+//
+// 优先匹配已知的路径模板；无法匹配时，将包含数字的路径段（版本号如 v3 除外）替换为 {id}
+func Operation(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if template, ok := operations.match(path); ok {
+		return template
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isVariableSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isVariableSegment(segment string) bool {
+	if len(segment) > 1 && segment[0] == 'v' && strings.IndexFunc(segment[1:], func(r rune) bool {
+		return !unicode.IsDigit(r)
+	}) < 0 {
+		return false
+	}
+	return strings.IndexFunc(segment, unicode.IsDigit) >= 0
+}
+
+type operationTable struct {
+	lock      sync.RWMutex
+	templates map[int][][]string // 路径段数 -> 路径模板
+}
+
+func newOperationTable(templates []string) *operationTable {
+	t := &operationTable{templates: make(map[int][][]string)}
+	for _, template := range templates {
+		t.add(template)
+	}
+	return t
+}
+
+func (t *operationTable) add(template string) {
+	segments := strings.Split(template, "/")
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.templates[len(segments)] = append(t.templates[len(segments)], segments)
+}
+
+// match 匹配路径模板，有多个模板匹配时选择字面量路径段最多的模板
+func (t *operationTable) match(path string) (string, bool) {
+	segments := strings.Split(path, "/")
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var best []string
+	bestScore := -1
+	for _, template := range t.templates[len(segments)] {
+		score := 0
+		for i, segment := range template {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				continue
+			}
+			if segment != segments[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = template, score
+		}
+	}
+
+	if best == nil {
+		return "", false
+	}
+	return strings.Join(best, "/"), true
+}
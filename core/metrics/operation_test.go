@@ -0,0 +1,89 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v3/pay/transactions/jsapi", "/v3/pay/transactions/jsapi"},
+		{"/v3/pay/transactions/id/4200000001202106243137", "/v3/pay/transactions/id/{transaction_id}"},
+		{"/v3/pay/transactions/out-trade-no/ORDER_abc?mchid=1900009191", "/v3/pay/transactions/out-trade-no/{out_trade_no}"},
+		{"/v3/pay/transactions/out-trade-no/ORDER_abc/close", "/v3/pay/transactions/out-trade-no/{out_trade_no}/close"},
+		{"/v3/marketing/busifavor/coupons/use", "/v3/marketing/busifavor/coupons/use"},
+		{"/v3/marketing/busifavor/coupons/CARD1/send", "/v3/marketing/busifavor/coupons/{card_id}/send"},
+		{"/v3/refund/domestic/refunds/50000000382019052709732678859/apply-abnormal-refund",
+			"/v3/refund/domestic/refunds/{refund_id}/apply-abnormal-refund"},
+		{"/v3/certificates", "/v3/certificates"},
+		{"/v3/combine-transactions/out-trade-no/P_combine", "/v3/combine-transactions/out-trade-no/{combine_out_trade_no}"},
+		{"/v3/combine-transactions/out-trade-no/P_combine/close",
+			"/v3/combine-transactions/out-trade-no/{combine_out_trade_no}/close"},
+		{"/v3/unknown/orders/1217752501201407033233368018", "/v3/unknown/orders/{id}"},
+		{"/v3/unknown/orders/abc", "/v3/unknown/orders/abc"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Operation(tt.path), tt.path)
+	}
+}
+
+// TestKnownOperations_CoverServices 确保 services 中的每个接口路径都注册了模板，
+// 否则包含字母的路径参数（如商户订单号）会原样成为指标的标签值
+func TestKnownOperations_CoverServices(t *testing.T) {
+	pathPattern := regexp.MustCompile(`consts\.WechatPayAPIServer \+ "([^"]+)"`)
+	var templates []string
+	err := filepath.Walk(filepath.Join("..", "..", "services"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range pathPattern.FindAllSubmatch(content, -1) {
+			templates = append(templates, string(match[1]))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+
+	known := make(map[string]bool, len(knownOperations))
+	for _, template := range knownOperations {
+		known[template] = true
+	}
+	for _, template := range templates {
+		assert.True(t, known[template], "%s is not in knownOperations", template)
+	}
+}
+
+func TestRegisterOperation(t *testing.T) {
+	RegisterOperation("/v3/custom/orders/{out_order_no}")
+	assert.Equal(t, "/v3/custom/orders/{out_order_no}", Operation("/v3/custom/orders/abc"))
+}
+
+func TestWithOperation(t *testing.T) {
+	_, ok := OperationFromContext(context.Background())
+	assert.False(t, ok)
+
+	operation, ok := OperationFromContext(WithOperation(context.Background(), "QueryOrder"))
+	assert.True(t, ok)
+	assert.Equal(t, "QueryOrder", operation)
+}
+
+func TestOrNop(t *testing.T) {
+	assert.Equal(t, Nop, OrNop(nil))
+}
@@ -0,0 +1,336 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package prometheus 微信支付 API v3 Go SDK 监控指标的 Prometheus 兼容实现
+//
+// Exporter 实现了 metrics.Recorder，并以 Prometheus 文本格式（0.0.4）输出指标，不依赖 Prometheus 客户端库。
+// 你可以直接将 Exporter 挂载为 HTTP Handler 供 Prometheus 抓取：
+//
+//	exporter := prometheus.NewExporter("", nil)
+//	client, err := core.NewClient(ctx, opts..., option.WithMetricsRecorder(exporter))
+//	http.Handle("/metrics/wechatpay", exporter)
+//
+// 输出的指标如下（以默认命名空间 wechatpay 为例）：
+//
+//	wechatpay_client_requests_total{method,operation,status_code,error_code,result}  API 请求次数
+//	wechatpay_client_request_duration_seconds{method,operation}                      API 请求耗时直方图
+//	wechatpay_validation_failures_total{source,reason}                                验签失败次数
+//	wechatpay_notifications_total{event_type,outcome}                                 回调通知解析次数
+//	wechatpay_certificate_downloads_total{mchid,result}                               平台证书下载次数
+//	wechatpay_certificate_last_success_timestamp_seconds{mchid}                       最近一次下载成功的时间
+//	wechatpay_certificate_last_success_age_seconds{mchid}                             距最近一次下载成功的时长
+//	wechatpay_certificate_expiry_timestamp_seconds{mchid,serial_no}                   平台证书的过期时间
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+)
+
+const (
+	// DefaultNamespace 默认的指标命名空间
+	DefaultNamespace = "wechatpay"
+	// ContentType Prometheus 文本格式的 Content-Type
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// DefaultBuckets 默认的请求耗时直方图分桶（秒）
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// labels 指标标签值，顺序与指标声明的标签名一致
+type labels string
+
+func newLabels(values ...string) labels {
+	return labels(strings.Join(values, "\x00"))
+}
+
+func (l labels) values() []string {
+	return strings.Split(string(l), "\x00")
+}
+
+type histogram struct {
+	counts []uint64 // 与 buckets 一一对应，非累积
+	count  uint64
+	sum    float64
+}
+
+// Exporter Prometheus 兼容的 metrics.Recorder，可以在多个 goroutine 中并发使用
+type Exporter struct {
+	namespace string
+	buckets   []float64
+	clock     clock.Clock
+
+	lock               sync.Mutex
+	requests           map[labels]float64
+	durations          map[labels]*histogram
+	validationFailures map[labels]float64
+	notifications      map[labels]float64
+	downloads          map[labels]float64
+	lastSuccess        map[string]time.Time
+	expiries           map[string]map[string]time.Time
+}
+
+// ObserveRequest 记录一次 API 请求
+func (e *Exporter) ObserveRequest(_ context.Context, m metrics.RequestMetric) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.requests[newLabels(m.Method, m.Operation, strconv.Itoa(m.StatusCode), m.ErrorCode, m.Result)]++
+
+	key := newLabels(m.Method, m.Operation)
+	h, ok := e.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(e.buckets))}
+		e.durations[key] = h
+	}
+	seconds := m.Duration.Seconds()
+	for i, bound := range e.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// IncValidationFailure 记录一次验签失败
+func (e *Exporter) IncValidationFailure(_ context.Context, source, reason string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.validationFailures[newLabels(source, reason)]++
+}
+
+// ObserveNotification 记录一次回调通知的解析结果
+func (e *Exporter) ObserveNotification(_ context.Context, eventType, outcome string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.notifications[newLabels(eventType, outcome)]++
+}
+
+// ObserveCertificateDownload 记录商户的一次平台证书下载结果
+func (e *Exporter) ObserveCertificateDownload(mchID string, success bool, at time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	e.downloads[newLabels(mchID, result)]++
+	if success {
+		e.lastSuccess[mchID] = at
+	}
+}
+
+// SetCertificateExpiries 设置商户当前全部平台证书的过期时间，expiries 为 nil 时移除该商户的证书指标
+func (e *Exporter) SetCertificateExpiries(mchID string, expiries map[string]time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if expiries == nil {
+		delete(e.expiries, mchID)
+		delete(e.lastSuccess, mchID)
+		return
+	}
+
+	copied := make(map[string]time.Time, len(expiries))
+	for serialNo, notAfter := range expiries {
+		copied[serialNo] = notAfter
+	}
+	e.expiries[mchID] = copied
+}
+
+// ServeHTTP 以 Prometheus 文本格式输出全部指标
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = e.WriteTo(w)
+}
+
+// WriteTo 将全部指标以 Prometheus 文本格式写入 w
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	e.lock.Lock()
+	now := e.clock.Now()
+	e.writeCounter(&buf, "client_requests_total", "Total number of WeChat Pay API requests.",
+		[]string{"method", "operation", "status_code", "error_code", "result"}, e.requests)
+	e.writeHistogram(&buf, "client_request_duration_seconds", "Latency of WeChat Pay API requests.",
+		[]string{"method", "operation"})
+	e.writeCounter(&buf, "validation_failures_total", "Total number of signature validation failures.",
+		[]string{"source", "reason"}, e.validationFailures)
+	e.writeCounter(&buf, "notifications_total", "Total number of parsed notifications.",
+		[]string{"event_type", "outcome"}, e.notifications)
+	e.writeCounter(&buf, "certificate_downloads_total", "Total number of platform certificate downloads.",
+		[]string{"mchid", "result"}, e.downloads)
+	e.writeLastSuccess(&buf, now)
+	e.writeExpiries(&buf)
+	e.lock.Unlock()
+
+	return buf.WriteTo(w)
+}
+
+func (e *Exporter) name(name string) string {
+	return e.namespace + "_" + name
+}
+
+func (e *Exporter) writeHeader(w io.Writer, name, help, typ string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (e *Exporter) writeCounter(w io.Writer, name, help string, labelNames []string, values map[labels]float64) {
+	name = e.name(name)
+	e.writeHeader(w, name, help, "counter")
+	for _, key := range sortedKeys(values) {
+		writeSample(w, name, labelNames, key.values(), values[key])
+	}
+}
+
+func (e *Exporter) writeHistogram(w io.Writer, name, help string, labelNames []string) {
+	name = e.name(name)
+	e.writeHeader(w, name, help, "histogram")
+
+	keys := make([]labels, 0, len(e.durations))
+	for key := range e.durations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	bucketLabelNames := append(append([]string{}, labelNames...), "le")
+	for _, key := range keys {
+		h := e.durations[key]
+		values := key.values()
+
+		var cumulative uint64
+		for i, bound := range e.buckets {
+			cumulative += h.counts[i]
+			writeSample(w, name+"_bucket", bucketLabelNames, withLabel(values, formatFloat(bound)), float64(cumulative))
+		}
+		writeSample(w, name+"_bucket", bucketLabelNames, withLabel(values, "+Inf"), float64(h.count))
+		writeSample(w, name+"_sum", labelNames, values, h.sum)
+		writeSample(w, name+"_count", labelNames, values, float64(h.count))
+	}
+}
+
+func (e *Exporter) writeLastSuccess(w io.Writer, now time.Time) {
+	mchIDs := make([]string, 0, len(e.lastSuccess))
+	for mchID := range e.lastSuccess {
+		mchIDs = append(mchIDs, mchID)
+	}
+	sort.Strings(mchIDs)
+
+	timestampName := e.name("certificate_last_success_timestamp_seconds")
+	e.writeHeader(w, timestampName, "Unix time of the last successful platform certificate download.", "gauge")
+	for _, mchID := range mchIDs {
+		writeSample(w, timestampName, []string{"mchid"}, []string{mchID}, unixSeconds(e.lastSuccess[mchID]))
+	}
+
+	ageName := e.name("certificate_last_success_age_seconds")
+	e.writeHeader(w, ageName, "Seconds since the last successful platform certificate download.", "gauge")
+	for _, mchID := range mchIDs {
+		writeSample(w, ageName, []string{"mchid"}, []string{mchID}, now.Sub(e.lastSuccess[mchID]).Seconds())
+	}
+}
+
+func (e *Exporter) writeExpiries(w io.Writer) {
+	name := e.name("certificate_expiry_timestamp_seconds")
+	e.writeHeader(w, name, "Unix time when the platform certificate expires.", "gauge")
+
+	mchIDs := make([]string, 0, len(e.expiries))
+	for mchID := range e.expiries {
+		mchIDs = append(mchIDs, mchID)
+	}
+	sort.Strings(mchIDs)
+
+	for _, mchID := range mchIDs {
+		serialNos := make([]string, 0, len(e.expiries[mchID]))
+		for serialNo := range e.expiries[mchID] {
+			serialNos = append(serialNos, serialNo)
+		}
+		sort.Strings(serialNos)
+
+		for _, serialNo := range serialNos {
+			writeSample(
+				w, name, []string{"mchid", "serial_no"}, []string{mchID, serialNo},
+				unixSeconds(e.expiries[mchID][serialNo]),
+			)
+		}
+	}
+}
+
+func withLabel(values []string, value string) []string {
+	return append(append(make([]string, 0, len(values)+1), values...), value)
+}
+
+func sortedKeys(values map[labels]float64) []labels {
+	keys := make([]labels, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func writeSample(w io.Writer, name string, labelNames, labelValues []string, value float64) {
+	pairs := make([]string, len(labelNames))
+	for i, labelName := range labelNames {
+		pairs[i] = labelName + `="` + escapeLabelValue(labelValues[i]) + `"`
+	}
+	_, _ = fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(pairs, ","), formatFloat(value))
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// NewExporter 创建 Exporter，namespace 为空时使用 DefaultNamespace，buckets 为空时使用 DefaultBuckets
+func NewExporter(namespace string, buckets []float64) *Exporter {
+	return NewExporterWithClock(namespace, buckets, clock.System)
+}
+
+// NewExporterWithClock 使用指定时钟创建 Exporter，时钟用于计算距最近一次下载成功的时长
+func NewExporterWithClock(namespace string, buckets []float64, c clock.Clock) *Exporter {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &Exporter{
+		namespace:          namespace,
+		buckets:            buckets,
+		clock:              clock.OrSystem(c),
+		requests:           make(map[labels]float64),
+		durations:          make(map[labels]*histogram),
+		validationFailures: make(map[labels]float64),
+		notifications:      make(map[labels]float64),
+		downloads:          make(map[labels]float64),
+		lastSuccess:        make(map[string]time.Time),
+		expiries:           make(map[string]map[string]time.Time),
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+)
+
+var _ metrics.Recorder = (*Exporter)(nil)
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	exporter := NewExporterWithClock("", []float64{1, 0.1}, clock.Fixed(now))
+
+	exporter.ObserveRequest(ctx, metrics.RequestMetric{
+		Method: "GET", Operation: "/v3/pay/transactions/id/{transaction_id}", StatusCode: 200,
+		Result: metrics.ResultSuccess, Duration: 50 * time.Millisecond,
+	})
+	exporter.ObserveRequest(ctx, metrics.RequestMetric{
+		Method: "GET", Operation: "/v3/pay/transactions/id/{transaction_id}", StatusCode: 404,
+		ErrorCode: "ORDER_NOT_EXIST", Result: metrics.ResultAPIError, Duration: 500 * time.Millisecond,
+	})
+	exporter.IncValidationFailure(ctx, metrics.SourceResponse, "signature_mismatch")
+	exporter.ObserveNotification(ctx, "TRANSACTION.SUCCESS", metrics.NotificationSuccess)
+	exporter.ObserveNotification(ctx, `bad"type`, metrics.NotificationDecryptFailed)
+	exporter.ObserveCertificateDownload("1900009191", true, now.Add(-time.Hour))
+	exporter.ObserveCertificateDownload("1900009191", false, now)
+	exporter.SetCertificateExpiries("1900009191", map[string]time.Time{"SERIAL": time.Unix(1800000000, 0)})
+
+	var buf bytes.Buffer
+	_, err := exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	output := buf.String()
+
+	for _, line := range []string{
+		"# TYPE wechatpay_client_requests_total counter",
+		`wechatpay_client_requests_total{method="GET",operation="/v3/pay/transactions/id/{transaction_id}",` +
+			`status_code="200",error_code="",result="success"} 1`,
+		`wechatpay_client_requests_total{method="GET",operation="/v3/pay/transactions/id/{transaction_id}",` +
+			`status_code="404",error_code="ORDER_NOT_EXIST",result="api_error"} 1`,
+		"# TYPE wechatpay_client_request_duration_seconds histogram",
+		`wechatpay_client_request_duration_seconds_bucket{method="GET",` +
+			`operation="/v3/pay/transactions/id/{transaction_id}",le="0.1"} 1`,
+		`wechatpay_client_request_duration_seconds_bucket{method="GET",` +
+			`operation="/v3/pay/transactions/id/{transaction_id}",le="1"} 2`,
+		`wechatpay_client_request_duration_seconds_bucket{method="GET",` +
+			`operation="/v3/pay/transactions/id/{transaction_id}",le="+Inf"} 2`,
+		`wechatpay_client_request_duration_seconds_sum{method="GET",` +
+			`operation="/v3/pay/transactions/id/{transaction_id}"} 0.55`,
+		`wechatpay_validation_failures_total{source="response",reason="signature_mismatch"} 1`,
+		`wechatpay_notifications_total{event_type="TRANSACTION.SUCCESS",outcome="success"} 1`,
+		`wechatpay_notifications_total{event_type="bad\"type",outcome="decrypt_failed"} 1`,
+		`wechatpay_certificate_downloads_total{mchid="1900009191",result="failure"} 1`,
+		`wechatpay_certificate_downloads_total{mchid="1900009191",result="success"} 1`,
+		`wechatpay_certificate_last_success_timestamp_seconds{mchid="1900009191"} 1.6999964e+09`,
+		`wechatpay_certificate_last_success_age_seconds{mchid="1900009191"} 3600`,
+		`wechatpay_certificate_expiry_timestamp_seconds{mchid="1900009191",serial_no="SERIAL"} 1.8e+09`,
+	} {
+		assert.Contains(t, output, line+"\n")
+	}
+
+	exporter.SetCertificateExpiries("1900009191", nil)
+	buf.Reset()
+	_, err = exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "wechatpay_certificate_expiry_timestamp_seconds{")
+	assert.NotContains(t, buf.String(), "wechatpay_certificate_last_success_age_seconds{")
+}
+
+func TestExporter_ServeHTTP(t *testing.T) {
+	exporter := NewExporter("mypay", nil)
+	exporter.IncValidationFailure(context.Background(), metrics.SourceNotification, "replay")
+
+	recorder := httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `mypay_validation_failures_total{source="notification",reason="replay"} 1`)
+}
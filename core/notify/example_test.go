@@ -8,7 +8,9 @@ import (
 	"net/http"
 
 	"github.com/jemuri/wechatpay-go/core/notify"
+	"github.com/jemuri/wechatpay-go/services/combinepayments"
 	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic"
 )
 
 func ExampleHandler_ParseNotifyRequest_transaction() {
@@ -42,3 +44,42 @@ func ExampleHandler_ParseNotifyRequest_no_model() {
 	fmt.Println(notifyReq.Summary)
 	fmt.Println(content)
 }
+
+func ExampleHandler_ParseNotifyRequest_combine_transaction() {
+	var handler notify.Handler
+	var request *http.Request
+
+	// 合单支付结果通知的内容为合单订单
+	content := new(combinepayments.CombineTransaction)
+	notifyReq, err := handler.ParseNotifyRequest(context.Background(), request, content)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// 处理通知内容，各子单的支付结果见 SubOrders
+	fmt.Println(notifyReq.Summary)
+	for _, subOrder := range content.SubOrders {
+		fmt.Println(subOrder)
+	}
+}
+
+func ExampleHandler_ParseNotifyRequest_refund() {
+	var handler notify.Handler
+	var request *http.Request
+
+	content := new(refunddomestic.RefundNotification)
+	notifyReq, err := handler.ParseNotifyRequest(context.Background(), request, content)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// 退款成功、退款异常与退款关闭使用同一种通知内容，以事件类型区分
+	switch notifyReq.EventType {
+	case refunddomestic.EventTypeRefundSuccess:
+		fmt.Println("refund success", *content.OutRefundNo)
+	case refunddomestic.EventTypeRefundAbnormal, refunddomestic.EventTypeRefundClosed:
+		fmt.Println(notifyReq.Summary, content)
+	}
+}
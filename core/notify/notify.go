@@ -12,10 +12,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/jemuri/wechatpay-go/core/auth"
 	"github.com/jemuri/wechatpay-go/core/auth/validators"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/jemuri/wechatpay-go/core/metrics"
 )
 
 const rsaSignatureType = "WECHATPAY2-SHA256-RSA2048"
@@ -25,6 +30,12 @@ const aeadAesGcmAlgorithm = "AEAD_AES_256_GCM"
 // Handler 通知处理器，使用前先设置验签和解密的算法套件
 type Handler struct {
 	cipherSuites map[string]CipherSuite
+
+	clock       clock.Clock
+	tolerance   time.Duration
+	replayCache auth.ReplayCache
+	metrics     metrics.Recorder
+	logger      *slog.Logger
 }
 
 // CipherSuite 算法套件，包括验签和解密
@@ -46,10 +57,74 @@ func NewEmptyHandler() *Handler {
 
 // AddCipherSuite 添加一个算法套件
 func (h *Handler) AddCipherSuite(cipherSuite CipherSuite) *Handler {
+	h.configureValidator(&cipherSuite.validator)
 	h.cipherSuites[cipherSuite.signatureType] = cipherSuite
 	return h
 }
 
+// SetClock 设置校验通知时间戳所用的时钟，对已添加和之后添加的算法套件均生效
+//
+// 请在处理器投入使用前完成设置
+func (h *Handler) SetClock(c clock.Clock) *Handler {
+	h.clock = c
+	return h.reconfigureCipherSuites()
+}
+
+// SetTimestampTolerance 设置允许的通知时间戳与当前时间之差，对已添加和之后添加的算法套件均生效，
+// tolerance <= 0 时使用 validators.DefaultTimestampTolerance
+//
+// 请在处理器投入使用前完成设置
+func (h *Handler) SetTimestampTolerance(tolerance time.Duration) *Handler {
+	h.tolerance = tolerance
+	return h.reconfigureCipherSuites()
+}
+
+// SetReplayCache 设置通知防重放缓存，对已添加和之后添加的算法套件均生效
+//
+// 设置后，同一个 Wechatpay-Nonce 的通知在时间戳有效期内重复出现时，ParseNotifyRequest 将返回包装了
+// *validators.ReplayError 的错误，可使用 validators.IsReplayError 判断。
+// 请在处理器投入使用前完成设置
+func (h *Handler) SetReplayCache(c auth.ReplayCache) *Handler {
+	h.replayCache = c
+	return h.reconfigureCipherSuites()
+}
+
+// SetMetricsRecorder 设置监控指标记录器，处理器将上报通知的解析结果与验签失败原因
+//
+// 请在处理器投入使用前完成设置
+func (h *Handler) SetMetricsRecorder(recorder metrics.Recorder) *Handler {
+	h.metrics = recorder
+	return h
+}
+
+// SetLogger 设置结构化日志 Logger，对已添加和之后添加的算法套件均生效
+//
+// 处理器将输出通知的事件类型、通知 ID、处理结果与验签结果，通知中的敏感内容会被自动脱敏。
+// 请在处理器投入使用前完成设置
+func (h *Handler) SetLogger(logger *slog.Logger) *Handler {
+	if logger == nil {
+		h.logger = nil
+	} else {
+		h.logger = logging.Redacting(logger)
+	}
+	return h.reconfigureCipherSuites()
+}
+
+func (h *Handler) reconfigureCipherSuites() *Handler {
+	for signatureType, suite := range h.cipherSuites {
+		h.configureValidator(&suite.validator)
+		h.cipherSuites[signatureType] = suite
+	}
+	return h
+}
+
+func (h *Handler) configureValidator(v *validators.WechatPayNotifyValidator) {
+	v.SetClock(h.clock)
+	v.SetTimestampTolerance(h.tolerance)
+	v.SetReplayCache(h.replayCache)
+	v.SetLogger(h.logger)
+}
+
 // AddRSAWithAESGCM 添加一个 RSA + AES-GCM 的算法套件
 func (h *Handler) AddRSAWithAESGCM(verifier auth.Verifier, aesgcm cipher.AEAD) *Handler {
 	v := CipherSuite{
@@ -74,20 +149,64 @@ func (h *Handler) ParseNotifyRequest(
 
 	suite, ok := h.cipherSuites[signType]
 	if !ok {
-		return nil, fmt.Errorf("unsupported Wechatpay-Signature-Type: %s", signType)
+		err := fmt.Errorf("unsupported Wechatpay-Signature-Type: %s", signType)
+		h.observe(ctx, nil, metrics.NotificationInvalidBody, err)
+		return nil, err
 	}
 
 	if err := suite.validator.Validate(ctx, request); err != nil {
-		return nil, fmt.Errorf("invalid notification, err: %v, request: %+v",
+		metrics.OrNop(h.metrics).IncValidationFailure(ctx, metrics.SourceNotification, validators.FailureReason(err))
+		h.observe(ctx, nil, metrics.NotificationValidationFailed, err)
+		return nil, fmt.Errorf("invalid notification, err: %w, request: %+v",
 			err, request)
 	}
 
 	body, err := getRequestBody(request)
 	if err != nil {
+		h.observe(ctx, nil, metrics.NotificationInvalidBody, err)
 		return nil, err
 	}
 
-	return processBody(suite, body, content)
+	ret, err := processBody(suite, body, content)
+	switch {
+	case err == nil:
+		h.observe(ctx, ret, metrics.NotificationSuccess, nil)
+	case ret == nil:
+		h.observe(ctx, nil, metrics.NotificationInvalidBody, err)
+	case ret.Resource.Plaintext == "":
+		h.observe(ctx, ret, metrics.NotificationDecryptFailed, err)
+	default:
+		h.observe(ctx, ret, metrics.NotificationInvalidBody, err)
+	}
+	return ret, err
+}
+
+// observe 记录通知的处理结果并输出日志，ret 仅在通过验签后传入，以免伪造的通知内容进入监控标签与日志
+func (h *Handler) observe(ctx context.Context, ret *Request, outcome string, err error) {
+	var eventType, notifyID string
+	if ret != nil {
+		eventType, notifyID = ret.EventType, ret.ID
+	}
+	metrics.OrNop(h.metrics).ObserveNotification(ctx, eventType, outcome)
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	logger := logging.OrDiscard(h.logger)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String(logging.KeyEventType, eventType),
+		slog.String(logging.KeyNotifyID, notifyID),
+		slog.String(logging.KeyResult, outcome),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String(logging.KeyError, err.Error()))
+	}
+	logger.LogAttrs(ctx, level, "wechatpay notification handled", attrs...)
 }
 
 func processBody(suite CipherSuite, body []byte, content interface{}) (*Request, error) {
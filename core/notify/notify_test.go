@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,7 +21,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth/replaycaches"
 	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/logging"
+	"github.com/jemuri/wechatpay-go/core/metrics/prometheus"
 	"github.com/jemuri/wechatpay-go/utils"
 
 	"github.com/agiledragon/gomonkey"
@@ -103,17 +108,9 @@ func (o contentType) String() string {
 	return fmt.Sprintf("contentType{%s}", ret)
 }
 
-func TestHandler_ParseNotifyRequest(t *testing.T) {
-	patch := gomonkey.ApplyFunc(
-		time.Now, func() time.Time {
-			return time.Unix(1624523846, 0)
-		},
-	)
-	defer patch.Reset()
-
-	const (
-		mchAPIv3Key          = "testMchAPIv3Key0"
-		wechatPayCertificate = `-----BEGIN CERTIFICATE-----
+const (
+	testMchAPIv3Key          = "testMchAPIv3Key0"
+	testWechatPayCertificate = `-----BEGIN CERTIFICATE-----
 MIIDVzCCAj+gAwIBAgIJANfOWdH1ItcBMA0GCSqGSIb3DQEBCwUAMEIxCzAJBgNV
 BAYTAlhYMRUwEwYDVQQHDAxEZWZhdWx0IENpdHkxHDAaBgNVBAoME0RlZmF1bHQg
 Q29tcGFueSBMdGQwHhcNMjEwNDI3MDg1NTIzWhcNMzEwNDI1MDg1NTIzWjBCMQsw
@@ -133,14 +130,16 @@ fHMq4tsbKO0dKAeydPM/nrUZBmaYQVKMVOORGLFjFKVO7JV6Kq/R86ouhjEPgJOe
 2xulNBUcjicqtZlBdEh/PWCYP2SpGVDclKm8jeo175T3EVAkdKzzmfpxtMmnMlmq
 cTJOU9TxuGvNASMtjj7pYIerTx+xgZDXEVBWFW9PjJ0TV06tCRsgSHItgg==
 -----END CERTIFICATE-----`
-		data = "{" +
-			"\"mchid\":\"1234567890\"," +
-			"\"appid\":\"054aa7d7a2a54ab5898df65bd96f001c\"," +
-			"\"create_time\":\"2020-06-30T12:12:00+08:00\"," +
-			"\"out_contract_code\":\"21640bdbd08e473e828f3206a2741c6e\"" +
-			"}"
-	)
+	testNotifyData = "{" +
+		"\"mchid\":\"1234567890\"," +
+		"\"appid\":\"054aa7d7a2a54ab5898df65bd96f001c\"," +
+		"\"create_time\":\"2020-06-30T12:12:00+08:00\"," +
+		"\"out_contract_code\":\"21640bdbd08e473e828f3206a2741c6e\"" +
+		"}"
+)
 
+// newTestNotifyRequest 构造一个签名时间为 1624523846 的微信支付通知请求
+func newTestNotifyRequest() *http.Request {
 	headers := map[string]string{
 		"Content-Type":        "application/json",
 		"Wechatpay-Nonce":     "EcZ9Cmy4Xyx1i6RlJQzLcCyEqDa26NBz",
@@ -176,13 +175,30 @@ cTJOU9TxuGvNASMtjj7pYIerTx+xgZDXEVBWFW9PjJ0TV06tCRsgSHItgg==
 		req.Header.Set(key, value)
 	}
 
-	cert, err := utils.LoadCertificate(wechatPayCertificate)
+	return req
+}
+
+func newTestNotifyHandler(t *testing.T) *Handler {
+	cert, err := utils.LoadCertificate(testWechatPayCertificate)
 	require.NoError(t, err)
 
 	handler, err := NewRSANotifyHandler(
-		mchAPIv3Key, verifiers.NewSHA256WithRSAVerifier(core.NewCertificateMapWithList([]*x509.Certificate{cert})),
+		testMchAPIv3Key, verifiers.NewSHA256WithRSAVerifier(core.NewCertificateMapWithList([]*x509.Certificate{cert})),
 	)
-	assert.Nil(t, err)
+	require.NoError(t, err)
+	return handler
+}
+
+func TestHandler_ParseNotifyRequest(t *testing.T) {
+	patch := gomonkey.ApplyFunc(
+		time.Now, func() time.Time {
+			return time.Unix(1624523846, 0)
+		},
+	)
+	defer patch.Reset()
+
+	handler := newTestNotifyHandler(t)
+	req := newTestNotifyRequest()
 
 	content := new(contentType)
 
@@ -199,7 +215,7 @@ cTJOU9TxuGvNASMtjj7pYIerTx+xgZDXEVBWFW9PjJ0TV06tCRsgSHItgg==
 	assert.Equal(t, "AEAD_AES_256_GCM", notifyReq.Resource.Algorithm)
 	assert.Equal(t, "payscore", notifyReq.Resource.OriginalType)
 
-	assert.Equal(t, data, notifyReq.Resource.Plaintext)
+	assert.Equal(t, testNotifyData, notifyReq.Resource.Plaintext)
 
 	assert.Equal(t, "1234567890", *content.Mchid)
 	assert.Equal(t, "054aa7d7a2a54ab5898df65bd96f001c", *content.Appid)
@@ -208,6 +224,93 @@ cTJOU9TxuGvNASMtjj7pYIerTx+xgZDXEVBWFW9PjJ0TV06tCRsgSHItgg==
 	assert.Zero(t, content.CreateTime.Sub(createTime))
 }
 
+func TestHandler_ParseNotifyRequestWithClock(t *testing.T) {
+	const notifyTimestamp = 1624523846
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		tolerance time.Duration
+		wantErr   bool
+	}{
+		{name: "notify timestamp is current", now: time.Unix(notifyTimestamp, 0), wantErr: false},
+		{name: "notify timestamp expires", now: time.Unix(notifyTimestamp+300, 0), wantErr: true},
+		{
+			name: "notify timestamp within custom tolerance", now: time.Unix(notifyTimestamp+300, 0),
+			tolerance: 10 * time.Minute, wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestNotifyHandler(t).SetClock(clock.Fixed(tt.now)).SetTimestampTolerance(tt.tolerance)
+
+			content := new(contentType)
+			_, err := handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), content)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "1234567890", *content.Mchid)
+			}
+		})
+	}
+}
+
+func TestHandler_ParseNotifyRequestWithReplayCache(t *testing.T) {
+	handler := newTestNotifyHandler(t).
+		SetClock(clock.Fixed(time.Unix(1624523846, 0))).
+		SetReplayCache(replaycaches.NewMemoryReplayCache())
+
+	_, err := handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.NoError(t, err)
+
+	_, err = handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.Error(t, err)
+	assert.True(t, validators.IsReplayError(err))
+}
+
+func TestHandler_ParseNotifyRequestWithMetricsRecorder(t *testing.T) {
+	exporter := prometheus.NewExporter("", nil)
+	handler := newTestNotifyHandler(t).
+		SetClock(clock.Fixed(time.Unix(1624523846, 0))).
+		SetReplayCache(replaycaches.NewMemoryReplayCache()).
+		SetMetricsRecorder(exporter)
+
+	_, err := handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.NoError(t, err)
+	_, err = handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	_, err = exporter.WriteTo(&buf)
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, `wechatpay_notifications_total{event_type="PAYSCORE.USER_OPEN_SERVICE",outcome="success"} 1`)
+	assert.Contains(t, output, `wechatpay_notifications_total{event_type="",outcome="validation_failed"} 1`)
+	assert.Contains(t, output, `wechatpay_validation_failures_total{source="notification",reason="replay"} 1`)
+}
+
+func TestHandler_ParseNotifyRequestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newTestNotifyHandler(t).
+		SetClock(clock.Fixed(time.Unix(1624523846, 0))).
+		SetReplayCache(replaycaches.NewMemoryReplayCache()).
+		SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	_, err := handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.NoError(t, err)
+	_, err = handler.ParseNotifyRequest(context.Background(), newTestNotifyRequest(), new(contentType))
+	require.Error(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, `"msg":"wechatpay notification handled"`)
+	assert.Contains(t, output, `"event_type":"PAYSCORE.USER_OPEN_SERVICE","notify_id":"3119dfba-e649-5eec-ab1e-3412bc4d2e17"`)
+	assert.Contains(t, output, `"msg":"wechatpay signature validation failed"`)
+	assert.Contains(t, output, `"`+logging.KeyReason+`":"replay"`)
+	assert.NotContains(t, output, "054aa7d7a2a54ab5898df65bd96f001c")
+}
+
 func TestHandler_ParseNotifyRequestValidateError(t *testing.T) {
 	patch := gomonkey.ApplyFunc(
 		(*validators.WechatPayNotifyValidator).Validate,
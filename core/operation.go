@@ -0,0 +1,323 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+)
+
+// ParamIn 参数在请求中的位置
+type ParamIn int
+
+// 参数位置
+const (
+	InPath   ParamIn = iota + 1 // 路径参数，替换路径模板中的 {Name}
+	InQuery                     // 查询参数
+	InHeader                    // Header 参数
+)
+
+// Param 描述请求结构中的一个路径、查询或 Header 参数
+type Param struct {
+	Name             string  // 参数名，路径参数需与路径模板中 {} 内的名称一致
+	Field            string  // 请求结构中对应的字段名，如 OutRefundNo
+	In               ParamIn // 参数位置
+	Required         bool    // 是否必填，必填参数为空时不发出请求
+	CollectionFormat string  // 列表参数的分隔方式，见 ParameterToString
+}
+
+// Operation 描述一个微信支付 API 接口
+//
+// Do 根据 Operation 统一完成路径与查询参数的填充、必填检查、敏感信息加密、请求发送与应答解析。
+// Req 与 Resp 均需为结构体类型（而非指针），Param.Field 与 Required 使用 Req 中的字段名。
+//
+// 示例：
+//
+//	var queryByOutRefundNo = &core.Operation[refunddomestic.QueryByOutRefundNoRequest, refunddomestic.Refund]{
+//		Method: http.MethodGet,
+//		Path:   "/v3/refund/domestic/refunds/{out_refund_no}",
+//		Params: []core.Param{
+//			{Name: "out_refund_no", Field: "OutRefundNo", In: core.InPath, Required: true},
+//			{Name: "sub_mchid", Field: "SubMchid", In: core.InQuery},
+//		},
+//	}
+type Operation[Req, Resp any] struct {
+	Method       string   // HTTP 方法
+	Path         string   // 路径模板，不包含域名，如 /v3/refund/domestic/refunds/{out_refund_no}
+	Host         string   // 请求域名，为空时使用 consts.WechatPayAPIServer
+	Params       []Param  // 路径、查询与 Header 参数
+	Body         bool     // 是否发送 JSON Body，Body 为 Req 中除 Params 以外的全部字段
+	Required     []string // Body 中的必填字段名
+	ContentTypes []string // 可选的 Content-Type，见 SelectHeaderContentType
+	Encrypt      bool     // 发送前对 Req 的副本进行敏感信息加密，并设置 Wechatpay-Serial
+	Decrypt      bool     // 对应答中的敏感信息进行解密
+}
+
+// Do 使用 client 调用 op 描述的接口
+//
+// 与生成的接口方法一致，Client 开启请求校验时会先调用 Client.ValidateRequest 校验 req，再进行加密与发送。
+// 应答 Body 为空时（如 HTTP 204）返回 Resp 的零值。请求未发出时 result 为 nil；
+// 请求已发出但失败时，result 中包含请求与应答，可用于排查问题
+func Do[Req, Resp any](
+	ctx context.Context, client *Client, op *Operation[Req, Resp], req Req,
+) (resp *Resp, result *APIResult, err error) {
+	v := reflect.ValueOf(&req).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("request of operation %s %s must be a struct, got %s", op.Method, op.Path, v.Type())
+	}
+
+	if err = client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	headerParams := http.Header{}
+	if op.Encrypt {
+		req = cloneRequest(req)
+		v = reflect.ValueOf(&req).Elem()
+
+		encryptCertificate, err := client.EncryptRequest(ctx, &req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encrypt request failed: %v", err)
+		}
+		if encryptCertificate != "" {
+			headerParams.Set(consts.WechatPaySerial, encryptCertificate)
+		}
+	}
+
+	requestPath, queryParams, err := op.bindParams(v, headerParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range op.Required {
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return nil, nil, fmt.Errorf("field `%s` not found in %s", name, v.Type().Name())
+		}
+		if isEmptyValue(field) {
+			return nil, nil, fmt.Errorf("field `%s` is required and must be specified in %s", name, v.Type().Name())
+		}
+	}
+
+	var postBody interface{}
+	if op.Body {
+		postBody = op.body(v)
+	}
+
+	if _, ok := metrics.OperationFromContext(ctx); !ok {
+		ctx = metrics.WithOperation(ctx, op.Path)
+	}
+	result, err = client.Request(
+		ctx, op.Method, requestPath, headerParams, queryParams, postBody, SelectHeaderContentType(op.ContentTypes),
+	)
+	if err != nil {
+		return nil, result, err
+	}
+
+	body, err := ioutil.ReadAll(result.Response.Body)
+	_ = result.Response.Body.Close()
+	if err != nil {
+		return nil, result, err
+	}
+	result.Response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+	resp = new(Resp)
+	if len(bytes.TrimSpace(body)) == 0 {
+		return resp, result, nil
+	}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, result, err
+	}
+
+	if op.Decrypt {
+		// 对应答中隐私字段进行解密
+		if err = client.DecryptResponse(ctx, resp); err != nil {
+			return resp, result, err
+		}
+	}
+	return resp, result, nil
+}
+
+// bindParams 填充路径参数，并返回完整的请求地址与查询参数，Header 参数直接写入 header
+func (op *Operation[Req, Resp]) bindParams(v reflect.Value, header http.Header) (string, url.Values, error) {
+	host := op.Host
+	if host == "" {
+		host = consts.WechatPayAPIServer
+	}
+	requestPath := host + op.Path
+
+	queryParams := url.Values{}
+	for _, param := range op.Params {
+		field := v.FieldByName(param.Field)
+		if !field.IsValid() {
+			return "", nil, fmt.Errorf("field `%s` not found in %s", param.Field, v.Type().Name())
+		}
+		if isEmptyValue(field) {
+			if param.Required {
+				return "", nil, fmt.Errorf(
+					"field `%s` is required and must be specified in %s", param.Field, v.Type().Name(),
+				)
+			}
+			continue
+		}
+
+		value := ParameterToString(reflect.Indirect(field).Interface(), param.CollectionFormat)
+		switch param.In {
+		case InPath:
+			requestPath = strings.Replace(requestPath, "{"+param.Name+"}", url.PathEscape(value), -1)
+		case InQuery:
+			queryParams.Add(param.Name, value)
+		case InHeader:
+			header.Set(param.Name, value)
+		default:
+			return "", nil, fmt.Errorf("invalid location of param `%s`: %d", param.Name, param.In)
+		}
+	}
+
+	if i := strings.IndexByte(requestPath, '{'); i >= 0 {
+		return "", nil, fmt.Errorf("path param in %s is not specified", requestPath[i:])
+	}
+	return requestPath, queryParams, nil
+}
+
+// body 返回 Req 中除 Params 以外的字段，字段顺序与 JSON Tag 保持不变
+//
+// time.Time 与 *time.Time 字段以北京时间序列化，见 chinatime.Format
+func (op *Operation[Req, Resp]) body(v reflect.Value) interface{} {
+	excluded := make(map[string]bool, len(op.Params))
+	for _, param := range op.Params {
+		excluded[param.Field] = true
+	}
+	if _, ok := v.Addr().Interface().(json.Marshaler); ok && len(excluded) == 0 {
+		return v.Addr().Interface()
+	}
+
+	rebuild := len(excluded) > 0
+	var fields []reflect.StructField
+	var values []reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if excluded[field.Name] || field.PkgPath != "" {
+			continue
+		}
+		if typ := bodyFieldType(field.Type); typ != field.Type {
+			field.Type, rebuild = typ, true
+		}
+		fields = append(fields, field)
+		values = append(values, v.Field(i))
+	}
+	if !rebuild {
+		return v.Addr().Interface()
+	}
+
+	body := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		body.Field(i).Set(value.Convert(fields[i].Type))
+	}
+	return body.Addr().Interface()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// bodyFieldType 返回字段在 Body 中的类型，time.Time 与 *time.Time 替换为以北京时间序列化的 chinaTime
+func bodyFieldType(t reflect.Type) reflect.Type {
+	switch t {
+	case timeType:
+		return reflect.TypeOf(chinaTime{})
+	case reflect.PtrTo(timeType):
+		return reflect.TypeOf((*chinaTime)(nil))
+	}
+	return t
+}
+
+// chinaTime 以 RFC3339 格式的北京时间序列化的 time.Time
+type chinaTime time.Time
+
+// MarshalJSON 将时间序列化为北京时间，如 "2021-06-08T18:34:56+08:00"
+func (t chinaTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chinatime.Format(time.Time(t)))
+}
+
+// cloneRequest 深拷贝请求结构，避免加密时修改调用方的数据
+//
+// Req 实现了 Clone() *Req 时（如生成的请求结构）使用该方法，否则通过反射逐字段拷贝
+func cloneRequest[Req any](req Req) Req {
+	if cloner, ok := interface{}(req).(interface{ Clone() *Req }); ok {
+		return *cloner.Clone()
+	}
+
+	var clone Req
+	deepCopy(reflect.ValueOf(&clone).Elem(), reflect.ValueOf(req))
+	return clone
+}
+
+// deepCopy 将 src 深拷贝到 dst，包含未导出字段的结构（如 time.Time）按值拷贝
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		deepCopy(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		if hasUnexportedField(src.Type()) {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < src.NumField(); i++ {
+			deepCopy(dst.Field(i), src.Field(i))
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(value, iter.Value())
+			dst.SetMapIndex(iter.Key(), value)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+func hasUnexportedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	case reflect.String:
+		return v.Len() == 0
+	}
+	return false
+}
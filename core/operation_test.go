@@ -0,0 +1,208 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package core_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/cipher/decryptors"
+	"github.com/jemuri/wechatpay-go/core/cipher/encryptors"
+	"github.com/jemuri/wechatpay-go/core/option"
+)
+
+type addReceiverRequest struct {
+	SubMchid     *string    `json:"sub_mchid,omitempty"`
+	Appid        *string    `json:"appid"`
+	Type         *string    `json:"type"`
+	Account      *string    `json:"account"`
+	Name         *string    `json:"name,omitempty" encryption:"EM_APIV3"`
+	Tags         []string   `json:"tags,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	IdempotentID *string    `json:"-"`
+}
+
+// Validate 开启请求校验时由 core.Do 调用
+func (o addReceiverRequest) Validate() error {
+	if o.Type != nil && *o.Type != "MERCHANT_ID" && *o.Type != "PERSONAL_OPENID" {
+		return fmt.Errorf("addReceiverRequest.type: unknown type %q", *o.Type)
+	}
+	return nil
+}
+
+type receiver struct {
+	Type    *string `json:"type"`
+	Account *string `json:"account"`
+	Name    *string `json:"name,omitempty" encryption:"EM_APIV3"`
+}
+
+var addReceiver = &core.Operation[addReceiverRequest, receiver]{
+	Method: http.MethodPost,
+	Path:   "/v3/profitsharing/merchants/{sub_mchid}/receivers",
+	Params: []core.Param{
+		{Name: "sub_mchid", Field: "SubMchid", In: core.InPath, Required: true},
+		{Name: "tags", Field: "Tags", In: core.InQuery, CollectionFormat: "csv"},
+		{Name: "Idempotency-Key", Field: "IdempotentID", In: core.InHeader},
+	},
+	Body:     true,
+	Required: []string{"Appid", "Account"},
+	Encrypt:  true,
+	Decrypt:  true,
+}
+
+func TestDo(t *testing.T) {
+	var received *http.Request
+	var receivedBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ := ioutil.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &receivedBody))
+
+		response := `{"type":"MERCHANT_ID","account":"86693852","name":"Encrypted张三"}`
+		writeSignature(w, response)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer ts.Close()
+
+	client, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCertificate([]*x509.Certificate{wechatPayCertificate}),
+		option.WithWechatPayCipher(&encryptors.MockEncryptor{Serial: "mock-serial"}, &decryptors.MockDecryptor{}),
+	)
+	require.NoError(t, err)
+
+	op := *addReceiver
+	op.Host = ts.URL
+	req := addReceiverRequest{
+		SubMchid:     core.String("1900000109"),
+		Appid:        core.String("wx8888888888888888"),
+		Type:         core.String("MERCHANT_ID"),
+		Account:      core.String("86693852"),
+		Name:         core.String("张三"),
+		Tags:         []string{"a", "b"},
+		CreatedAt:    core.Time(time.Date(2021, 6, 8, 10, 34, 56, 0, time.UTC)),
+		IdempotentID: core.String("key-1"),
+	}
+	resp, result, err := core.Do(ctx, client, &op, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.Equal(t, "张三", *resp.Name)
+
+	assert.Equal(t, "/v3/profitsharing/merchants/1900000109/receivers", received.URL.Path)
+	assert.Equal(t, "a,b", received.URL.Query().Get("tags"))
+	assert.Equal(t, "key-1", received.Header.Get("Idempotency-Key"))
+	// 参数字段不出现在 Body 中，敏感字段已加密，且不修改调用方的请求
+	assert.Equal(t, map[string]interface{}{
+		"appid": "wx8888888888888888", "type": "MERCHANT_ID", "account": "86693852", "name": "Encrypted张三",
+		"created_at": "2021-06-08T18:34:56+08:00",
+	}, receivedBody)
+	assert.Equal(t, "张三", *req.Name)
+}
+
+func TestDo_InvalidRequest(t *testing.T) {
+	client, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithoutValidator(),
+	)
+	require.NoError(t, err)
+
+	op := *addReceiver
+	op.Encrypt, op.Decrypt = false, false
+	tests := []struct {
+		name    string
+		op      core.Operation[addReceiverRequest, receiver]
+		req     addReceiverRequest
+		wantErr string
+	}{
+		{
+			name:    "missing path param",
+			op:      op,
+			req:     addReceiverRequest{Appid: core.String("wx8888888888888888"), Account: core.String("86693852")},
+			wantErr: "field `SubMchid` is required and must be specified in addReceiverRequest",
+		},
+		{
+			name:    "missing body field",
+			op:      op,
+			req:     addReceiverRequest{SubMchid: core.String("1900000109"), Appid: core.String("wx8888888888888888")},
+			wantErr: "field `Account` is required and must be specified in addReceiverRequest",
+		},
+		{
+			name: "unbound path param",
+			op: core.Operation[addReceiverRequest, receiver]{
+				Method: http.MethodGet, Path: "/v3/profitsharing/merchants/{sub_mchid}/receivers",
+			},
+			wantErr: "path param in {sub_mchid}/receivers is not specified",
+		},
+		{
+			name: "unknown field",
+			op: core.Operation[addReceiverRequest, receiver]{
+				Method: http.MethodGet, Path: "/v3/receivers", Params: []core.Param{{Name: "x", Field: "X", In: core.InQuery}},
+			},
+			wantErr: "field `X` not found in addReceiverRequest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, result, err := core.Do(context.Background(), client, &tt.op, tt.req)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+			assert.Nil(t, resp)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestDo_RequestValidation(t *testing.T) {
+	requested := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	op := *addReceiver
+	op.Host = ts.URL
+	req := addReceiverRequest{
+		SubMchid: core.String("1900000109"),
+		Appid:    core.String("wx8888888888888888"),
+		Type:     core.String("UNKNOWN"),
+		Account:  core.String("86693852"),
+	}
+
+	client, err := core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCipher(&encryptors.MockEncryptor{Serial: "mock-serial"}, &decryptors.MockDecryptor{}),
+		option.WithoutValidator(),
+		option.WithRequestValidation(),
+	)
+	require.NoError(t, err)
+
+	resp, result, err := core.Do(ctx, client, &op, req)
+	require.Error(t, err)
+	assert.Equal(t, `addReceiverRequest.type: unknown type "UNKNOWN"`, err.Error())
+	assert.Nil(t, resp)
+	assert.Nil(t, result)
+	assert.False(t, requested)
+
+	// 未开启请求校验时照常发送
+	client, err = core.NewClient(ctx,
+		option.WithMerchantCredential(testMchID, testCertificateSerialNumber, privateKey),
+		option.WithWechatPayCipher(&encryptors.MockEncryptor{Serial: "mock-serial"}, &decryptors.MockDecryptor{}),
+		option.WithoutValidator(),
+	)
+	require.NoError(t, err)
+
+	_, _, err = core.Do(ctx, client, &op, req)
+	require.NoError(t, err)
+	assert.True(t, requested)
+}
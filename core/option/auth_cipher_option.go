@@ -4,10 +4,13 @@ package option
 
 import (
 	"context"
+	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
 
 	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
 	"github.com/jemuri/wechatpay-go/core/auth/signers"
 	"github.com/jemuri/wechatpay-go/core/auth/validators"
 	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
@@ -117,3 +120,86 @@ func WithWechatPayPublicKeyAuthCipher(
 		},
 	}
 }
+
+// WithWechatPayAutoAuthCipherUsingKeyRing 一键初始化 Client，使其具备「签名/验签/敏感字段加解密」能力，并提供证书定时更新功能。
+// 与 WithWechatPayAutoAuthCipher 不同的是，商户私钥来自可热更新的 keyring.KeyRing：
+// 私钥轮换后，Client 与平台证书下载器均会使用新的私钥签名，重叠期内旧私钥仍可用于解密
+func WithWechatPayAutoAuthCipherUsingKeyRing(ring *keyring.KeyRing, mchAPIv3Key string) core.ClientOption {
+	mgr := downloader.MgrInstance()
+	mchID := ring.Current().MchID
+
+	if !mgr.HasDownloader(context.Background(), mchID) {
+		if err := mgr.RegisterDownloaderWithKeyRing(context.Background(), ring, mchAPIv3Key); err != nil {
+			return core.ErrorOption{Error: err}
+		}
+	}
+
+	certVisitor := mgr.GetCertificateVisitor(mchID)
+	return withAuthCipherOption{
+		settings: core.DialSettings{
+			Signer:    signers.NewKeyRingSigner(ring),
+			Validator: validators.NewWechatPayResponseValidator(verifiers.NewSHA256WithRSAVerifier(certVisitor)),
+			Cipher: ciphers.NewWechatPayCipher(
+				encryptors.NewWechatPayEncryptor(certVisitor),
+				decryptors.NewKeyRingDecryptor(ring),
+			),
+		},
+	}
+}
+
+// WithWechatPayPublicKeyAuthCipherUsingKeyRing 一键初始化 Client，使其具备「签名/验签/敏感字段加解密」能力。
+// 使用微信支付提供的公钥验签，商户私钥来自可热更新的 keyring.KeyRing
+func WithWechatPayPublicKeyAuthCipherUsingKeyRing(
+	ring *keyring.KeyRing, publicKeyID string, publicKey *rsa.PublicKey,
+) core.ClientOption {
+	return withAuthCipherOption{
+		settings: core.DialSettings{
+			Signer: signers.NewKeyRingSigner(ring),
+			Validator: validators.NewWechatPayResponseValidator(
+				verifiers.NewSHA256WithRSAPubkeyVerifier(publicKeyID, *publicKey),
+			),
+			Cipher: ciphers.NewWechatPayCipher(
+				encryptors.NewWechatPayPubKeyEncryptor(publicKeyID, *publicKey),
+				decryptors.NewKeyRingDecryptor(ring),
+			),
+		},
+	}
+}
+
+// WithWechatPayAutoAuthCipherUsingSigner 一键初始化 Client，使其具备「签名/验签/敏感字段加解密」能力。
+// 商户私钥无需出现在进程内存中：签名使用任意 auth.Signer（如 signers.CryptoSigner、signers.RemoteSigner），
+// 解密使用 crypto.Decrypter。需要使用者自行向 mgr 注册商户的平台证书下载器（如 RegisterDownloaderWithClient）
+func WithWechatPayAutoAuthCipherUsingSigner(
+	mchID string, signer auth.Signer, decrypter crypto.Decrypter, mgr *downloader.CertificateDownloaderMgr,
+) core.ClientOption {
+	certVisitor := mgr.GetCertificateVisitor(mchID)
+	return withAuthCipherOption{
+		settings: core.DialSettings{
+			Signer:    signer,
+			Validator: validators.NewWechatPayResponseValidator(verifiers.NewSHA256WithRSAVerifier(certVisitor)),
+			Cipher: ciphers.NewWechatPayCipher(
+				encryptors.NewWechatPayEncryptor(certVisitor),
+				decryptors.NewWechatPayDecryptorWithDecrypter(decrypter),
+			),
+		},
+	}
+}
+
+// WithWechatPayPublicKeyAuthCipherUsingSigner 一键初始化 Client，使其具备「签名/验签/敏感字段加解密」能力。
+// 使用微信支付提供的公钥验签，签名使用任意 auth.Signer，解密使用 crypto.Decrypter
+func WithWechatPayPublicKeyAuthCipherUsingSigner(
+	signer auth.Signer, decrypter crypto.Decrypter, publicKeyID string, publicKey *rsa.PublicKey,
+) core.ClientOption {
+	return withAuthCipherOption{
+		settings: core.DialSettings{
+			Signer: signer,
+			Validator: validators.NewWechatPayResponseValidator(
+				verifiers.NewSHA256WithRSAPubkeyVerifier(publicKeyID, *publicKey),
+			),
+			Cipher: ciphers.NewWechatPayCipher(
+				encryptors.NewWechatPayPubKeyEncryptor(publicKeyID, *publicKey),
+				decryptors.NewWechatPayDecryptorWithDecrypter(decrypter),
+			),
+		},
+	}
+}
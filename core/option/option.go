@@ -3,17 +3,28 @@
 package option
 
 import (
+	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/jemuri/wechatpay-go/core"
 	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/auth/keyring"
 	"github.com/jemuri/wechatpay-go/core/auth/signers"
 	"github.com/jemuri/wechatpay-go/core/auth/validators"
 	"github.com/jemuri/wechatpay-go/core/auth/verifiers"
+	"github.com/jemuri/wechatpay-go/core/breaker"
 	"github.com/jemuri/wechatpay-go/core/cipher"
 	"github.com/jemuri/wechatpay-go/core/cipher/ciphers"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+	"github.com/jemuri/wechatpay-go/core/ratelimit"
 )
 
 // region SignerOption
@@ -44,6 +55,16 @@ func WithMerchantCredential(mchID, certificateSerialNo string, privateKey *rsa.P
 	return WithSigner(signer)
 }
 
+// WithCryptoSigner 通过商户号、商户证书序列号与 crypto.Signer 构建 Signer，商户私钥可以保存在 KMS/HSM 等外部服务中
+func WithCryptoSigner(mchID, certificateSerialNo string, signer crypto.Signer) core.ClientOption {
+	return WithSigner(signers.NewCryptoSigner(mchID, certificateSerialNo, signer))
+}
+
+// WithKeyRingCredential 通过可热更新的商户私钥环构建 Credential/Signer，私钥轮换后 Client 无需重建即可使用新的私钥签名
+func WithKeyRingCredential(ring *keyring.KeyRing) core.ClientOption {
+	return WithSigner(signers.NewKeyRingSigner(ring))
+}
+
 // endregion
 
 // region ValidatorOption
@@ -118,3 +139,247 @@ func WithWechatPayCipher(encryptor cipher.Encryptor, decryptor cipher.Decryptor)
 }
 
 // endregion
+
+// region ClockOption
+
+// withClockOption 为 Client 设置时钟
+type withClockOption struct {
+	Clock clock.Clock
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withClockOption) Apply(o *core.DialSettings) error {
+	o.Clock = w.Clock
+	return nil
+}
+
+// WithClock 返回一个指定时钟的 ClientOption，Client 将使用该时钟生成请求时间戳并校验应答时间戳，
+// 未指定时使用本机系统时钟
+func WithClock(c clock.Clock) core.ClientOption {
+	return withClockOption{Clock: c}
+}
+
+// withTimestampToleranceOption 为 Client 设置应答时间戳容差
+type withTimestampToleranceOption struct {
+	Tolerance time.Duration
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withTimestampToleranceOption) Apply(o *core.DialSettings) error {
+	o.TimestampTolerance = w.Tolerance
+	return nil
+}
+
+// WithTimestampTolerance 返回一个指定应答时间戳容差的 ClientOption，
+// 应答报文中的 Wechatpay-Timestamp 与当前时间之差超过该值时校验失败，默认为 5 分钟
+func WithTimestampTolerance(tolerance time.Duration) core.ClientOption {
+	return withTimestampToleranceOption{Tolerance: tolerance}
+}
+
+// withClockSkewDetectionOption 为 Client 开启时钟偏差补偿
+type withClockSkewDetectionOption struct{}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withClockSkewDetectionOption) Apply(o *core.DialSettings) error {
+	o.ClockSkewDetection = true
+	return nil
+}
+
+// WithClockSkewDetection 返回一个开启时钟偏差补偿的 ClientOption
+//
+// 开启后，Client 会根据验签通过的应答报文中的 Wechatpay-Timestamp 计算本机与微信支付服务器的时钟偏差，
+// 并在生成 Authorization 时间戳与校验应答时间戳时进行补偿，适用于本机时钟不准确的场景。
+// 偏差不超过 clock.MaxSkewOffset 时才会被补偿；使用 WithoutValidator 时无法验签，不会进行补偿
+func WithClockSkewDetection() core.ClientOption {
+	return withClockSkewDetectionOption{}
+}
+
+// endregion
+
+// region ReplayCacheOption
+
+// withReplayCacheOption 为 Client 设置应答报文防重放缓存
+type withReplayCacheOption struct {
+	ReplayCache auth.ReplayCache
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withReplayCacheOption) Apply(o *core.DialSettings) error {
+	o.ReplayCache = w.ReplayCache
+	return nil
+}
+
+// WithReplayCache 返回一个指定应答报文防重放缓存的 ClientOption，如 replaycaches.NewMemoryReplayCache()
+//
+// 设置后，同一个 Wechatpay-Nonce 的应答在时间戳有效期内重复出现时，请求将返回 *validators.ReplayError
+func WithReplayCache(c auth.ReplayCache) core.ClientOption {
+	return withReplayCacheOption{ReplayCache: c}
+}
+
+// endregion
+
+// region MetricsOption
+
+// withMetricsRecorderOption 为 Client 设置监控指标记录器
+type withMetricsRecorderOption struct {
+	Recorder metrics.Recorder
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withMetricsRecorderOption) Apply(o *core.DialSettings) error {
+	o.MetricsRecorder = w.Recorder
+	return nil
+}
+
+// WithMetricsRecorder 返回一个指定监控指标记录器的 ClientOption，如 prometheus.NewExporter()
+//
+// 设置后，Client 将上报每次请求的次数、耗时、HTTP 状态码、APIError 错误码与应答验签失败原因
+func WithMetricsRecorder(recorder metrics.Recorder) core.ClientOption {
+	return withMetricsRecorderOption{Recorder: recorder}
+}
+
+// endregion
+
+// region LoggerOption
+
+// withLoggerOption 为 Client 设置结构化日志 Logger
+type withLoggerOption struct {
+	Logger *slog.Logger
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withLoggerOption) Apply(o *core.DialSettings) error {
+	o.Logger = w.Logger
+	return nil
+}
+
+// WithLogger 返回一个指定结构化日志 Logger 的 ClientOption，如 logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
+//
+// 设置后，Client 将输出每次请求的方法、路径、Request-Id、耗时、HTTP 状态码、APIError 错误码与验签结果，
+// 需要输出的日志级别由 logger 的 slog.Handler 决定。Authorization、签名与敏感字段会被自动脱敏，详见 logging 包
+func WithLogger(logger *slog.Logger) core.ClientOption {
+	return withLoggerOption{Logger: logger}
+}
+
+// endregion
+
+// region RateLimiterOption
+
+// withRateLimiterOption 为 Client 设置客户端限流器
+type withRateLimiterOption struct {
+	Limiter ratelimit.Limiter
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withRateLimiterOption) Apply(o *core.DialSettings) error {
+	o.RateLimiter = w.Limiter
+	return nil
+}
+
+// WithRateLimiter 返回一个指定客户端限流器的 ClientOption，如 ratelimit.NewTokenBucketLimiter()
+//
+// 设置后，Client 在每次请求发出前按 商户号 + 接口 获取许可，并将 HTTP 429 与限频错误码反馈给限流器。
+// 同一个限流器可以在多个商户的 Client 间共享
+func WithRateLimiter(limiter ratelimit.Limiter) core.ClientOption {
+	return withRateLimiterOption{Limiter: limiter}
+}
+
+// endregion
+
+// region CircuitBreakerOption
+
+// withCircuitBreakerOption 为 Client 设置熔断器
+type withCircuitBreakerOption struct {
+	Breaker *breaker.CircuitBreaker
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withCircuitBreakerOption) Apply(o *core.DialSettings) error {
+	o.CircuitBreaker = w.Breaker
+	return nil
+}
+
+// WithCircuitBreaker 返回一个指定熔断器的 ClientOption，如 breaker.NewCircuitBreaker(breaker.Config{})
+//
+// 设置后，Client 按 域名 + 接口 统计请求结果，网络错误与 HTTP 5xx 应答视为失败，熔断期间请求立即返回
+// *breaker.OpenError。同一个熔断器可以在多个 Client 间共享
+func WithCircuitBreaker(b *breaker.CircuitBreaker) core.ClientOption {
+	return withCircuitBreakerOption{Breaker: b}
+}
+
+// withBackupHostOption 为 Client 设置主域名的备份地址
+type withBackupHostOption struct {
+	Primary string
+	Backup  string
+}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withBackupHostOption) Apply(o *core.DialSettings) error {
+	primary, err := url.Parse(w.Primary)
+	if err != nil || primary.Host == "" {
+		return fmt.Errorf("invalid primary host %q", w.Primary)
+	}
+	backup, err := url.Parse(w.Backup)
+	if err != nil || backup.Host == "" {
+		return fmt.Errorf("invalid backup host %q", w.Backup)
+	}
+
+	if o.BackupHosts == nil {
+		o.BackupHosts = make(map[string]*url.URL)
+	}
+	o.BackupHosts[primary.Host] = backup
+	return nil
+}
+
+// WithBackupHost 返回一个指定主域名备份地址的 ClientOption，primary 与 backup 均为形如 https://host 的地址
+//
+// 需要同时设置熔断器：主域名的接口熔断时，幂等请求（GET、HEAD 以及使用 breaker.WithIdempotent 标记的请求）
+// 将改为发往备份地址，备份地址的同一接口同样受熔断器保护
+func WithBackupHost(primary, backup string) core.ClientOption {
+	return withBackupHostOption{Primary: primary, Backup: backup}
+}
+
+// WithWechatPayBackupHost 返回一个使用微信支付备份域名 consts.WechatPayAPIServerBackup 的 ClientOption
+func WithWechatPayBackupHost() core.ClientOption {
+	return WithBackupHost(consts.WechatPayAPIServer, consts.WechatPayAPIServerBackup)
+}
+
+// endregion
+
+// region DryRunOption
+
+// withDryRunOption 为 Client 开启试运行模式
+type withDryRunOption struct{}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withDryRunOption) Apply(o *core.DialSettings) error {
+	o.DryRun = true
+	return nil
+}
+
+// WithDryRun 返回一个开启试运行模式的 ClientOption
+//
+// 开启后 Client 的所有请求只构建并签名而不发出，请求返回 *core.DryRunError，其中包含已签名的请求与签名原文，
+// 并可以导出为 curl 命令。如只需对单个请求试运行，请使用 core.WithDryRun(ctx)
+func WithDryRun() core.ClientOption {
+	return withDryRunOption{}
+}
+
+// withRequestValidationOption 为 Client 开启请求校验
+type withRequestValidationOption struct{}
+
+// Apply 将配置添加到 core.DialSettings 中
+func (w withRequestValidationOption) Apply(o *core.DialSettings) error {
+	o.RequestValidation = true
+	return nil
+}
+
+// WithRequestValidation 返回一个开启请求校验的 ClientOption
+//
+// 开启后服务接口在加密敏感字段与签名前调用请求模型的 Validate 方法，按接口文档中的长度、取值范围、枚举值与格式约束
+// 校验请求参数，校验失败时不发出请求，直接返回 *validation.Error（见 core/validation 包）
+func WithRequestValidation() core.ClientOption {
+	return withRequestValidationOption{}
+}
+
+// endregion
@@ -0,0 +1,71 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package ratelimit 微信支付 API v3 Go SDK 客户端限流
+//
+// 微信支付按商户、按接口限制调用频率（例如下载账单、发放代金券、查询转账明细），超出时返回
+// HTTP 429 与 RATELIMIT_EXCEED / FREQUENCY_LIMITED 等错误码。在 core.Client 中设置 Limiter 后，
+// 每次请求发出前都会按 商户号 + 接口 获取许可，批量任务（如并发调用 cashcoupons.SendCoupon）
+// 因此不会触发微信支付的频率限制；收到限频应答后 TokenBucketLimiter 还会自动降低对应接口的速率。
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Key 限流的维度：商户号与接口
+//
+// Operation 为 metrics.Operation 返回的接口路径模板，如 `/v3/marketing/favor/users/{openid}/coupons`，
+// 或使用 metrics.WithOperation 为请求指定的接口名
+type Key struct {
+	MchID     string // 商户号
+	Operation string // 接口
+}
+
+// String 输出 Key
+func (k Key) String() string {
+	return fmt.Sprintf("mchid=[%s] operation=[%s]", k.MchID, k.Operation)
+}
+
+// Limiter 客户端限流器，可以在多个 goroutine 中并发使用
+type Limiter interface {
+	// Wait 在请求发出前获取一次许可，无法获得许可时返回 *RateLimitedError 或 ctx 的错误
+	Wait(ctx context.Context, key Key) error
+	// Feedback 反馈请求的结果，limited 为 true 表示请求被微信支付限频
+	Feedback(key Key, limited bool)
+}
+
+// 微信支付表示请求被限频的错误码
+const (
+	CodeRateLimitExceed      = "RATELIMIT_EXCEED"
+	CodeFrequencyLimited     = "FREQUENCY_LIMITED"
+	CodeFrequencyLimitExceed = "FREQUENCY_LIMIT_EXCEED"
+)
+
+// IsRateLimitCode 判断微信支付返回的错误码是否表示请求被限频
+func IsRateLimitCode(code string) bool {
+	switch code {
+	case CodeRateLimitExceed, CodeFrequencyLimited, CodeFrequencyLimitExceed:
+		return true
+	}
+	return false
+}
+
+// RateLimitedError 客户端限流错误，请求未被发出
+type RateLimitedError struct {
+	Key        Key           // 被限流的商户号与接口
+	RetryAfter time.Duration // 预计可以获得许可的等待时间
+}
+
+// Error 输出 RateLimitedError
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("client side rate limited, %s retry after %s", e.Key, e.RetryAfter)
+}
+
+// IsRateLimitedError 判断当前 error 是否为 *RateLimitedError（包括被包装的情况）
+func IsRateLimitedError(err error) bool {
+	var limitedErr *RateLimitedError
+	return errors.As(err, &limitedErr)
+}
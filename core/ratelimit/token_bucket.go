@@ -0,0 +1,303 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+)
+
+// Mode 无法立即获得许可时的处理方式
+type Mode int
+
+const (
+	// ModeBlock 等待直到获得许可；若 ctx 的截止时间早于预计获得许可的时间，立即返回 *RateLimitedError
+	ModeBlock Mode = iota
+	// ModeFailFast 无法立即获得许可时立即返回 *RateLimitedError
+	ModeFailFast
+)
+
+const (
+	// DefaultRecoveryInterval 默认的速率恢复间隔，收到限频应答后每经过一个间隔速率恢复 recoveryStep
+	DefaultRecoveryInterval = 10 * time.Second
+
+	decreaseFactor   = 0.5         // 收到限频应答后速率降低的比例
+	minFactor        = 0.1         // 自适应速率不低于配置速率的比例
+	recoveryStep     = 0.1         // 每个恢复间隔恢复的速率比例
+	decreaseCooldown = time.Second // 在此时间内的多次限频应答只降低一次速率
+)
+
+// Rule 令牌桶限流规则
+type Rule struct {
+	Rate  float64 // 每秒产生的许可数，<= 0 表示不限流
+	Burst int     // 令牌桶容量，即允许的突发请求数，<= 0 时取 Rate 向上取整（至少为 1）
+}
+
+func (r Rule) unlimited() bool {
+	return r.Rate <= 0
+}
+
+func (r Rule) burst() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return math.Max(1, math.Ceil(r.Rate))
+}
+
+// bucket 单个 商户号 + 接口 的令牌桶
+type bucket struct {
+	rule   Rule
+	tokens float64
+	last   time.Time
+
+	// 自适应速率：factor 为当前速率占配置速率的比例，limitedAt 为最近一次降低速率的时间
+	factor        float64
+	limitedFactor float64
+	limitedAt     time.Time
+}
+
+func newBucket(rule Rule, now time.Time) *bucket {
+	return &bucket{rule: rule, tokens: rule.burst(), last: now, factor: 1}
+}
+
+func (b *bucket) rate() float64 {
+	return b.rule.Rate * b.factor
+}
+
+// advance 按流逝的时间恢复自适应速率并补充令牌
+func (b *bucket) advance(now time.Time, recoveryInterval time.Duration) {
+	if !b.limitedAt.IsZero() && recoveryInterval > 0 {
+		steps := math.Floor(float64(now.Sub(b.limitedAt)) / float64(recoveryInterval))
+		b.factor = math.Min(1, b.limitedFactor+recoveryStep*steps)
+		if b.factor >= 1 {
+			b.limitedAt = time.Time{}
+		}
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.rule.burst(), b.tokens+elapsed.Seconds()*b.rate())
+		b.last = now
+	}
+}
+
+// TokenBucketLimiter 按 商户号 + 接口 限流的令牌桶限流器
+//
+// 每个 Key 使用独立的令牌桶，规则按以下优先级匹配：商户号 + 接口 > 接口 > 商户号 > 默认规则。
+// 收到限频应答（Feedback 的 limited 为 true）后，对应令牌桶的速率减半（不低于配置速率的 10%）并清空令牌，
+// 此后每经过一个恢复间隔速率恢复配置速率的 10%，直至恢复到配置速率。
+type TokenBucketLimiter struct {
+	defaultRule      Rule
+	rules            map[Key]Rule
+	mode             Mode
+	adaptive         bool
+	recoveryInterval time.Duration
+	clock            clock.Clock
+
+	lock    sync.Mutex
+	buckets map[Key]*bucket
+}
+
+// SetRule 为商户号与接口设置限流规则，mchID 或 operation 为空表示匹配所有商户或所有接口
+//
+// 设置后已有的令牌桶会被重置
+func (l *TokenBucketLimiter) SetRule(mchID, operation string, rule Rule) *TokenBucketLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := Key{MchID: mchID, Operation: operation}
+	if key == (Key{}) {
+		l.defaultRule = rule
+	} else {
+		l.rules[key] = rule
+	}
+	l.buckets = make(map[Key]*bucket)
+	return l
+}
+
+// SetMode 设置无法立即获得许可时的处理方式，默认为 ModeBlock
+func (l *TokenBucketLimiter) SetMode(mode Mode) *TokenBucketLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.mode = mode
+	return l
+}
+
+// SetAdaptive 设置是否根据限频应答自动调整速率，默认开启
+func (l *TokenBucketLimiter) SetAdaptive(adaptive bool) *TokenBucketLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.adaptive = adaptive
+	return l
+}
+
+// SetRecoveryInterval 设置自适应速率的恢复间隔，interval <= 0 时使用 DefaultRecoveryInterval
+func (l *TokenBucketLimiter) SetRecoveryInterval(interval time.Duration) *TokenBucketLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if interval <= 0 {
+		interval = DefaultRecoveryInterval
+	}
+	l.recoveryInterval = interval
+	return l
+}
+
+// SetClock 设置限流器使用的时钟，未设置时使用本机系统时钟
+//
+// 请在限流器投入使用前完成设置
+func (l *TokenBucketLimiter) SetClock(c clock.Clock) *TokenBucketLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.clock = clock.OrSystem(c)
+	return l
+}
+
+// Rule 获取 Key 匹配的限流规则
+func (l *TokenBucketLimiter) Rule(key Key) Rule {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.matchRule(key)
+}
+
+func (l *TokenBucketLimiter) matchRule(key Key) Rule {
+	for _, k := range []Key{key, {Operation: key.Operation}, {MchID: key.MchID}} {
+		if rule, ok := l.rules[k]; ok {
+			return rule
+		}
+	}
+	return l.defaultRule
+}
+
+// getBucket 获取 Key 对应的令牌桶，不限流时返回 nil，调用方需持有锁
+func (l *TokenBucketLimiter) getBucket(key Key, now time.Time) *bucket {
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+
+	rule := l.matchRule(key)
+	if rule.unlimited() {
+		return nil
+	}
+	b := newBucket(rule, now)
+	l.buckets[key] = b
+	return b
+}
+
+// Wait 获取一次许可
+//
+// ModeBlock 下会等待至令牌可用，等待期间 ctx 结束时归还预占的令牌并返回 ctx 的错误
+func (l *TokenBucketLimiter) Wait(ctx context.Context, key Key) error {
+	wait, err := l.reserve(ctx, key)
+	if err != nil || wait <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(key)
+		return ctx.Err()
+	}
+}
+
+// reserve 预占一个令牌，返回需要等待的时间
+func (l *TokenBucketLimiter) reserve(ctx context.Context, key Key) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	b := l.getBucket(key, now)
+	if b == nil {
+		return 0, nil
+	}
+	b.advance(now, l.recoveryInterval)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / b.rate() * float64(time.Second))
+	if l.mode == ModeFailFast {
+		return 0, &RateLimitedError{Key: key, RetryAfter: wait}
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < wait {
+		return 0, &RateLimitedError{Key: key, RetryAfter: wait}
+	}
+
+	b.tokens--
+	return wait, nil
+}
+
+// cancel 归还预占的令牌
+func (l *TokenBucketLimiter) cancel(key Key) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.tokens = math.Min(b.rule.burst(), b.tokens+1)
+	}
+}
+
+// Feedback 反馈请求结果，请求被限频且开启自适应时降低对应令牌桶的速率并清空令牌
+func (l *TokenBucketLimiter) Feedback(key Key, limited bool) {
+	if !limited {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !l.adaptive {
+		return
+	}
+
+	now := l.clock.Now()
+	b := l.getBucket(key, now)
+	if b == nil {
+		return
+	}
+	b.advance(now, l.recoveryInterval)
+
+	if !b.limitedAt.IsZero() && now.Sub(b.limitedAt) < decreaseCooldown {
+		return
+	}
+	b.limitedFactor = math.Max(minFactor, b.factor*decreaseFactor)
+	b.factor = b.limitedFactor
+	b.limitedAt = now
+	b.tokens = math.Min(b.tokens, 0)
+}
+
+// NewTokenBucketLimiter 创建令牌桶限流器，defaultRule 为未单独设置规则的商户与接口所使用的规则
+//
+// 可以使用 SetRule 为个别接口或商户设置规则，例如：
+//
+//	limiter := ratelimit.NewTokenBucketLimiter(ratelimit.Rule{Rate: 100}).
+//		SetRule("", "/v3/marketing/favor/users/{openid}/coupons", ratelimit.Rule{Rate: 10, Burst: 10})
+func NewTokenBucketLimiter(defaultRule Rule) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		defaultRule:      defaultRule,
+		rules:            make(map[Key]Rule),
+		mode:             ModeBlock,
+		adaptive:         true,
+		recoveryInterval: DefaultRecoveryInterval,
+		clock:            clock.System,
+		buckets:          make(map[Key]*bucket),
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/ratelimit"
+)
+
+type mockClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *mockClock) Add(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+const testOperation = "/v3/marketing/favor/users/{openid}/coupons"
+
+func newTestLimiter(rule ratelimit.Rule) (*ratelimit.TokenBucketLimiter, *mockClock) {
+	c := &mockClock{now: time.Unix(1700000000, 0)}
+	limiter := ratelimit.NewTokenBucketLimiter(rule).SetMode(ratelimit.ModeFailFast).SetClock(c)
+	return limiter, c
+}
+
+func TestTokenBucketLimiter_FailFast(t *testing.T) {
+	ctx := context.Background()
+	limiter, c := newTestLimiter(ratelimit.Rule{Rate: 2, Burst: 2})
+	key := ratelimit.Key{MchID: "1900009191", Operation: testOperation}
+
+	require.NoError(t, limiter.Wait(ctx, key))
+	require.NoError(t, limiter.Wait(ctx, key))
+
+	err := limiter.Wait(ctx, key)
+	require.Error(t, err)
+	assert.True(t, ratelimit.IsRateLimitedError(err))
+	assert.True(t, ratelimit.IsRateLimitedError(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, 500*time.Millisecond, err.(*ratelimit.RateLimitedError).RetryAfter)
+
+	// 不同商户与接口使用独立的令牌桶
+	require.NoError(t, limiter.Wait(ctx, ratelimit.Key{MchID: "1900009192", Operation: testOperation}))
+	require.NoError(t, limiter.Wait(ctx, ratelimit.Key{MchID: "1900009191", Operation: "/v3/certificates"}))
+
+	c.Add(500 * time.Millisecond)
+	require.NoError(t, limiter.Wait(ctx, key))
+	assert.Error(t, limiter.Wait(ctx, key))
+}
+
+func TestTokenBucketLimiter_Rules(t *testing.T) {
+	limiter, _ := newTestLimiter(ratelimit.Rule{})
+	limiter.
+		SetRule("", testOperation, ratelimit.Rule{Rate: 10}).
+		SetRule("1900009191", "", ratelimit.Rule{Rate: 20}).
+		SetRule("1900009191", testOperation, ratelimit.Rule{Rate: 5, Burst: 1})
+
+	assert.Equal(t, ratelimit.Rule{Rate: 5, Burst: 1}, limiter.Rule(ratelimit.Key{MchID: "1900009191", Operation: testOperation}))
+	assert.Equal(t, ratelimit.Rule{Rate: 10}, limiter.Rule(ratelimit.Key{MchID: "1900009192", Operation: testOperation}))
+	assert.Equal(t, ratelimit.Rule{Rate: 20}, limiter.Rule(ratelimit.Key{MchID: "1900009191", Operation: "/v3/certificates"}))
+	assert.Equal(t, ratelimit.Rule{}, limiter.Rule(ratelimit.Key{MchID: "1900009192", Operation: "/v3/certificates"}))
+
+	// 未配置规则的接口不限流
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), ratelimit.Key{Operation: "/v3/certificates"}))
+	}
+}
+
+func TestTokenBucketLimiter_Adaptive(t *testing.T) {
+	ctx := context.Background()
+	limiter, c := newTestLimiter(ratelimit.Rule{Rate: 10, Burst: 10})
+	limiter.SetRecoveryInterval(10 * time.Second)
+	key := ratelimit.Key{MchID: "1900009191", Operation: testOperation}
+
+	require.NoError(t, limiter.Wait(ctx, key))
+	limiter.Feedback(key, true)
+	// 冷却时间内的限频应答不会再次降低速率
+	limiter.Feedback(key, true)
+
+	// 令牌被清空，速率降为 5/s
+	err := limiter.Wait(ctx, key)
+	require.Error(t, err)
+	assert.Equal(t, 200*time.Millisecond, err.(*ratelimit.RateLimitedError).RetryAfter)
+
+	// 一个恢复间隔后速率恢复到 6/s
+	c.Add(10 * time.Second)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Wait(ctx, key))
+	}
+	err = limiter.Wait(ctx, key)
+	require.Error(t, err)
+	assert.Equal(t, time.Second/6, err.(*ratelimit.RateLimitedError).RetryAfter)
+
+	// 完全恢复
+	c.Add(time.Minute)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Wait(ctx, key))
+	}
+	err = limiter.Wait(ctx, key)
+	require.Error(t, err)
+	assert.Equal(t, 100*time.Millisecond, err.(*ratelimit.RateLimitedError).RetryAfter)
+}
+
+func TestTokenBucketLimiter_NonAdaptive(t *testing.T) {
+	limiter, _ := newTestLimiter(ratelimit.Rule{Rate: 10, Burst: 10})
+	limiter.SetAdaptive(false)
+	key := ratelimit.Key{MchID: "1900009191", Operation: testOperation}
+
+	limiter.Feedback(key, true)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), key))
+	}
+}
+
+func TestTokenBucketLimiter_Block(t *testing.T) {
+	limiter := ratelimit.NewTokenBucketLimiter(ratelimit.Rule{Rate: 20, Burst: 1})
+	key := ratelimit.Key{MchID: "1900009191", Operation: testOperation}
+
+	require.NoError(t, limiter.Wait(context.Background(), key))
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), key))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// ctx 的截止时间早于预计获得许可的时间时立即返回
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	err := limiter.Wait(ctx, key)
+	assert.True(t, ratelimit.IsRateLimitedError(err))
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+	// 等待期间 ctx 被取消时归还令牌
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, limiter.Wait(ctx, key), context.Canceled)
+}
+
+func TestIsRateLimitCode(t *testing.T) {
+	assert.True(t, ratelimit.IsRateLimitCode("RATELIMIT_EXCEED"))
+	assert.True(t, ratelimit.IsRateLimitCode("FREQUENCY_LIMITED"))
+	assert.False(t, ratelimit.IsRateLimitCode("SYSTEM_ERROR"))
+}
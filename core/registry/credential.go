@@ -0,0 +1,106 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package registry
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MerchantCredential 构建商户 core.Client 所需的凭据
+//
+// 设置了 WechatPayPublicKeyID 与 WechatPayPublicKey 时使用微信支付公钥验签，
+// 否则使用 MchAPIv3Key 自动下载并更新微信支付平台证书
+type MerchantCredential struct {
+	MchID               string          // 商户号
+	CertificateSerialNo string          // 商户证书序列号
+	PrivateKey          *rsa.PrivateKey // 商户私钥
+	MchAPIv3Key         string          // 商户APIv3密钥，平台证书模式下必填
+
+	WechatPayPublicKeyID string         // 微信支付公钥ID，公钥模式下必填
+	WechatPayPublicKey   *rsa.PublicKey // 微信支付公钥，公钥模式下必填
+}
+
+// UsePublicKey 是否使用微信支付公钥验签
+func (c *MerchantCredential) UsePublicKey() bool {
+	return c.WechatPayPublicKeyID != "" && c.WechatPayPublicKey != nil
+}
+
+// Validate 校验凭据是否完整
+func (c *MerchantCredential) Validate() error {
+	if strings.TrimSpace(c.MchID) == "" {
+		return fmt.Errorf("mchid is required in MerchantCredential")
+	}
+	if strings.TrimSpace(c.CertificateSerialNo) == "" {
+		return fmt.Errorf("certificate serial no is required in MerchantCredential of mchid=[%s]", c.MchID)
+	}
+	if c.PrivateKey == nil {
+		return fmt.Errorf("private key is required in MerchantCredential of mchid=[%s]", c.MchID)
+	}
+	if !c.UsePublicKey() && c.MchAPIv3Key == "" {
+		return fmt.Errorf(
+			"either mch apiv3 key or wechatpay public key is required in MerchantCredential of mchid=[%s]", c.MchID,
+		)
+	}
+	return nil
+}
+
+// CredentialProvider 商户凭据提供器，ClientRegistry 在首次使用某个商户时通过它获取凭据
+type CredentialProvider interface {
+	// GetCredential 获取商户号对应的凭据
+	GetCredential(ctx context.Context, mchID string) (*MerchantCredential, error)
+}
+
+// CredentialProviderFunc 使用函数实现 CredentialProvider
+type CredentialProviderFunc func(ctx context.Context, mchID string) (*MerchantCredential, error)
+
+// GetCredential 获取商户号对应的凭据
+func (f CredentialProviderFunc) GetCredential(ctx context.Context, mchID string) (*MerchantCredential, error) {
+	return f(ctx, mchID)
+}
+
+// StaticCredentialProvider 基于内存 Map 的商户凭据提供器，可以并发地增删商户
+type StaticCredentialProvider struct {
+	credentials map[string]*MerchantCredential
+	lock        sync.RWMutex
+}
+
+// GetCredential 获取商户号对应的凭据
+func (p *StaticCredentialProvider) GetCredential(_ context.Context, mchID string) (*MerchantCredential, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	credential, ok := p.credentials[mchID]
+	if !ok {
+		return nil, fmt.Errorf("credential of mchid=[%s] not found", mchID)
+	}
+	return credential, nil
+}
+
+// Set 设置商户凭据，已存在时覆盖
+func (p *StaticCredentialProvider) Set(credential *MerchantCredential) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.credentials[credential.MchID] = credential
+}
+
+// Delete 删除商户凭据
+func (p *StaticCredentialProvider) Delete(mchID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.credentials, mchID)
+}
+
+// NewStaticCredentialProvider 使用凭据列表初始化 StaticCredentialProvider
+func NewStaticCredentialProvider(credentials ...*MerchantCredential) *StaticCredentialProvider {
+	p := &StaticCredentialProvider{credentials: make(map[string]*MerchantCredential)}
+	for _, credential := range credentials {
+		p.credentials[credential.MchID] = credential
+	}
+	return p
+}
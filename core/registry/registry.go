@@ -0,0 +1,275 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package registry 微信支付 API v3 Go SDK 多商户 Client 注册表
+//
+// 服务商往往同时运营大量直连商户，每个商户都需要独立的 core.Client（签名器、验证器、加解密套件与平台证书）。
+// ClientRegistry 按商户号从 CredentialProvider 获取凭据，按需构建并缓存 core.Client，
+// 所有商户共享同一个 downloader.CertificateDownloaderMgr 与 HTTP 连接池。
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/downloader"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services"
+)
+
+// clientEntry 商户 Client 缓存项，done 关闭后 client 与 err 才可读取
+type clientEntry struct {
+	done   chan struct{}
+	client *core.Client
+	err    error
+}
+
+// ClientRegistry 多商户 core.Client 注册表，可以在多个 goroutine 中并发使用
+type ClientRegistry struct {
+	provider   CredentialProvider
+	mgr        *downloader.CertificateDownloaderMgr
+	httpClient *http.Client
+	opts       []core.ClientOption
+
+	lock             sync.Mutex
+	entries          map[string]*clientEntry
+	added            map[string]*MerchantCredential // 通过 Add 添加的凭据，优先于 provider
+	ownedDownloaders map[string]bool                // 由本注册表注册到 mgr 的下载器
+}
+
+// Client 获取商户的 core.Client，首次获取时构建并缓存
+//
+// 同一商户的并发调用只会构建一次；构建失败时不缓存错误，下次调用将重新构建
+func (r *ClientRegistry) Client(ctx context.Context, mchID string) (*core.Client, error) {
+	r.lock.Lock()
+	entry, ok := r.entries[mchID]
+	if !ok {
+		entry = &clientEntry{done: make(chan struct{})}
+		r.entries[mchID] = entry
+	}
+	r.lock.Unlock()
+
+	if !ok {
+		r.build(ctx, mchID, entry, false)
+	}
+
+	select {
+	case <-entry.done:
+		return entry.client, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Service 获取商户的 services.Service，可直接转换为任意 ApiService，例如：
+//
+//	svc, err := registry.Service(ctx, mchID)
+//	api := jsapi.JsapiApiService(svc)
+func (r *ClientRegistry) Service(ctx context.Context, mchID string) (services.Service, error) {
+	client, err := r.Client(ctx, mchID)
+	if err != nil {
+		return services.Service{}, err
+	}
+	return services.Service{Client: client}, nil
+}
+
+// Add 使用凭据立即构建商户的 core.Client 并加入注册表，已存在时替换
+//
+// 通过 Add 添加的凭据优先于 CredentialProvider，Reload 时同样使用该凭据
+func (r *ClientRegistry) Add(ctx context.Context, credential *MerchantCredential) (*core.Client, error) {
+	if credential == nil {
+		return nil, fmt.Errorf("credential is required")
+	}
+
+	client, err := r.newClient(ctx, credential, true)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &clientEntry{done: make(chan struct{}), client: client}
+	close(entry.done)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.added[credential.MchID] = credential
+	r.entries[credential.MchID] = entry
+	return client, nil
+}
+
+// Reload 重新获取商户凭据并重建 core.Client，常用于商户私钥或证书轮换后
+//
+// 重建成功前仍使用原有 Client；已经获取到原有 Client 的调用方不受影响
+func (r *ClientRegistry) Reload(ctx context.Context, mchID string) (*core.Client, error) {
+	entry := &clientEntry{done: make(chan struct{})}
+	r.build(ctx, mchID, entry, true)
+	if entry.err != nil {
+		return nil, entry.err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.entries[mchID] = entry
+	return entry.client, nil
+}
+
+// Remove 从注册表中移除商户，并移除由本注册表注册的平台证书下载器
+//
+// 已经获取到该商户 Client 的调用方仍可使用，但在平台证书模式下将无法再获取平台证书
+func (r *ClientRegistry) Remove(ctx context.Context, mchID string) {
+	r.lock.Lock()
+	delete(r.entries, mchID)
+	delete(r.added, mchID)
+	owned := r.ownedDownloaders[mchID]
+	delete(r.ownedDownloaders, mchID)
+	r.lock.Unlock()
+
+	if owned {
+		r.mgr.RemoveDownloader(ctx, mchID)
+	}
+}
+
+// MchIDs 获取注册表中已构建（或正在构建）Client 的商户号列表
+func (r *ClientRegistry) MchIDs() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ret := make([]string, 0, len(r.entries))
+	for mchID := range r.entries {
+		ret = append(ret, mchID)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// build 获取凭据并构建 Client，结果写入 entry 后关闭 entry.done
+func (r *ClientRegistry) build(ctx context.Context, mchID string, entry *clientEntry, forceDownload bool) {
+	defer close(entry.done)
+
+	credential, err := r.getCredential(ctx, mchID)
+	if err == nil {
+		entry.client, err = r.newClient(ctx, credential, forceDownload)
+	}
+	if err == nil {
+		return
+	}
+
+	entry.err = fmt.Errorf("build client for mchid=[%s] failed: %w", mchID, err)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.entries[mchID] == entry {
+		delete(r.entries, mchID)
+	}
+}
+
+func (r *ClientRegistry) getCredential(ctx context.Context, mchID string) (*MerchantCredential, error) {
+	r.lock.Lock()
+	credential, ok := r.added[mchID]
+	r.lock.Unlock()
+
+	if ok {
+		return credential, nil
+	}
+	if r.provider == nil {
+		return nil, fmt.Errorf("credential of mchid=[%s] not found", mchID)
+	}
+
+	credential, err := r.provider.GetCredential(ctx, mchID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil || credential.MchID != mchID {
+		return nil, fmt.Errorf("credential provider returns mismatched credential for mchid=[%s]", mchID)
+	}
+	return credential, nil
+}
+
+func (r *ClientRegistry) newClient(
+	ctx context.Context, credential *MerchantCredential, forceDownload bool,
+) (*core.Client, error) {
+	if err := credential.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := []core.ClientOption{option.WithHTTPClient(r.httpClient)}
+	if credential.UsePublicKey() {
+		opts = append(
+			opts, option.WithWechatPayPublicKeyAuthCipher(
+				credential.MchID, credential.CertificateSerialNo, credential.PrivateKey,
+				credential.WechatPayPublicKeyID, credential.WechatPayPublicKey,
+			),
+		)
+	} else {
+		if err := r.registerDownloader(ctx, credential, forceDownload); err != nil {
+			return nil, err
+		}
+		opts = append(
+			opts, option.WithWechatPayAutoAuthCipherUsingDownloaderMgr(
+				credential.MchID, credential.CertificateSerialNo, credential.PrivateKey, r.mgr,
+			),
+		)
+	}
+	opts = append(opts, r.opts...)
+
+	return core.NewClient(ctx, opts...)
+}
+
+// registerDownloader 为商户注册平台证书下载器，下载器与所有商户 Client 共享 HTTP 连接池
+func (r *ClientRegistry) registerDownloader(
+	ctx context.Context, credential *MerchantCredential, force bool,
+) error {
+	if !force && r.mgr.HasDownloader(ctx, credential.MchID) {
+		return nil
+	}
+
+	client, err := core.NewClient(
+		ctx,
+		option.WithMerchantCredential(credential.MchID, credential.CertificateSerialNo, credential.PrivateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(r.httpClient),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = r.mgr.RegisterDownloaderWithClient(ctx, client, credential.MchID, credential.MchAPIv3Key); err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ownedDownloaders[credential.MchID] = true
+	return nil
+}
+
+// NewClientRegistry 初始化多商户 Client 注册表
+//
+// mgr 为 nil 时使用 downloader.MgrInstance()；httpClient 为 nil 时创建一个所有商户共享的默认 HTTPClient；
+// opts 会追加到每个商户 Client 的初始化参数之后，可用于设置时钟、防重放缓存等公共配置
+func NewClientRegistry(
+	provider CredentialProvider, mgr *downloader.CertificateDownloaderMgr, httpClient *http.Client,
+	opts ...core.ClientOption,
+) *ClientRegistry {
+	if mgr == nil {
+		mgr = downloader.MgrInstance()
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: consts.DefaultTimeout}
+	}
+
+	return &ClientRegistry{
+		provider:         provider,
+		mgr:              mgr,
+		httpClient:       httpClient,
+		opts:             opts,
+		entries:          make(map[string]*clientEntry),
+		added:            make(map[string]*MerchantCredential),
+		ownedDownloaders: make(map[string]bool),
+	}
+}
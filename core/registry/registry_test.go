@@ -0,0 +1,155 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/downloader"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+)
+
+var (
+	testPrivateKey          *rsa.PrivateKey
+	testWechatPayPrivateKey *rsa.PrivateKey
+)
+
+func init() {
+	var err error
+	if testPrivateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+	if testWechatPayPrivateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+}
+
+func newTestCredential(mchID string) *MerchantCredential {
+	return &MerchantCredential{
+		MchID:                mchID,
+		CertificateSerialNo:  "SERIAL-" + mchID,
+		PrivateKey:           testPrivateKey,
+		WechatPayPublicKeyID: "PUB_KEY_ID_" + mchID,
+		WechatPayPublicKey:   &testWechatPayPrivateKey.PublicKey,
+	}
+}
+
+func newTestRegistry(t *testing.T, provider CredentialProvider) *ClientRegistry {
+	mgr := downloader.NewCertificateDownloaderMgr(context.Background())
+	t.Cleanup(mgr.Stop)
+	return NewClientRegistry(provider, mgr, nil)
+}
+
+func TestClientRegistry_ClientIsBuiltOnce(t *testing.T) {
+	var calls int32
+	provider := CredentialProviderFunc(func(_ context.Context, mchID string) (*MerchantCredential, error) {
+		atomic.AddInt32(&calls, 1)
+		return newTestCredential(mchID), nil
+	})
+	r := newTestRegistry(t, provider)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := r.Client(context.Background(), "1900000001")
+			assert.NoError(t, err)
+			assert.NotNil(t, client)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []string{"1900000001"}, r.MchIDs())
+
+	first, _ := r.Client(context.Background(), "1900000001")
+	second, _ := r.Client(context.Background(), "1900000001")
+	assert.Same(t, first, second)
+
+	other, err := r.Client(context.Background(), "1900000002")
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+}
+
+func TestClientRegistry_ErrorIsNotCached(t *testing.T) {
+	var fail int32 = 1
+	provider := CredentialProviderFunc(func(_ context.Context, mchID string) (*MerchantCredential, error) {
+		if atomic.LoadInt32(&fail) == 1 {
+			return nil, fmt.Errorf("provider unavailable")
+		}
+		return newTestCredential(mchID), nil
+	})
+	r := newTestRegistry(t, provider)
+
+	_, err := r.Client(context.Background(), "1900000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider unavailable")
+	assert.Empty(t, r.MchIDs())
+
+	atomic.StoreInt32(&fail, 0)
+	client, err := r.Client(context.Background(), "1900000001")
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+func TestClientRegistry_InvalidCredential(t *testing.T) {
+	provider := NewStaticCredentialProvider(&MerchantCredential{MchID: "1900000001", PrivateKey: testPrivateKey})
+	r := newTestRegistry(t, provider)
+
+	_, err := r.Client(context.Background(), "1900000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate serial no is required")
+
+	_, err = r.Client(context.Background(), "1900000002")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestClientRegistry_AddReloadRemove(t *testing.T) {
+	provider := NewStaticCredentialProvider(newTestCredential("1900000001"))
+	r := newTestRegistry(t, provider)
+	ctx := context.Background()
+
+	original, err := r.Client(ctx, "1900000001")
+	require.NoError(t, err)
+
+	reloaded, err := r.Reload(ctx, "1900000001")
+	require.NoError(t, err)
+	assert.NotSame(t, original, reloaded)
+	current, _ := r.Client(ctx, "1900000001")
+	assert.Same(t, reloaded, current)
+
+	added, err := r.Add(ctx, newTestCredential("1900000009"))
+	require.NoError(t, err)
+	current, _ = r.Client(ctx, "1900000009")
+	assert.Same(t, added, current)
+	assert.Equal(t, []string{"1900000001", "1900000009"}, r.MchIDs())
+
+	// 通过 Add 添加的商户不依赖 provider 也可以重新加载
+	_, err = r.Reload(ctx, "1900000009")
+	require.NoError(t, err)
+
+	r.Remove(ctx, "1900000009")
+	assert.Equal(t, []string{"1900000001"}, r.MchIDs())
+	_, err = r.Client(ctx, "1900000009")
+	assert.Error(t, err)
+}
+
+func TestClientRegistry_Service(t *testing.T) {
+	r := newTestRegistry(t, NewStaticCredentialProvider(newTestCredential("1900000001")))
+
+	svc, err := r.Service(context.Background(), "1900000001")
+	require.NoError(t, err)
+
+	api := jsapi.JsapiApiService(svc)
+	assert.NotNil(t, api.Client)
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package core
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jemuri/wechatpay-go/core/breaker"
+)
+
+// IsIdempotent 判断请求是否可以安全地重复发送
+//
+// GET、HEAD 请求以及 ctx 使用 breaker.WithIdempotent 标记的请求为幂等请求。
+// 退款、转账、发券等 POST 请求重复发送可能造成重复操作，除非业务确认可以重入，否则不应标记
+func IsIdempotent(request *http.Request) bool {
+	return request.Method == http.MethodGet || request.Method == http.MethodHead ||
+		breaker.IsIdempotent(request.Context())
+}
+
+type signerKey struct{}
+
+func withSigner(ctx context.Context, sign func(*http.Request) error) context.Context {
+	return context.WithValue(ctx, signerKey{}, sign)
+}
+
+// Resign 为重新发送的请求生成新的 Authorization 头
+//
+// Authorization 中的随机串与时间戳只能使用一次，重试 Client 发出的请求前需重新签名。
+// 不是由 Client 发出的请求没有签名信息，Resign 不做修改
+func Resign(request *http.Request) error {
+	sign, ok := request.Context().Value(signerKey{}).(func(*http.Request) error)
+	if !ok {
+		return nil
+	}
+	return sign(request)
+}
@@ -4,10 +4,17 @@ package core
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/breaker"
 	"github.com/jemuri/wechatpay-go/core/cipher"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/metrics"
+	"github.com/jemuri/wechatpay-go/core/ratelimit"
 )
 
 // DialSettings 微信支付 API v3 Go SDK core.Client 需要的配置信息
@@ -16,6 +23,25 @@ type DialSettings struct {
 	Signer     auth.Signer    // 签名器
 	Validator  auth.Validator // 应答包签名校验器
 	Cipher     cipher.Cipher  // 敏感字段加解密套件
+
+	Clock              clock.Clock   // 时钟，用于生成请求时间戳与校验应答时间戳，为 nil 时使用本机系统时钟
+	TimestampTolerance time.Duration // 允许的应答时间戳与当前时间之差，为 0 时使用验证器的默认值
+	ClockSkewDetection bool          // 是否根据应答报文中的时间自动补偿本机时钟偏差
+
+	ReplayCache auth.ReplayCache // 应答报文防重放缓存，为 nil 时不检查重放
+
+	MetricsRecorder metrics.Recorder // 监控指标记录器，为 nil 时不记录
+
+	Logger *slog.Logger // 结构化日志 Logger，输出前自动脱敏，为 nil 时不输出日志
+
+	RateLimiter ratelimit.Limiter // 客户端限流器，为 nil 时不限流
+
+	CircuitBreaker *breaker.CircuitBreaker // 熔断器，为 nil 时不熔断
+	BackupHosts    map[string]*url.URL     // 主域名到备份地址的映射，熔断时幂等请求改为发往备份地址
+
+	DryRun bool // 试运行模式，请求只构建并签名而不发出，见 core.WithDryRun
+
+	RequestValidation bool // 是否在签名前使用请求模型的 Validate 方法校验请求参数
 }
 
 // Validate 校验请求配置是否有效
@@ -0,0 +1,266 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package validation 请求参数的本地校验
+//
+// 微信支付会校验请求参数的长度、取值范围、枚举值与格式，不符合时返回 HTTP 400 与 PARAM_ERROR，
+// 且错误信息往往不指明具体字段。服务包中的请求模型均有生成的 Validate 方法，按接口文档中的约束在本地校验参数，
+// 返回的 *Error 列出所有不符合约束的字段。
+//
+// 使用 option.WithRequestValidation 创建的 Client 会在签名前自动调用请求的 Validate 方法，校验失败时不会发出请求；
+// 也可以在构造请求后直接调用：
+//
+//	if err := req.Validate(); err != nil {
+//		verr, _ := validation.AsError(err)
+//		for _, f := range verr.Fields { ... }
+//	}
+//
+// 与当前时间相关的约束（如订单失效时间）使用 WithClock 指定的时钟，Client 会传入自身的时钟（包括时钟偏差补偿）。
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field  string // 字段路径，使用 JSON 字段名，如 amount.total、detail.goods_detail[0].quantity
+	Reason string // 不符合约束的原因
+}
+
+// String 输出 FieldError
+func (e FieldError) String() string {
+	return e.Field + " " + e.Reason
+}
+
+// Error 请求参数未通过本地校验
+type Error struct {
+	Type   string       // 请求模型的类型名，如 PrepayRequest
+	Fields []FieldError // 所有不符合约束的字段
+}
+
+// Error 输出 Error
+func (e *Error) Error() string {
+	reasons := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		reasons = append(reasons, f.String())
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Type, strings.Join(reasons, "; "))
+}
+
+// AsError 判断当前 error 是否为 *Error（包括被包装的情况），是则返回该错误
+func AsError(err error) (*Error, bool) {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
+
+// IsError 判断当前 error 是否为 *Error（包括被包装的情况）
+func IsError(err error) bool {
+	_, ok := AsError(err)
+	return ok
+}
+
+// String 返回 v 的指针，v 为空字符串时返回 nil，供以值类型表示字段的 v2 接口模型使用
+func String(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// Int64 返回 v 的指针，v 为 0 时返回 nil，供以值类型表示字段的 v2 接口模型使用
+func Int64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// Index 返回数组元素的字段路径，如 goods_detail[0]
+func Index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
+
+// Option 校验选项，生成的 Validate 方法会将选项传递给嵌套模型
+type Option func(c *Collector)
+
+// WithClock 指定校验时间窗口时使用的时钟，未指定时使用 clock.System
+func WithClock(c clock.Clock) Option {
+	return func(collector *Collector) {
+		collector.clock = c
+	}
+}
+
+// Collector 收集字段校验错误，供生成的 Validate 方法使用
+//
+// 除 Required 外，各方法在字段未设置（指针为 nil）时均不做校验
+type Collector struct {
+	typ    string
+	clock  clock.Clock
+	fields []FieldError
+}
+
+// NewCollector 创建校验类型 typ 的 Collector
+func NewCollector(typ string, opts ...Option) *Collector {
+	c := &Collector{typ: typ}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Add 记录字段 field 的校验错误
+func (c *Collector) Add(field, format string, args ...interface{}) {
+	c.fields = append(c.fields, FieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Required 校验必填字段已设置
+func (c *Collector) Required(field string, set bool) {
+	if !set {
+		c.Add(field, "is required")
+	}
+}
+
+// Length 校验字符串的字节数在 [min, max] 内，max 为 0 表示不限制
+func (c *Collector) Length(field string, v *string, min, max int) {
+	if v == nil {
+		return
+	}
+	n := len(*v)
+	switch {
+	case max > 0 && min > 0 && (n < min || n > max):
+		if min == max {
+			c.Add(field, "must be %d bytes, got %d", min, n)
+		} else {
+			c.Add(field, "must be %d to %d bytes, got %d", min, max, n)
+		}
+	case n < min:
+		c.Add(field, "must be at least %d bytes, got %d", min, n)
+	case max > 0 && n > max:
+		c.Add(field, "must be at most %d bytes, got %d", max, n)
+	}
+}
+
+// Pattern 校验字符串匹配正则表达式 pattern，编译后的表达式会被缓存
+func (c *Collector) Pattern(field string, v *string, pattern string) {
+	if v != nil && !compile(pattern).MatchString(*v) {
+		c.Add(field, "must match %s, got %q", pattern, *v)
+	}
+}
+
+var patterns sync.Map // pattern -> *regexp.Regexp
+
+func compile(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, _ := patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	return re.(*regexp.Regexp)
+}
+
+// ID 校验商户侧单号的长度与可用字符，见 idgen.Field
+func (c *Collector) ID(field string, v *string, f idgen.Field) {
+	if v == nil {
+		return
+	}
+	f.Name = field
+	if err := f.Validate(*v); err != nil {
+		c.Add(field, "%s", strings.TrimPrefix(err.Error(), field+" "))
+	}
+}
+
+// Window 校验时间在当前时间之后的窗口 w 内，如订单失效时间需在当前时间 1 分钟之后，见 chinatime.Window
+//
+// 当前时间取自 WithClock 指定的时钟
+func (c *Collector) Window(field string, v *time.Time, w chinatime.Window) {
+	if v == nil {
+		return
+	}
+	if err := w.Validate(field, *v, clock.OrSystem(c.clock).Now()); err != nil {
+		c.Add(field, "%s", strings.TrimPrefix(err.Error(), field+" "))
+	}
+}
+
+// Range 校验整数在 [min, max] 内，max 为 0 表示不限制
+func (c *Collector) Range(field string, v *int64, min, max int64) {
+	if v == nil {
+		return
+	}
+	switch {
+	case *v < min:
+		c.Add(field, "must be at least %d, got %d", min, *v)
+	case max > 0 && *v > max:
+		c.Add(field, "must be at most %d, got %d", max, *v)
+	}
+}
+
+// Enum 校验枚举字段的取值为 allowed 之一
+func (c *Collector) Enum(field string, v *string, allowed ...string) {
+	if v == nil {
+		return
+	}
+	for _, a := range allowed {
+		if *v == a {
+			return
+		}
+	}
+	c.Add(field, "must be one of %s, got %q", strings.Join(allowed, ", "), *v)
+}
+
+// ExactlyOne 校验互斥字段中有且只有一个已设置，set 与 fields 一一对应
+func (c *Collector) ExactlyOne(fields []string, set ...bool) {
+	if n := countSet(set); n != 1 {
+		c.Add(strings.Join(fields, ", "), "must have exactly one specified, got %d", n)
+	}
+}
+
+// AtMostOne 校验互斥字段中至多一个已设置，set 与 fields 一一对应
+func (c *Collector) AtMostOne(fields []string, set ...bool) {
+	if n := countSet(set); n > 1 {
+		c.Add(strings.Join(fields, ", "), "must have at most one specified, got %d", n)
+	}
+}
+
+func countSet(set []bool) int {
+	n := 0
+	for _, s := range set {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// Nested 记录嵌套模型 Validate 返回的错误，字段路径加上前缀 field
+func (c *Collector) Nested(field string, err error) {
+	if err == nil {
+		return
+	}
+	nested, ok := AsError(err)
+	if !ok {
+		c.Add(field, "%v", err)
+		return
+	}
+	for _, f := range nested.Fields {
+		c.fields = append(c.fields, FieldError{Field: field + "." + f.Field, Reason: f.Reason})
+	}
+}
+
+// Err 返回收集到的错误，没有错误时返回 nil
+func (c *Collector) Err() error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+	return &Error{Type: c.typ, Fields: c.fields}
+}
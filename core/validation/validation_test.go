@@ -0,0 +1,116 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package validation_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+func TestCollector(t *testing.T) {
+	c := validation.NewCollector("PrepayRequest")
+	c.Required("appid", false)
+	c.Length("description", core.String("商品"), 1, 3)
+	c.Length("attach", core.String(""), 1, 0)
+	c.Length("goods_tag", core.String("1234"), 0, 3)
+	c.Length("currency", core.String("CN"), 3, 3)
+	c.Pattern("notify_url", core.String("http://example.com"), "^https://")
+	c.ID("out_trade_no", core.String("order@123"), idgen.OutTradeNo)
+	c.Range("total", core.Int64(0), 1, 0)
+	c.Range("total_num", core.Int64(3001), 1, 3000)
+	c.Enum("trade_type", core.String("MWEB"), "JSAPI", "NATIVE")
+	c.ExactlyOne([]string{"transaction_id", "out_trade_no"}, false, false)
+	c.AtMostOne([]string{"sp_openid", "sub_openid"}, true, true)
+
+	err := c.Err()
+	validationErr, ok := validation.AsError(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	assert.Equal(t, "PrepayRequest", validationErr.Type)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "appid", Reason: "is required"},
+		{Field: "description", Reason: "must be 1 to 3 bytes, got 6"},
+		{Field: "attach", Reason: "must be at least 1 bytes, got 0"},
+		{Field: "goods_tag", Reason: "must be at most 3 bytes, got 4"},
+		{Field: "currency", Reason: "must be 3 bytes, got 2"},
+		{Field: "notify_url", Reason: `must match ^https://, got "http://example.com"`},
+		{Field: "out_trade_no", Reason: `contains invalid character "@" at 5`},
+		{Field: "total", Reason: "must be at least 1, got 0"},
+		{Field: "total_num", Reason: "must be at most 3000, got 3001"},
+		{Field: "trade_type", Reason: `must be one of JSAPI, NATIVE, got "MWEB"`},
+		{Field: "transaction_id, out_trade_no", Reason: "must have exactly one specified, got 0"},
+		{Field: "sp_openid, sub_openid", Reason: "must have at most one specified, got 2"},
+	}, validationErr.Fields)
+}
+
+func TestCollector_Valid(t *testing.T) {
+	c := validation.NewCollector("PrepayRequest")
+	// 未设置的字段不做校验
+	c.Length("attach", nil, 1, 128)
+	c.Pattern("notify_url", nil, "^https://")
+	c.ID("out_trade_no", nil, idgen.OutTradeNo)
+	c.Range("total", nil, 1, 0)
+	c.Enum("trade_type", nil, "JSAPI")
+
+	c.Required("appid", true)
+	c.Length("description", core.String("商品"), 1, 127)
+	c.ID("out_trade_no", core.String("1217752501201407033233368018"), idgen.OutTradeNo)
+	c.Enum("trade_type", core.String("JSAPI"), "JSAPI", "NATIVE")
+	c.ExactlyOne([]string{"transaction_id", "out_trade_no"}, false, true)
+	c.AtMostOne([]string{"sp_openid", "sub_openid"}, false, false)
+	assert.NoError(t, c.Err())
+	assert.False(t, validation.IsError(c.Err()))
+}
+
+func TestValueHelpers(t *testing.T) {
+	// v2 接口模型的零值视为未设置，不做校验
+	c := validation.NewCollector("PapPayApplyRequest")
+	c.Length("body", validation.String(""), 1, 128)
+	c.Range("total_fee", validation.Int64(0), 1, 0)
+	assert.NoError(t, c.Err())
+
+	c.Length("body", validation.String("商品"), 1, 3)
+	c.Range("total_fee", validation.Int64(-1), 1, 0)
+	validationErr, ok := validation.AsError(c.Err())
+	require.True(t, ok)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "body", Reason: "must be 1 to 3 bytes, got 6"},
+		{Field: "total_fee", Reason: "must be at least 1, got -1"},
+	}, validationErr.Fields)
+}
+
+func TestCollector_Nested(t *testing.T) {
+	amount := validation.NewCollector("Amount")
+	amount.Range("total", core.Int64(0), 1, 0)
+
+	c := validation.NewCollector("PrepayRequest")
+	c.Nested("amount", amount.Err())
+	c.Nested(validation.Index("goods_detail", 1), fmt.Errorf("unexpected"))
+	c.Nested("payer", nil)
+	assert.EqualError(t, c.Err(),
+		"invalid PrepayRequest: amount.total must be at least 1, got 0; goods_detail[1] unexpected")
+}
+
+func TestCollector_WindowWithClock(t *testing.T) {
+	now := chinatime.Date(2021, 6, 8, 10, 0, 0)
+	window := chinatime.Window{Min: time.Minute, Max: 2 * time.Hour}
+
+	c := validation.NewCollector("PrepayRequest", validation.WithClock(clock.Fixed(now)))
+	c.Window("time_expire", nil, window)
+	c.Window("time_expire", core.Time(now.Add(time.Hour)), window)
+	assert.NoError(t, c.Err())
+
+	c.Window("time_expire", core.Time(now.Add(30*time.Second)), window)
+	validationErr, ok := validation.AsError(c.Err())
+	require.True(t, ok)
+	require.Len(t, validationErr.Fields, 1)
+	assert.Equal(t, "time_expire", validationErr.Fields[0].Field)
+}
@@ -1,8 +1,14 @@
 module github.com/jemuri/wechatpay-go
 
-go 1.16
+go 1.21
 
 require (
 	github.com/agiledragon/gomonkey v2.0.2+incompatible
 	github.com/stretchr/testify v1.8.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
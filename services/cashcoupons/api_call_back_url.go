@@ -38,6 +38,11 @@ func (a *CallBackUrlApiService) QueryCallback(ctx context.Context, req QueryCall
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/favor/callbacks"
 	// Make sure All Required Params are properly set
 	if req.Mchid == nil {
@@ -89,6 +94,11 @@ func (a *CallBackUrlApiService) SetCallback(ctx context.Context, req SetCallback
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/favor/callbacks"
 	// Make sure All Required Params are properly set
 
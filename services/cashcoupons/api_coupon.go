@@ -43,6 +43,11 @@ func (a *CouponApiService) ListCouponsByFilter(ctx context.Context, req ListCoup
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in ListCouponsByFilterRequest")
@@ -121,6 +126,11 @@ func (a *CouponApiService) QueryCoupon(ctx context.Context, req QueryCouponReque
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.CouponId == nil {
 		return nil, nil, fmt.Errorf("field `CouponId` is required and must be specified in QueryCouponRequest")
@@ -185,6 +195,11 @@ func (a *CouponApiService) SendCoupon(ctx context.Context, req SendCouponRequest
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in SendCouponRequest")
@@ -39,6 +39,11 @@ func (a *StockApiService) CreateCouponStock(ctx context.Context, req CreateCoupo
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/favor/coupon-stocks"
 	// Make sure All Required Params are properly set
 
@@ -82,6 +87,11 @@ func (a *StockApiService) ListAvailableMerchants(ctx context.Context, req ListAv
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in ListAvailableMerchantsRequest")
@@ -145,6 +155,11 @@ func (a *StockApiService) ListAvailableSingleitems(ctx context.Context, req List
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in ListAvailableSingleitemsRequest")
@@ -206,6 +221,11 @@ func (a *StockApiService) ListStocks(ctx context.Context, req ListStocksRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/favor/stocks"
 	// Make sure All Required Params are properly set
 	if req.Offset == nil {
@@ -272,6 +292,11 @@ func (a *StockApiService) PauseStock(ctx context.Context, req PauseStockRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in PauseStockRequest")
@@ -327,6 +352,11 @@ func (a *StockApiService) QueryStock(ctx context.Context, req QueryStockRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in QueryStockRequest")
@@ -388,6 +418,11 @@ func (a *StockApiService) RefundFlow(ctx context.Context, req RefundFlowRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in RefundFlowRequest")
@@ -434,6 +469,11 @@ func (a *StockApiService) RestartStock(ctx context.Context, req RestartStockRequ
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in RestartStockRequest")
@@ -487,6 +527,11 @@ func (a *StockApiService) StartStock(ctx context.Context, req StartStockRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in StartStockRequest")
@@ -536,6 +581,11 @@ func (a *StockApiService) StopStock(ctx context.Context, req StopStockRequest) (
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in StopStockRequest")
@@ -595,6 +645,11 @@ func (a *StockApiService) UseFlow(ctx context.Context, req UseFlowRequest) (resp
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in UseFlowRequest")
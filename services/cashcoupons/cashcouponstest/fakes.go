@@ -0,0 +1,351 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package cashcouponstest 提供 cashcoupons 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package cashcouponstest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/cashcoupons"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeCallBackUrlAPI cashcoupons.CallBackUrlAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCallBackUrlAPI struct {
+	servicetest.Recorder
+
+	QueryCallbackFunc func(context.Context, cashcoupons.QueryCallbackRequest) (*cashcoupons.Callback, *core.APIResult, error)
+	SetCallbackFunc   func(context.Context, cashcoupons.SetCallbackRequest) (*cashcoupons.SetCallbackResponse, *core.APIResult, error)
+}
+
+var _ cashcoupons.CallBackUrlAPI = (*FakeCallBackUrlAPI)(nil)
+
+// QueryCallback 记录调用并返回 QueryCallbackFunc 的结果
+func (f *FakeCallBackUrlAPI) QueryCallback(ctx context.Context, req cashcoupons.QueryCallbackRequest) (resp *cashcoupons.Callback, result *core.APIResult, err error) {
+	f.Record("QueryCallback", req)
+	if f.QueryCallbackFunc == nil {
+		err = servicetest.NotStubbed("CallBackUrlAPI", "QueryCallback")
+		return
+	}
+	return f.QueryCallbackFunc(ctx, req)
+}
+
+// QueryCallbackReturns 设置 QueryCallback 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCallBackUrlAPI) QueryCallbackReturns(resp *cashcoupons.Callback, err error) *FakeCallBackUrlAPI {
+	f.QueryCallbackFunc = func(context.Context, cashcoupons.QueryCallbackRequest) (*cashcoupons.Callback, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SetCallback 记录调用并返回 SetCallbackFunc 的结果
+func (f *FakeCallBackUrlAPI) SetCallback(ctx context.Context, req cashcoupons.SetCallbackRequest) (resp *cashcoupons.SetCallbackResponse, result *core.APIResult, err error) {
+	f.Record("SetCallback", req)
+	if f.SetCallbackFunc == nil {
+		err = servicetest.NotStubbed("CallBackUrlAPI", "SetCallback")
+		return
+	}
+	return f.SetCallbackFunc(ctx, req)
+}
+
+// SetCallbackReturns 设置 SetCallback 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCallBackUrlAPI) SetCallbackReturns(resp *cashcoupons.SetCallbackResponse, err error) *FakeCallBackUrlAPI {
+	f.SetCallbackFunc = func(context.Context, cashcoupons.SetCallbackRequest) (*cashcoupons.SetCallbackResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeCouponAPI cashcoupons.CouponAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCouponAPI struct {
+	servicetest.Recorder
+
+	ListCouponsByFilterFunc func(context.Context, cashcoupons.ListCouponsByFilterRequest) (*cashcoupons.CouponCollection, *core.APIResult, error)
+	QueryCouponFunc         func(context.Context, cashcoupons.QueryCouponRequest) (*cashcoupons.Coupon, *core.APIResult, error)
+	SendCouponFunc          func(context.Context, cashcoupons.SendCouponRequest) (*cashcoupons.SendCouponResponse, *core.APIResult, error)
+}
+
+var _ cashcoupons.CouponAPI = (*FakeCouponAPI)(nil)
+
+// ListCouponsByFilter 记录调用并返回 ListCouponsByFilterFunc 的结果
+func (f *FakeCouponAPI) ListCouponsByFilter(ctx context.Context, req cashcoupons.ListCouponsByFilterRequest) (resp *cashcoupons.CouponCollection, result *core.APIResult, err error) {
+	f.Record("ListCouponsByFilter", req)
+	if f.ListCouponsByFilterFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "ListCouponsByFilter")
+		return
+	}
+	return f.ListCouponsByFilterFunc(ctx, req)
+}
+
+// ListCouponsByFilterReturns 设置 ListCouponsByFilter 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) ListCouponsByFilterReturns(resp *cashcoupons.CouponCollection, err error) *FakeCouponAPI {
+	f.ListCouponsByFilterFunc = func(context.Context, cashcoupons.ListCouponsByFilterRequest) (*cashcoupons.CouponCollection, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryCoupon 记录调用并返回 QueryCouponFunc 的结果
+func (f *FakeCouponAPI) QueryCoupon(ctx context.Context, req cashcoupons.QueryCouponRequest) (resp *cashcoupons.Coupon, result *core.APIResult, err error) {
+	f.Record("QueryCoupon", req)
+	if f.QueryCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "QueryCoupon")
+		return
+	}
+	return f.QueryCouponFunc(ctx, req)
+}
+
+// QueryCouponReturns 设置 QueryCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) QueryCouponReturns(resp *cashcoupons.Coupon, err error) *FakeCouponAPI {
+	f.QueryCouponFunc = func(context.Context, cashcoupons.QueryCouponRequest) (*cashcoupons.Coupon, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SendCoupon 记录调用并返回 SendCouponFunc 的结果
+func (f *FakeCouponAPI) SendCoupon(ctx context.Context, req cashcoupons.SendCouponRequest) (resp *cashcoupons.SendCouponResponse, result *core.APIResult, err error) {
+	f.Record("SendCoupon", req)
+	if f.SendCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "SendCoupon")
+		return
+	}
+	return f.SendCouponFunc(ctx, req)
+}
+
+// SendCouponReturns 设置 SendCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) SendCouponReturns(resp *cashcoupons.SendCouponResponse, err error) *FakeCouponAPI {
+	f.SendCouponFunc = func(context.Context, cashcoupons.SendCouponRequest) (*cashcoupons.SendCouponResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeStockAPI cashcoupons.StockAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeStockAPI struct {
+	servicetest.Recorder
+
+	CreateCouponStockFunc        func(context.Context, cashcoupons.CreateCouponStockRequest) (*cashcoupons.CreateCouponStockResponse, *core.APIResult, error)
+	ListAvailableMerchantsFunc   func(context.Context, cashcoupons.ListAvailableMerchantsRequest) (*cashcoupons.AvailableMerchantCollection, *core.APIResult, error)
+	ListAvailableSingleitemsFunc func(context.Context, cashcoupons.ListAvailableSingleitemsRequest) (*cashcoupons.AvailableSingleitemCollection, *core.APIResult, error)
+	ListStocksFunc               func(context.Context, cashcoupons.ListStocksRequest) (*cashcoupons.StockCollection, *core.APIResult, error)
+	PauseStockFunc               func(context.Context, cashcoupons.PauseStockRequest) (*cashcoupons.PauseStockResponse, *core.APIResult, error)
+	QueryStockFunc               func(context.Context, cashcoupons.QueryStockRequest) (*cashcoupons.Stock, *core.APIResult, error)
+	RefundFlowFunc               func(context.Context, cashcoupons.RefundFlowRequest) (*cashcoupons.RefundFlowResponse, *core.APIResult, error)
+	RestartStockFunc             func(context.Context, cashcoupons.RestartStockRequest) (*cashcoupons.RestartStockResponse, *core.APIResult, error)
+	StartStockFunc               func(context.Context, cashcoupons.StartStockRequest) (*cashcoupons.StartStockResponse, *core.APIResult, error)
+	StopStockFunc                func(context.Context, cashcoupons.StopStockRequest) (*cashcoupons.StopStockResponse, *core.APIResult, error)
+	UseFlowFunc                  func(context.Context, cashcoupons.UseFlowRequest) (*cashcoupons.UseFlowResponse, *core.APIResult, error)
+}
+
+var _ cashcoupons.StockAPI = (*FakeStockAPI)(nil)
+
+// CreateCouponStock 记录调用并返回 CreateCouponStockFunc 的结果
+func (f *FakeStockAPI) CreateCouponStock(ctx context.Context, req cashcoupons.CreateCouponStockRequest) (resp *cashcoupons.CreateCouponStockResponse, result *core.APIResult, err error) {
+	f.Record("CreateCouponStock", req)
+	if f.CreateCouponStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "CreateCouponStock")
+		return
+	}
+	return f.CreateCouponStockFunc(ctx, req)
+}
+
+// CreateCouponStockReturns 设置 CreateCouponStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) CreateCouponStockReturns(resp *cashcoupons.CreateCouponStockResponse, err error) *FakeStockAPI {
+	f.CreateCouponStockFunc = func(context.Context, cashcoupons.CreateCouponStockRequest) (*cashcoupons.CreateCouponStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListAvailableMerchants 记录调用并返回 ListAvailableMerchantsFunc 的结果
+func (f *FakeStockAPI) ListAvailableMerchants(ctx context.Context, req cashcoupons.ListAvailableMerchantsRequest) (resp *cashcoupons.AvailableMerchantCollection, result *core.APIResult, err error) {
+	f.Record("ListAvailableMerchants", req)
+	if f.ListAvailableMerchantsFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "ListAvailableMerchants")
+		return
+	}
+	return f.ListAvailableMerchantsFunc(ctx, req)
+}
+
+// ListAvailableMerchantsReturns 设置 ListAvailableMerchants 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) ListAvailableMerchantsReturns(resp *cashcoupons.AvailableMerchantCollection, err error) *FakeStockAPI {
+	f.ListAvailableMerchantsFunc = func(context.Context, cashcoupons.ListAvailableMerchantsRequest) (*cashcoupons.AvailableMerchantCollection, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListAvailableSingleitems 记录调用并返回 ListAvailableSingleitemsFunc 的结果
+func (f *FakeStockAPI) ListAvailableSingleitems(ctx context.Context, req cashcoupons.ListAvailableSingleitemsRequest) (resp *cashcoupons.AvailableSingleitemCollection, result *core.APIResult, err error) {
+	f.Record("ListAvailableSingleitems", req)
+	if f.ListAvailableSingleitemsFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "ListAvailableSingleitems")
+		return
+	}
+	return f.ListAvailableSingleitemsFunc(ctx, req)
+}
+
+// ListAvailableSingleitemsReturns 设置 ListAvailableSingleitems 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) ListAvailableSingleitemsReturns(resp *cashcoupons.AvailableSingleitemCollection, err error) *FakeStockAPI {
+	f.ListAvailableSingleitemsFunc = func(context.Context, cashcoupons.ListAvailableSingleitemsRequest) (*cashcoupons.AvailableSingleitemCollection, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListStocks 记录调用并返回 ListStocksFunc 的结果
+func (f *FakeStockAPI) ListStocks(ctx context.Context, req cashcoupons.ListStocksRequest) (resp *cashcoupons.StockCollection, result *core.APIResult, err error) {
+	f.Record("ListStocks", req)
+	if f.ListStocksFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "ListStocks")
+		return
+	}
+	return f.ListStocksFunc(ctx, req)
+}
+
+// ListStocksReturns 设置 ListStocks 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) ListStocksReturns(resp *cashcoupons.StockCollection, err error) *FakeStockAPI {
+	f.ListStocksFunc = func(context.Context, cashcoupons.ListStocksRequest) (*cashcoupons.StockCollection, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PauseStock 记录调用并返回 PauseStockFunc 的结果
+func (f *FakeStockAPI) PauseStock(ctx context.Context, req cashcoupons.PauseStockRequest) (resp *cashcoupons.PauseStockResponse, result *core.APIResult, err error) {
+	f.Record("PauseStock", req)
+	if f.PauseStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "PauseStock")
+		return
+	}
+	return f.PauseStockFunc(ctx, req)
+}
+
+// PauseStockReturns 设置 PauseStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) PauseStockReturns(resp *cashcoupons.PauseStockResponse, err error) *FakeStockAPI {
+	f.PauseStockFunc = func(context.Context, cashcoupons.PauseStockRequest) (*cashcoupons.PauseStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryStock 记录调用并返回 QueryStockFunc 的结果
+func (f *FakeStockAPI) QueryStock(ctx context.Context, req cashcoupons.QueryStockRequest) (resp *cashcoupons.Stock, result *core.APIResult, err error) {
+	f.Record("QueryStock", req)
+	if f.QueryStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "QueryStock")
+		return
+	}
+	return f.QueryStockFunc(ctx, req)
+}
+
+// QueryStockReturns 设置 QueryStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) QueryStockReturns(resp *cashcoupons.Stock, err error) *FakeStockAPI {
+	f.QueryStockFunc = func(context.Context, cashcoupons.QueryStockRequest) (*cashcoupons.Stock, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// RefundFlow 记录调用并返回 RefundFlowFunc 的结果
+func (f *FakeStockAPI) RefundFlow(ctx context.Context, req cashcoupons.RefundFlowRequest) (resp *cashcoupons.RefundFlowResponse, result *core.APIResult, err error) {
+	f.Record("RefundFlow", req)
+	if f.RefundFlowFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "RefundFlow")
+		return
+	}
+	return f.RefundFlowFunc(ctx, req)
+}
+
+// RefundFlowReturns 设置 RefundFlow 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) RefundFlowReturns(resp *cashcoupons.RefundFlowResponse, err error) *FakeStockAPI {
+	f.RefundFlowFunc = func(context.Context, cashcoupons.RefundFlowRequest) (*cashcoupons.RefundFlowResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// RestartStock 记录调用并返回 RestartStockFunc 的结果
+func (f *FakeStockAPI) RestartStock(ctx context.Context, req cashcoupons.RestartStockRequest) (resp *cashcoupons.RestartStockResponse, result *core.APIResult, err error) {
+	f.Record("RestartStock", req)
+	if f.RestartStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "RestartStock")
+		return
+	}
+	return f.RestartStockFunc(ctx, req)
+}
+
+// RestartStockReturns 设置 RestartStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) RestartStockReturns(resp *cashcoupons.RestartStockResponse, err error) *FakeStockAPI {
+	f.RestartStockFunc = func(context.Context, cashcoupons.RestartStockRequest) (*cashcoupons.RestartStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// StartStock 记录调用并返回 StartStockFunc 的结果
+func (f *FakeStockAPI) StartStock(ctx context.Context, req cashcoupons.StartStockRequest) (resp *cashcoupons.StartStockResponse, result *core.APIResult, err error) {
+	f.Record("StartStock", req)
+	if f.StartStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "StartStock")
+		return
+	}
+	return f.StartStockFunc(ctx, req)
+}
+
+// StartStockReturns 设置 StartStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) StartStockReturns(resp *cashcoupons.StartStockResponse, err error) *FakeStockAPI {
+	f.StartStockFunc = func(context.Context, cashcoupons.StartStockRequest) (*cashcoupons.StartStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// StopStock 记录调用并返回 StopStockFunc 的结果
+func (f *FakeStockAPI) StopStock(ctx context.Context, req cashcoupons.StopStockRequest) (resp *cashcoupons.StopStockResponse, result *core.APIResult, err error) {
+	f.Record("StopStock", req)
+	if f.StopStockFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "StopStock")
+		return
+	}
+	return f.StopStockFunc(ctx, req)
+}
+
+// StopStockReturns 设置 StopStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) StopStockReturns(resp *cashcoupons.StopStockResponse, err error) *FakeStockAPI {
+	f.StopStockFunc = func(context.Context, cashcoupons.StopStockRequest) (*cashcoupons.StopStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// UseFlow 记录调用并返回 UseFlowFunc 的结果
+func (f *FakeStockAPI) UseFlow(ctx context.Context, req cashcoupons.UseFlowRequest) (resp *cashcoupons.UseFlowResponse, result *core.APIResult, err error) {
+	f.Record("UseFlow", req)
+	if f.UseFlowFunc == nil {
+		err = servicetest.NotStubbed("StockAPI", "UseFlow")
+		return
+	}
+	return f.UseFlowFunc(ctx, req)
+}
+
+// UseFlowReturns 设置 UseFlow 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStockAPI) UseFlowReturns(resp *cashcoupons.UseFlowResponse, err error) *FakeStockAPI {
+	f.UseFlowFunc = func(context.Context, cashcoupons.UseFlowRequest) (*cashcoupons.UseFlowResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package cashcoupons
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// CallBackUrlAPI CallBackUrlApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CallBackUrlApiService，即可在测试中使用 cashcouponstest.FakeCallBackUrlAPI 替代
+type CallBackUrlAPI interface {
+	// QueryCallback 查询代金券消息通知地址
+	QueryCallback(ctx context.Context, req QueryCallbackRequest) (resp *Callback, result *core.APIResult, err error)
+
+	// SetCallback 设置代金券消息通知地址
+	SetCallback(ctx context.Context, req SetCallbackRequest) (resp *SetCallbackResponse, result *core.APIResult, err error)
+}
+
+// CouponAPI CouponApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CouponApiService，即可在测试中使用 cashcouponstest.FakeCouponAPI 替代
+type CouponAPI interface {
+	// ListCouponsByFilter 根据过滤条件查询用户的券
+	ListCouponsByFilter(ctx context.Context, req ListCouponsByFilterRequest) (resp *CouponCollection, result *core.APIResult, err error)
+
+	// QueryCoupon 查询代金券详情
+	QueryCoupon(ctx context.Context, req QueryCouponRequest) (resp *Coupon, result *core.APIResult, err error)
+
+	// SendCoupon 发放指定批次的代金券
+	SendCoupon(ctx context.Context, req SendCouponRequest) (resp *SendCouponResponse, result *core.APIResult, err error)
+}
+
+// StockAPI StockApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *StockApiService，即可在测试中使用 cashcouponstest.FakeStockAPI 替代
+type StockAPI interface {
+	// CreateCouponStock 创建代金券批次
+	CreateCouponStock(ctx context.Context, req CreateCouponStockRequest) (resp *CreateCouponStockResponse, result *core.APIResult, err error)
+
+	// ListAvailableMerchants 查询代金券可用商户
+	ListAvailableMerchants(ctx context.Context, req ListAvailableMerchantsRequest) (resp *AvailableMerchantCollection, result *core.APIResult, err error)
+
+	// ListAvailableSingleitems 查询可核销商品编码
+	ListAvailableSingleitems(ctx context.Context, req ListAvailableSingleitemsRequest) (resp *AvailableSingleitemCollection, result *core.APIResult, err error)
+
+	// ListStocks 条件查询批次列表
+	ListStocks(ctx context.Context, req ListStocksRequest) (resp *StockCollection, result *core.APIResult, err error)
+
+	// PauseStock 暂停批次
+	PauseStock(ctx context.Context, req PauseStockRequest) (resp *PauseStockResponse, result *core.APIResult, err error)
+
+	// QueryStock 查询批次详情
+	QueryStock(ctx context.Context, req QueryStockRequest) (resp *Stock, result *core.APIResult, err error)
+
+	// RefundFlow 下载批次退款明细
+	RefundFlow(ctx context.Context, req RefundFlowRequest) (resp *RefundFlowResponse, result *core.APIResult, err error)
+
+	// RestartStock 重启批次
+	RestartStock(ctx context.Context, req RestartStockRequest) (resp *RestartStockResponse, result *core.APIResult, err error)
+
+	// StartStock 激活开启批次
+	StartStock(ctx context.Context, req StartStockRequest) (resp *StartStockResponse, result *core.APIResult, err error)
+
+	// StopStock 终止批次
+	StopStock(ctx context.Context, req StopStockRequest) (resp *StopStockResponse, result *core.APIResult, err error)
+
+	// UseFlow 下载批次核销明细
+	UseFlow(ctx context.Context, req UseFlowRequest) (resp *UseFlowResponse, result *core.APIResult, err error)
+}
+
+var (
+	_ CallBackUrlAPI = (*CallBackUrlApiService)(nil)
+	_ CouponAPI      = (*CouponApiService)(nil)
+	_ StockAPI       = (*StockApiService)(nil)
+)
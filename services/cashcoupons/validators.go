@@ -0,0 +1,243 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package cashcoupons
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+)
+
+// Validate 按接口文档中的约束校验 CardLimitation 的字段
+func (o CardLimitation) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CardLimitation", opts...)
+	c.Required("name", o.Name != nil)
+	c.Required("bin", o.Bin != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CouponRule 的字段
+func (o CouponRule) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CouponRule", opts...)
+	if o.CouponAvailableTime != nil {
+		c.Nested("coupon_available_time", o.CouponAvailableTime.Validate(opts...))
+	}
+	if o.FixedNormalCoupon != nil {
+		c.Nested("fixed_normal_coupon", o.FixedNormalCoupon.Validate(opts...))
+	}
+	for i := range o.TradeType {
+		c.Enum(validation.Index("trade_type", i), (*string)(&o.TradeType[i]), "MICROAPP", "APPPAY", "PPAY", "CARD", "FACE", "OTHER")
+	}
+	if o.LimitCard != nil {
+		c.Nested("limit_card", o.LimitCard.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateCouponStockRequest 的字段
+func (o CreateCouponStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateCouponStockRequest", opts...)
+	c.Required("stock_name", o.StockName != nil)
+	c.Required("belong_merchant", o.BelongMerchant != nil)
+	c.Required("available_begin_time", o.AvailableBeginTime != nil)
+	c.Required("available_end_time", o.AvailableEndTime != nil)
+	c.Required("stock_use_rule", o.StockUseRule != nil)
+	if o.StockUseRule != nil {
+		c.Nested("stock_use_rule", o.StockUseRule.Validate(opts...))
+	}
+	if o.PatternInfo != nil {
+		c.Nested("pattern_info", o.PatternInfo.Validate(opts...))
+	}
+	c.Required("coupon_use_rule", o.CouponUseRule != nil)
+	if o.CouponUseRule != nil {
+		c.Nested("coupon_use_rule", o.CouponUseRule.Validate(opts...))
+	}
+	c.Required("no_cash", o.NoCash != nil)
+	c.Required("stock_type", o.StockType != nil)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FavorAvailableTime 的字段
+func (o FavorAvailableTime) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FavorAvailableTime", opts...)
+	if o.FixAvailableTime != nil {
+		c.Nested("fix_available_time", o.FixAvailableTime.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FixedAvailableTime 的字段
+func (o FixedAvailableTime) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FixedAvailableTime", opts...)
+	c.Required("begin_time", o.BeginTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FixedValueStockMsg 的字段
+func (o FixedValueStockMsg) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FixedValueStockMsg", opts...)
+	c.Required("coupon_amount", o.CouponAmount != nil)
+	c.Required("transaction_minimum", o.TransactionMinimum != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListAvailableMerchantsRequest 的字段
+func (o ListAvailableMerchantsRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListAvailableMerchantsRequest", opts...)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListAvailableSingleitemsRequest 的字段
+func (o ListAvailableSingleitemsRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListAvailableSingleitemsRequest", opts...)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListCouponsByFilterRequest 的字段
+func (o ListCouponsByFilterRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListCouponsByFilterRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListStocksRequest 的字段
+func (o ListStocksRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListStocksRequest", opts...)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PatternInfo 的字段
+func (o PatternInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PatternInfo", opts...)
+	c.Required("description", o.Description != nil)
+	c.Enum("background_color", (*string)(o.BackgroundColor), "COLOR010", "COLOR020", "COLOR030", "COLOR040", "COLOR050", "COLOR060", "COLOR070", "COLOR080", "COLOR081", "COLOR082", "COLOR090", "COLOR100", "COLOR101", "COLOR102")
+	c.Enum("jump_target", (*string)(o.JumpTarget), "PAYMENT_CODE", "MINI_PROGRAM", "DEFAULT_PAGE")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PauseStockRequest 的字段
+func (o PauseStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PauseStockRequest", opts...)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryCallbackRequest 的字段
+func (o QueryCallbackRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryCallbackRequest", opts...)
+	c.Required("mchid", o.Mchid != nil)
+	c.Length("mchid", o.Mchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryCouponRequest 的字段
+func (o QueryCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryCouponRequest", opts...)
+	c.Required("coupon_id", o.CouponId != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryStockRequest 的字段
+func (o QueryStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryStockRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 RefundFlowRequest 的字段
+func (o RefundFlowRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("RefundFlowRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 RestartStockRequest 的字段
+func (o RestartStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("RestartStockRequest", opts...)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SendCouponRequest 的字段
+func (o SendCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SendCouponRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SetCallbackRequest 的字段
+func (o SetCallbackRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SetCallbackRequest", opts...)
+	c.Required("mchid", o.Mchid != nil)
+	c.Length("mchid", o.Mchid, 0, 32)
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 StartStockRequest 的字段
+func (o StartStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("StartStockRequest", opts...)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 StockRule 的字段
+func (o StockRule) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("StockRule", opts...)
+	c.Required("max_coupons", o.MaxCoupons != nil)
+	c.Required("max_amount", o.MaxAmount != nil)
+	c.Required("max_coupons_per_user", o.MaxCouponsPerUser != nil)
+	c.Required("natural_person_limit", o.NaturalPersonLimit != nil)
+	c.Required("prevent_api_abuse", o.PreventApiAbuse != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 StopStockRequest 的字段
+func (o StopStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("StopStockRequest", opts...)
+	c.Required("stock_creator_mchid", o.StockCreatorMchid != nil)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 UseFlowRequest 的字段
+func (o UseFlowRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("UseFlowRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package certificatestest 提供 certificates 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package certificatestest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/certificates"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeCertificatesAPI certificates.CertificatesAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCertificatesAPI struct {
+	servicetest.Recorder
+
+	DownloadCertificatesFunc func(context.Context) (*certificates.DownloadCertificatesResponse, *core.APIResult, error)
+}
+
+var _ certificates.CertificatesAPI = (*FakeCertificatesAPI)(nil)
+
+// DownloadCertificates 记录调用并返回 DownloadCertificatesFunc 的结果
+func (f *FakeCertificatesAPI) DownloadCertificates(ctx context.Context) (resp *certificates.DownloadCertificatesResponse, result *core.APIResult, err error) {
+	f.Record("DownloadCertificates", nil)
+	if f.DownloadCertificatesFunc == nil {
+		err = servicetest.NotStubbed("CertificatesAPI", "DownloadCertificates")
+		return
+	}
+	return f.DownloadCertificatesFunc(ctx)
+}
+
+// DownloadCertificatesReturns 设置 DownloadCertificates 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCertificatesAPI) DownloadCertificatesReturns(resp *certificates.DownloadCertificatesResponse, err error) *FakeCertificatesAPI {
+	f.DownloadCertificatesFunc = func(context.Context) (*certificates.DownloadCertificatesResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
@@ -0,0 +1,21 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package certificates
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// CertificatesAPI CertificatesApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CertificatesApiService，即可在测试中使用 certificatestest.FakeCertificatesAPI 替代
+type CertificatesAPI interface {
+	// DownloadCertificates 获取平台证书列表
+	DownloadCertificates(ctx context.Context) (resp *DownloadCertificatesResponse, result *core.APIResult, err error)
+}
+
+var _ CertificatesAPI = (*CertificatesApiService)(nil)
@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // Certificate 微信支付平台证书信息
@@ -39,12 +41,12 @@ func (o Certificate) MarshalJSON() ([]byte, error) {
 	if o.EffectiveTime == nil {
 		return nil, fmt.Errorf("field `EffectiveTime` is required and must be specified in Certificate")
 	}
-	toSerialize["effective_time"] = o.EffectiveTime.Format(time.RFC3339)
+	toSerialize["effective_time"] = chinatime.Format(*o.EffectiveTime)
 
 	if o.ExpireTime == nil {
 		return nil, fmt.Errorf("field `ExpireTime` is required and must be specified in Certificate")
 	}
-	toSerialize["expire_time"] = o.ExpireTime.Format(time.RFC3339)
+	toSerialize["expire_time"] = chinatime.Format(*o.ExpireTime)
 
 	if o.EncryptCertificate == nil {
 		return nil, fmt.Errorf("field `EncryptCertificate` is required and must be specified in Certificate")
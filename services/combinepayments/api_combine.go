@@ -0,0 +1,91 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package combinepayments
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services"
+)
+
+// CombineApiService 合单支付 API 服务
+type CombineApiService services.Service
+
+// prepayRequired 合单下单请求的必填字段
+var prepayRequired = []string{"CombineAppid", "CombineMchid", "CombineOutTradeNo", "SubOrders", "NotifyUrl"}
+
+var (
+	appPrepay = &core.Operation[PrepayRequest, PrepayResponse]{
+		Method: http.MethodPost, Path: "/v3/combine-transactions/app", Body: true, Required: prepayRequired,
+	}
+	h5Prepay = &core.Operation[PrepayRequest, H5PrepayResponse]{
+		Method: http.MethodPost, Path: "/v3/combine-transactions/h5", Body: true, Required: prepayRequired,
+	}
+	jsapiPrepay = &core.Operation[PrepayRequest, PrepayResponse]{
+		Method: http.MethodPost, Path: "/v3/combine-transactions/jsapi", Body: true, Required: prepayRequired,
+	}
+	nativePrepay = &core.Operation[PrepayRequest, NativePrepayResponse]{
+		Method: http.MethodPost, Path: "/v3/combine-transactions/native", Body: true, Required: prepayRequired,
+	}
+	queryOrder = &core.Operation[QueryOrderRequest, CombineTransaction]{
+		Method: http.MethodGet,
+		Path:   "/v3/combine-transactions/out-trade-no/{combine_out_trade_no}",
+		Params: []core.Param{
+			{Name: "combine_out_trade_no", Field: "CombineOutTradeNo", In: core.InPath, Required: true},
+		},
+	}
+	closeOrder = &core.Operation[CloseOrderRequest, struct{}]{
+		Method: http.MethodPost,
+		Path:   "/v3/combine-transactions/out-trade-no/{combine_out_trade_no}/close",
+		Params: []core.Param{
+			{Name: "combine_out_trade_no", Field: "CombineOutTradeNo", In: core.InPath, Required: true},
+		},
+		Body:     true,
+		Required: []string{"CombineAppid", "SubOrders"},
+	}
+)
+
+// AppPrepay 合单APP下单
+//
+// 合单支付下单，一次下单最多包含 50 个子单，子单可以属于不同的商户（服务商模式下为不同的二级商户）。
+func (a *CombineApiService) AppPrepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error) {
+	return core.Do(ctx, a.Client, appPrepay, req)
+}
+
+// CloseOrder 合单关闭订单
+//
+// 合单支付订单只能使用此合单关单 API 完成关单，子单需全部列出。
+func (a *CombineApiService) CloseOrder(ctx context.Context, req CloseOrderRequest) (result *core.APIResult, err error) {
+	_, result, err = core.Do(ctx, a.Client, closeOrder, req)
+	return result, err
+}
+
+// H5Prepay 合单H5下单
+//
+// 合单支付下单，一次下单最多包含 50 个子单，子单可以属于不同的商户（服务商模式下为不同的二级商户）。
+func (a *CombineApiService) H5Prepay(ctx context.Context, req PrepayRequest) (resp *H5PrepayResponse, result *core.APIResult, err error) {
+	return core.Do(ctx, a.Client, h5Prepay, req)
+}
+
+// JsapiPrepay 合单JSAPI下单
+//
+// 合单支付下单，一次下单最多包含 50 个子单，子单可以属于不同的商户（服务商模式下为不同的二级商户）。
+func (a *CombineApiService) JsapiPrepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error) {
+	return core.Do(ctx, a.Client, jsapiPrepay, req)
+}
+
+// NativePrepay 合单Native下单
+//
+// 合单支付下单，一次下单最多包含 50 个子单，子单可以属于不同的商户（服务商模式下为不同的二级商户）。
+func (a *CombineApiService) NativePrepay(ctx context.Context, req PrepayRequest) (resp *NativePrepayResponse, result *core.APIResult, err error) {
+	return core.Do(ctx, a.Client, nativePrepay, req)
+}
+
+// QueryOrder 合单查询订单
+//
+// 电商平台通过合单查询订单 API 查询订单状态，完成下一步的业务逻辑。
+func (a *CombineApiService) QueryOrder(ctx context.Context, req QueryOrderRequest) (resp *CombineTransaction, result *core.APIResult, err error) {
+	return core.Do(ctx, a.Client, queryOrder, req)
+}
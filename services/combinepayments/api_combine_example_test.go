@@ -0,0 +1,75 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package combinepayments_test
+
+import (
+	"context"
+	"log"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services/combinepayments"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+func ExampleCombineApiService_JsapiPrepayWithRequestPayment() {
+	var (
+		mchID                      string = "190000****"                               // 商户号
+		mchCertificateSerialNumber string = "3775************************************" // 商户证书序列号
+		mchAPIv3Key                string = "2ab9****************************"         // 商户APIv3密钥
+	)
+
+	// 使用 utils 提供的函数从本地文件中加载商户私钥，商户私钥会用来生成请求的签名
+	mchPrivateKey, err := utils.LoadPrivateKeyWithPath("/path/to/merchant/apiclient_key.pem")
+	if err != nil {
+		log.Print("load merchant private key error")
+	}
+
+	ctx := context.Background()
+	// 使用商户私钥等初始化 client，并使它具有自动定时获取微信支付平台证书的能力
+	opts := []core.ClientOption{
+		option.WithWechatPayAutoAuthCipher(mchID, mchCertificateSerialNumber, mchPrivateKey, mchAPIv3Key),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	if err != nil {
+		log.Printf("new wechat pay client err:%s", err)
+	}
+
+	svc := combinepayments.CombineApiService{Client: client}
+	// 一次结账拆分为属于不同二级商户的多个子单
+	resp, result, err := svc.JsapiPrepayWithRequestPayment(ctx,
+		combinepayments.PrepayRequest{
+			CombineAppid:      core.String("wxd678efh567hg6787"),
+			CombineMchid:      core.String(mchID),
+			CombineOutTradeNo: core.String("P20150806125346"),
+			SubOrders: []combinepayments.SubOrder{
+				{
+					Mchid:       core.String(mchID),
+					SubMchid:    core.String("1230000109"),
+					Attach:      core.String("深圳分店"),
+					Amount:      &combinepayments.Amount{TotalAmount: core.Int64(10), Currency: core.String("CNY")},
+					OutTradeNo:  core.String("20150806125346"),
+					Description: core.String("腾讯充值中心-QQ会员充值"),
+				},
+				{
+					Mchid:       core.String(mchID),
+					SubMchid:    core.String("1230000110"),
+					Attach:      core.String("广州分店"),
+					Amount:      &combinepayments.Amount{TotalAmount: core.Int64(20), Currency: core.String("CNY")},
+					OutTradeNo:  core.String("20150806125347"),
+					Description: core.String("腾讯充值中心-QQ会员充值"),
+				},
+			},
+			CombinePayerInfo: &combinepayments.CombinePayerInfo{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+			NotifyUrl:        core.String("https://yourapp.com/notify"),
+		},
+	)
+
+	if err != nil {
+		// 处理错误
+		log.Printf("call JsapiPrepayWithRequestPayment err:%s", err)
+	} else {
+		// 处理返回结果
+		log.Printf("status=%d resp=%s", result.Response.StatusCode, resp)
+	}
+}
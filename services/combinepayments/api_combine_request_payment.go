@@ -0,0 +1,172 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package combinepayments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/requestpayment"
+)
+
+// JsapiPrepayWithRequestPaymentResponse 预下单ID，并包含了调起支付的请求参数
+type JsapiPrepayWithRequestPaymentResponse struct {
+	// 预支付交易会话标识
+	PrepayId *string `json:"prepay_id"` // revive:disable-line:var-naming
+	// 应用ID
+	Appid *string `json:"appId"`
+	// 时间戳
+	TimeStamp *string `json:"timeStamp"`
+	// 随机字符串
+	NonceStr *string `json:"nonceStr"`
+	// 订单详情扩展字符串
+	Package *string `json:"package"`
+	// 签名方式
+	SignType *string `json:"signType"`
+	// 签名
+	PaySign *string `json:"paySign"`
+}
+
+// JsapiPrepayWithRequestPayment 合单JSAPI下单，并返回调起支付的请求参数，调起支付使用合单发起方的appid
+func (a *CombineApiService) JsapiPrepayWithRequestPayment(
+	ctx context.Context, req PrepayRequest,
+) (resp *JsapiPrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	prepayResp, result, err := a.JsapiPrepay(ctx, req)
+	if err != nil {
+		return nil, result, err
+	}
+
+	// CombineAppid 与 CombineMchid 为必填字段，JsapiPrepay 与 AppPrepay 在发出请求前已校验
+	payment, err := (&requestpayment.Builder{Client: a.Client}).Jsapi(ctx, *req.CombineAppid, *prepayResp.PrepayId)
+	if err != nil {
+		return nil, result, err
+	}
+	return &JsapiPrepayWithRequestPaymentResponse{
+		PrepayId:  prepayResp.PrepayId,
+		Appid:     payment.Appid,
+		TimeStamp: payment.TimeStamp,
+		NonceStr:  payment.NonceStr,
+		Package:   payment.Package,
+		SignType:  payment.SignType,
+		PaySign:   payment.PaySign,
+	}, result, nil
+}
+
+func (o JsapiPrepayWithRequestPaymentResponse) String() string {
+	var ret string
+	if o.PrepayId == nil {
+		ret += "PrepayId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PrepayId:%v, ", *o.PrepayId)
+	}
+	if o.Appid == nil {
+		ret += "Appid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Appid:%v, ", *o.Appid)
+	}
+	if o.TimeStamp == nil {
+		ret += "TimeStamp:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TimeStamp:%v, ", *o.TimeStamp)
+	}
+	if o.NonceStr == nil {
+		ret += "NonceStr:<nil>, "
+	} else {
+		ret += fmt.Sprintf("NonceStr:%v, ", *o.NonceStr)
+	}
+	if o.Package == nil {
+		ret += "Package:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Package:%v, ", *o.Package)
+	}
+	if o.SignType == nil {
+		ret += "SignType:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SignType:%v, ", *o.SignType)
+	}
+	if o.PaySign == nil {
+		ret += "PaySign:<nil>"
+	} else {
+		ret += fmt.Sprintf("PaySign:%v", *o.PaySign)
+	}
+
+	return fmt.Sprintf("JsapiPrepayWithRequestPaymentResponse{%s}", ret)
+}
+
+// AppPrepayWithRequestPaymentResponse 预下单ID，并包含了调起支付的请求参数
+type AppPrepayWithRequestPaymentResponse struct {
+	// 预支付交易会话标识
+	PrepayId *string `json:"prepayId"` // revive:disable-line:var-naming
+	// 商户号
+	PartnerId *string `json:"partnerId"` // revive:disable-line:var-naming
+	// 时间戳
+	TimeStamp *string `json:"timeStamp"`
+	// 随机字符串
+	NonceStr *string `json:"nonceStr"`
+	// 订单详情扩展字符串
+	Package *string `json:"package"`
+	// 签名
+	Sign *string `json:"sign"`
+}
+
+// AppPrepayWithRequestPayment 合单APP下单，并返回调起支付的请求参数，调起支付使用合单发起方的appid与商户号
+func (a *CombineApiService) AppPrepayWithRequestPayment(
+	ctx context.Context, req PrepayRequest,
+) (resp *AppPrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	prepayResp, result, err := a.AppPrepay(ctx, req)
+	if err != nil {
+		return nil, result, err
+	}
+
+	payment, err := (&requestpayment.Builder{Client: a.Client}).App(
+		ctx, *req.CombineAppid, *req.CombineMchid, *prepayResp.PrepayId,
+	)
+	if err != nil {
+		return nil, result, err
+	}
+	return &AppPrepayWithRequestPaymentResponse{
+		PrepayId:  payment.PrepayId,
+		PartnerId: payment.PartnerId,
+		TimeStamp: payment.TimeStamp,
+		NonceStr:  payment.NonceStr,
+		Package:   payment.Package,
+		Sign:      payment.Sign,
+	}, result, nil
+}
+
+func (o AppPrepayWithRequestPaymentResponse) String() string {
+	var ret string
+	if o.PrepayId == nil {
+		ret += "PrepayId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PrepayId:%v, ", *o.PrepayId)
+	}
+	if o.PartnerId == nil {
+		ret += "PartnerId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PartnerId:%v, ", *o.PartnerId)
+	}
+	if o.TimeStamp == nil {
+		ret += "TimeStamp:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TimeStamp:%v, ", *o.TimeStamp)
+	}
+	if o.NonceStr == nil {
+		ret += "NonceStr:<nil>, "
+	} else {
+		ret += fmt.Sprintf("NonceStr:%v, ", *o.NonceStr)
+	}
+	if o.Package == nil {
+		ret += "Package:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Package:%v, ", *o.Package)
+	}
+	if o.Sign == nil {
+		ret += "Sign:<nil>"
+	} else {
+		ret += fmt.Sprintf("Sign:%v", *o.Sign)
+	}
+
+	return fmt.Sprintf("AppPrepayWithRequestPaymentResponse{%s}", ret)
+}
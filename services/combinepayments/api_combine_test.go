@@ -0,0 +1,265 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package combinepayments_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/services/combinepayments"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type capturedRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+func newTestService(
+	t *testing.T, captured *capturedRequest, statusCode int, respBody string, opts ...core.ClientOption,
+) *combinepayments.CombineApiService {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		captured.method, captured.path, captured.body = req.Method, req.URL.Path, nil
+		if req.Body != nil {
+			data, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			if len(data) > 0 {
+				require.NoError(t, json.Unmarshal(data, &captured.body))
+			}
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{consts.ContentType: []string{consts.ApplicationJSON}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(respBody)),
+			Request:    req,
+		}, nil
+	})
+	client, err := core.NewClient(context.Background(), append([]core.ClientOption{
+		option.WithMerchantCredential("1900000109", "SERIAL", privateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	}, opts...)...)
+	require.NoError(t, err)
+	return &combinepayments.CombineApiService{Client: client}
+}
+
+func newPrepayRequest() combinepayments.PrepayRequest {
+	return combinepayments.PrepayRequest{
+		CombineAppid:      core.String("wxd678efh567hg6787"),
+		CombineMchid:      core.String("1900000109"),
+		CombineOutTradeNo: core.String("P20150806125346"),
+		SubOrders: []combinepayments.SubOrder{
+			{
+				Mchid:       core.String("1900000109"),
+				Attach:      core.String("深圳分店"),
+				Amount:      &combinepayments.Amount{TotalAmount: core.Int64(10), Currency: core.String("CNY")},
+				OutTradeNo:  core.String("20150806125346"),
+				SubMchid:    core.String("1230000109"),
+				Description: core.String("腾讯充值中心-QQ会员充值"),
+				SettleInfo:  &combinepayments.SettleInfo{ProfitSharing: core.Bool(true), SubsidyAmount: core.Int64(5)},
+			},
+			{
+				Mchid:       core.String("1900000109"),
+				Attach:      core.String("广州分店"),
+				Amount:      &combinepayments.Amount{TotalAmount: core.Int64(20), Currency: core.String("CNY")},
+				OutTradeNo:  core.String("20150806125347"),
+				SubMchid:    core.String("1230000110"),
+				Description: core.String("腾讯充值中心-QQ会员充值"),
+			},
+		},
+		CombinePayerInfo: &combinepayments.CombinePayerInfo{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+		NotifyUrl:        core.String("https://yourapp.com/notify"),
+	}
+}
+
+func TestCombineApiService_JsapiPrepayWithRequestPayment(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{"prepay_id":"wx201410272009395522657a690389285100"}`)
+
+	resp, result, err := svc.JsapiPrepayWithRequestPayment(context.Background(), newPrepayRequest())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.Equal(t, "wx201410272009395522657a690389285100", *resp.PrepayId)
+	assert.Equal(t, "wxd678efh567hg6787", *resp.Appid)
+	assert.Equal(t, "prepay_id=wx201410272009395522657a690389285100", *resp.Package)
+	assert.NotEmpty(t, *resp.PaySign)
+
+	assert.Equal(t, http.MethodPost, captured.method)
+	assert.Equal(t, "/v3/combine-transactions/jsapi", captured.path)
+	subOrders := captured.body["sub_orders"].([]interface{})
+	require.Len(t, subOrders, 2)
+	first := subOrders[0].(map[string]interface{})
+	assert.Equal(t, "1230000109", first["sub_mchid"])
+	assert.Equal(t, map[string]interface{}{"total_amount": float64(10), "currency": "CNY"}, first["amount"])
+	assert.Equal(t, map[string]interface{}{"profit_sharing": true, "subsidy_amount": float64(5)}, first["settle_info"])
+	assert.Equal(t, map[string]interface{}{"openid": "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}, captured.body["combine_payer_info"])
+}
+
+func TestCombineApiService_PrepayWithRequestPaymentMissingField(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{"prepay_id":"wx201410272009395522657a690389285100"}`)
+
+	req := newPrepayRequest()
+	req.CombineAppid = nil
+	resp, result, err := svc.JsapiPrepayWithRequestPayment(context.Background(), req)
+	assert.EqualError(t, err, "field `CombineAppid` is required and must be specified in PrepayRequest")
+	assert.Nil(t, resp)
+	assert.Nil(t, result)
+
+	req = newPrepayRequest()
+	req.CombineMchid = nil
+	_, _, err = svc.AppPrepayWithRequestPayment(context.Background(), req)
+	assert.EqualError(t, err, "field `CombineMchid` is required and must be specified in PrepayRequest")
+	assert.Empty(t, captured.path)
+}
+
+func TestCombineApiService_AppPrepayWithRequestPayment(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{"prepay_id":"wx201410272009395522657a690389285100"}`)
+
+	resp, _, err := svc.AppPrepayWithRequestPayment(context.Background(), newPrepayRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "/v3/combine-transactions/app", captured.path)
+	assert.Equal(t, "1900000109", *resp.PartnerId)
+	assert.Equal(t, "Sign=WXPay", *resp.Package)
+}
+
+func TestCombineApiService_Prepay(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{"h5_url":"https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb"}`)
+
+	req := newPrepayRequest()
+	req.SceneInfo = &combinepayments.SceneInfo{
+		PayerClientIp: core.String("14.23.150.211"),
+		H5Info:        &combinepayments.H5Info{Type: core.String("Wap")},
+	}
+	resp, _, err := svc.H5Prepay(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "/v3/combine-transactions/h5", captured.path)
+	assert.Equal(t, "https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb", *resp.H5Url)
+
+	// 缺少必填字段时不发起请求
+	req.SubOrders[1].Amount = nil
+	captured.path = ""
+	_, _, err = svc.NativePrepay(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field `Amount` is required and must be specified in SubOrder")
+	assert.Empty(t, captured.path)
+}
+
+func TestCombineApiService_PrepayWithRequestValidation(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`,
+		option.WithRequestValidation())
+
+	req := newPrepayRequest()
+	_, _, err := svc.NativePrepay(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "/v3/combine-transactions/native", captured.path)
+
+	// 合单支付的总订单号与子单商户订单号可以包含 @，且不限制最小长度
+	req.CombineOutTradeNo = core.String("P@2021")
+	req.SubOrders[0].OutTradeNo = core.String("S@1")
+	_, _, err = svc.NativePrepay(context.Background(), req)
+	require.NoError(t, err)
+
+	// 不符合约束的请求在签名前被拒绝，不发起请求
+	req.SubOrders[0].Attach = core.String(strings.Repeat("a", 129))
+	req.SubOrders[1].Amount.TotalAmount = core.Int64(0)
+	captured.path = ""
+	_, _, err = svc.NativePrepay(context.Background(), req)
+	validationErr, ok := validation.AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, "PrepayRequest", validationErr.Type)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "sub_orders[0].attach", Reason: "must be at most 128 bytes, got 129"},
+		{Field: "sub_orders[1].amount.total_amount", Reason: "must be at least 1, got 0"},
+	}, validationErr.Fields)
+	assert.Empty(t, captured.path)
+}
+
+func TestCombineApiService_QueryOrder(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusOK, `{
+		"combine_appid": "wxd678efh567hg6787",
+		"combine_mchid": "1900000109",
+		"combine_out_trade_no": "P20150806125346",
+		"sub_orders": [{
+			"mchid": "1900000109",
+			"trade_type": "JSAPI",
+			"trade_state": "SUCCESS",
+			"transaction_id": "4200000000000000000000000001",
+			"out_trade_no": "20150806125346",
+			"sub_mchid": "1230000109",
+			"amount": {"total_amount": 10, "currency": "CNY", "payer_amount": 10, "payer_currency": "CNY"},
+			"promotion_detail": [{"coupon_id": "109519", "amount": 1}]
+		}],
+		"combine_payer_info": {"openid": "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}
+	}`)
+
+	resp, _, err := svc.QueryOrder(context.Background(), combinepayments.QueryOrderRequest{
+		CombineOutTradeNo: core.String("P20150806125346"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodGet, captured.method)
+	assert.Equal(t, "/v3/combine-transactions/out-trade-no/P20150806125346", captured.path)
+	require.Len(t, resp.SubOrders, 1)
+	subOrder := resp.SubOrders[0]
+	assert.Equal(t, "SUCCESS", *subOrder.TradeState)
+	assert.Equal(t, int64(10), *subOrder.Amount.PayerAmount)
+	assert.Equal(t, "109519", *subOrder.PromotionDetail[0].CouponId)
+
+	clone := resp.Clone()
+	*clone.SubOrders[0].TradeState = "REFUND"
+	assert.Equal(t, "SUCCESS", *resp.SubOrders[0].TradeState)
+}
+
+func TestCombineApiService_CloseOrder(t *testing.T) {
+	captured := &capturedRequest{}
+	svc := newTestService(t, captured, http.StatusNoContent, "")
+
+	result, err := svc.CloseOrder(context.Background(), combinepayments.CloseOrderRequest{
+		CombineOutTradeNo: core.String("P20150806125346"),
+		CombineAppid:      core.String("wxd678efh567hg6787"),
+		SubOrders: []combinepayments.CloseSubOrder{
+			{Mchid: core.String("1900000109"), OutTradeNo: core.String("20150806125346"), SubMchid: core.String("1230000109")},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, result.Response.StatusCode)
+	assert.Equal(t, "/v3/combine-transactions/out-trade-no/P20150806125346/close", captured.path)
+	assert.Equal(t, "wxd678efh567hg6787", captured.body["combine_appid"])
+	assert.NotContains(t, captured.body, "combine_out_trade_no")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"mchid": "1900000109", "out_trade_no": "20150806125346", "sub_mchid": "1230000109",
+	}}, captured.body["sub_orders"])
+
+	captured.path = ""
+	_, err = svc.CloseOrder(context.Background(), combinepayments.CloseOrderRequest{
+		CombineOutTradeNo: core.String("P20150806125346"),
+	})
+	assert.EqualError(t, err, "field `CombineAppid` is required and must be specified in CloseOrderRequest")
+	assert.Empty(t, captured.path)
+}
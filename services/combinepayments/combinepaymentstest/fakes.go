@@ -0,0 +1,177 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package combinepaymentstest 提供 combinepayments 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package combinepaymentstest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/combinepayments"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeCombineAPI combinepayments.CombineAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCombineAPI struct {
+	servicetest.Recorder
+
+	AppPrepayFunc                     func(context.Context, combinepayments.PrepayRequest) (*combinepayments.PrepayResponse, *core.APIResult, error)
+	AppPrepayWithRequestPaymentFunc   func(context.Context, combinepayments.PrepayRequest) (*combinepayments.AppPrepayWithRequestPaymentResponse, *core.APIResult, error)
+	CloseOrderFunc                    func(context.Context, combinepayments.CloseOrderRequest) (*core.APIResult, error)
+	H5PrepayFunc                      func(context.Context, combinepayments.PrepayRequest) (*combinepayments.H5PrepayResponse, *core.APIResult, error)
+	JsapiPrepayFunc                   func(context.Context, combinepayments.PrepayRequest) (*combinepayments.PrepayResponse, *core.APIResult, error)
+	JsapiPrepayWithRequestPaymentFunc func(context.Context, combinepayments.PrepayRequest) (*combinepayments.JsapiPrepayWithRequestPaymentResponse, *core.APIResult, error)
+	NativePrepayFunc                  func(context.Context, combinepayments.PrepayRequest) (*combinepayments.NativePrepayResponse, *core.APIResult, error)
+	QueryOrderFunc                    func(context.Context, combinepayments.QueryOrderRequest) (*combinepayments.CombineTransaction, *core.APIResult, error)
+}
+
+var _ combinepayments.CombineAPI = (*FakeCombineAPI)(nil)
+
+// AppPrepay 记录调用并返回 AppPrepayFunc 的结果
+func (f *FakeCombineAPI) AppPrepay(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("AppPrepay", req)
+	if f.AppPrepayFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "AppPrepay")
+		return
+	}
+	return f.AppPrepayFunc(ctx, req)
+}
+
+// AppPrepayReturns 设置 AppPrepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) AppPrepayReturns(resp *combinepayments.PrepayResponse, err error) *FakeCombineAPI {
+	f.AppPrepayFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// AppPrepayWithRequestPayment 记录调用并返回 AppPrepayWithRequestPaymentFunc 的结果
+func (f *FakeCombineAPI) AppPrepayWithRequestPayment(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.AppPrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	f.Record("AppPrepayWithRequestPayment", req)
+	if f.AppPrepayWithRequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "AppPrepayWithRequestPayment")
+		return
+	}
+	return f.AppPrepayWithRequestPaymentFunc(ctx, req)
+}
+
+// AppPrepayWithRequestPaymentReturns 设置 AppPrepayWithRequestPayment 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) AppPrepayWithRequestPaymentReturns(resp *combinepayments.AppPrepayWithRequestPaymentResponse, err error) *FakeCombineAPI {
+	f.AppPrepayWithRequestPaymentFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.AppPrepayWithRequestPaymentResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// CloseOrder 记录调用并返回 CloseOrderFunc 的结果
+func (f *FakeCombineAPI) CloseOrder(ctx context.Context, req combinepayments.CloseOrderRequest) (result *core.APIResult, err error) {
+	f.Record("CloseOrder", req)
+	if f.CloseOrderFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "CloseOrder")
+		return
+	}
+	return f.CloseOrderFunc(ctx, req)
+}
+
+// CloseOrderReturns 设置 CloseOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) CloseOrderReturns(err error) *FakeCombineAPI {
+	f.CloseOrderFunc = func(context.Context, combinepayments.CloseOrderRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// H5Prepay 记录调用并返回 H5PrepayFunc 的结果
+func (f *FakeCombineAPI) H5Prepay(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.H5PrepayResponse, result *core.APIResult, err error) {
+	f.Record("H5Prepay", req)
+	if f.H5PrepayFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "H5Prepay")
+		return
+	}
+	return f.H5PrepayFunc(ctx, req)
+}
+
+// H5PrepayReturns 设置 H5Prepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) H5PrepayReturns(resp *combinepayments.H5PrepayResponse, err error) *FakeCombineAPI {
+	f.H5PrepayFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.H5PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// JsapiPrepay 记录调用并返回 JsapiPrepayFunc 的结果
+func (f *FakeCombineAPI) JsapiPrepay(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("JsapiPrepay", req)
+	if f.JsapiPrepayFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "JsapiPrepay")
+		return
+	}
+	return f.JsapiPrepayFunc(ctx, req)
+}
+
+// JsapiPrepayReturns 设置 JsapiPrepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) JsapiPrepayReturns(resp *combinepayments.PrepayResponse, err error) *FakeCombineAPI {
+	f.JsapiPrepayFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// JsapiPrepayWithRequestPayment 记录调用并返回 JsapiPrepayWithRequestPaymentFunc 的结果
+func (f *FakeCombineAPI) JsapiPrepayWithRequestPayment(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.JsapiPrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	f.Record("JsapiPrepayWithRequestPayment", req)
+	if f.JsapiPrepayWithRequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "JsapiPrepayWithRequestPayment")
+		return
+	}
+	return f.JsapiPrepayWithRequestPaymentFunc(ctx, req)
+}
+
+// JsapiPrepayWithRequestPaymentReturns 设置 JsapiPrepayWithRequestPayment 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) JsapiPrepayWithRequestPaymentReturns(resp *combinepayments.JsapiPrepayWithRequestPaymentResponse, err error) *FakeCombineAPI {
+	f.JsapiPrepayWithRequestPaymentFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.JsapiPrepayWithRequestPaymentResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// NativePrepay 记录调用并返回 NativePrepayFunc 的结果
+func (f *FakeCombineAPI) NativePrepay(ctx context.Context, req combinepayments.PrepayRequest) (resp *combinepayments.NativePrepayResponse, result *core.APIResult, err error) {
+	f.Record("NativePrepay", req)
+	if f.NativePrepayFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "NativePrepay")
+		return
+	}
+	return f.NativePrepayFunc(ctx, req)
+}
+
+// NativePrepayReturns 设置 NativePrepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) NativePrepayReturns(resp *combinepayments.NativePrepayResponse, err error) *FakeCombineAPI {
+	f.NativePrepayFunc = func(context.Context, combinepayments.PrepayRequest) (*combinepayments.NativePrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrder 记录调用并返回 QueryOrderFunc 的结果
+func (f *FakeCombineAPI) QueryOrder(ctx context.Context, req combinepayments.QueryOrderRequest) (resp *combinepayments.CombineTransaction, result *core.APIResult, err error) {
+	f.Record("QueryOrder", req)
+	if f.QueryOrderFunc == nil {
+		err = servicetest.NotStubbed("CombineAPI", "QueryOrder")
+		return
+	}
+	return f.QueryOrderFunc(ctx, req)
+}
+
+// QueryOrderReturns 设置 QueryOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCombineAPI) QueryOrderReturns(resp *combinepayments.CombineTransaction, err error) *FakeCombineAPI {
+	f.QueryOrderFunc = func(context.Context, combinepayments.QueryOrderRequest) (*combinepayments.CombineTransaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
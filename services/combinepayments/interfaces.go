@@ -0,0 +1,42 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package combinepayments
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// CombineAPI CombineApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CombineApiService，即可在测试中使用 combinepaymentstest.FakeCombineAPI 替代
+type CombineAPI interface {
+	// AppPrepay 合单APP下单
+	AppPrepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error)
+
+	// AppPrepayWithRequestPayment 合单APP下单，并返回调起支付的请求参数，调起支付使用合单发起方的appid与商户号
+	AppPrepayWithRequestPayment(ctx context.Context, req PrepayRequest) (resp *AppPrepayWithRequestPaymentResponse, result *core.APIResult, err error)
+
+	// CloseOrder 合单关闭订单
+	CloseOrder(ctx context.Context, req CloseOrderRequest) (result *core.APIResult, err error)
+
+	// H5Prepay 合单H5下单
+	H5Prepay(ctx context.Context, req PrepayRequest) (resp *H5PrepayResponse, result *core.APIResult, err error)
+
+	// JsapiPrepay 合单JSAPI下单
+	JsapiPrepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error)
+
+	// JsapiPrepayWithRequestPayment 合单JSAPI下单，并返回调起支付的请求参数，调起支付使用合单发起方的appid
+	JsapiPrepayWithRequestPayment(ctx context.Context, req PrepayRequest) (resp *JsapiPrepayWithRequestPaymentResponse, result *core.APIResult, err error)
+
+	// NativePrepay 合单Native下单
+	NativePrepay(ctx context.Context, req PrepayRequest) (resp *NativePrepayResponse, result *core.APIResult, err error)
+
+	// QueryOrder 合单查询订单
+	QueryOrder(ctx context.Context, req QueryOrderRequest) (resp *CombineTransaction, result *core.APIResult, err error)
+}
+
+var _ CombineAPI = (*CombineApiService)(nil)
@@ -0,0 +1,1538 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+//
+// 合单支付
+//
+// 微信支付 API v3 合单支付
+//
+// API version: 1.0.0
+
+package combinepayments
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/services/payments"
+)
+
+// Amount 订单金额
+type Amount struct {
+	// 子单金额，单位为分
+	TotalAmount *int64 `json:"total_amount"`
+	// 符合ISO 4217标准的三位字母代码，人民币：CNY
+	Currency *string `json:"currency"`
+}
+
+func (o Amount) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.TotalAmount == nil {
+		return nil, fmt.Errorf("field `TotalAmount` is required and must be specified in Amount")
+	}
+	toSerialize["total_amount"] = o.TotalAmount
+
+	if o.Currency == nil {
+		return nil, fmt.Errorf("field `Currency` is required and must be specified in Amount")
+	}
+	toSerialize["currency"] = o.Currency
+	return json.Marshal(toSerialize)
+}
+
+func (o Amount) String() string {
+	var ret string
+	if o.TotalAmount == nil {
+		ret += "TotalAmount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TotalAmount:%v, ", *o.TotalAmount)
+	}
+
+	if o.Currency == nil {
+		ret += "Currency:<nil>"
+	} else {
+		ret += fmt.Sprintf("Currency:%v", *o.Currency)
+	}
+
+	return fmt.Sprintf("Amount{%s}", ret)
+}
+
+func (o Amount) Clone() *Amount {
+	ret := Amount{}
+
+	if o.TotalAmount != nil {
+		ret.TotalAmount = new(int64)
+		*ret.TotalAmount = *o.TotalAmount
+	}
+
+	if o.Currency != nil {
+		ret.Currency = new(string)
+		*ret.Currency = *o.Currency
+	}
+
+	return &ret
+}
+
+// CloseOrderRequest
+type CloseOrderRequest struct {
+	// 合单支付总订单号
+	CombineOutTradeNo *string `json:"combine_out_trade_no"`
+	// 合单发起方的appid
+	CombineAppid *string `json:"combine_appid"`
+	// 最多支持子单条数：50
+	SubOrders []CloseSubOrder `json:"sub_orders"`
+}
+
+func (o CloseOrderRequest) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CombineOutTradeNo == nil {
+		return nil, fmt.Errorf("field `CombineOutTradeNo` is required and must be specified in CloseOrderRequest")
+	}
+	toSerialize["combine_out_trade_no"] = o.CombineOutTradeNo
+
+	if o.CombineAppid == nil {
+		return nil, fmt.Errorf("field `CombineAppid` is required and must be specified in CloseOrderRequest")
+	}
+	toSerialize["combine_appid"] = o.CombineAppid
+
+	if o.SubOrders == nil {
+		return nil, fmt.Errorf("field `SubOrders` is required and must be specified in CloseOrderRequest")
+	}
+	toSerialize["sub_orders"] = o.SubOrders
+	return json.Marshal(toSerialize)
+}
+
+func (o CloseOrderRequest) String() string {
+	var ret string
+	if o.CombineOutTradeNo == nil {
+		ret += "CombineOutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineOutTradeNo:%v, ", *o.CombineOutTradeNo)
+	}
+
+	if o.CombineAppid == nil {
+		ret += "CombineAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineAppid:%v, ", *o.CombineAppid)
+	}
+
+	ret += fmt.Sprintf("SubOrders:%v", o.SubOrders)
+
+	return fmt.Sprintf("CloseOrderRequest{%s}", ret)
+}
+
+func (o CloseOrderRequest) Clone() *CloseOrderRequest {
+	ret := CloseOrderRequest{}
+
+	if o.CombineOutTradeNo != nil {
+		ret.CombineOutTradeNo = new(string)
+		*ret.CombineOutTradeNo = *o.CombineOutTradeNo
+	}
+
+	if o.CombineAppid != nil {
+		ret.CombineAppid = new(string)
+		*ret.CombineAppid = *o.CombineAppid
+	}
+
+	if o.SubOrders != nil {
+		ret.SubOrders = make([]CloseSubOrder, len(o.SubOrders))
+		for i, item := range o.SubOrders {
+			ret.SubOrders[i] = *item.Clone()
+		}
+	}
+
+	return &ret
+}
+
+// CloseRequest
+type CloseRequest struct {
+	// 合单发起方的appid
+	CombineAppid *string `json:"combine_appid"`
+	// 最多支持子单条数：50
+	SubOrders []CloseSubOrder `json:"sub_orders"`
+}
+
+func (o CloseRequest) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CombineAppid == nil {
+		return nil, fmt.Errorf("field `CombineAppid` is required and must be specified in CloseRequest")
+	}
+	toSerialize["combine_appid"] = o.CombineAppid
+
+	if o.SubOrders == nil {
+		return nil, fmt.Errorf("field `SubOrders` is required and must be specified in CloseRequest")
+	}
+	toSerialize["sub_orders"] = o.SubOrders
+	return json.Marshal(toSerialize)
+}
+
+func (o CloseRequest) String() string {
+	var ret string
+	if o.CombineAppid == nil {
+		ret += "CombineAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineAppid:%v, ", *o.CombineAppid)
+	}
+
+	ret += fmt.Sprintf("SubOrders:%v", o.SubOrders)
+
+	return fmt.Sprintf("CloseRequest{%s}", ret)
+}
+
+func (o CloseRequest) Clone() *CloseRequest {
+	ret := CloseRequest{}
+
+	if o.CombineAppid != nil {
+		ret.CombineAppid = new(string)
+		*ret.CombineAppid = *o.CombineAppid
+	}
+
+	if o.SubOrders != nil {
+		ret.SubOrders = make([]CloseSubOrder, len(o.SubOrders))
+		for i, item := range o.SubOrders {
+			ret.SubOrders[i] = *item.Clone()
+		}
+	}
+
+	return &ret
+}
+
+// CloseSubOrder 关单的子单信息
+type CloseSubOrder struct {
+	// 子单发起方商户号，服务商模式下为服务商商户号
+	Mchid *string `json:"mchid"`
+	// 子单商户订单号
+	OutTradeNo *string `json:"out_trade_no"`
+	// 二级商户商户号，服务商模式下必填
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 子商户申请的应用ID
+	SubAppid *string `json:"sub_appid,omitempty"`
+}
+
+func (o CloseSubOrder) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Mchid == nil {
+		return nil, fmt.Errorf("field `Mchid` is required and must be specified in CloseSubOrder")
+	}
+	toSerialize["mchid"] = o.Mchid
+
+	if o.OutTradeNo == nil {
+		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in CloseSubOrder")
+	}
+	toSerialize["out_trade_no"] = o.OutTradeNo
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		toSerialize["sub_appid"] = o.SubAppid
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o CloseSubOrder) String() string {
+	var ret string
+	if o.Mchid == nil {
+		ret += "Mchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Mchid:%v, ", *o.Mchid)
+	}
+
+	if o.OutTradeNo == nil {
+		ret += "OutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutTradeNo:%v, ", *o.OutTradeNo)
+	}
+
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.SubAppid == nil {
+		ret += "SubAppid:<nil>"
+	} else {
+		ret += fmt.Sprintf("SubAppid:%v", *o.SubAppid)
+	}
+
+	return fmt.Sprintf("CloseSubOrder{%s}", ret)
+}
+
+func (o CloseSubOrder) Clone() *CloseSubOrder {
+	ret := CloseSubOrder{}
+
+	if o.Mchid != nil {
+		ret.Mchid = new(string)
+		*ret.Mchid = *o.Mchid
+	}
+
+	if o.OutTradeNo != nil {
+		ret.OutTradeNo = new(string)
+		*ret.OutTradeNo = *o.OutTradeNo
+	}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		ret.SubAppid = new(string)
+		*ret.SubAppid = *o.SubAppid
+	}
+
+	return &ret
+}
+
+// CombinePayerInfo 支付者信息
+type CombinePayerInfo struct {
+	// 使用合单appid获取的对应用户openid，JSAPI 支付必填
+	Openid *string `json:"openid,omitempty"`
+}
+
+func (o CombinePayerInfo) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Openid != nil {
+		toSerialize["openid"] = o.Openid
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o CombinePayerInfo) String() string {
+	var ret string
+	if o.Openid == nil {
+		ret += "Openid:<nil>"
+	} else {
+		ret += fmt.Sprintf("Openid:%v", *o.Openid)
+	}
+
+	return fmt.Sprintf("CombinePayerInfo{%s}", ret)
+}
+
+func (o CombinePayerInfo) Clone() *CombinePayerInfo {
+	ret := CombinePayerInfo{}
+
+	if o.Openid != nil {
+		ret.Openid = new(string)
+		*ret.Openid = *o.Openid
+	}
+
+	return &ret
+}
+
+// CombineTransaction 合单订单，查询合单订单与合单支付结果通知的订单内容
+type CombineTransaction struct {
+	// 合单发起方的appid
+	CombineAppid *string `json:"combine_appid,omitempty"`
+	// 合单发起方商户号
+	CombineMchid *string `json:"combine_mchid,omitempty"`
+	// 合单支付总订单号
+	CombineOutTradeNo *string               `json:"combine_out_trade_no,omitempty"`
+	SceneInfo         *TransactionSceneInfo `json:"scene_info,omitempty"`
+	// 子单交易信息
+	SubOrders        []SubOrderTransaction `json:"sub_orders,omitempty"`
+	CombinePayerInfo *CombinePayerInfo     `json:"combine_payer_info,omitempty"`
+}
+
+func (o CombineTransaction) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CombineAppid != nil {
+		toSerialize["combine_appid"] = o.CombineAppid
+	}
+
+	if o.CombineMchid != nil {
+		toSerialize["combine_mchid"] = o.CombineMchid
+	}
+
+	if o.CombineOutTradeNo != nil {
+		toSerialize["combine_out_trade_no"] = o.CombineOutTradeNo
+	}
+
+	if o.SceneInfo != nil {
+		toSerialize["scene_info"] = o.SceneInfo
+	}
+
+	if o.SubOrders != nil {
+		toSerialize["sub_orders"] = o.SubOrders
+	}
+
+	if o.CombinePayerInfo != nil {
+		toSerialize["combine_payer_info"] = o.CombinePayerInfo
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o CombineTransaction) String() string {
+	var ret string
+	if o.CombineAppid == nil {
+		ret += "CombineAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineAppid:%v, ", *o.CombineAppid)
+	}
+
+	if o.CombineMchid == nil {
+		ret += "CombineMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineMchid:%v, ", *o.CombineMchid)
+	}
+
+	if o.CombineOutTradeNo == nil {
+		ret += "CombineOutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineOutTradeNo:%v, ", *o.CombineOutTradeNo)
+	}
+
+	ret += fmt.Sprintf("SceneInfo:%v, ", o.SceneInfo)
+
+	ret += fmt.Sprintf("SubOrders:%v, ", o.SubOrders)
+
+	ret += fmt.Sprintf("CombinePayerInfo:%v", o.CombinePayerInfo)
+
+	return fmt.Sprintf("CombineTransaction{%s}", ret)
+}
+
+func (o CombineTransaction) Clone() *CombineTransaction {
+	ret := CombineTransaction{}
+
+	if o.CombineAppid != nil {
+		ret.CombineAppid = new(string)
+		*ret.CombineAppid = *o.CombineAppid
+	}
+
+	if o.CombineMchid != nil {
+		ret.CombineMchid = new(string)
+		*ret.CombineMchid = *o.CombineMchid
+	}
+
+	if o.CombineOutTradeNo != nil {
+		ret.CombineOutTradeNo = new(string)
+		*ret.CombineOutTradeNo = *o.CombineOutTradeNo
+	}
+
+	if o.SceneInfo != nil {
+		ret.SceneInfo = o.SceneInfo.Clone()
+	}
+
+	if o.SubOrders != nil {
+		ret.SubOrders = make([]SubOrderTransaction, len(o.SubOrders))
+		for i, item := range o.SubOrders {
+			ret.SubOrders[i] = *item.Clone()
+		}
+	}
+
+	if o.CombinePayerInfo != nil {
+		ret.CombinePayerInfo = o.CombinePayerInfo.Clone()
+	}
+
+	return &ret
+}
+
+// H5Info H5 场景信息
+type H5Info struct {
+	// 场景类型，如 iOS、Android、Wap
+	Type *string `json:"type"`
+	// 应用名称
+	AppName *string `json:"app_name,omitempty"`
+	// 网站URL
+	AppUrl *string `json:"app_url,omitempty"`
+	// iOS平台BundleID
+	BundleId *string `json:"bundle_id,omitempty"`
+	// Android平台PackageName
+	PackageName *string `json:"package_name,omitempty"`
+}
+
+func (o H5Info) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Type == nil {
+		return nil, fmt.Errorf("field `Type` is required and must be specified in H5Info")
+	}
+	toSerialize["type"] = o.Type
+
+	if o.AppName != nil {
+		toSerialize["app_name"] = o.AppName
+	}
+
+	if o.AppUrl != nil {
+		toSerialize["app_url"] = o.AppUrl
+	}
+
+	if o.BundleId != nil {
+		toSerialize["bundle_id"] = o.BundleId
+	}
+
+	if o.PackageName != nil {
+		toSerialize["package_name"] = o.PackageName
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o H5Info) String() string {
+	var ret string
+	if o.Type == nil {
+		ret += "Type:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Type:%v, ", *o.Type)
+	}
+
+	if o.AppName == nil {
+		ret += "AppName:<nil>, "
+	} else {
+		ret += fmt.Sprintf("AppName:%v, ", *o.AppName)
+	}
+
+	if o.AppUrl == nil {
+		ret += "AppUrl:<nil>, "
+	} else {
+		ret += fmt.Sprintf("AppUrl:%v, ", *o.AppUrl)
+	}
+
+	if o.BundleId == nil {
+		ret += "BundleId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BundleId:%v, ", *o.BundleId)
+	}
+
+	if o.PackageName == nil {
+		ret += "PackageName:<nil>"
+	} else {
+		ret += fmt.Sprintf("PackageName:%v", *o.PackageName)
+	}
+
+	return fmt.Sprintf("H5Info{%s}", ret)
+}
+
+func (o H5Info) Clone() *H5Info {
+	ret := H5Info{}
+
+	if o.Type != nil {
+		ret.Type = new(string)
+		*ret.Type = *o.Type
+	}
+
+	if o.AppName != nil {
+		ret.AppName = new(string)
+		*ret.AppName = *o.AppName
+	}
+
+	if o.AppUrl != nil {
+		ret.AppUrl = new(string)
+		*ret.AppUrl = *o.AppUrl
+	}
+
+	if o.BundleId != nil {
+		ret.BundleId = new(string)
+		*ret.BundleId = *o.BundleId
+	}
+
+	if o.PackageName != nil {
+		ret.PackageName = new(string)
+		*ret.PackageName = *o.PackageName
+	}
+
+	return &ret
+}
+
+// H5PrepayResponse
+type H5PrepayResponse struct {
+	// 支付跳转链接，有效期为5分钟
+	H5Url *string `json:"h5_url"`
+}
+
+func (o H5PrepayResponse) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.H5Url == nil {
+		return nil, fmt.Errorf("field `H5Url` is required and must be specified in H5PrepayResponse")
+	}
+	toSerialize["h5_url"] = o.H5Url
+	return json.Marshal(toSerialize)
+}
+
+func (o H5PrepayResponse) String() string {
+	var ret string
+	if o.H5Url == nil {
+		ret += "H5Url:<nil>"
+	} else {
+		ret += fmt.Sprintf("H5Url:%v", *o.H5Url)
+	}
+
+	return fmt.Sprintf("H5PrepayResponse{%s}", ret)
+}
+
+func (o H5PrepayResponse) Clone() *H5PrepayResponse {
+	ret := H5PrepayResponse{}
+
+	if o.H5Url != nil {
+		ret.H5Url = new(string)
+		*ret.H5Url = *o.H5Url
+	}
+
+	return &ret
+}
+
+// NativePrepayResponse
+type NativePrepayResponse struct {
+	// 此URL用于生成支付二维码，然后提供给用户扫码支付，有效期为2小时
+	CodeUrl *string `json:"code_url"`
+}
+
+func (o NativePrepayResponse) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CodeUrl == nil {
+		return nil, fmt.Errorf("field `CodeUrl` is required and must be specified in NativePrepayResponse")
+	}
+	toSerialize["code_url"] = o.CodeUrl
+	return json.Marshal(toSerialize)
+}
+
+func (o NativePrepayResponse) String() string {
+	var ret string
+	if o.CodeUrl == nil {
+		ret += "CodeUrl:<nil>"
+	} else {
+		ret += fmt.Sprintf("CodeUrl:%v", *o.CodeUrl)
+	}
+
+	return fmt.Sprintf("NativePrepayResponse{%s}", ret)
+}
+
+func (o NativePrepayResponse) Clone() *NativePrepayResponse {
+	ret := NativePrepayResponse{}
+
+	if o.CodeUrl != nil {
+		ret.CodeUrl = new(string)
+		*ret.CodeUrl = *o.CodeUrl
+	}
+
+	return &ret
+}
+
+// PrepayRequest
+type PrepayRequest struct {
+	// 合单发起方的appid
+	CombineAppid *string `json:"combine_appid"`
+	// 合单发起方商户号，服务商模式下为服务商商户号
+	CombineMchid *string `json:"combine_mchid"`
+	// 合单支付总订单号，要求32个字符内，只能是数字、大小写字母_-|*@ ，且在同一个商户号下唯一
+	CombineOutTradeNo *string `json:"combine_out_trade_no"`
+	// 支付场景信息，H5 支付必填
+	SceneInfo *SceneInfo `json:"scene_info,omitempty"`
+	// 最多支持子单条数：50
+	SubOrders []SubOrder `json:"sub_orders"`
+	// 支付者信息，JSAPI 支付必填
+	CombinePayerInfo *CombinePayerInfo `json:"combine_payer_info,omitempty"`
+	// 订单生成时间，遵循rfc3339标准格式
+	TimeStart *time.Time `json:"time_start,omitempty"`
+	// 订单失效时间，遵循rfc3339标准格式
+	TimeExpire *time.Time `json:"time_expire,omitempty"`
+	// 接收微信支付异步通知回调地址，通知url必须为直接可访问的URL，不能携带参数
+	NotifyUrl *string `json:"notify_url"`
+}
+
+func (o PrepayRequest) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CombineAppid == nil {
+		return nil, fmt.Errorf("field `CombineAppid` is required and must be specified in PrepayRequest")
+	}
+	toSerialize["combine_appid"] = o.CombineAppid
+
+	if o.CombineMchid == nil {
+		return nil, fmt.Errorf("field `CombineMchid` is required and must be specified in PrepayRequest")
+	}
+	toSerialize["combine_mchid"] = o.CombineMchid
+
+	if o.CombineOutTradeNo == nil {
+		return nil, fmt.Errorf("field `CombineOutTradeNo` is required and must be specified in PrepayRequest")
+	}
+	toSerialize["combine_out_trade_no"] = o.CombineOutTradeNo
+
+	if o.SceneInfo != nil {
+		toSerialize["scene_info"] = o.SceneInfo
+	}
+
+	if o.SubOrders == nil {
+		return nil, fmt.Errorf("field `SubOrders` is required and must be specified in PrepayRequest")
+	}
+	toSerialize["sub_orders"] = o.SubOrders
+
+	if o.CombinePayerInfo != nil {
+		toSerialize["combine_payer_info"] = o.CombinePayerInfo
+	}
+
+	if o.TimeStart != nil {
+		toSerialize["time_start"] = chinatime.Format(*o.TimeStart)
+	}
+
+	if o.TimeExpire != nil {
+		toSerialize["time_expire"] = chinatime.Format(*o.TimeExpire)
+	}
+
+	if o.NotifyUrl == nil {
+		return nil, fmt.Errorf("field `NotifyUrl` is required and must be specified in PrepayRequest")
+	}
+	toSerialize["notify_url"] = o.NotifyUrl
+	return json.Marshal(toSerialize)
+}
+
+func (o PrepayRequest) String() string {
+	var ret string
+	if o.CombineAppid == nil {
+		ret += "CombineAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineAppid:%v, ", *o.CombineAppid)
+	}
+
+	if o.CombineMchid == nil {
+		ret += "CombineMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineMchid:%v, ", *o.CombineMchid)
+	}
+
+	if o.CombineOutTradeNo == nil {
+		ret += "CombineOutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CombineOutTradeNo:%v, ", *o.CombineOutTradeNo)
+	}
+
+	ret += fmt.Sprintf("SceneInfo:%v, ", o.SceneInfo)
+
+	ret += fmt.Sprintf("SubOrders:%v, ", o.SubOrders)
+
+	ret += fmt.Sprintf("CombinePayerInfo:%v, ", o.CombinePayerInfo)
+
+	if o.TimeStart == nil {
+		ret += "TimeStart:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TimeStart:%v, ", *o.TimeStart)
+	}
+
+	if o.TimeExpire == nil {
+		ret += "TimeExpire:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TimeExpire:%v, ", *o.TimeExpire)
+	}
+
+	if o.NotifyUrl == nil {
+		ret += "NotifyUrl:<nil>"
+	} else {
+		ret += fmt.Sprintf("NotifyUrl:%v", *o.NotifyUrl)
+	}
+
+	return fmt.Sprintf("PrepayRequest{%s}", ret)
+}
+
+func (o PrepayRequest) Clone() *PrepayRequest {
+	ret := PrepayRequest{}
+
+	if o.CombineAppid != nil {
+		ret.CombineAppid = new(string)
+		*ret.CombineAppid = *o.CombineAppid
+	}
+
+	if o.CombineMchid != nil {
+		ret.CombineMchid = new(string)
+		*ret.CombineMchid = *o.CombineMchid
+	}
+
+	if o.CombineOutTradeNo != nil {
+		ret.CombineOutTradeNo = new(string)
+		*ret.CombineOutTradeNo = *o.CombineOutTradeNo
+	}
+
+	if o.SceneInfo != nil {
+		ret.SceneInfo = o.SceneInfo.Clone()
+	}
+
+	if o.SubOrders != nil {
+		ret.SubOrders = make([]SubOrder, len(o.SubOrders))
+		for i, item := range o.SubOrders {
+			ret.SubOrders[i] = *item.Clone()
+		}
+	}
+
+	if o.CombinePayerInfo != nil {
+		ret.CombinePayerInfo = o.CombinePayerInfo.Clone()
+	}
+
+	if o.TimeStart != nil {
+		ret.TimeStart = new(time.Time)
+		*ret.TimeStart = *o.TimeStart
+	}
+
+	if o.TimeExpire != nil {
+		ret.TimeExpire = new(time.Time)
+		*ret.TimeExpire = *o.TimeExpire
+	}
+
+	if o.NotifyUrl != nil {
+		ret.NotifyUrl = new(string)
+		*ret.NotifyUrl = *o.NotifyUrl
+	}
+
+	return &ret
+}
+
+// PrepayResponse
+type PrepayResponse struct {
+	// 预支付交易会话标识，有效期为2小时
+	PrepayId *string `json:"prepay_id"`
+}
+
+func (o PrepayResponse) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.PrepayId == nil {
+		return nil, fmt.Errorf("field `PrepayId` is required and must be specified in PrepayResponse")
+	}
+	toSerialize["prepay_id"] = o.PrepayId
+	return json.Marshal(toSerialize)
+}
+
+func (o PrepayResponse) String() string {
+	var ret string
+	if o.PrepayId == nil {
+		ret += "PrepayId:<nil>"
+	} else {
+		ret += fmt.Sprintf("PrepayId:%v", *o.PrepayId)
+	}
+
+	return fmt.Sprintf("PrepayResponse{%s}", ret)
+}
+
+func (o PrepayResponse) Clone() *PrepayResponse {
+	ret := PrepayResponse{}
+
+	if o.PrepayId != nil {
+		ret.PrepayId = new(string)
+		*ret.PrepayId = *o.PrepayId
+	}
+
+	return &ret
+}
+
+// QueryOrderRequest
+type QueryOrderRequest struct {
+	// 合单支付总订单号
+	CombineOutTradeNo *string `json:"combine_out_trade_no"`
+}
+
+func (o QueryOrderRequest) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.CombineOutTradeNo == nil {
+		return nil, fmt.Errorf("field `CombineOutTradeNo` is required and must be specified in QueryOrderRequest")
+	}
+	toSerialize["combine_out_trade_no"] = o.CombineOutTradeNo
+	return json.Marshal(toSerialize)
+}
+
+func (o QueryOrderRequest) String() string {
+	var ret string
+	if o.CombineOutTradeNo == nil {
+		ret += "CombineOutTradeNo:<nil>"
+	} else {
+		ret += fmt.Sprintf("CombineOutTradeNo:%v", *o.CombineOutTradeNo)
+	}
+
+	return fmt.Sprintf("QueryOrderRequest{%s}", ret)
+}
+
+func (o QueryOrderRequest) Clone() *QueryOrderRequest {
+	ret := QueryOrderRequest{}
+
+	if o.CombineOutTradeNo != nil {
+		ret.CombineOutTradeNo = new(string)
+		*ret.CombineOutTradeNo = *o.CombineOutTradeNo
+	}
+
+	return &ret
+}
+
+// SceneInfo 支付场景信息
+type SceneInfo struct {
+	// 终端设备号（门店号或收银设备ID）
+	DeviceId *string `json:"device_id,omitempty"`
+	// 用户端实际ip，支持IPv4和IPv6两种格式的IP地址
+	PayerClientIp *string `json:"payer_client_ip"`
+	// H5 场景信息，H5 支付必填
+	H5Info *H5Info `json:"h5_info,omitempty"`
+}
+
+func (o SceneInfo) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.DeviceId != nil {
+		toSerialize["device_id"] = o.DeviceId
+	}
+
+	if o.PayerClientIp == nil {
+		return nil, fmt.Errorf("field `PayerClientIp` is required and must be specified in SceneInfo")
+	}
+	toSerialize["payer_client_ip"] = o.PayerClientIp
+
+	if o.H5Info != nil {
+		toSerialize["h5_info"] = o.H5Info
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o SceneInfo) String() string {
+	var ret string
+	if o.DeviceId == nil {
+		ret += "DeviceId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("DeviceId:%v, ", *o.DeviceId)
+	}
+
+	if o.PayerClientIp == nil {
+		ret += "PayerClientIp:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PayerClientIp:%v, ", *o.PayerClientIp)
+	}
+
+	ret += fmt.Sprintf("H5Info:%v", o.H5Info)
+
+	return fmt.Sprintf("SceneInfo{%s}", ret)
+}
+
+func (o SceneInfo) Clone() *SceneInfo {
+	ret := SceneInfo{}
+
+	if o.DeviceId != nil {
+		ret.DeviceId = new(string)
+		*ret.DeviceId = *o.DeviceId
+	}
+
+	if o.PayerClientIp != nil {
+		ret.PayerClientIp = new(string)
+		*ret.PayerClientIp = *o.PayerClientIp
+	}
+
+	if o.H5Info != nil {
+		ret.H5Info = o.H5Info.Clone()
+	}
+
+	return &ret
+}
+
+// SettleInfo 结算信息
+type SettleInfo struct {
+	// 是否指定分账
+	ProfitSharing *bool `json:"profit_sharing,omitempty"`
+	// SettleInfo.profit_sharing为true时，该金额才生效。单位为分
+	SubsidyAmount *int64 `json:"subsidy_amount,omitempty"`
+}
+
+func (o SettleInfo) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.ProfitSharing != nil {
+		toSerialize["profit_sharing"] = o.ProfitSharing
+	}
+
+	if o.SubsidyAmount != nil {
+		toSerialize["subsidy_amount"] = o.SubsidyAmount
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o SettleInfo) String() string {
+	var ret string
+	if o.ProfitSharing == nil {
+		ret += "ProfitSharing:<nil>, "
+	} else {
+		ret += fmt.Sprintf("ProfitSharing:%v, ", *o.ProfitSharing)
+	}
+
+	if o.SubsidyAmount == nil {
+		ret += "SubsidyAmount:<nil>"
+	} else {
+		ret += fmt.Sprintf("SubsidyAmount:%v", *o.SubsidyAmount)
+	}
+
+	return fmt.Sprintf("SettleInfo{%s}", ret)
+}
+
+func (o SettleInfo) Clone() *SettleInfo {
+	ret := SettleInfo{}
+
+	if o.ProfitSharing != nil {
+		ret.ProfitSharing = new(bool)
+		*ret.ProfitSharing = *o.ProfitSharing
+	}
+
+	if o.SubsidyAmount != nil {
+		ret.SubsidyAmount = new(int64)
+		*ret.SubsidyAmount = *o.SubsidyAmount
+	}
+
+	return &ret
+}
+
+// SubOrder 子单信息
+type SubOrder struct {
+	// 子单发起方商户号，必须与发起方appid有绑定关系。服务商模式下为服务商商户号
+	Mchid *string `json:"mchid"`
+	// 附加数据，在查询API和支付通知中原样返回，可作为自定义参数使用
+	Attach *string `json:"attach"`
+	Amount *Amount `json:"amount"`
+	// 商户系统内部订单号，要求32个字符内，只能是数字、大小写字母_-|*@ ，且在同一个商户号下唯一
+	OutTradeNo *string `json:"out_trade_no"`
+	// 二级商户商户号，由微信支付生成并下发。服务商模式下必填
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 子商户申请的应用ID，服务商模式下选填
+	SubAppid *string `json:"sub_appid,omitempty"`
+	// 商品详细描述
+	Detail *string `json:"detail,omitempty"`
+	// 商品描述
+	Description *string `json:"description"`
+	// 订单优惠标记
+	GoodsTag   *string     `json:"goods_tag,omitempty"`
+	SettleInfo *SettleInfo `json:"settle_info,omitempty"`
+}
+
+func (o SubOrder) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Mchid == nil {
+		return nil, fmt.Errorf("field `Mchid` is required and must be specified in SubOrder")
+	}
+	toSerialize["mchid"] = o.Mchid
+
+	if o.Attach == nil {
+		return nil, fmt.Errorf("field `Attach` is required and must be specified in SubOrder")
+	}
+	toSerialize["attach"] = o.Attach
+
+	if o.Amount == nil {
+		return nil, fmt.Errorf("field `Amount` is required and must be specified in SubOrder")
+	}
+	toSerialize["amount"] = o.Amount
+
+	if o.OutTradeNo == nil {
+		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in SubOrder")
+	}
+	toSerialize["out_trade_no"] = o.OutTradeNo
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		toSerialize["sub_appid"] = o.SubAppid
+	}
+
+	if o.Detail != nil {
+		toSerialize["detail"] = o.Detail
+	}
+
+	if o.Description == nil {
+		return nil, fmt.Errorf("field `Description` is required and must be specified in SubOrder")
+	}
+	toSerialize["description"] = o.Description
+
+	if o.GoodsTag != nil {
+		toSerialize["goods_tag"] = o.GoodsTag
+	}
+
+	if o.SettleInfo != nil {
+		toSerialize["settle_info"] = o.SettleInfo
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o SubOrder) String() string {
+	var ret string
+	if o.Mchid == nil {
+		ret += "Mchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Mchid:%v, ", *o.Mchid)
+	}
+
+	if o.Attach == nil {
+		ret += "Attach:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Attach:%v, ", *o.Attach)
+	}
+
+	ret += fmt.Sprintf("Amount:%v, ", o.Amount)
+
+	if o.OutTradeNo == nil {
+		ret += "OutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutTradeNo:%v, ", *o.OutTradeNo)
+	}
+
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.SubAppid == nil {
+		ret += "SubAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubAppid:%v, ", *o.SubAppid)
+	}
+
+	if o.Detail == nil {
+		ret += "Detail:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Detail:%v, ", *o.Detail)
+	}
+
+	if o.Description == nil {
+		ret += "Description:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Description:%v, ", *o.Description)
+	}
+
+	if o.GoodsTag == nil {
+		ret += "GoodsTag:<nil>, "
+	} else {
+		ret += fmt.Sprintf("GoodsTag:%v, ", *o.GoodsTag)
+	}
+
+	ret += fmt.Sprintf("SettleInfo:%v", o.SettleInfo)
+
+	return fmt.Sprintf("SubOrder{%s}", ret)
+}
+
+func (o SubOrder) Clone() *SubOrder {
+	ret := SubOrder{}
+
+	if o.Mchid != nil {
+		ret.Mchid = new(string)
+		*ret.Mchid = *o.Mchid
+	}
+
+	if o.Attach != nil {
+		ret.Attach = new(string)
+		*ret.Attach = *o.Attach
+	}
+
+	if o.Amount != nil {
+		ret.Amount = o.Amount.Clone()
+	}
+
+	if o.OutTradeNo != nil {
+		ret.OutTradeNo = new(string)
+		*ret.OutTradeNo = *o.OutTradeNo
+	}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		ret.SubAppid = new(string)
+		*ret.SubAppid = *o.SubAppid
+	}
+
+	if o.Detail != nil {
+		ret.Detail = new(string)
+		*ret.Detail = *o.Detail
+	}
+
+	if o.Description != nil {
+		ret.Description = new(string)
+		*ret.Description = *o.Description
+	}
+
+	if o.GoodsTag != nil {
+		ret.GoodsTag = new(string)
+		*ret.GoodsTag = *o.GoodsTag
+	}
+
+	if o.SettleInfo != nil {
+		ret.SettleInfo = o.SettleInfo.Clone()
+	}
+
+	return &ret
+}
+
+// SubOrderTransaction 子单交易信息
+type SubOrderTransaction struct {
+	// 子单发起方商户号
+	Mchid *string `json:"mchid,omitempty"`
+	// 交易类型：NATIVE、JSAPI、APP、MWEB
+	TradeType *string `json:"trade_type,omitempty"`
+	// 交易状态：SUCCESS、REFUND、NOTPAY、CLOSED、PAYERROR
+	TradeState *string `json:"trade_state,omitempty"`
+	// 银行类型，采用字符串类型的银行标识
+	BankType *string `json:"bank_type,omitempty"`
+	// 附加数据
+	Attach *string `json:"attach,omitempty"`
+	// 支付完成时间，遵循rfc3339标准格式
+	SuccessTime *string `json:"success_time,omitempty"`
+	// 微信支付订单号
+	TransactionId *string `json:"transaction_id,omitempty"`
+	// 子单商户订单号
+	OutTradeNo *string `json:"out_trade_no,omitempty"`
+	// 二级商户商户号
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 子商户应用ID
+	SubAppid *string `json:"sub_appid,omitempty"`
+	// 用户在子商户appid下的唯一标识
+	SubOpenid *string            `json:"sub_openid,omitempty"`
+	Amount    *TransactionAmount `json:"amount,omitempty"`
+	// 优惠功能，享受优惠时返回该字段
+	PromotionDetail []payments.PromotionDetail `json:"promotion_detail,omitempty"`
+}
+
+func (o SubOrderTransaction) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Mchid != nil {
+		toSerialize["mchid"] = o.Mchid
+	}
+
+	if o.TradeType != nil {
+		toSerialize["trade_type"] = o.TradeType
+	}
+
+	if o.TradeState != nil {
+		toSerialize["trade_state"] = o.TradeState
+	}
+
+	if o.BankType != nil {
+		toSerialize["bank_type"] = o.BankType
+	}
+
+	if o.Attach != nil {
+		toSerialize["attach"] = o.Attach
+	}
+
+	if o.SuccessTime != nil {
+		toSerialize["success_time"] = o.SuccessTime
+	}
+
+	if o.TransactionId != nil {
+		toSerialize["transaction_id"] = o.TransactionId
+	}
+
+	if o.OutTradeNo != nil {
+		toSerialize["out_trade_no"] = o.OutTradeNo
+	}
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		toSerialize["sub_appid"] = o.SubAppid
+	}
+
+	if o.SubOpenid != nil {
+		toSerialize["sub_openid"] = o.SubOpenid
+	}
+
+	if o.Amount != nil {
+		toSerialize["amount"] = o.Amount
+	}
+
+	if o.PromotionDetail != nil {
+		toSerialize["promotion_detail"] = o.PromotionDetail
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o SubOrderTransaction) String() string {
+	var ret string
+	if o.Mchid == nil {
+		ret += "Mchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Mchid:%v, ", *o.Mchid)
+	}
+
+	if o.TradeType == nil {
+		ret += "TradeType:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TradeType:%v, ", *o.TradeType)
+	}
+
+	if o.TradeState == nil {
+		ret += "TradeState:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TradeState:%v, ", *o.TradeState)
+	}
+
+	if o.BankType == nil {
+		ret += "BankType:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BankType:%v, ", *o.BankType)
+	}
+
+	if o.Attach == nil {
+		ret += "Attach:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Attach:%v, ", *o.Attach)
+	}
+
+	if o.SuccessTime == nil {
+		ret += "SuccessTime:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SuccessTime:%v, ", *o.SuccessTime)
+	}
+
+	if o.TransactionId == nil {
+		ret += "TransactionId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TransactionId:%v, ", *o.TransactionId)
+	}
+
+	if o.OutTradeNo == nil {
+		ret += "OutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutTradeNo:%v, ", *o.OutTradeNo)
+	}
+
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.SubAppid == nil {
+		ret += "SubAppid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubAppid:%v, ", *o.SubAppid)
+	}
+
+	if o.SubOpenid == nil {
+		ret += "SubOpenid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubOpenid:%v, ", *o.SubOpenid)
+	}
+
+	ret += fmt.Sprintf("Amount:%v, ", o.Amount)
+
+	ret += fmt.Sprintf("PromotionDetail:%v", o.PromotionDetail)
+
+	return fmt.Sprintf("SubOrderTransaction{%s}", ret)
+}
+
+func (o SubOrderTransaction) Clone() *SubOrderTransaction {
+	ret := SubOrderTransaction{}
+
+	if o.Mchid != nil {
+		ret.Mchid = new(string)
+		*ret.Mchid = *o.Mchid
+	}
+
+	if o.TradeType != nil {
+		ret.TradeType = new(string)
+		*ret.TradeType = *o.TradeType
+	}
+
+	if o.TradeState != nil {
+		ret.TradeState = new(string)
+		*ret.TradeState = *o.TradeState
+	}
+
+	if o.BankType != nil {
+		ret.BankType = new(string)
+		*ret.BankType = *o.BankType
+	}
+
+	if o.Attach != nil {
+		ret.Attach = new(string)
+		*ret.Attach = *o.Attach
+	}
+
+	if o.SuccessTime != nil {
+		ret.SuccessTime = new(string)
+		*ret.SuccessTime = *o.SuccessTime
+	}
+
+	if o.TransactionId != nil {
+		ret.TransactionId = new(string)
+		*ret.TransactionId = *o.TransactionId
+	}
+
+	if o.OutTradeNo != nil {
+		ret.OutTradeNo = new(string)
+		*ret.OutTradeNo = *o.OutTradeNo
+	}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.SubAppid != nil {
+		ret.SubAppid = new(string)
+		*ret.SubAppid = *o.SubAppid
+	}
+
+	if o.SubOpenid != nil {
+		ret.SubOpenid = new(string)
+		*ret.SubOpenid = *o.SubOpenid
+	}
+
+	if o.Amount != nil {
+		ret.Amount = o.Amount.Clone()
+	}
+
+	if o.PromotionDetail != nil {
+		ret.PromotionDetail = make([]payments.PromotionDetail, len(o.PromotionDetail))
+		for i, item := range o.PromotionDetail {
+			ret.PromotionDetail[i] = *item.Clone()
+		}
+	}
+
+	return &ret
+}
+
+// TransactionAmount 订单金额信息
+type TransactionAmount struct {
+	// 子单金额，单位为分
+	TotalAmount *int64 `json:"total_amount,omitempty"`
+	// 标价币种
+	Currency *string `json:"currency,omitempty"`
+	// 用户支付金额，单位为分
+	PayerAmount *int64 `json:"payer_amount,omitempty"`
+	// 用户支付币种
+	PayerCurrency *string `json:"payer_currency,omitempty"`
+	// 结算汇率
+	SettlementRate *int64 `json:"settlement_rate,omitempty"`
+}
+
+func (o TransactionAmount) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.TotalAmount != nil {
+		toSerialize["total_amount"] = o.TotalAmount
+	}
+
+	if o.Currency != nil {
+		toSerialize["currency"] = o.Currency
+	}
+
+	if o.PayerAmount != nil {
+		toSerialize["payer_amount"] = o.PayerAmount
+	}
+
+	if o.PayerCurrency != nil {
+		toSerialize["payer_currency"] = o.PayerCurrency
+	}
+
+	if o.SettlementRate != nil {
+		toSerialize["settlement_rate"] = o.SettlementRate
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o TransactionAmount) String() string {
+	var ret string
+	if o.TotalAmount == nil {
+		ret += "TotalAmount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TotalAmount:%v, ", *o.TotalAmount)
+	}
+
+	if o.Currency == nil {
+		ret += "Currency:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Currency:%v, ", *o.Currency)
+	}
+
+	if o.PayerAmount == nil {
+		ret += "PayerAmount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PayerAmount:%v, ", *o.PayerAmount)
+	}
+
+	if o.PayerCurrency == nil {
+		ret += "PayerCurrency:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PayerCurrency:%v, ", *o.PayerCurrency)
+	}
+
+	if o.SettlementRate == nil {
+		ret += "SettlementRate:<nil>"
+	} else {
+		ret += fmt.Sprintf("SettlementRate:%v", *o.SettlementRate)
+	}
+
+	return fmt.Sprintf("TransactionAmount{%s}", ret)
+}
+
+func (o TransactionAmount) Clone() *TransactionAmount {
+	ret := TransactionAmount{}
+
+	if o.TotalAmount != nil {
+		ret.TotalAmount = new(int64)
+		*ret.TotalAmount = *o.TotalAmount
+	}
+
+	if o.Currency != nil {
+		ret.Currency = new(string)
+		*ret.Currency = *o.Currency
+	}
+
+	if o.PayerAmount != nil {
+		ret.PayerAmount = new(int64)
+		*ret.PayerAmount = *o.PayerAmount
+	}
+
+	if o.PayerCurrency != nil {
+		ret.PayerCurrency = new(string)
+		*ret.PayerCurrency = *o.PayerCurrency
+	}
+
+	if o.SettlementRate != nil {
+		ret.SettlementRate = new(int64)
+		*ret.SettlementRate = *o.SettlementRate
+	}
+
+	return &ret
+}
+
+// TransactionSceneInfo 支付场景信息
+type TransactionSceneInfo struct {
+	// 终端设备号（门店号或收银设备ID）
+	DeviceId *string `json:"device_id,omitempty"`
+}
+
+func (o TransactionSceneInfo) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.DeviceId != nil {
+		toSerialize["device_id"] = o.DeviceId
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o TransactionSceneInfo) String() string {
+	var ret string
+	if o.DeviceId == nil {
+		ret += "DeviceId:<nil>"
+	} else {
+		ret += fmt.Sprintf("DeviceId:%v", *o.DeviceId)
+	}
+
+	return fmt.Sprintf("TransactionSceneInfo{%s}", ret)
+}
+
+func (o TransactionSceneInfo) Clone() *TransactionSceneInfo {
+	ret := TransactionSceneInfo{}
+
+	if o.DeviceId != nil {
+		ret.DeviceId = new(string)
+		*ret.DeviceId = *o.DeviceId
+	}
+
+	return &ret
+}
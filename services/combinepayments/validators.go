@@ -0,0 +1,138 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package combinepayments
+
+import (
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 Amount 的字段
+func (o Amount) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("Amount", opts...)
+	c.Required("total_amount", o.TotalAmount != nil)
+	c.Range("total_amount", o.TotalAmount, 1, 0)
+	c.Required("currency", o.Currency != nil)
+	c.Pattern("currency", o.Currency, "^[A-Z]{3}$")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CloseOrderRequest 的字段
+func (o CloseOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CloseOrderRequest", opts...)
+	c.Required("combine_out_trade_no", o.CombineOutTradeNo != nil)
+	c.ID("combine_out_trade_no", o.CombineOutTradeNo, idgen.CombineOutTradeNo)
+	c.Required("combine_appid", o.CombineAppid != nil)
+	c.Length("combine_appid", o.CombineAppid, 0, 32)
+	c.Required("sub_orders", o.SubOrders != nil)
+	for i, item := range o.SubOrders {
+		c.Nested(validation.Index("sub_orders", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CloseSubOrder 的字段
+func (o CloseSubOrder) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CloseSubOrder", opts...)
+	c.Required("mchid", o.Mchid != nil)
+	c.Length("mchid", o.Mchid, 0, 32)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.CombineOutTradeNo)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CombinePayerInfo 的字段
+func (o CombinePayerInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CombinePayerInfo", opts...)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 H5Info 的字段
+func (o H5Info) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("H5Info", opts...)
+	c.Required("type", o.Type != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PrepayRequest 的字段
+func (o PrepayRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PrepayRequest", opts...)
+	c.Required("combine_appid", o.CombineAppid != nil)
+	c.Length("combine_appid", o.CombineAppid, 0, 32)
+	c.Required("combine_mchid", o.CombineMchid != nil)
+	c.Length("combine_mchid", o.CombineMchid, 0, 32)
+	c.Required("combine_out_trade_no", o.CombineOutTradeNo != nil)
+	c.ID("combine_out_trade_no", o.CombineOutTradeNo, idgen.CombineOutTradeNo)
+	if o.SceneInfo != nil {
+		c.Nested("scene_info", o.SceneInfo.Validate(opts...))
+	}
+	c.Required("sub_orders", o.SubOrders != nil)
+	for i, item := range o.SubOrders {
+		c.Nested(validation.Index("sub_orders", i), item.Validate(opts...))
+	}
+	if o.CombinePayerInfo != nil {
+		c.Nested("combine_payer_info", o.CombinePayerInfo.Validate(opts...))
+	}
+	c.Window("time_expire", o.TimeExpire, chinatime.Window{Min: time.Minute})
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryOrderRequest 的字段
+func (o QueryOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryOrderRequest", opts...)
+	c.Required("combine_out_trade_no", o.CombineOutTradeNo != nil)
+	c.ID("combine_out_trade_no", o.CombineOutTradeNo, idgen.CombineOutTradeNo)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SceneInfo 的字段
+func (o SceneInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SceneInfo", opts...)
+	c.Required("payer_client_ip", o.PayerClientIp != nil)
+	c.Length("payer_client_ip", o.PayerClientIp, 0, 45)
+	if o.H5Info != nil {
+		c.Nested("h5_info", o.H5Info.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SettleInfo 的字段
+func (o SettleInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SettleInfo", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SubOrder 的字段
+func (o SubOrder) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SubOrder", opts...)
+	c.Required("mchid", o.Mchid != nil)
+	c.Length("mchid", o.Mchid, 0, 32)
+	c.Required("attach", o.Attach != nil)
+	c.Length("attach", o.Attach, 0, 128)
+	c.Required("amount", o.Amount != nil)
+	if o.Amount != nil {
+		c.Nested("amount", o.Amount.Validate(opts...))
+	}
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.CombineOutTradeNo)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("description", o.Description != nil)
+	c.Length("description", o.Description, 1, 127)
+	c.Length("goods_tag", o.GoodsTag, 0, 32)
+	if o.SettleInfo != nil {
+		c.Nested("settle_info", o.SettleInfo.Validate(opts...))
+	}
+	return c.Err()
+}
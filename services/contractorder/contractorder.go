@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+
+	"github.com/jemuri/wechatpay-go/core"
 )
 
 // ContractOrderApiService 支付中签约服务
@@ -284,6 +286,9 @@ func (s *ContractOrderApiService) doRequest(ctx context.Context, xmlStr string)
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/xml")
+	if core.IsDryRun(ctx) {
+		return nil, &core.DryRunError{Request: req}
+	}
 	client := &http.Client{}
 	return client.Do(req)
 }
@@ -0,0 +1,63 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package contractordertest 提供 contractorder 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package contractordertest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jemuri/wechatpay-go/services/contractorder"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeContractOrderAPI contractorder.ContractOrderAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeContractOrderAPI struct {
+	servicetest.Recorder
+
+	ContractOrderFunc        func(context.Context, *contractorder.ContractOrderRequest) (*contractorder.ContractOrderResponse, error)
+	HandleContractNotifyFunc func(context.Context, *http.Request) (*contractorder.ContractNotifyRequest, *contractorder.ContractNotifyResponse, error)
+}
+
+var _ contractorder.ContractOrderAPI = (*FakeContractOrderAPI)(nil)
+
+// ContractOrder 记录调用并返回 ContractOrderFunc 的结果
+func (f *FakeContractOrderAPI) ContractOrder(ctx context.Context, req *contractorder.ContractOrderRequest) (r0 *contractorder.ContractOrderResponse, err error) {
+	f.Record("ContractOrder", req)
+	if f.ContractOrderFunc == nil {
+		err = servicetest.NotStubbed("ContractOrderAPI", "ContractOrder")
+		return
+	}
+	return f.ContractOrderFunc(ctx, req)
+}
+
+// ContractOrderReturns 设置 ContractOrder 的返回值
+func (f *FakeContractOrderAPI) ContractOrderReturns(r0 *contractorder.ContractOrderResponse, err error) *FakeContractOrderAPI {
+	f.ContractOrderFunc = func(context.Context, *contractorder.ContractOrderRequest) (*contractorder.ContractOrderResponse, error) {
+		return r0, err
+	}
+	return f
+}
+
+// HandleContractNotify 记录调用并返回 HandleContractNotifyFunc 的结果
+func (f *FakeContractOrderAPI) HandleContractNotify(ctx context.Context, req *http.Request) (r0 *contractorder.ContractNotifyRequest, r1 *contractorder.ContractNotifyResponse, err error) {
+	f.Record("HandleContractNotify", req)
+	if f.HandleContractNotifyFunc == nil {
+		err = servicetest.NotStubbed("ContractOrderAPI", "HandleContractNotify")
+		return
+	}
+	return f.HandleContractNotifyFunc(ctx, req)
+}
+
+// HandleContractNotifyReturns 设置 HandleContractNotify 的返回值
+func (f *FakeContractOrderAPI) HandleContractNotifyReturns(r0 *contractorder.ContractNotifyRequest, r1 *contractorder.ContractNotifyResponse, err error) *FakeContractOrderAPI {
+	f.HandleContractNotifyFunc = func(context.Context, *http.Request) (*contractorder.ContractNotifyRequest, *contractorder.ContractNotifyResponse, error) {
+		return r0, r1, err
+	}
+	return f
+}
@@ -0,0 +1,23 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package contractorder
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContractOrderAPI ContractOrderApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ContractOrderApiService，即可在测试中使用 contractordertest.FakeContractOrderAPI 替代
+type ContractOrderAPI interface {
+	// ContractOrder 支付中签约
+	ContractOrder(ctx context.Context, req *ContractOrderRequest) (*ContractOrderResponse, error)
+
+	// HandleContractNotify 处理签约、解约结果通知
+	HandleContractNotify(ctx context.Context, req *http.Request) (*ContractNotifyRequest, *ContractNotifyResponse, error)
+}
+
+var _ ContractOrderAPI = (*ContractOrderApiService)(nil)
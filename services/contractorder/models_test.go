@@ -0,0 +1,44 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package contractorder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/services/contractorder"
+)
+
+func TestContractOrderRequest_Validate(t *testing.T) {
+	req := contractorder.ContractOrderRequest{
+		OutTradeNo:             "order@123",
+		NonceStr:               "5K8264ILTKCH16CQ2502SI8ZNMTM67VS",
+		Body:                   "Ipad mini 16G 白色",
+		NotifyURL:              "http://weixin.qq.com",
+		TotalFee:               888,
+		SpbillCreateIP:         "123.12.12.123",
+		TradeType:              "JSAPI",
+		PlanID:                 123,
+		ContractCode:           "100001256",
+		RequestSerial:          1000,
+		ContractDisplayAccount: "微信代扣",
+		ContractNotifyURL:      "https://yoursite.com",
+	}
+	// 由服务填充的 appid、mch_id、sign 等字段未设置时不报错
+	assert.NoError(t, req.Validate())
+
+	req.OutTradeNo = strings.Repeat("1", 33)
+	req.TotalFee = -1
+	req.TimeExpire = "2021-06-08T10:34:56+08:00"
+	validationErr, ok := validation.AsError(req.Validate())
+	require.True(t, ok)
+	assert.Equal(t, "ContractOrderRequest", validationErr.Type)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "out_trade_no", Reason: "must be 1 to 32 bytes, got 33"},
+		{Field: "total_fee", Reason: "must be at least 1, got -1"},
+		{Field: "time_expire", Reason: `must match ^[0-9]{14}$, got "2021-06-08T10:34:56+08:00"`},
+	}, validationErr.Fields)
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package contractorder
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+)
+
+// Validate 按接口文档中的约束校验 ContractOrderRequest 的字段
+func (o ContractOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ContractOrderRequest", opts...)
+	c.Length("appid", validation.String(o.AppID), 0, 32)
+	c.Length("out_trade_no", validation.String(o.OutTradeNo), 1, 32)
+	c.Length("nonce_str", validation.String(o.NonceStr), 0, 32)
+	c.Length("body", validation.String(o.Body), 1, 128)
+	c.Length("detail", validation.String(o.Detail), 0, 6000)
+	c.Length("attach", validation.String(o.Attach), 0, 128)
+	c.Length("notify_url", validation.String(o.NotifyURL), 0, 256)
+	c.Range("total_fee", validation.Int64(int64(o.TotalFee)), 1, 0)
+	c.Length("spbill_create_ip", validation.String(o.SpbillCreateIP), 0, 64)
+	c.Pattern("time_start", validation.String(o.TimeStart), "^[0-9]{14}$")
+	c.Pattern("time_expire", validation.String(o.TimeExpire), "^[0-9]{14}$")
+	c.Length("goods_tag", validation.String(o.GoodsTag), 0, 32)
+	c.Length("openid", validation.String(o.OpenID), 0, 128)
+	c.Range("plan_id", validation.Int64(int64(o.PlanID)), 1, 0)
+	c.Length("contract_code", validation.String(o.ContractCode), 1, 32)
+	c.Range("request_serial", validation.Int64(o.RequestSerial), 1, 0)
+	c.Length("contract_display_account", validation.String(o.ContractDisplayAccount), 1, 32)
+	c.Length("contract_notify_url", validation.String(o.ContractNotifyURL), 0, 256)
+	return c.Err()
+}
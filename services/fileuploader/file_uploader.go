@@ -7,35 +7,50 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
+	"strings"
+	"sync"
 
 	"github.com/jemuri/wechatpay-go/core"
 	"github.com/jemuri/wechatpay-go/core/consts"
 	"github.com/jemuri/wechatpay-go/services"
 )
 
+// 各上传接口允许的文件大小上限
+const (
+	MaxImageSize          = 2 * 1024 * 1024 // 图片上传 API：2MB
+	MaxVideoSize          = 5 * 1024 * 1024 // 视频上传 API：5MB
+	MaxMarketingImageSize = 2 * 1024 * 1024 // 图片上传（营销专用）API：2MB
+	MaxMchBizImageSize    = 2 * 1024 * 1024 // 商户上传反馈图片 API：2MB
+)
+
 // baseFileUploader 基础文件上传
 type baseFileUploader services.Service
 
 // upload 将指定文件内容上传到指定地址
 //
+// 文件内容以流式方式发送：先计算文件的 SHA-256 与大小并检查大小上限（fileReader 实现了 io.Seeker 时不会缓存文件内容），
+// 再通过 io.Pipe 边读取文件边发送 multipart 请求体，只有 meta JSON 参与签名。
+// maxSize <= 0 时不检查文件大小。
+//
 // 注意：urlpath 不要包含微信支付API服务地址，只包含路径即可，例如 `/v3/merchant/media/upload`
 func (s *baseFileUploader) upload(
 	ctx context.Context, urlpath string, fileReader io.Reader, filename, contentType string,
-	extra map[string]interface{},
+	extra map[string]interface{}, maxSize int64,
 ) (*core.APIResult, error) {
 	urlpath = consts.WechatPayAPIServer + urlpath
 
-	content, err := ioutil.ReadAll(fileReader)
+	file, size, digest, err := prepareFile(fileReader, maxSize)
 	if err != nil {
 		return nil, err
 	}
 
 	meta := make(map[string]interface{})
 	meta["filename"] = core.String(filename)
-	meta["sha256"] = core.String(fmt.Sprintf("%x", sha256.Sum256(content)))
+	meta["sha256"] = core.String(digest)
 
 	// Override with extra info
 	for key, value := range extra {
@@ -47,21 +62,125 @@ func (s *baseFileUploader) upload(
 		return nil, err
 	}
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+	writeBody := func(w io.Writer, file io.Reader) error {
+		writer := multipart.NewWriter(w)
+		if err := writer.SetBoundary(boundary); err != nil {
+			return err
+		}
+		if err := core.CreateFormField(writer, "meta", "application/json", []byte(metaStr)); err != nil {
+			return err
+		}
+		if err := core.CreateFormFileWithReader(writer, filename, contentType, file); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
 
-	if err = core.CreateFormField(writer, "meta", "application/json", []byte(metaStr)); err != nil {
+	// 使用空文件计算 multipart 的额外长度，得到请求体的 Content-Length
+	counter := &countingWriter{}
+	if err = writeBody(counter, strings.NewReader("")); err != nil {
 		return nil, err
 	}
 
-	if err = core.CreateFormFile(writer, filename, contentType, content); err != nil {
-		return nil, err
+	body := &multipartStream{write: func(w io.Writer) error { return writeBody(w, file) }}
+	return s.Client.UploadStream(
+		ctx, urlpath, metaStr, body, counter.n+size, "multipart/form-data; boundary="+boundary,
+	)
+}
+
+// prepareFile 计算文件的 SHA-256 与大小并检查大小上限，返回用于发送的文件内容
+//
+// fileReader 实现了 io.Seeker 时读取后回到原位置，不缓存文件内容；否则文件内容只会被读入内存一次
+func prepareFile(fileReader io.Reader, maxSize int64) (file io.Reader, size int64, digest string, err error) {
+	limited := fileReader
+	if maxSize > 0 {
+		limited = io.LimitReader(fileReader, maxSize+1)
 	}
 
-	if err = writer.Close(); err != nil {
-		return nil, err
+	h := sha256.New()
+	if seeker, ok := fileReader.(io.ReadSeeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		if size, err = io.Copy(h, limited); err != nil {
+			return nil, 0, "", err
+		}
+		if err = checkFileSize(size, maxSize); err != nil {
+			return nil, 0, "", err
+		}
+		if _, err = seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, 0, "", err
+		}
+		return io.LimitReader(seeker, size), size, hexDigest(h), nil
+	}
+
+	content, err := ioutil.ReadAll(limited)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	size = int64(len(content))
+	if err = checkFileSize(size, maxSize); err != nil {
+		return nil, 0, "", err
+	}
+	_, _ = h.Write(content)
+	return bytes.NewReader(content), size, hexDigest(h), nil
+}
+
+func checkFileSize(size, maxSize int64) error {
+	if maxSize > 0 && size > maxSize {
+		return fmt.Errorf("file size exceeds the limit of %d bytes", maxSize)
 	}
+	return nil
+}
+
+func hexDigest(h hash.Hash) string {
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// countingWriter 只统计写入字节数的 io.Writer
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// multipartStream 通过 io.Pipe 流式输出 multipart 请求体
+//
+// 写入 goroutine 在首次读取时才启动，请求未发出即被关闭时不会泄漏 goroutine；
+// 关闭后写入 goroutine 的写操作会失败并退出
+type multipartStream struct {
+	write func(w io.Writer) error
+
+	initOnce  sync.Once
+	startOnce sync.Once
+	reader    *io.PipeReader
+	writer    *io.PipeWriter
+}
+
+func (s *multipartStream) init() {
+	s.initOnce.Do(func() {
+		s.reader, s.writer = io.Pipe()
+	})
+}
+
+// Read 读取 multipart 请求体，首次读取时启动写入 goroutine
+func (s *multipartStream) Read(p []byte) (int, error) {
+	s.init()
+	s.startOnce.Do(func() {
+		go func() {
+			_ = s.writer.CloseWithError(s.write(s.writer))
+		}()
+	})
+	return s.reader.Read(p)
+}
 
-	result, err := s.Client.Upload(ctx, urlpath, metaStr, body.String(), writer.FormDataContentType())
-	return result, err
+// Close 关闭请求体
+func (s *multipartStream) Close() error {
+	s.init()
+	return s.reader.Close()
 }
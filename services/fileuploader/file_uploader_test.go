@@ -0,0 +1,183 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package fileuploader_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services/fileuploader"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type capturedUpload struct {
+	calls         int
+	contentLength int64
+	bodyLength    int64
+	meta          map[string]string
+	filename      string
+	content       []byte
+}
+
+func newTestUploadClient(t *testing.T, captured *capturedUpload) *core.Client {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		captured.calls++
+		captured.contentLength = req.ContentLength
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		captured.bodyLength = int64(len(body))
+
+		_, params, err := mime.ParseMediaType(req.Header.Get(consts.ContentType))
+		if err != nil {
+			return nil, err
+		}
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(form.Value["meta"][0]), &captured.meta); err != nil {
+			return nil, err
+		}
+		file, err := form.File["file"][0].Open()
+		if err != nil {
+			return nil, err
+		}
+		captured.filename = form.File["file"][0].Filename
+		captured.content, _ = ioutil.ReadAll(file)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{consts.ContentType: []string{consts.ApplicationJSON}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"media_id":"media-id"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client, err := core.NewClient(
+		context.Background(),
+		option.WithMerchantCredential("1900009191", "SERIAL", privateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func TestVideoUploader_Upload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100*1024)
+	digest := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	tests := []struct {
+		name   string
+		reader io.Reader
+	}{
+		{name: "seekable reader", reader: bytes.NewReader(content)},
+		{name: "non-seekable reader", reader: ioutil.NopCloser(bytes.NewReader(content))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured := &capturedUpload{}
+			svc := fileuploader.VideoUploader{Client: newTestUploadClient(t, captured)}
+
+			resp, result, err := svc.Upload(context.Background(), tt.reader, "video.mp4", consts.VideoMP4)
+			require.NoError(t, err)
+			assert.Equal(t, "media-id", *resp.MediaId)
+			assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+
+			assert.Equal(t, captured.bodyLength, captured.contentLength)
+			assert.Equal(t, map[string]string{"filename": "video.mp4", "sha256": digest}, captured.meta)
+			assert.Equal(t, "video.mp4", captured.filename)
+			assert.Equal(t, content, captured.content)
+		})
+	}
+}
+
+func TestImageUploader_UploadExceedsLimit(t *testing.T) {
+	captured := &capturedUpload{}
+	svc := fileuploader.ImageUploader{Client: newTestUploadClient(t, captured)}
+
+	content := make([]byte, fileuploader.MaxImageSize+1)
+	_, _, err := svc.Upload(context.Background(), bytes.NewReader(content), "picture.jpg", consts.ImageJPG)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the limit")
+	assert.Equal(t, 0, captured.calls)
+
+	_, _, err = svc.Upload(
+		context.Background(), ioutil.NopCloser(bytes.NewReader(content)), "picture.jpg", consts.ImageJPG,
+	)
+	require.Error(t, err)
+	assert.Equal(t, 0, captured.calls)
+}
+
+func TestImageUploader_UploadFromCurrentOffset(t *testing.T) {
+	captured := &capturedUpload{}
+	svc := fileuploader.ImageUploader{Client: newTestUploadClient(t, captured)}
+
+	reader := bytes.NewReader([]byte("headerpicture"))
+	_, err := reader.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+
+	_, _, err = svc.Upload(context.Background(), reader, "picture.jpg", consts.ImageJPG)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("picture"), captured.content)
+	assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte("picture"))), captured.meta["sha256"])
+}
+
+func TestImageUploader_UploadDryRun(t *testing.T) {
+	captured := &capturedUpload{}
+	svc := fileuploader.ImageUploader{Client: newTestUploadClient(t, captured)}
+
+	content := []byte("\x89PNG\r\n\x1a\n")
+	resp, _, err := svc.Upload(
+		core.WithDryRun(context.Background()), ioutil.NopCloser(bytes.NewReader(content)), "picture.png", consts.ImagePNG,
+	)
+	require.Error(t, err)
+	assert.Equal(t, 0, captured.calls)
+	assert.Nil(t, resp)
+
+	dryRunErr, ok := core.AsDryRunError(err)
+	require.True(t, ok)
+	assert.Equal(t, "/v3/merchant/media/upload", dryRunErr.Request.URL.Path)
+	assert.Contains(t, dryRunErr.SignMessage, `"filename":"picture.png"`)
+
+	// 流式请求体在试运行时被读入内存，可以重复读取
+	body, err := dryRunErr.Request.GetBody()
+	require.NoError(t, err)
+	content2, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content2)), dryRunErr.Request.ContentLength)
+	assert.True(t, bytes.Contains(content2, content))
+
+	curl, err := dryRunErr.Curl()
+	require.NoError(t, err)
+	assert.Contains(t, curl, `--data-binary $'`)
+	assert.Contains(t, curl, `\x89PNG\x0d\x0a\x1a\x0a`)
+}
@@ -27,6 +27,7 @@ func (u *ImageUploader) Upload(
 ) (*ImageUploadResponse, *core.APIResult, error) {
 	result, err := (*baseFileUploader)(u).upload(
 		ctx, "/v3/merchant/media/upload", fileReader, filename, contentType, map[string]interface{}{},
+		MaxImageSize,
 	)
 	if err != nil {
 		return nil, result, err
@@ -28,6 +28,7 @@ func (u *MarketingImageUploader) Upload(
 ) (*MarketingImageUploadResponse, *core.APIResult, error) {
 	result, err := (*baseFileUploader)(u).upload(
 		ctx, "/v3/marketing/favor/media/image-upload", fileReader, filename, contentType, map[string]interface{}{},
+		MaxMarketingImageSize,
 	)
 	if err != nil {
 		return nil, result, err
@@ -27,6 +27,7 @@ func (u *MchBizUploader) Upload(
 ) (*MchBizUploadResponse, *core.APIResult, error) {
 	result, err := (*baseFileUploader)(u).upload(
 		ctx, "/v3/merchant-service/images/upload", fileReader, filename, contentType, map[string]interface{}{},
+		MaxMchBizImageSize,
 	)
 	if err != nil {
 		return nil, result, err
@@ -27,6 +27,7 @@ func (u *VideoUploader) Upload(
 ) (*VideoUploadResponse, *core.APIResult, error) {
 	result, err := (*baseFileUploader)(u).upload(
 		ctx, "/v3/merchant/media/video_upload", fileReader, filename, contentType, map[string]interface{}{},
+		MaxVideoSize,
 	)
 	if err != nil {
 		return nil, result, err
@@ -36,6 +36,11 @@ func (a *ActivityApiService) AddActivityMerchant(ctx context.Context, req AddAct
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in AddActivityMerchantRequest")
@@ -85,6 +90,11 @@ func (a *ActivityApiService) CreateFullSendAct(ctx context.Context, req CreateFu
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/paygiftactivity/unique-threshold-activity"
 	// Make sure All Required Params are properly set
 
@@ -123,6 +133,11 @@ func (a *ActivityApiService) DeleteActivityMerchant(ctx context.Context, req Del
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in DeleteActivityMerchantRequest")
@@ -172,6 +187,11 @@ func (a *ActivityApiService) GetActDetail(ctx context.Context, req GetActDetailR
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in GetActDetailRequest")
@@ -215,6 +235,11 @@ func (a *ActivityApiService) ListActivities(ctx context.Context, req ListActivit
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/paygiftactivity/activities"
 	// Make sure All Required Params are properly set
 	if req.Offset == nil {
@@ -270,6 +295,11 @@ func (a *ActivityApiService) ListActivityMerchant(ctx context.Context, req ListA
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in ListActivityMerchantRequest")
@@ -322,6 +352,11 @@ func (a *ActivityApiService) ListActivitySku(ctx context.Context, req ListActivi
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in ListActivitySkuRequest")
@@ -374,6 +409,11 @@ func (a *ActivityApiService) TerminateActivity(ctx context.Context, req Terminat
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in TerminateActivityRequest")
@@ -0,0 +1,177 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package giftactivitytest 提供 giftactivity 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package giftactivitytest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/giftactivity"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeActivityAPI giftactivity.ActivityAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeActivityAPI struct {
+	servicetest.Recorder
+
+	AddActivityMerchantFunc    func(context.Context, giftactivity.AddActivityMerchantRequest) (*giftactivity.AddActivityMerchantResponse, *core.APIResult, error)
+	CreateFullSendActFunc      func(context.Context, giftactivity.CreateFullSendActRequest) (*giftactivity.CreateFullSendActResponse, *core.APIResult, error)
+	DeleteActivityMerchantFunc func(context.Context, giftactivity.DeleteActivityMerchantRequest) (*giftactivity.DeleteActivityMerchantResponse, *core.APIResult, error)
+	GetActDetailFunc           func(context.Context, giftactivity.GetActDetailRequest) (*giftactivity.GetActDetailResponse, *core.APIResult, error)
+	ListActivitiesFunc         func(context.Context, giftactivity.ListActivitiesRequest) (*giftactivity.ListActivitiesResponse, *core.APIResult, error)
+	ListActivityMerchantFunc   func(context.Context, giftactivity.ListActivityMerchantRequest) (*giftactivity.ListActMchResponse, *core.APIResult, error)
+	ListActivitySkuFunc        func(context.Context, giftactivity.ListActivitySkuRequest) (*giftactivity.ListActSkuResponse, *core.APIResult, error)
+	TerminateActivityFunc      func(context.Context, giftactivity.TerminateActivityRequest) (*giftactivity.TerminateActResponse, *core.APIResult, error)
+}
+
+var _ giftactivity.ActivityAPI = (*FakeActivityAPI)(nil)
+
+// AddActivityMerchant 记录调用并返回 AddActivityMerchantFunc 的结果
+func (f *FakeActivityAPI) AddActivityMerchant(ctx context.Context, req giftactivity.AddActivityMerchantRequest) (resp *giftactivity.AddActivityMerchantResponse, result *core.APIResult, err error) {
+	f.Record("AddActivityMerchant", req)
+	if f.AddActivityMerchantFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "AddActivityMerchant")
+		return
+	}
+	return f.AddActivityMerchantFunc(ctx, req)
+}
+
+// AddActivityMerchantReturns 设置 AddActivityMerchant 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) AddActivityMerchantReturns(resp *giftactivity.AddActivityMerchantResponse, err error) *FakeActivityAPI {
+	f.AddActivityMerchantFunc = func(context.Context, giftactivity.AddActivityMerchantRequest) (*giftactivity.AddActivityMerchantResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// CreateFullSendAct 记录调用并返回 CreateFullSendActFunc 的结果
+func (f *FakeActivityAPI) CreateFullSendAct(ctx context.Context, req giftactivity.CreateFullSendActRequest) (resp *giftactivity.CreateFullSendActResponse, result *core.APIResult, err error) {
+	f.Record("CreateFullSendAct", req)
+	if f.CreateFullSendActFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "CreateFullSendAct")
+		return
+	}
+	return f.CreateFullSendActFunc(ctx, req)
+}
+
+// CreateFullSendActReturns 设置 CreateFullSendAct 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) CreateFullSendActReturns(resp *giftactivity.CreateFullSendActResponse, err error) *FakeActivityAPI {
+	f.CreateFullSendActFunc = func(context.Context, giftactivity.CreateFullSendActRequest) (*giftactivity.CreateFullSendActResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeleteActivityMerchant 记录调用并返回 DeleteActivityMerchantFunc 的结果
+func (f *FakeActivityAPI) DeleteActivityMerchant(ctx context.Context, req giftactivity.DeleteActivityMerchantRequest) (resp *giftactivity.DeleteActivityMerchantResponse, result *core.APIResult, err error) {
+	f.Record("DeleteActivityMerchant", req)
+	if f.DeleteActivityMerchantFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "DeleteActivityMerchant")
+		return
+	}
+	return f.DeleteActivityMerchantFunc(ctx, req)
+}
+
+// DeleteActivityMerchantReturns 设置 DeleteActivityMerchant 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) DeleteActivityMerchantReturns(resp *giftactivity.DeleteActivityMerchantResponse, err error) *FakeActivityAPI {
+	f.DeleteActivityMerchantFunc = func(context.Context, giftactivity.DeleteActivityMerchantRequest) (*giftactivity.DeleteActivityMerchantResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// GetActDetail 记录调用并返回 GetActDetailFunc 的结果
+func (f *FakeActivityAPI) GetActDetail(ctx context.Context, req giftactivity.GetActDetailRequest) (resp *giftactivity.GetActDetailResponse, result *core.APIResult, err error) {
+	f.Record("GetActDetail", req)
+	if f.GetActDetailFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "GetActDetail")
+		return
+	}
+	return f.GetActDetailFunc(ctx, req)
+}
+
+// GetActDetailReturns 设置 GetActDetail 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) GetActDetailReturns(resp *giftactivity.GetActDetailResponse, err error) *FakeActivityAPI {
+	f.GetActDetailFunc = func(context.Context, giftactivity.GetActDetailRequest) (*giftactivity.GetActDetailResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListActivities 记录调用并返回 ListActivitiesFunc 的结果
+func (f *FakeActivityAPI) ListActivities(ctx context.Context, req giftactivity.ListActivitiesRequest) (resp *giftactivity.ListActivitiesResponse, result *core.APIResult, err error) {
+	f.Record("ListActivities", req)
+	if f.ListActivitiesFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "ListActivities")
+		return
+	}
+	return f.ListActivitiesFunc(ctx, req)
+}
+
+// ListActivitiesReturns 设置 ListActivities 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) ListActivitiesReturns(resp *giftactivity.ListActivitiesResponse, err error) *FakeActivityAPI {
+	f.ListActivitiesFunc = func(context.Context, giftactivity.ListActivitiesRequest) (*giftactivity.ListActivitiesResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListActivityMerchant 记录调用并返回 ListActivityMerchantFunc 的结果
+func (f *FakeActivityAPI) ListActivityMerchant(ctx context.Context, req giftactivity.ListActivityMerchantRequest) (resp *giftactivity.ListActMchResponse, result *core.APIResult, err error) {
+	f.Record("ListActivityMerchant", req)
+	if f.ListActivityMerchantFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "ListActivityMerchant")
+		return
+	}
+	return f.ListActivityMerchantFunc(ctx, req)
+}
+
+// ListActivityMerchantReturns 设置 ListActivityMerchant 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) ListActivityMerchantReturns(resp *giftactivity.ListActMchResponse, err error) *FakeActivityAPI {
+	f.ListActivityMerchantFunc = func(context.Context, giftactivity.ListActivityMerchantRequest) (*giftactivity.ListActMchResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListActivitySku 记录调用并返回 ListActivitySkuFunc 的结果
+func (f *FakeActivityAPI) ListActivitySku(ctx context.Context, req giftactivity.ListActivitySkuRequest) (resp *giftactivity.ListActSkuResponse, result *core.APIResult, err error) {
+	f.Record("ListActivitySku", req)
+	if f.ListActivitySkuFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "ListActivitySku")
+		return
+	}
+	return f.ListActivitySkuFunc(ctx, req)
+}
+
+// ListActivitySkuReturns 设置 ListActivitySku 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) ListActivitySkuReturns(resp *giftactivity.ListActSkuResponse, err error) *FakeActivityAPI {
+	f.ListActivitySkuFunc = func(context.Context, giftactivity.ListActivitySkuRequest) (*giftactivity.ListActSkuResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// TerminateActivity 记录调用并返回 TerminateActivityFunc 的结果
+func (f *FakeActivityAPI) TerminateActivity(ctx context.Context, req giftactivity.TerminateActivityRequest) (resp *giftactivity.TerminateActResponse, result *core.APIResult, err error) {
+	f.Record("TerminateActivity", req)
+	if f.TerminateActivityFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "TerminateActivity")
+		return
+	}
+	return f.TerminateActivityFunc(ctx, req)
+}
+
+// TerminateActivityReturns 设置 TerminateActivity 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) TerminateActivityReturns(resp *giftactivity.TerminateActResponse, err error) *FakeActivityAPI {
+	f.TerminateActivityFunc = func(context.Context, giftactivity.TerminateActivityRequest) (*giftactivity.TerminateActResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package giftactivity
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// ActivityAPI ActivityApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ActivityApiService，即可在测试中使用 giftactivitytest.FakeActivityAPI 替代
+type ActivityAPI interface {
+	// AddActivityMerchant 新增活动发券商户号
+	AddActivityMerchant(ctx context.Context, req AddActivityMerchantRequest) (resp *AddActivityMerchantResponse, result *core.APIResult, err error)
+
+	// CreateFullSendAct 创建全场满额送活动
+	CreateFullSendAct(ctx context.Context, req CreateFullSendActRequest) (resp *CreateFullSendActResponse, result *core.APIResult, err error)
+
+	// DeleteActivityMerchant 删除活动发券商户号
+	DeleteActivityMerchant(ctx context.Context, req DeleteActivityMerchantRequest) (resp *DeleteActivityMerchantResponse, result *core.APIResult, err error)
+
+	// GetActDetail 获取活动详情接口
+	GetActDetail(ctx context.Context, req GetActDetailRequest) (resp *GetActDetailResponse, result *core.APIResult, err error)
+
+	// ListActivities 获取支付有礼活动列表
+	ListActivities(ctx context.Context, req ListActivitiesRequest) (resp *ListActivitiesResponse, result *core.APIResult, err error)
+
+	// ListActivityMerchant 获取活动发券商户号
+	ListActivityMerchant(ctx context.Context, req ListActivityMerchantRequest) (resp *ListActMchResponse, result *core.APIResult, err error)
+
+	// ListActivitySku 获取活动指定商品列表
+	ListActivitySku(ctx context.Context, req ListActivitySkuRequest) (resp *ListActSkuResponse, result *core.APIResult, err error)
+
+	// TerminateActivity 终止活动
+	TerminateActivity(ctx context.Context, req TerminateActivityRequest) (resp *TerminateActResponse, result *core.APIResult, err error)
+}
+
+var _ ActivityAPI = (*ActivityApiService)(nil)
@@ -0,0 +1,185 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package giftactivity
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+)
+
+// Validate 按接口文档中的约束校验 ActAdvancedSetting 的字段
+func (o ActAdvancedSetting) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ActAdvancedSetting", opts...)
+	c.Enum("delivery_user_category", (*string)(o.DeliveryUserCategory), "DELIVERY_ALL_PERSON", "DELIVERY_MEMBER_PERSON")
+	if o.PaymentMode != nil {
+		c.Nested("payment_mode", o.PaymentMode.Validate(opts...))
+	}
+	if o.PaymentMethodInformation != nil {
+		c.Nested("payment_method_information", o.PaymentMethodInformation.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ActBaseInfo 的字段
+func (o ActBaseInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ActBaseInfo", opts...)
+	c.Required("activity_name", o.ActivityName != nil)
+	c.Required("activity_second_title", o.ActivitySecondTitle != nil)
+	c.Required("merchant_logo_url", o.MerchantLogoUrl != nil)
+	c.Required("begin_time", o.BeginTime != nil)
+	c.Required("end_time", o.EndTime != nil)
+	if o.AvailablePeriods != nil {
+		c.Nested("available_periods", o.AvailablePeriods.Validate(opts...))
+	}
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("delivery_purpose", o.DeliveryPurpose != nil)
+	c.Enum("delivery_purpose", (*string)(o.DeliveryPurpose), "OFF_LINE_PAY", "JUMP_MINI_APP")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AddActivityMerchantRequest 的字段
+func (o AddActivityMerchantRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AddActivityMerchantRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("merchant_id_list", o.MerchantIdList != nil)
+	c.Required("add_request_no", o.AddRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AvailableDayTime 的字段
+func (o AvailableDayTime) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AvailableDayTime", opts...)
+	c.Required("begin_day_time", o.BeginDayTime != nil)
+	c.Required("end_day_time", o.EndDayTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AvailablePeriod 的字段
+func (o AvailablePeriod) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AvailablePeriod", opts...)
+	for i, item := range o.AvailableTime {
+		c.Nested(validation.Index("available_time", i), item.Validate(opts...))
+	}
+	for i, item := range o.AvailableDayTime {
+		c.Nested(validation.Index("available_day_time", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AvailableTime 的字段
+func (o AvailableTime) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AvailableTime", opts...)
+	c.Required("begin_time", o.BeginTime != nil)
+	c.Required("end_time", o.EndTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AwardBaseInfo 的字段
+func (o AwardBaseInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AwardBaseInfo", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("original_image_url", o.OriginalImageUrl != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateFullSendActRequest 的字段
+func (o CreateFullSendActRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateFullSendActRequest", opts...)
+	c.Required("activity_base_info", o.ActivityBaseInfo != nil)
+	if o.ActivityBaseInfo != nil {
+		c.Nested("activity_base_info", o.ActivityBaseInfo.Validate(opts...))
+	}
+	c.Required("award_send_rule", o.AwardSendRule != nil)
+	if o.AwardSendRule != nil {
+		c.Nested("award_send_rule", o.AwardSendRule.Validate(opts...))
+	}
+	if o.AdvancedSetting != nil {
+		c.Nested("advanced_setting", o.AdvancedSetting.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeleteActivityMerchantRequest 的字段
+func (o DeleteActivityMerchantRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeleteActivityMerchantRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("merchant_id_list", o.MerchantIdList != nil)
+	c.Required("delete_request_no", o.DeleteRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FullSendRule 的字段
+func (o FullSendRule) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FullSendRule", opts...)
+	c.Required("transaction_amount_minimum", o.TransactionAmountMinimum != nil)
+	c.Required("send_content", o.SendContent != nil)
+	c.Enum("send_content", (*string)(o.SendContent), "SINGLE_COUPON", "GIFT_PACKAGE")
+	c.Required("award_type", o.AwardType != nil)
+	c.Enum("award_type", (*string)(o.AwardType), "BUSIFAVOR")
+	c.Required("award_list", o.AwardList != nil)
+	for i, item := range o.AwardList {
+		c.Nested(validation.Index("award_list", i), item.Validate(opts...))
+	}
+	c.Required("merchant_option", o.MerchantOption != nil)
+	c.Enum("merchant_option", (*string)(o.MerchantOption), "IN_SEVICE_COUPON_MERCHANT", "MANUAL_INPUT_MERCHANT")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetActDetailRequest 的字段
+func (o GetActDetailRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetActDetailRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListActivitiesRequest 的字段
+func (o ListActivitiesRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListActivitiesRequest", opts...)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	c.Enum("activity_status", (*string)(o.ActivityStatus), "ACT_STATUS_UNKNOWN", "CREATE_ACT_STATUS", "ONGOING_ACT_STATUS", "TERMINATE_ACT_STATUS", "STOP_ACT_STATUS", "OVER_TIME_ACT_STATUS", "CREATE_ACT_FAILED")
+	c.Enum("award_type", (*string)(o.AwardType), "BUSIFAVOR")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListActivityMerchantRequest 的字段
+func (o ListActivityMerchantRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListActivityMerchantRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListActivitySkuRequest 的字段
+func (o ListActivitySkuRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListActivitySkuRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PaymentMethodInfo 的字段
+func (o PaymentMethodInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PaymentMethodInfo", opts...)
+	c.Required("payment_method", o.PaymentMethod != nil)
+	c.Enum("payment_method", (*string)(o.PaymentMethod), "CFT", "SPECIFIC_BANK_CARD")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PaymentMode 的字段
+func (o PaymentMode) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PaymentMode", opts...)
+	for i := range o.PaymentSceneList {
+		c.Enum(validation.Index("payment_scene_list", i), (*string)(&o.PaymentSceneList[i]), "APP_SCENE", "SWING_CARD_SCENE", "NO_SECRET_SCENE", "MINIAPP_SCENE", "FACE_PAY_SCENE", "OTHER_SCENE")
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 TerminateActivityRequest 的字段
+func (o TerminateActivityRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("TerminateActivityRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	return c.Err()
+}
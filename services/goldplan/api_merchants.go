@@ -33,6 +33,11 @@ func (a *MerchantsApiService) CloseAdvertisingShow(ctx context.Context, req Clos
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/goldplan/merchants/close-advertising-show"
 	// Make sure All Required Params are properly set
 
@@ -64,6 +69,11 @@ func (a *MerchantsApiService) OpenAdvertisingShow(ctx context.Context, req OpenA
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/goldplan/merchants/open-advertising-show"
 	// Make sure All Required Params are properly set
 
@@ -95,6 +105,11 @@ func (a *MerchantsApiService) SetAdvertisingIndustryFilter(ctx context.Context,
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/goldplan/merchants/set-advertising-industry-filter"
 	// Make sure All Required Params are properly set
 
@@ -33,6 +33,11 @@ func (a *StatusApiService) ChangeCustomPageStatus(ctx context.Context, req Chang
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/goldplan/merchants/changecustompagestatus"
 	// Make sure All Required Params are properly set
 
@@ -70,6 +75,11 @@ func (a *StatusApiService) ChangeGoldPlanStatus(ctx context.Context, req ChangeG
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/goldplan/merchants/changegoldplanstatus"
 	// Make sure All Required Params are properly set
 
@@ -0,0 +1,131 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package goldplantest 提供 goldplan 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package goldplantest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/goldplan"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeMerchantsAPI goldplan.MerchantsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeMerchantsAPI struct {
+	servicetest.Recorder
+
+	CloseAdvertisingShowFunc         func(context.Context, goldplan.CloseAdvertisingShowRequest) (*core.APIResult, error)
+	OpenAdvertisingShowFunc          func(context.Context, goldplan.OpenAdvertisingShowRequest) (*core.APIResult, error)
+	SetAdvertisingIndustryFilterFunc func(context.Context, goldplan.SetAdvertisingIndustryFilterRequest) (*core.APIResult, error)
+}
+
+var _ goldplan.MerchantsAPI = (*FakeMerchantsAPI)(nil)
+
+// CloseAdvertisingShow 记录调用并返回 CloseAdvertisingShowFunc 的结果
+func (f *FakeMerchantsAPI) CloseAdvertisingShow(ctx context.Context, req goldplan.CloseAdvertisingShowRequest) (result *core.APIResult, err error) {
+	f.Record("CloseAdvertisingShow", req)
+	if f.CloseAdvertisingShowFunc == nil {
+		err = servicetest.NotStubbed("MerchantsAPI", "CloseAdvertisingShow")
+		return
+	}
+	return f.CloseAdvertisingShowFunc(ctx, req)
+}
+
+// CloseAdvertisingShowReturns 设置 CloseAdvertisingShow 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeMerchantsAPI) CloseAdvertisingShowReturns(err error) *FakeMerchantsAPI {
+	f.CloseAdvertisingShowFunc = func(context.Context, goldplan.CloseAdvertisingShowRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// OpenAdvertisingShow 记录调用并返回 OpenAdvertisingShowFunc 的结果
+func (f *FakeMerchantsAPI) OpenAdvertisingShow(ctx context.Context, req goldplan.OpenAdvertisingShowRequest) (result *core.APIResult, err error) {
+	f.Record("OpenAdvertisingShow", req)
+	if f.OpenAdvertisingShowFunc == nil {
+		err = servicetest.NotStubbed("MerchantsAPI", "OpenAdvertisingShow")
+		return
+	}
+	return f.OpenAdvertisingShowFunc(ctx, req)
+}
+
+// OpenAdvertisingShowReturns 设置 OpenAdvertisingShow 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeMerchantsAPI) OpenAdvertisingShowReturns(err error) *FakeMerchantsAPI {
+	f.OpenAdvertisingShowFunc = func(context.Context, goldplan.OpenAdvertisingShowRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SetAdvertisingIndustryFilter 记录调用并返回 SetAdvertisingIndustryFilterFunc 的结果
+func (f *FakeMerchantsAPI) SetAdvertisingIndustryFilter(ctx context.Context, req goldplan.SetAdvertisingIndustryFilterRequest) (result *core.APIResult, err error) {
+	f.Record("SetAdvertisingIndustryFilter", req)
+	if f.SetAdvertisingIndustryFilterFunc == nil {
+		err = servicetest.NotStubbed("MerchantsAPI", "SetAdvertisingIndustryFilter")
+		return
+	}
+	return f.SetAdvertisingIndustryFilterFunc(ctx, req)
+}
+
+// SetAdvertisingIndustryFilterReturns 设置 SetAdvertisingIndustryFilter 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeMerchantsAPI) SetAdvertisingIndustryFilterReturns(err error) *FakeMerchantsAPI {
+	f.SetAdvertisingIndustryFilterFunc = func(context.Context, goldplan.SetAdvertisingIndustryFilterRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeStatusAPI goldplan.StatusAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeStatusAPI struct {
+	servicetest.Recorder
+
+	ChangeCustomPageStatusFunc func(context.Context, goldplan.ChangeCustomPageStatusRequest) (*goldplan.ChangeCustomPageStatusResponse, *core.APIResult, error)
+	ChangeGoldPlanStatusFunc   func(context.Context, goldplan.ChangeGoldPlanStatusRequest) (*goldplan.ChangeGoldPlanStatusResponse, *core.APIResult, error)
+}
+
+var _ goldplan.StatusAPI = (*FakeStatusAPI)(nil)
+
+// ChangeCustomPageStatus 记录调用并返回 ChangeCustomPageStatusFunc 的结果
+func (f *FakeStatusAPI) ChangeCustomPageStatus(ctx context.Context, req goldplan.ChangeCustomPageStatusRequest) (resp *goldplan.ChangeCustomPageStatusResponse, result *core.APIResult, err error) {
+	f.Record("ChangeCustomPageStatus", req)
+	if f.ChangeCustomPageStatusFunc == nil {
+		err = servicetest.NotStubbed("StatusAPI", "ChangeCustomPageStatus")
+		return
+	}
+	return f.ChangeCustomPageStatusFunc(ctx, req)
+}
+
+// ChangeCustomPageStatusReturns 设置 ChangeCustomPageStatus 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStatusAPI) ChangeCustomPageStatusReturns(resp *goldplan.ChangeCustomPageStatusResponse, err error) *FakeStatusAPI {
+	f.ChangeCustomPageStatusFunc = func(context.Context, goldplan.ChangeCustomPageStatusRequest) (*goldplan.ChangeCustomPageStatusResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ChangeGoldPlanStatus 记录调用并返回 ChangeGoldPlanStatusFunc 的结果
+func (f *FakeStatusAPI) ChangeGoldPlanStatus(ctx context.Context, req goldplan.ChangeGoldPlanStatusRequest) (resp *goldplan.ChangeGoldPlanStatusResponse, result *core.APIResult, err error) {
+	f.Record("ChangeGoldPlanStatus", req)
+	if f.ChangeGoldPlanStatusFunc == nil {
+		err = servicetest.NotStubbed("StatusAPI", "ChangeGoldPlanStatus")
+		return
+	}
+	return f.ChangeGoldPlanStatusFunc(ctx, req)
+}
+
+// ChangeGoldPlanStatusReturns 设置 ChangeGoldPlanStatus 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeStatusAPI) ChangeGoldPlanStatusReturns(resp *goldplan.ChangeGoldPlanStatusResponse, err error) *FakeStatusAPI {
+	f.ChangeGoldPlanStatusFunc = func(context.Context, goldplan.ChangeGoldPlanStatusRequest) (*goldplan.ChangeGoldPlanStatusResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
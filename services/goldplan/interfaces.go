@@ -0,0 +1,41 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package goldplan
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// MerchantsAPI MerchantsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *MerchantsApiService，即可在测试中使用 goldplantest.FakeMerchantsAPI 替代
+type MerchantsAPI interface {
+	// CloseAdvertisingShow 关闭广告展示
+	CloseAdvertisingShow(ctx context.Context, req CloseAdvertisingShowRequest) (result *core.APIResult, err error)
+
+	// OpenAdvertisingShow 开通广告展示
+	OpenAdvertisingShow(ctx context.Context, req OpenAdvertisingShowRequest) (result *core.APIResult, err error)
+
+	// SetAdvertisingIndustryFilter 同业过滤标签管理
+	SetAdvertisingIndustryFilter(ctx context.Context, req SetAdvertisingIndustryFilterRequest) (result *core.APIResult, err error)
+}
+
+// StatusAPI StatusApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *StatusApiService，即可在测试中使用 goldplantest.FakeStatusAPI 替代
+type StatusAPI interface {
+	// ChangeCustomPageStatus 商家小票管理
+	ChangeCustomPageStatus(ctx context.Context, req ChangeCustomPageStatusRequest) (resp *ChangeCustomPageStatusResponse, result *core.APIResult, err error)
+
+	// ChangeGoldPlanStatus 点金计划管理
+	ChangeGoldPlanStatus(ctx context.Context, req ChangeGoldPlanStatusRequest) (resp *ChangeGoldPlanStatusResponse, result *core.APIResult, err error)
+}
+
+var (
+	_ MerchantsAPI = (*MerchantsApiService)(nil)
+	_ StatusAPI    = (*StatusApiService)(nil)
+)
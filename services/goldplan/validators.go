@@ -0,0 +1,61 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package goldplan
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+)
+
+// Validate 按接口文档中的约束校验 ChangeCustomPageStatusRequest 的字段
+func (o ChangeCustomPageStatusRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ChangeCustomPageStatusRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("operation_type", o.OperationType != nil)
+	c.Enum("operation_type", (*string)(o.OperationType), "OPEN", "CLOSE")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ChangeGoldPlanStatusRequest 的字段
+func (o ChangeGoldPlanStatusRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ChangeGoldPlanStatusRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("operation_type", o.OperationType != nil)
+	c.Enum("operation_type", (*string)(o.OperationType), "OPEN", "CLOSE")
+	c.Enum("operation_pay_scene", (*string)(o.OperationPayScene), "JSAPI_AND_MINIPROGRAM", "JSAPI", "MINIPROGRAM")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CloseAdvertisingShowRequest 的字段
+func (o CloseAdvertisingShowRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CloseAdvertisingShowRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 OpenAdvertisingShowRequest 的字段
+func (o OpenAdvertisingShowRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("OpenAdvertisingShowRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	for i := range o.AdvertisingIndustryFilters {
+		c.Enum(validation.Index("advertising_industry_filters", i), (*string)(&o.AdvertisingIndustryFilters[i]), "E_COMMERCE", "LOVE_MARRIAGE", "POTOGRAPHY", "EDUCATION", "FINANCE", "TOURISM", "SKINCARE", "FOOD", "SPORT", "JEWELRY_WATCH", "HEALTHCARE", "BUSSINESS", "PARENTING", "CATERING", "RETAIL", "SERVICES", "LAW", "ESTATE", "TRANSPORTATION", "ENERGY_SAVING", "SECURITY", "BUILDING_MATERIAL", "COMMUNICATION", "MERCHANDISE", "ASSOCIATION", "COMMUNITY", "ONLINE_AVR", "WE_MEDIA", "CAR", "SOFTWARE", "GAME", "CLOTHING", "INDUSTY", "AGRICULTURE", "PUBLISHING_MEDIA", "HOME_DIGITAL")
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SetAdvertisingIndustryFilterRequest 的字段
+func (o SetAdvertisingIndustryFilterRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SetAdvertisingIndustryFilterRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("advertising_industry_filters", o.AdvertisingIndustryFilters != nil)
+	for i := range o.AdvertisingIndustryFilters {
+		c.Enum(validation.Index("advertising_industry_filters", i), (*string)(&o.AdvertisingIndustryFilters[i]), "E_COMMERCE", "LOVE_MARRIAGE", "POTOGRAPHY", "EDUCATION", "FINANCE", "TOURISM", "SKINCARE", "FOOD", "SPORT", "JEWELRY_WATCH", "HEALTHCARE", "BUSSINESS", "PARENTING", "CATERING", "RETAIL", "SERVICES", "LAW", "ESTATE", "TRANSPORTATION", "ENERGY_SAVING", "SECURITY", "BUILDING_MATERIAL", "COMMUNICATION", "MERCHANDISE", "ASSOCIATION", "COMMUNITY", "ONLINE_AVR", "WE_MEDIA", "CAR", "SOFTWARE", "GAME", "CLOTHING", "INDUSTY", "AGRICULTURE", "PUBLISHING_MEDIA", "HOME_DIGITAL")
+	}
+	return c.Err()
+}
@@ -36,6 +36,11 @@ func (a *BrandsApiService) GetBrand(ctx context.Context, req GetBrandRequest) (r
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in GetBrandRequest")
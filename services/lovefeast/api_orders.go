@@ -36,6 +36,11 @@ func (a *OrdersApiService) GetByUser(ctx context.Context, req GetByUserRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in GetByUserRequest")
@@ -83,6 +88,11 @@ func (a *OrdersApiService) ListByUser(ctx context.Context, req ListByUserRequest
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in ListByUserRequest")
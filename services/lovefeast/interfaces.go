@@ -0,0 +1,35 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package lovefeast
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// BrandsAPI BrandsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *BrandsApiService，即可在测试中使用 lovefeasttest.FakeBrandsAPI 替代
+type BrandsAPI interface {
+	// GetBrand 查询爱心餐品牌信息
+	GetBrand(ctx context.Context, req GetBrandRequest) (resp *BrandEntity, result *core.APIResult, err error)
+}
+
+// OrdersAPI OrdersApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *OrdersApiService，即可在测试中使用 lovefeasttest.FakeOrdersAPI 替代
+type OrdersAPI interface {
+	// GetByUser 查询用户捐赠单详情
+	GetByUser(ctx context.Context, req GetByUserRequest) (resp *OrdersEntity, result *core.APIResult, err error)
+
+	// ListByUser 查询用户捐赠单列表
+	ListByUser(ctx context.Context, req ListByUserRequest) (resp *OrdersListByUserResponse, result *core.APIResult, err error)
+}
+
+var (
+	_ BrandsAPI = (*BrandsApiService)(nil)
+	_ OrdersAPI = (*OrdersApiService)(nil)
+)
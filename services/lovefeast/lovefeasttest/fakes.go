@@ -0,0 +1,93 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package lovefeasttest 提供 lovefeast 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package lovefeasttest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/lovefeast"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeBrandsAPI lovefeast.BrandsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeBrandsAPI struct {
+	servicetest.Recorder
+
+	GetBrandFunc func(context.Context, lovefeast.GetBrandRequest) (*lovefeast.BrandEntity, *core.APIResult, error)
+}
+
+var _ lovefeast.BrandsAPI = (*FakeBrandsAPI)(nil)
+
+// GetBrand 记录调用并返回 GetBrandFunc 的结果
+func (f *FakeBrandsAPI) GetBrand(ctx context.Context, req lovefeast.GetBrandRequest) (resp *lovefeast.BrandEntity, result *core.APIResult, err error) {
+	f.Record("GetBrand", req)
+	if f.GetBrandFunc == nil {
+		err = servicetest.NotStubbed("BrandsAPI", "GetBrand")
+		return
+	}
+	return f.GetBrandFunc(ctx, req)
+}
+
+// GetBrandReturns 设置 GetBrand 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBrandsAPI) GetBrandReturns(resp *lovefeast.BrandEntity, err error) *FakeBrandsAPI {
+	f.GetBrandFunc = func(context.Context, lovefeast.GetBrandRequest) (*lovefeast.BrandEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeOrdersAPI lovefeast.OrdersAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeOrdersAPI struct {
+	servicetest.Recorder
+
+	GetByUserFunc  func(context.Context, lovefeast.GetByUserRequest) (*lovefeast.OrdersEntity, *core.APIResult, error)
+	ListByUserFunc func(context.Context, lovefeast.ListByUserRequest) (*lovefeast.OrdersListByUserResponse, *core.APIResult, error)
+}
+
+var _ lovefeast.OrdersAPI = (*FakeOrdersAPI)(nil)
+
+// GetByUser 记录调用并返回 GetByUserFunc 的结果
+func (f *FakeOrdersAPI) GetByUser(ctx context.Context, req lovefeast.GetByUserRequest) (resp *lovefeast.OrdersEntity, result *core.APIResult, err error) {
+	f.Record("GetByUser", req)
+	if f.GetByUserFunc == nil {
+		err = servicetest.NotStubbed("OrdersAPI", "GetByUser")
+		return
+	}
+	return f.GetByUserFunc(ctx, req)
+}
+
+// GetByUserReturns 设置 GetByUser 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeOrdersAPI) GetByUserReturns(resp *lovefeast.OrdersEntity, err error) *FakeOrdersAPI {
+	f.GetByUserFunc = func(context.Context, lovefeast.GetByUserRequest) (*lovefeast.OrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListByUser 记录调用并返回 ListByUserFunc 的结果
+func (f *FakeOrdersAPI) ListByUser(ctx context.Context, req lovefeast.ListByUserRequest) (resp *lovefeast.OrdersListByUserResponse, result *core.APIResult, err error) {
+	f.Record("ListByUser", req)
+	if f.ListByUserFunc == nil {
+		err = servicetest.NotStubbed("OrdersAPI", "ListByUser")
+		return
+	}
+	return f.ListByUserFunc(ctx, req)
+}
+
+// ListByUserReturns 设置 ListByUser 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeOrdersAPI) ListByUserReturns(resp *lovefeast.OrdersListByUserResponse, err error) *FakeOrdersAPI {
+	f.ListByUserFunc = func(context.Context, lovefeast.ListByUserRequest) (*lovefeast.OrdersListByUserResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
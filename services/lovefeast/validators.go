@@ -0,0 +1,38 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package lovefeast
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 GetBrandRequest 的字段
+func (o GetBrandRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetBrandRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetByUserRequest 的字段
+func (o GetByUserRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetByUserRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListByUserRequest 的字段
+func (o ListByUserRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListByUserRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("limit", o.Limit != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
@@ -35,6 +35,11 @@ func (a *BusiFavorApiService) CouponCodeInfo(ctx context.Context, req CouponCode
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in CouponCodeInfoRequest")
@@ -88,6 +93,11 @@ func (a *BusiFavorApiService) CreateBusifavorStock(ctx context.Context, req Crea
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/stocks"
 	// Make sure All Required Params are properly set
 
@@ -125,6 +135,11 @@ func (a *BusiFavorApiService) DeleteCouponCode(ctx context.Context, req DeleteCo
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in DeleteCouponCodeRequest")
@@ -178,6 +193,11 @@ func (a *BusiFavorApiService) ModifyBudget(ctx context.Context, req ModifyBudget
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in ModifyBudgetRequest")
@@ -230,6 +250,11 @@ func (a *BusiFavorApiService) ModifyStockInfo(ctx context.Context, req ModifySto
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, fmt.Errorf("field `StockId` is required and must be specified in ModifyStockInfoRequest")
@@ -280,6 +305,11 @@ func (a *BusiFavorApiService) QueryCouponCodeList(ctx context.Context, req Query
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in QueryCouponCodeListRequest")
@@ -337,6 +367,11 @@ func (a *BusiFavorApiService) QueryStock(ctx context.Context, req QueryStockRequ
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in QueryStockRequest")
@@ -379,6 +414,11 @@ func (a *BusiFavorApiService) UploadCouponCode(ctx context.Context, req UploadCo
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.StockId == nil {
 		return nil, nil, fmt.Errorf("field `StockId` is required and must be specified in UploadCouponCodeRequest")
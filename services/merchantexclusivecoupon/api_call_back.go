@@ -34,6 +34,11 @@ func (a *CallBackApiService) GetCouponNotify(ctx context.Context, req GetCouponN
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/callbacks"
 	// Make sure All Required Params are properly set
 
@@ -80,6 +85,11 @@ func (a *CallBackApiService) SetCouponNotify(ctx context.Context, req SetCouponN
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/callbacks"
 	// Make sure All Required Params are properly set
 
@@ -40,6 +40,11 @@ func (a *CouponApiService) AssociateTradeInfo(ctx context.Context, req Associate
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/associate"
 	// Make sure All Required Params are properly set
 
@@ -77,6 +82,11 @@ func (a *CouponApiService) DeactivateCoupon(ctx context.Context, req DeactivateC
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/deactivate"
 	// Make sure All Required Params are properly set
 
@@ -119,6 +129,11 @@ func (a *CouponApiService) DisassociateTradeInfo(ctx context.Context, req Disass
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/disassociate"
 	// Make sure All Required Params are properly set
 
@@ -156,6 +171,11 @@ func (a *CouponApiService) ListCouponsByFilter(ctx context.Context, req ListCoup
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in ListCouponsByFilterRequest")
@@ -226,6 +246,11 @@ func (a *CouponApiService) QueryCoupon(ctx context.Context, req QueryCouponReque
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.CouponCode == nil {
 		return nil, nil, fmt.Errorf("field `CouponCode` is required and must be specified in QueryCouponRequest")
@@ -278,6 +303,11 @@ func (a *CouponApiService) ReturnCoupon(ctx context.Context, req ReturnCouponReq
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/return"
 	// Make sure All Required Params are properly set
 
@@ -315,6 +345,11 @@ func (a *CouponApiService) SendCoupon(ctx context.Context, req SendCouponRequest
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/send"
 	// Make sure All Required Params are properly set
 
@@ -352,6 +387,11 @@ func (a *CouponApiService) SendGovCard(ctx context.Context, req SendGovCardReque
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.CardId == nil {
 		return nil, nil, fmt.Errorf("field `CardId` is required and must be specified in SendGovCardRequest")
@@ -403,6 +443,11 @@ func (a *CouponApiService) UseCoupon(ctx context.Context, req UseCouponRequest)
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/coupons/use"
 	// Make sure All Required Params are properly set
 
@@ -35,6 +35,11 @@ func (a *SubsidyApiService) PayReceiptInfo(ctx context.Context, req PayReceiptIn
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.SubsidyReceiptId == nil {
 		return nil, nil, fmt.Errorf("field `SubsidyReceiptId` is required and must be specified in PayReceiptInfoRequest")
@@ -77,6 +82,11 @@ func (a *SubsidyApiService) PayReceiptList(ctx context.Context, req PayReceiptLi
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/subsidy/pay-receipts"
 	// Make sure All Required Params are properly set
 	if req.StockId == nil {
@@ -125,6 +135,11 @@ func (a *SubsidyApiService) ReturnReceiptInfo(ctx context.Context, req ReturnRec
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.SubsidyReturnReceiptId == nil {
 		return nil, nil, fmt.Errorf("field `SubsidyReturnReceiptId` is required and must be specified in ReturnReceiptInfoRequest")
@@ -170,6 +185,11 @@ func (a *SubsidyApiService) SubsidyPay(ctx context.Context, req SubsidyPayReques
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/subsidy/pay-receipts"
 	// Make sure All Required Params are properly set
 
@@ -210,6 +230,11 @@ func (a *SubsidyApiService) SubsidyReturn(ctx context.Context, req SubsidyReturn
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/busifavor/subsidy/return-receipts"
 	// Make sure All Required Params are properly set
 
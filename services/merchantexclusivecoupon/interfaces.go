@@ -0,0 +1,110 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package merchantexclusivecoupon
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// BusiFavorAPI BusiFavorApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *BusiFavorApiService，即可在测试中使用 merchantexclusivecoupontest.FakeBusiFavorAPI 替代
+type BusiFavorAPI interface {
+	// CouponCodeInfo 查询预存code详情
+	CouponCodeInfo(ctx context.Context, req CouponCodeInfoRequest) (resp *CouponCodeInfoResponse, result *core.APIResult, err error)
+
+	// CreateBusifavorStock 创建商家券
+	CreateBusifavorStock(ctx context.Context, req CreateBusifavorStockRequest) (resp *CreateBusiFavorStockResponse, result *core.APIResult, err error)
+
+	// DeleteCouponCode 删除预存code
+	DeleteCouponCode(ctx context.Context, req DeleteCouponCodeRequest) (resp *DeleteCouponCodeResponse, result *core.APIResult, err error)
+
+	// ModifyBudget 修改批次预算
+	ModifyBudget(ctx context.Context, req ModifyBudgetRequest) (resp *ModifyBudgetResponse, result *core.APIResult, err error)
+
+	// ModifyStockInfo 修改商家券基本信息
+	ModifyStockInfo(ctx context.Context, req ModifyStockInfoRequest) (result *core.APIResult, err error)
+
+	// QueryCouponCodeList 查询预存code列表
+	QueryCouponCodeList(ctx context.Context, req QueryCouponCodeListRequest) (resp *CouponCodeListResponse, result *core.APIResult, err error)
+
+	// QueryStock 查询商家券批次详情
+	QueryStock(ctx context.Context, req QueryStockRequest) (resp *StockGetResponse, result *core.APIResult, err error)
+
+	// UploadCouponCode 上传预存code
+	UploadCouponCode(ctx context.Context, req UploadCouponCodeRequest) (resp *UploadCouponCodeResponse, result *core.APIResult, err error)
+}
+
+// CallBackAPI CallBackApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CallBackApiService，即可在测试中使用 merchantexclusivecoupontest.FakeCallBackAPI 替代
+type CallBackAPI interface {
+	// GetCouponNotify 获取商家券事件通知地址
+	GetCouponNotify(ctx context.Context, req GetCouponNotifyRequest) (resp *GetCouponNotifyResponse, result *core.APIResult, err error)
+
+	// SetCouponNotify 设置商家券事件通知地址
+	SetCouponNotify(ctx context.Context, req SetCouponNotifyRequest) (resp *SetCouponNotifyResponse, result *core.APIResult, err error)
+}
+
+// CouponAPI CouponApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *CouponApiService，即可在测试中使用 merchantexclusivecoupontest.FakeCouponAPI 替代
+type CouponAPI interface {
+	// AssociateTradeInfo 关联订单信息
+	AssociateTradeInfo(ctx context.Context, req AssociateTradeInfoRequest) (resp *AssociateTradeInfoResponse, result *core.APIResult, err error)
+
+	// DeactivateCoupon 使券失效
+	DeactivateCoupon(ctx context.Context, req DeactivateCouponRequest) (resp *DeactivateCouponResponse, result *core.APIResult, err error)
+
+	// DisassociateTradeInfo 取消关联订单信息
+	DisassociateTradeInfo(ctx context.Context, req DisassociateTradeInfoRequest) (resp *DisassociateTradeInfoResponse, result *core.APIResult, err error)
+
+	// ListCouponsByFilter 根据过滤条件查询用户的券
+	ListCouponsByFilter(ctx context.Context, req ListCouponsByFilterRequest) (resp *CouponListResponse, result *core.APIResult, err error)
+
+	// QueryCoupon 查询用户券详情
+	QueryCoupon(ctx context.Context, req QueryCouponRequest) (resp *CouponEntity, result *core.APIResult, err error)
+
+	// ReturnCoupon 申请退券
+	ReturnCoupon(ctx context.Context, req ReturnCouponRequest) (resp *ReturnCouponResponse, result *core.APIResult, err error)
+
+	// SendCoupon 向用户发券
+	SendCoupon(ctx context.Context, req SendCouponRequest) (resp *SendCouponResponse, result *core.APIResult, err error)
+
+	// SendGovCard 发放政府消费卡
+	SendGovCard(ctx context.Context, req SendGovCardRequest) (resp *CouponSendGovCardResponse, result *core.APIResult, err error)
+
+	// UseCoupon 核销用户的券
+	UseCoupon(ctx context.Context, req UseCouponRequest) (resp *UseCouponResponse, result *core.APIResult, err error)
+}
+
+// SubsidyAPI SubsidyApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *SubsidyApiService，即可在测试中使用 merchantexclusivecoupontest.FakeSubsidyAPI 替代
+type SubsidyAPI interface {
+	// PayReceiptInfo 查询商家券营销补差付款单详情
+	PayReceiptInfo(ctx context.Context, req PayReceiptInfoRequest) (resp *SubsidyPayReceipt, result *core.APIResult, err error)
+
+	// PayReceiptList 查询商家券营销补差付款单列表
+	PayReceiptList(ctx context.Context, req PayReceiptListRequest) (resp *SubsidyPayReceiptListResponse, result *core.APIResult, err error)
+
+	// ReturnReceiptInfo 查询商家券营销补差回退单详情
+	ReturnReceiptInfo(ctx context.Context, req ReturnReceiptInfoRequest) (resp *SubsidyReturnReceipt, result *core.APIResult, err error)
+
+	// SubsidyPay 商家券营销补差付款
+	SubsidyPay(ctx context.Context, req SubsidyPayRequest) (resp *SubsidyPayReceipt, result *core.APIResult, err error)
+
+	// SubsidyReturn 商家券营销补差回退
+	SubsidyReturn(ctx context.Context, req SubsidyReturnRequest) (resp *SubsidyReturnReceipt, result *core.APIResult, err error)
+}
+
+var (
+	_ BusiFavorAPI = (*BusiFavorApiService)(nil)
+	_ CallBackAPI  = (*CallBackApiService)(nil)
+	_ CouponAPI    = (*CouponApiService)(nil)
+	_ SubsidyAPI   = (*SubsidyApiService)(nil)
+)
@@ -0,0 +1,514 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package merchantexclusivecoupontest 提供 merchantexclusivecoupon 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package merchantexclusivecoupontest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/merchantexclusivecoupon"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeBusiFavorAPI merchantexclusivecoupon.BusiFavorAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeBusiFavorAPI struct {
+	servicetest.Recorder
+
+	CouponCodeInfoFunc       func(context.Context, merchantexclusivecoupon.CouponCodeInfoRequest) (*merchantexclusivecoupon.CouponCodeInfoResponse, *core.APIResult, error)
+	CreateBusifavorStockFunc func(context.Context, merchantexclusivecoupon.CreateBusifavorStockRequest) (*merchantexclusivecoupon.CreateBusiFavorStockResponse, *core.APIResult, error)
+	DeleteCouponCodeFunc     func(context.Context, merchantexclusivecoupon.DeleteCouponCodeRequest) (*merchantexclusivecoupon.DeleteCouponCodeResponse, *core.APIResult, error)
+	ModifyBudgetFunc         func(context.Context, merchantexclusivecoupon.ModifyBudgetRequest) (*merchantexclusivecoupon.ModifyBudgetResponse, *core.APIResult, error)
+	ModifyStockInfoFunc      func(context.Context, merchantexclusivecoupon.ModifyStockInfoRequest) (*core.APIResult, error)
+	QueryCouponCodeListFunc  func(context.Context, merchantexclusivecoupon.QueryCouponCodeListRequest) (*merchantexclusivecoupon.CouponCodeListResponse, *core.APIResult, error)
+	QueryStockFunc           func(context.Context, merchantexclusivecoupon.QueryStockRequest) (*merchantexclusivecoupon.StockGetResponse, *core.APIResult, error)
+	UploadCouponCodeFunc     func(context.Context, merchantexclusivecoupon.UploadCouponCodeRequest) (*merchantexclusivecoupon.UploadCouponCodeResponse, *core.APIResult, error)
+}
+
+var _ merchantexclusivecoupon.BusiFavorAPI = (*FakeBusiFavorAPI)(nil)
+
+// CouponCodeInfo 记录调用并返回 CouponCodeInfoFunc 的结果
+func (f *FakeBusiFavorAPI) CouponCodeInfo(ctx context.Context, req merchantexclusivecoupon.CouponCodeInfoRequest) (resp *merchantexclusivecoupon.CouponCodeInfoResponse, result *core.APIResult, err error) {
+	f.Record("CouponCodeInfo", req)
+	if f.CouponCodeInfoFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "CouponCodeInfo")
+		return
+	}
+	return f.CouponCodeInfoFunc(ctx, req)
+}
+
+// CouponCodeInfoReturns 设置 CouponCodeInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) CouponCodeInfoReturns(resp *merchantexclusivecoupon.CouponCodeInfoResponse, err error) *FakeBusiFavorAPI {
+	f.CouponCodeInfoFunc = func(context.Context, merchantexclusivecoupon.CouponCodeInfoRequest) (*merchantexclusivecoupon.CouponCodeInfoResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// CreateBusifavorStock 记录调用并返回 CreateBusifavorStockFunc 的结果
+func (f *FakeBusiFavorAPI) CreateBusifavorStock(ctx context.Context, req merchantexclusivecoupon.CreateBusifavorStockRequest) (resp *merchantexclusivecoupon.CreateBusiFavorStockResponse, result *core.APIResult, err error) {
+	f.Record("CreateBusifavorStock", req)
+	if f.CreateBusifavorStockFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "CreateBusifavorStock")
+		return
+	}
+	return f.CreateBusifavorStockFunc(ctx, req)
+}
+
+// CreateBusifavorStockReturns 设置 CreateBusifavorStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) CreateBusifavorStockReturns(resp *merchantexclusivecoupon.CreateBusiFavorStockResponse, err error) *FakeBusiFavorAPI {
+	f.CreateBusifavorStockFunc = func(context.Context, merchantexclusivecoupon.CreateBusifavorStockRequest) (*merchantexclusivecoupon.CreateBusiFavorStockResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeleteCouponCode 记录调用并返回 DeleteCouponCodeFunc 的结果
+func (f *FakeBusiFavorAPI) DeleteCouponCode(ctx context.Context, req merchantexclusivecoupon.DeleteCouponCodeRequest) (resp *merchantexclusivecoupon.DeleteCouponCodeResponse, result *core.APIResult, err error) {
+	f.Record("DeleteCouponCode", req)
+	if f.DeleteCouponCodeFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "DeleteCouponCode")
+		return
+	}
+	return f.DeleteCouponCodeFunc(ctx, req)
+}
+
+// DeleteCouponCodeReturns 设置 DeleteCouponCode 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) DeleteCouponCodeReturns(resp *merchantexclusivecoupon.DeleteCouponCodeResponse, err error) *FakeBusiFavorAPI {
+	f.DeleteCouponCodeFunc = func(context.Context, merchantexclusivecoupon.DeleteCouponCodeRequest) (*merchantexclusivecoupon.DeleteCouponCodeResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ModifyBudget 记录调用并返回 ModifyBudgetFunc 的结果
+func (f *FakeBusiFavorAPI) ModifyBudget(ctx context.Context, req merchantexclusivecoupon.ModifyBudgetRequest) (resp *merchantexclusivecoupon.ModifyBudgetResponse, result *core.APIResult, err error) {
+	f.Record("ModifyBudget", req)
+	if f.ModifyBudgetFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "ModifyBudget")
+		return
+	}
+	return f.ModifyBudgetFunc(ctx, req)
+}
+
+// ModifyBudgetReturns 设置 ModifyBudget 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) ModifyBudgetReturns(resp *merchantexclusivecoupon.ModifyBudgetResponse, err error) *FakeBusiFavorAPI {
+	f.ModifyBudgetFunc = func(context.Context, merchantexclusivecoupon.ModifyBudgetRequest) (*merchantexclusivecoupon.ModifyBudgetResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ModifyStockInfo 记录调用并返回 ModifyStockInfoFunc 的结果
+func (f *FakeBusiFavorAPI) ModifyStockInfo(ctx context.Context, req merchantexclusivecoupon.ModifyStockInfoRequest) (result *core.APIResult, err error) {
+	f.Record("ModifyStockInfo", req)
+	if f.ModifyStockInfoFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "ModifyStockInfo")
+		return
+	}
+	return f.ModifyStockInfoFunc(ctx, req)
+}
+
+// ModifyStockInfoReturns 设置 ModifyStockInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) ModifyStockInfoReturns(err error) *FakeBusiFavorAPI {
+	f.ModifyStockInfoFunc = func(context.Context, merchantexclusivecoupon.ModifyStockInfoRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryCouponCodeList 记录调用并返回 QueryCouponCodeListFunc 的结果
+func (f *FakeBusiFavorAPI) QueryCouponCodeList(ctx context.Context, req merchantexclusivecoupon.QueryCouponCodeListRequest) (resp *merchantexclusivecoupon.CouponCodeListResponse, result *core.APIResult, err error) {
+	f.Record("QueryCouponCodeList", req)
+	if f.QueryCouponCodeListFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "QueryCouponCodeList")
+		return
+	}
+	return f.QueryCouponCodeListFunc(ctx, req)
+}
+
+// QueryCouponCodeListReturns 设置 QueryCouponCodeList 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) QueryCouponCodeListReturns(resp *merchantexclusivecoupon.CouponCodeListResponse, err error) *FakeBusiFavorAPI {
+	f.QueryCouponCodeListFunc = func(context.Context, merchantexclusivecoupon.QueryCouponCodeListRequest) (*merchantexclusivecoupon.CouponCodeListResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryStock 记录调用并返回 QueryStockFunc 的结果
+func (f *FakeBusiFavorAPI) QueryStock(ctx context.Context, req merchantexclusivecoupon.QueryStockRequest) (resp *merchantexclusivecoupon.StockGetResponse, result *core.APIResult, err error) {
+	f.Record("QueryStock", req)
+	if f.QueryStockFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "QueryStock")
+		return
+	}
+	return f.QueryStockFunc(ctx, req)
+}
+
+// QueryStockReturns 设置 QueryStock 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) QueryStockReturns(resp *merchantexclusivecoupon.StockGetResponse, err error) *FakeBusiFavorAPI {
+	f.QueryStockFunc = func(context.Context, merchantexclusivecoupon.QueryStockRequest) (*merchantexclusivecoupon.StockGetResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// UploadCouponCode 记录调用并返回 UploadCouponCodeFunc 的结果
+func (f *FakeBusiFavorAPI) UploadCouponCode(ctx context.Context, req merchantexclusivecoupon.UploadCouponCodeRequest) (resp *merchantexclusivecoupon.UploadCouponCodeResponse, result *core.APIResult, err error) {
+	f.Record("UploadCouponCode", req)
+	if f.UploadCouponCodeFunc == nil {
+		err = servicetest.NotStubbed("BusiFavorAPI", "UploadCouponCode")
+		return
+	}
+	return f.UploadCouponCodeFunc(ctx, req)
+}
+
+// UploadCouponCodeReturns 设置 UploadCouponCode 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBusiFavorAPI) UploadCouponCodeReturns(resp *merchantexclusivecoupon.UploadCouponCodeResponse, err error) *FakeBusiFavorAPI {
+	f.UploadCouponCodeFunc = func(context.Context, merchantexclusivecoupon.UploadCouponCodeRequest) (*merchantexclusivecoupon.UploadCouponCodeResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeCallBackAPI merchantexclusivecoupon.CallBackAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCallBackAPI struct {
+	servicetest.Recorder
+
+	GetCouponNotifyFunc func(context.Context, merchantexclusivecoupon.GetCouponNotifyRequest) (*merchantexclusivecoupon.GetCouponNotifyResponse, *core.APIResult, error)
+	SetCouponNotifyFunc func(context.Context, merchantexclusivecoupon.SetCouponNotifyRequest) (*merchantexclusivecoupon.SetCouponNotifyResponse, *core.APIResult, error)
+}
+
+var _ merchantexclusivecoupon.CallBackAPI = (*FakeCallBackAPI)(nil)
+
+// GetCouponNotify 记录调用并返回 GetCouponNotifyFunc 的结果
+func (f *FakeCallBackAPI) GetCouponNotify(ctx context.Context, req merchantexclusivecoupon.GetCouponNotifyRequest) (resp *merchantexclusivecoupon.GetCouponNotifyResponse, result *core.APIResult, err error) {
+	f.Record("GetCouponNotify", req)
+	if f.GetCouponNotifyFunc == nil {
+		err = servicetest.NotStubbed("CallBackAPI", "GetCouponNotify")
+		return
+	}
+	return f.GetCouponNotifyFunc(ctx, req)
+}
+
+// GetCouponNotifyReturns 设置 GetCouponNotify 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCallBackAPI) GetCouponNotifyReturns(resp *merchantexclusivecoupon.GetCouponNotifyResponse, err error) *FakeCallBackAPI {
+	f.GetCouponNotifyFunc = func(context.Context, merchantexclusivecoupon.GetCouponNotifyRequest) (*merchantexclusivecoupon.GetCouponNotifyResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SetCouponNotify 记录调用并返回 SetCouponNotifyFunc 的结果
+func (f *FakeCallBackAPI) SetCouponNotify(ctx context.Context, req merchantexclusivecoupon.SetCouponNotifyRequest) (resp *merchantexclusivecoupon.SetCouponNotifyResponse, result *core.APIResult, err error) {
+	f.Record("SetCouponNotify", req)
+	if f.SetCouponNotifyFunc == nil {
+		err = servicetest.NotStubbed("CallBackAPI", "SetCouponNotify")
+		return
+	}
+	return f.SetCouponNotifyFunc(ctx, req)
+}
+
+// SetCouponNotifyReturns 设置 SetCouponNotify 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCallBackAPI) SetCouponNotifyReturns(resp *merchantexclusivecoupon.SetCouponNotifyResponse, err error) *FakeCallBackAPI {
+	f.SetCouponNotifyFunc = func(context.Context, merchantexclusivecoupon.SetCouponNotifyRequest) (*merchantexclusivecoupon.SetCouponNotifyResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeCouponAPI merchantexclusivecoupon.CouponAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeCouponAPI struct {
+	servicetest.Recorder
+
+	AssociateTradeInfoFunc    func(context.Context, merchantexclusivecoupon.AssociateTradeInfoRequest) (*merchantexclusivecoupon.AssociateTradeInfoResponse, *core.APIResult, error)
+	DeactivateCouponFunc      func(context.Context, merchantexclusivecoupon.DeactivateCouponRequest) (*merchantexclusivecoupon.DeactivateCouponResponse, *core.APIResult, error)
+	DisassociateTradeInfoFunc func(context.Context, merchantexclusivecoupon.DisassociateTradeInfoRequest) (*merchantexclusivecoupon.DisassociateTradeInfoResponse, *core.APIResult, error)
+	ListCouponsByFilterFunc   func(context.Context, merchantexclusivecoupon.ListCouponsByFilterRequest) (*merchantexclusivecoupon.CouponListResponse, *core.APIResult, error)
+	QueryCouponFunc           func(context.Context, merchantexclusivecoupon.QueryCouponRequest) (*merchantexclusivecoupon.CouponEntity, *core.APIResult, error)
+	ReturnCouponFunc          func(context.Context, merchantexclusivecoupon.ReturnCouponRequest) (*merchantexclusivecoupon.ReturnCouponResponse, *core.APIResult, error)
+	SendCouponFunc            func(context.Context, merchantexclusivecoupon.SendCouponRequest) (*merchantexclusivecoupon.SendCouponResponse, *core.APIResult, error)
+	SendGovCardFunc           func(context.Context, merchantexclusivecoupon.SendGovCardRequest) (*merchantexclusivecoupon.CouponSendGovCardResponse, *core.APIResult, error)
+	UseCouponFunc             func(context.Context, merchantexclusivecoupon.UseCouponRequest) (*merchantexclusivecoupon.UseCouponResponse, *core.APIResult, error)
+}
+
+var _ merchantexclusivecoupon.CouponAPI = (*FakeCouponAPI)(nil)
+
+// AssociateTradeInfo 记录调用并返回 AssociateTradeInfoFunc 的结果
+func (f *FakeCouponAPI) AssociateTradeInfo(ctx context.Context, req merchantexclusivecoupon.AssociateTradeInfoRequest) (resp *merchantexclusivecoupon.AssociateTradeInfoResponse, result *core.APIResult, err error) {
+	f.Record("AssociateTradeInfo", req)
+	if f.AssociateTradeInfoFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "AssociateTradeInfo")
+		return
+	}
+	return f.AssociateTradeInfoFunc(ctx, req)
+}
+
+// AssociateTradeInfoReturns 设置 AssociateTradeInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) AssociateTradeInfoReturns(resp *merchantexclusivecoupon.AssociateTradeInfoResponse, err error) *FakeCouponAPI {
+	f.AssociateTradeInfoFunc = func(context.Context, merchantexclusivecoupon.AssociateTradeInfoRequest) (*merchantexclusivecoupon.AssociateTradeInfoResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeactivateCoupon 记录调用并返回 DeactivateCouponFunc 的结果
+func (f *FakeCouponAPI) DeactivateCoupon(ctx context.Context, req merchantexclusivecoupon.DeactivateCouponRequest) (resp *merchantexclusivecoupon.DeactivateCouponResponse, result *core.APIResult, err error) {
+	f.Record("DeactivateCoupon", req)
+	if f.DeactivateCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "DeactivateCoupon")
+		return
+	}
+	return f.DeactivateCouponFunc(ctx, req)
+}
+
+// DeactivateCouponReturns 设置 DeactivateCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) DeactivateCouponReturns(resp *merchantexclusivecoupon.DeactivateCouponResponse, err error) *FakeCouponAPI {
+	f.DeactivateCouponFunc = func(context.Context, merchantexclusivecoupon.DeactivateCouponRequest) (*merchantexclusivecoupon.DeactivateCouponResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DisassociateTradeInfo 记录调用并返回 DisassociateTradeInfoFunc 的结果
+func (f *FakeCouponAPI) DisassociateTradeInfo(ctx context.Context, req merchantexclusivecoupon.DisassociateTradeInfoRequest) (resp *merchantexclusivecoupon.DisassociateTradeInfoResponse, result *core.APIResult, err error) {
+	f.Record("DisassociateTradeInfo", req)
+	if f.DisassociateTradeInfoFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "DisassociateTradeInfo")
+		return
+	}
+	return f.DisassociateTradeInfoFunc(ctx, req)
+}
+
+// DisassociateTradeInfoReturns 设置 DisassociateTradeInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) DisassociateTradeInfoReturns(resp *merchantexclusivecoupon.DisassociateTradeInfoResponse, err error) *FakeCouponAPI {
+	f.DisassociateTradeInfoFunc = func(context.Context, merchantexclusivecoupon.DisassociateTradeInfoRequest) (*merchantexclusivecoupon.DisassociateTradeInfoResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListCouponsByFilter 记录调用并返回 ListCouponsByFilterFunc 的结果
+func (f *FakeCouponAPI) ListCouponsByFilter(ctx context.Context, req merchantexclusivecoupon.ListCouponsByFilterRequest) (resp *merchantexclusivecoupon.CouponListResponse, result *core.APIResult, err error) {
+	f.Record("ListCouponsByFilter", req)
+	if f.ListCouponsByFilterFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "ListCouponsByFilter")
+		return
+	}
+	return f.ListCouponsByFilterFunc(ctx, req)
+}
+
+// ListCouponsByFilterReturns 设置 ListCouponsByFilter 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) ListCouponsByFilterReturns(resp *merchantexclusivecoupon.CouponListResponse, err error) *FakeCouponAPI {
+	f.ListCouponsByFilterFunc = func(context.Context, merchantexclusivecoupon.ListCouponsByFilterRequest) (*merchantexclusivecoupon.CouponListResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryCoupon 记录调用并返回 QueryCouponFunc 的结果
+func (f *FakeCouponAPI) QueryCoupon(ctx context.Context, req merchantexclusivecoupon.QueryCouponRequest) (resp *merchantexclusivecoupon.CouponEntity, result *core.APIResult, err error) {
+	f.Record("QueryCoupon", req)
+	if f.QueryCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "QueryCoupon")
+		return
+	}
+	return f.QueryCouponFunc(ctx, req)
+}
+
+// QueryCouponReturns 设置 QueryCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) QueryCouponReturns(resp *merchantexclusivecoupon.CouponEntity, err error) *FakeCouponAPI {
+	f.QueryCouponFunc = func(context.Context, merchantexclusivecoupon.QueryCouponRequest) (*merchantexclusivecoupon.CouponEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ReturnCoupon 记录调用并返回 ReturnCouponFunc 的结果
+func (f *FakeCouponAPI) ReturnCoupon(ctx context.Context, req merchantexclusivecoupon.ReturnCouponRequest) (resp *merchantexclusivecoupon.ReturnCouponResponse, result *core.APIResult, err error) {
+	f.Record("ReturnCoupon", req)
+	if f.ReturnCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "ReturnCoupon")
+		return
+	}
+	return f.ReturnCouponFunc(ctx, req)
+}
+
+// ReturnCouponReturns 设置 ReturnCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) ReturnCouponReturns(resp *merchantexclusivecoupon.ReturnCouponResponse, err error) *FakeCouponAPI {
+	f.ReturnCouponFunc = func(context.Context, merchantexclusivecoupon.ReturnCouponRequest) (*merchantexclusivecoupon.ReturnCouponResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SendCoupon 记录调用并返回 SendCouponFunc 的结果
+func (f *FakeCouponAPI) SendCoupon(ctx context.Context, req merchantexclusivecoupon.SendCouponRequest) (resp *merchantexclusivecoupon.SendCouponResponse, result *core.APIResult, err error) {
+	f.Record("SendCoupon", req)
+	if f.SendCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "SendCoupon")
+		return
+	}
+	return f.SendCouponFunc(ctx, req)
+}
+
+// SendCouponReturns 设置 SendCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) SendCouponReturns(resp *merchantexclusivecoupon.SendCouponResponse, err error) *FakeCouponAPI {
+	f.SendCouponFunc = func(context.Context, merchantexclusivecoupon.SendCouponRequest) (*merchantexclusivecoupon.SendCouponResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SendGovCard 记录调用并返回 SendGovCardFunc 的结果
+func (f *FakeCouponAPI) SendGovCard(ctx context.Context, req merchantexclusivecoupon.SendGovCardRequest) (resp *merchantexclusivecoupon.CouponSendGovCardResponse, result *core.APIResult, err error) {
+	f.Record("SendGovCard", req)
+	if f.SendGovCardFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "SendGovCard")
+		return
+	}
+	return f.SendGovCardFunc(ctx, req)
+}
+
+// SendGovCardReturns 设置 SendGovCard 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) SendGovCardReturns(resp *merchantexclusivecoupon.CouponSendGovCardResponse, err error) *FakeCouponAPI {
+	f.SendGovCardFunc = func(context.Context, merchantexclusivecoupon.SendGovCardRequest) (*merchantexclusivecoupon.CouponSendGovCardResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// UseCoupon 记录调用并返回 UseCouponFunc 的结果
+func (f *FakeCouponAPI) UseCoupon(ctx context.Context, req merchantexclusivecoupon.UseCouponRequest) (resp *merchantexclusivecoupon.UseCouponResponse, result *core.APIResult, err error) {
+	f.Record("UseCoupon", req)
+	if f.UseCouponFunc == nil {
+		err = servicetest.NotStubbed("CouponAPI", "UseCoupon")
+		return
+	}
+	return f.UseCouponFunc(ctx, req)
+}
+
+// UseCouponReturns 设置 UseCoupon 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeCouponAPI) UseCouponReturns(resp *merchantexclusivecoupon.UseCouponResponse, err error) *FakeCouponAPI {
+	f.UseCouponFunc = func(context.Context, merchantexclusivecoupon.UseCouponRequest) (*merchantexclusivecoupon.UseCouponResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeSubsidyAPI merchantexclusivecoupon.SubsidyAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeSubsidyAPI struct {
+	servicetest.Recorder
+
+	PayReceiptInfoFunc    func(context.Context, merchantexclusivecoupon.PayReceiptInfoRequest) (*merchantexclusivecoupon.SubsidyPayReceipt, *core.APIResult, error)
+	PayReceiptListFunc    func(context.Context, merchantexclusivecoupon.PayReceiptListRequest) (*merchantexclusivecoupon.SubsidyPayReceiptListResponse, *core.APIResult, error)
+	ReturnReceiptInfoFunc func(context.Context, merchantexclusivecoupon.ReturnReceiptInfoRequest) (*merchantexclusivecoupon.SubsidyReturnReceipt, *core.APIResult, error)
+	SubsidyPayFunc        func(context.Context, merchantexclusivecoupon.SubsidyPayRequest) (*merchantexclusivecoupon.SubsidyPayReceipt, *core.APIResult, error)
+	SubsidyReturnFunc     func(context.Context, merchantexclusivecoupon.SubsidyReturnRequest) (*merchantexclusivecoupon.SubsidyReturnReceipt, *core.APIResult, error)
+}
+
+var _ merchantexclusivecoupon.SubsidyAPI = (*FakeSubsidyAPI)(nil)
+
+// PayReceiptInfo 记录调用并返回 PayReceiptInfoFunc 的结果
+func (f *FakeSubsidyAPI) PayReceiptInfo(ctx context.Context, req merchantexclusivecoupon.PayReceiptInfoRequest) (resp *merchantexclusivecoupon.SubsidyPayReceipt, result *core.APIResult, err error) {
+	f.Record("PayReceiptInfo", req)
+	if f.PayReceiptInfoFunc == nil {
+		err = servicetest.NotStubbed("SubsidyAPI", "PayReceiptInfo")
+		return
+	}
+	return f.PayReceiptInfoFunc(ctx, req)
+}
+
+// PayReceiptInfoReturns 设置 PayReceiptInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeSubsidyAPI) PayReceiptInfoReturns(resp *merchantexclusivecoupon.SubsidyPayReceipt, err error) *FakeSubsidyAPI {
+	f.PayReceiptInfoFunc = func(context.Context, merchantexclusivecoupon.PayReceiptInfoRequest) (*merchantexclusivecoupon.SubsidyPayReceipt, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PayReceiptList 记录调用并返回 PayReceiptListFunc 的结果
+func (f *FakeSubsidyAPI) PayReceiptList(ctx context.Context, req merchantexclusivecoupon.PayReceiptListRequest) (resp *merchantexclusivecoupon.SubsidyPayReceiptListResponse, result *core.APIResult, err error) {
+	f.Record("PayReceiptList", req)
+	if f.PayReceiptListFunc == nil {
+		err = servicetest.NotStubbed("SubsidyAPI", "PayReceiptList")
+		return
+	}
+	return f.PayReceiptListFunc(ctx, req)
+}
+
+// PayReceiptListReturns 设置 PayReceiptList 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeSubsidyAPI) PayReceiptListReturns(resp *merchantexclusivecoupon.SubsidyPayReceiptListResponse, err error) *FakeSubsidyAPI {
+	f.PayReceiptListFunc = func(context.Context, merchantexclusivecoupon.PayReceiptListRequest) (*merchantexclusivecoupon.SubsidyPayReceiptListResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ReturnReceiptInfo 记录调用并返回 ReturnReceiptInfoFunc 的结果
+func (f *FakeSubsidyAPI) ReturnReceiptInfo(ctx context.Context, req merchantexclusivecoupon.ReturnReceiptInfoRequest) (resp *merchantexclusivecoupon.SubsidyReturnReceipt, result *core.APIResult, err error) {
+	f.Record("ReturnReceiptInfo", req)
+	if f.ReturnReceiptInfoFunc == nil {
+		err = servicetest.NotStubbed("SubsidyAPI", "ReturnReceiptInfo")
+		return
+	}
+	return f.ReturnReceiptInfoFunc(ctx, req)
+}
+
+// ReturnReceiptInfoReturns 设置 ReturnReceiptInfo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeSubsidyAPI) ReturnReceiptInfoReturns(resp *merchantexclusivecoupon.SubsidyReturnReceipt, err error) *FakeSubsidyAPI {
+	f.ReturnReceiptInfoFunc = func(context.Context, merchantexclusivecoupon.ReturnReceiptInfoRequest) (*merchantexclusivecoupon.SubsidyReturnReceipt, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SubsidyPay 记录调用并返回 SubsidyPayFunc 的结果
+func (f *FakeSubsidyAPI) SubsidyPay(ctx context.Context, req merchantexclusivecoupon.SubsidyPayRequest) (resp *merchantexclusivecoupon.SubsidyPayReceipt, result *core.APIResult, err error) {
+	f.Record("SubsidyPay", req)
+	if f.SubsidyPayFunc == nil {
+		err = servicetest.NotStubbed("SubsidyAPI", "SubsidyPay")
+		return
+	}
+	return f.SubsidyPayFunc(ctx, req)
+}
+
+// SubsidyPayReturns 设置 SubsidyPay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeSubsidyAPI) SubsidyPayReturns(resp *merchantexclusivecoupon.SubsidyPayReceipt, err error) *FakeSubsidyAPI {
+	f.SubsidyPayFunc = func(context.Context, merchantexclusivecoupon.SubsidyPayRequest) (*merchantexclusivecoupon.SubsidyPayReceipt, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// SubsidyReturn 记录调用并返回 SubsidyReturnFunc 的结果
+func (f *FakeSubsidyAPI) SubsidyReturn(ctx context.Context, req merchantexclusivecoupon.SubsidyReturnRequest) (resp *merchantexclusivecoupon.SubsidyReturnReceipt, result *core.APIResult, err error) {
+	f.Record("SubsidyReturn", req)
+	if f.SubsidyReturnFunc == nil {
+		err = servicetest.NotStubbed("SubsidyAPI", "SubsidyReturn")
+		return
+	}
+	return f.SubsidyReturnFunc(ctx, req)
+}
+
+// SubsidyReturnReturns 设置 SubsidyReturn 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeSubsidyAPI) SubsidyReturnReturns(resp *merchantexclusivecoupon.SubsidyReturnReceipt, err error) *FakeSubsidyAPI {
+	f.SubsidyReturnFunc = func(context.Context, merchantexclusivecoupon.SubsidyReturnRequest) (*merchantexclusivecoupon.SubsidyReturnReceipt, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
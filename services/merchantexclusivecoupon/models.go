@@ -15,6 +15,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // AssociateTradeInfoRequest
@@ -121,7 +123,7 @@ func (o AssociateTradeInfoResponse) MarshalJSON() ([]byte, error) {
 	if o.WechatpayAssociateTime == nil {
 		return nil, fmt.Errorf("field `WechatpayAssociateTime` is required and must be specified in AssociateTradeInfoResponse")
 	}
-	toSerialize["wechatpay_associate_time"] = o.WechatpayAssociateTime.Format(time.RFC3339)
+	toSerialize["wechatpay_associate_time"] = chinatime.Format(*o.WechatpayAssociateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -367,11 +369,11 @@ func (o CouponCodeEntity) MarshalJSON() ([]byte, error) {
 	toSerialize["code_status"] = o.CodeStatus
 
 	if o.UploadTime != nil {
-		toSerialize["upload_time"] = o.UploadTime.Format(time.RFC3339)
+		toSerialize["upload_time"] = chinatime.Format(*o.UploadTime)
 	}
 
 	if o.DispatchedTime != nil {
-		toSerialize["dispatched_time"] = o.DispatchedTime.Format(time.RFC3339)
+		toSerialize["dispatched_time"] = chinatime.Format(*o.DispatchedTime)
 	}
 
 	if o.Openid != nil {
@@ -843,15 +845,15 @@ func (o CouponEntity) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.AvailableStartTime != nil {
-		toSerialize["available_start_time"] = o.AvailableStartTime.Format(time.RFC3339)
+		toSerialize["available_start_time"] = chinatime.Format(*o.AvailableStartTime)
 	}
 
 	if o.ExpireTime != nil {
-		toSerialize["expire_time"] = o.ExpireTime.Format(time.RFC3339)
+		toSerialize["expire_time"] = chinatime.Format(*o.ExpireTime)
 	}
 
 	if o.ReceiveTime != nil {
-		toSerialize["receive_time"] = o.ReceiveTime.Format(time.RFC3339)
+		toSerialize["receive_time"] = chinatime.Format(*o.ReceiveTime)
 	}
 
 	if o.SendRequestNo != nil {
@@ -863,7 +865,7 @@ func (o CouponEntity) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.UseTime != nil {
-		toSerialize["use_time"] = o.UseTime.Format(time.RFC3339)
+		toSerialize["use_time"] = chinatime.Format(*o.UseTime)
 	}
 
 	if o.AssociateOutTradeNo != nil {
@@ -875,7 +877,7 @@ func (o CouponEntity) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.ReturnTime != nil {
-		toSerialize["return_time"] = o.ReturnTime.Format(time.RFC3339)
+		toSerialize["return_time"] = chinatime.Format(*o.ReturnTime)
 	}
 
 	if o.DeactivateRequestNo != nil {
@@ -883,7 +885,7 @@ func (o CouponEntity) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.DeactivateTime != nil {
-		toSerialize["deactivate_time"] = o.DeactivateTime.Format(time.RFC3339)
+		toSerialize["deactivate_time"] = chinatime.Format(*o.DeactivateTime)
 	}
 
 	if o.DeactivateReason != nil {
@@ -2031,7 +2033,7 @@ func (o DeactivateCouponResponse) MarshalJSON() ([]byte, error) {
 	if o.WechatpayDeactivateTime == nil {
 		return nil, fmt.Errorf("field `WechatpayDeactivateTime` is required and must be specified in DeactivateCouponResponse")
 	}
-	toSerialize["wechatpay_deactivate_time"] = o.WechatpayDeactivateTime.Format(time.RFC3339)
+	toSerialize["wechatpay_deactivate_time"] = chinatime.Format(*o.WechatpayDeactivateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -2291,7 +2293,7 @@ func (o DisassociateTradeInfoResponse) MarshalJSON() ([]byte, error) {
 	if o.WechatpayDisassociateTime == nil {
 		return nil, fmt.Errorf("field `WechatpayDisassociateTime` is required and must be specified in DisassociateTradeInfoResponse")
 	}
-	toSerialize["wechatpay_disassociate_time"] = o.WechatpayDisassociateTime.Format(time.RFC3339)
+	toSerialize["wechatpay_disassociate_time"] = chinatime.Format(*o.WechatpayDisassociateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -2564,12 +2566,12 @@ func (o FavorAvailableTime) MarshalJSON() ([]byte, error) {
 	if o.AvailableBeginTime == nil {
 		return nil, fmt.Errorf("field `AvailableBeginTime` is required and must be specified in FavorAvailableTime")
 	}
-	toSerialize["available_begin_time"] = o.AvailableBeginTime.Format(time.RFC3339)
+	toSerialize["available_begin_time"] = chinatime.Format(*o.AvailableBeginTime)
 
 	if o.AvailableEndTime == nil {
 		return nil, fmt.Errorf("field `AvailableEndTime` is required and must be specified in FavorAvailableTime")
 	}
-	toSerialize["available_end_time"] = o.AvailableEndTime.Format(time.RFC3339)
+	toSerialize["available_end_time"] = chinatime.Format(*o.AvailableEndTime)
 
 	if o.AvailableDayAfterReceive != nil {
 		toSerialize["available_day_after_receive"] = o.AvailableDayAfterReceive
@@ -2889,11 +2891,11 @@ func (o IrregularAvailableTime) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
 
 	if o.BeginTime != nil {
-		toSerialize["begin_time"] = o.BeginTime.Format(time.RFC3339)
+		toSerialize["begin_time"] = chinatime.Format(*o.BeginTime)
 	}
 
 	if o.EndTime != nil {
-		toSerialize["end_time"] = o.EndTime.Format(time.RFC3339)
+		toSerialize["end_time"] = chinatime.Format(*o.EndTime)
 	}
 	return json.Marshal(toSerialize)
 }
@@ -4580,7 +4582,7 @@ func (o ReturnCouponResponse) MarshalJSON() ([]byte, error) {
 	if o.WechatpayReturnTime == nil {
 		return nil, fmt.Errorf("field `WechatpayReturnTime` is required and must be specified in ReturnCouponResponse")
 	}
-	toSerialize["wechatpay_return_time"] = o.WechatpayReturnTime.Format(time.RFC3339)
+	toSerialize["wechatpay_return_time"] = chinatime.Format(*o.WechatpayReturnTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -5721,7 +5723,7 @@ func (o SubsidyPayReceipt) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.SuccessTime != nil {
-		toSerialize["success_time"] = o.SuccessTime.Format(time.RFC3339)
+		toSerialize["success_time"] = chinatime.Format(*o.SuccessTime)
 	}
 
 	if o.OutSubsidyNo == nil {
@@ -5730,7 +5732,7 @@ func (o SubsidyPayReceipt) MarshalJSON() ([]byte, error) {
 	toSerialize["out_subsidy_no"] = o.OutSubsidyNo
 
 	if o.CreateTime != nil {
-		toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+		toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 	}
 	return json.Marshal(toSerialize)
 }
@@ -6213,7 +6215,7 @@ func (o SubsidyReturnReceipt) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.ReturnDoneTime != nil {
-		toSerialize["return_done_time"] = o.ReturnDoneTime.Format(time.RFC3339)
+		toSerialize["return_done_time"] = chinatime.Format(*o.ReturnDoneTime)
 	}
 
 	if o.SubsidyReceiptId == nil {
@@ -6227,7 +6229,7 @@ func (o SubsidyReturnReceipt) MarshalJSON() ([]byte, error) {
 	toSerialize["out_subsidy_return_no"] = o.OutSubsidyReturnNo
 
 	if o.ReturnCreateTime != nil {
-		toSerialize["return_create_time"] = o.ReturnCreateTime.Format(time.RFC3339)
+		toSerialize["return_create_time"] = chinatime.Format(*o.ReturnCreateTime)
 	}
 	return json.Marshal(toSerialize)
 }
@@ -0,0 +1,308 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package merchantexclusivecoupon
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 AssociateTradeInfoRequest 的字段
+func (o AssociateTradeInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AssociateTradeInfoRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CouponCodeInfoRequest 的字段
+func (o CouponCodeInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CouponCodeInfoRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeactivateCouponRequest 的字段
+func (o DeactivateCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeactivateCouponRequest", opts...)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("deactivate_request_no", o.DeactivateRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeleteCouponCodeRequest 的字段
+func (o DeleteCouponCodeRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeleteCouponCodeRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("delete_request_no", o.DeleteRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DisassociateTradeInfoRequest 的字段
+func (o DisassociateTradeInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DisassociateTradeInfoRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DisplayPatternInfo 的字段
+func (o DisplayPatternInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DisplayPatternInfo", opts...)
+	if o.FinderInfo != nil {
+		c.Nested("finder_info", o.FinderInfo.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FinderInfo 的字段
+func (o FinderInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FinderInfo", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetCouponNotifyRequest 的字段
+func (o GetCouponNotifyRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetCouponNotifyRequest", opts...)
+	c.Length("mchid", o.Mchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListCouponsByFilterRequest 的字段
+func (o ListCouponsByFilterRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListCouponsByFilterRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Enum("coupon_state", (*string)(o.CouponState), "SENDED", "USED", "EXPIRED", "DELETED", "DEACTIVATED")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyBudgetRequest 的字段
+func (o ModifyBudgetRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyBudgetRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("modify_budget_request_no", o.ModifyBudgetRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyCouponUseRule 的字段
+func (o ModifyCouponUseRule) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyCouponUseRule", opts...)
+	c.Enum("use_method", (*string)(o.UseMethod), "OFF_LINE", "MINI_PROGRAMS", "SELF_CONSUME", "PAYMENT_CODE")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyCustomEntrance 的字段
+func (o ModifyCustomEntrance) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyCustomEntrance", opts...)
+	if o.MiniProgramsInfo != nil {
+		c.Nested("mini_programs_info", o.MiniProgramsInfo.Validate(opts...))
+	}
+	c.Length("appid", o.Appid, 0, 32)
+	c.Enum("code_display_mode", (*string)(o.CodeDisplayMode), "NOT_SHOW", "BARCODE", "QRCODE")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyMiniAppInfo 的字段
+func (o ModifyMiniAppInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyMiniAppInfo", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyStockInfoRequest 的字段
+func (o ModifyStockInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyStockInfoRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	if o.CustomEntrance != nil {
+		c.Nested("custom_entrance", o.CustomEntrance.Validate(opts...))
+	}
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	if o.DisplayPatternInfo != nil {
+		c.Nested("display_pattern_info", o.DisplayPatternInfo.Validate(opts...))
+	}
+	if o.CouponUseRule != nil {
+		c.Nested("coupon_use_rule", o.CouponUseRule.Validate(opts...))
+	}
+	if o.StockSendRule != nil {
+		c.Nested("stock_send_rule", o.StockSendRule.Validate(opts...))
+	}
+	if o.NotifyConfig != nil {
+		c.Nested("notify_config", o.NotifyConfig.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ModifyStockSendRule 的字段
+func (o ModifyStockSendRule) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ModifyStockSendRule", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 NotifyConfig 的字段
+func (o NotifyConfig) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("NotifyConfig", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PayReceiptInfoRequest 的字段
+func (o PayReceiptInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PayReceiptInfoRequest", opts...)
+	c.Required("subsidy_receipt_id", o.SubsidyReceiptId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PayReceiptListRequest 的字段
+func (o PayReceiptListRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PayReceiptListRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryCouponCodeListRequest 的字段
+func (o QueryCouponCodeListRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryCouponCodeListRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Enum("status", (*string)(o.Status), "AVAILABLE", "DISPATCHED")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryCouponRequest 的字段
+func (o QueryCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryCouponRequest", opts...)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryStockRequest 的字段
+func (o QueryStockRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryStockRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ReturnCouponRequest 的字段
+func (o ReturnCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ReturnCouponRequest", opts...)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("return_request_no", o.ReturnRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ReturnReceiptInfoRequest 的字段
+func (o ReturnReceiptInfoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ReturnReceiptInfoRequest", opts...)
+	c.Required("subsidy_return_receipt_id", o.SubsidyReturnReceiptId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SendCouponRequest 的字段
+func (o SendCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SendCouponRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SendGovCardRequest 的字段
+func (o SendGovCardRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SendGovCardRequest", opts...)
+	c.Required("card_id", o.CardId != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("send_time", o.SendTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SetCouponNotifyRequest 的字段
+func (o SetCouponNotifyRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SetCouponNotifyRequest", opts...)
+	c.Length("mchid", o.Mchid, 0, 32)
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SubsidyPayRequest 的字段
+func (o SubsidyPayRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SubsidyPayRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.Required("payer_merchant", o.PayerMerchant != nil)
+	c.Required("payee_merchant", o.PayeeMerchant != nil)
+	c.Required("amount", o.Amount != nil)
+	c.Range("amount", o.Amount, 1, 0)
+	c.Required("description", o.Description != nil)
+	c.Required("out_subsidy_no", o.OutSubsidyNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SubsidyReturnRequest 的字段
+func (o SubsidyReturnRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SubsidyReturnRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.Required("refund_id", o.RefundId != nil)
+	c.Required("payer_merchant", o.PayerMerchant != nil)
+	c.Required("payee_merchant", o.PayeeMerchant != nil)
+	c.Required("amount", o.Amount != nil)
+	c.Range("amount", o.Amount, 1, 0)
+	c.Required("description", o.Description != nil)
+	c.Required("out_subsidy_return_no", o.OutSubsidyReturnNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 UploadCouponCodeRequest 的字段
+func (o UploadCouponCodeRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("UploadCouponCodeRequest", opts...)
+	c.Required("stock_id", o.StockId != nil)
+	c.Required("coupon_code_list", o.CouponCodeList != nil)
+	c.Required("upload_request_no", o.UploadRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 UseCouponRequest 的字段
+func (o UseCouponRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("UseCouponRequest", opts...)
+	c.Required("coupon_code", o.CouponCode != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("use_time", o.UseTime != nil)
+	c.Required("use_request_no", o.UseRequestNo != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
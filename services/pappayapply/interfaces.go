@@ -0,0 +1,23 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package pappayapply
+
+import (
+	"context"
+	"net/http"
+)
+
+// PapPayApplyAPI PapPayApplyApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *PapPayApplyApiService，即可在测试中使用 pappayapplytest.FakePapPayApplyAPI 替代
+type PapPayApplyAPI interface {
+	// HandlePapPayNotify 处理扣款结果通知
+	HandlePapPayNotify(ctx context.Context, req *http.Request) (*PapPayNotifyRequest, *PapPayNotifyResponse, error)
+
+	// PapPayApply 申请扣款
+	PapPayApply(ctx context.Context, req *PapPayApplyRequest) (*PapPayApplyResponse, error)
+}
+
+var _ PapPayApplyAPI = (*PapPayApplyApiService)(nil)
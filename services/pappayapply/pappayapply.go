@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+
+	"github.com/jemuri/wechatpay-go/core"
 )
 
 // PapPayApplyApiService 申请扣款服务
@@ -215,6 +217,9 @@ func (s *PapPayApplyApiService) doRequest(ctx context.Context, xmlStr string) (*
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/xml")
+	if core.IsDryRun(ctx) {
+		return nil, &core.DryRunError{Request: req}
+	}
 	client := &http.Client{}
 	return client.Do(req)
 }
@@ -0,0 +1,63 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package pappayapplytest 提供 pappayapply 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package pappayapplytest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jemuri/wechatpay-go/services/pappayapply"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakePapPayApplyAPI pappayapply.PapPayApplyAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakePapPayApplyAPI struct {
+	servicetest.Recorder
+
+	HandlePapPayNotifyFunc func(context.Context, *http.Request) (*pappayapply.PapPayNotifyRequest, *pappayapply.PapPayNotifyResponse, error)
+	PapPayApplyFunc        func(context.Context, *pappayapply.PapPayApplyRequest) (*pappayapply.PapPayApplyResponse, error)
+}
+
+var _ pappayapply.PapPayApplyAPI = (*FakePapPayApplyAPI)(nil)
+
+// HandlePapPayNotify 记录调用并返回 HandlePapPayNotifyFunc 的结果
+func (f *FakePapPayApplyAPI) HandlePapPayNotify(ctx context.Context, req *http.Request) (r0 *pappayapply.PapPayNotifyRequest, r1 *pappayapply.PapPayNotifyResponse, err error) {
+	f.Record("HandlePapPayNotify", req)
+	if f.HandlePapPayNotifyFunc == nil {
+		err = servicetest.NotStubbed("PapPayApplyAPI", "HandlePapPayNotify")
+		return
+	}
+	return f.HandlePapPayNotifyFunc(ctx, req)
+}
+
+// HandlePapPayNotifyReturns 设置 HandlePapPayNotify 的返回值
+func (f *FakePapPayApplyAPI) HandlePapPayNotifyReturns(r0 *pappayapply.PapPayNotifyRequest, r1 *pappayapply.PapPayNotifyResponse, err error) *FakePapPayApplyAPI {
+	f.HandlePapPayNotifyFunc = func(context.Context, *http.Request) (*pappayapply.PapPayNotifyRequest, *pappayapply.PapPayNotifyResponse, error) {
+		return r0, r1, err
+	}
+	return f
+}
+
+// PapPayApply 记录调用并返回 PapPayApplyFunc 的结果
+func (f *FakePapPayApplyAPI) PapPayApply(ctx context.Context, req *pappayapply.PapPayApplyRequest) (r0 *pappayapply.PapPayApplyResponse, err error) {
+	f.Record("PapPayApply", req)
+	if f.PapPayApplyFunc == nil {
+		err = servicetest.NotStubbed("PapPayApplyAPI", "PapPayApply")
+		return
+	}
+	return f.PapPayApplyFunc(ctx, req)
+}
+
+// PapPayApplyReturns 设置 PapPayApply 的返回值
+func (f *FakePapPayApplyAPI) PapPayApplyReturns(r0 *pappayapply.PapPayApplyResponse, err error) *FakePapPayApplyAPI {
+	f.PapPayApplyFunc = func(context.Context, *pappayapply.PapPayApplyRequest) (*pappayapply.PapPayApplyResponse, error) {
+		return r0, err
+	}
+	return f
+}
@@ -0,0 +1,27 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package pappayapply
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+)
+
+// Validate 按接口文档中的约束校验 PapPayApplyRequest 的字段
+func (o PapPayApplyRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PapPayApplyRequest", opts...)
+	c.Length("appid", validation.String(o.AppID), 0, 32)
+	c.Length("nonce_str", validation.String(o.NonceStr), 0, 32)
+	c.Length("body", validation.String(o.Body), 1, 128)
+	c.Length("detail", validation.String(o.Detail), 0, 6000)
+	c.Length("attach", validation.String(o.Attach), 0, 128)
+	c.Length("out_trade_no", validation.String(o.OutTradeNo), 1, 32)
+	c.Range("total_fee", validation.Int64(int64(o.TotalFee)), 1, 0)
+	c.Pattern("fee_type", validation.String(o.FeeType), "^[A-Z]{3}$")
+	c.Length("spbill_create_ip", validation.String(o.SpbillCreateIP), 0, 64)
+	c.Length("goods_tag", validation.String(o.GoodsTag), 0, 32)
+	c.Length("notify_url", validation.String(o.NotifyURL), 0, 256)
+	c.Length("contract_id", validation.String(o.ContractID), 1, 32)
+	return c.Err()
+}
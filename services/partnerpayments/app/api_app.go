@@ -38,6 +38,11 @@ func (a *AppApiService) CloseOrder(ctx context.Context, req CloseOrderRequest) (
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutTradeNo == nil {
 		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in CloseOrderRequest")
@@ -80,6 +85,11 @@ func (a *AppApiService) Prepay(ctx context.Context, req PrepayRequest) (resp *Pr
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/pay/partner/transactions/app"
 	// Make sure All Required Params are properly set
 
@@ -117,6 +127,11 @@ func (a *AppApiService) QueryOrderById(ctx context.Context, req QueryOrderByIdRe
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.TransactionId == nil {
 		return nil, nil, fmt.Errorf("field `TransactionId` is required and must be specified in QueryOrderByIdRequest")
@@ -170,6 +185,11 @@ func (a *AppApiService) QueryOrderByOutTradeNo(ctx context.Context, req QueryOrd
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutTradeNo == nil {
 		return nil, nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in QueryOrderByOutTradeNoRequest")
@@ -0,0 +1,116 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/requestpayment"
+)
+
+// PrepayWithRequestPaymentResponse 预下单ID，并包含了调起支付的请求参数
+type PrepayWithRequestPaymentResponse struct {
+	// 预支付交易会话标识
+	PrepayId *string `json:"prepayId"` // revive:disable-line:var-naming
+	// 商户号
+	PartnerId *string `json:"partnerId"` // revive:disable-line:var-naming
+	// 时间戳
+	TimeStamp *string `json:"timeStamp"`
+	// 随机字符串
+	NonceStr *string `json:"nonceStr"`
+	// 订单详情扩展字符串
+	Package *string `json:"package"`
+	// 签名
+	Sign *string `json:"sign"`
+}
+
+// PrepayWithRequestPayment APP支付下单，并返回调起支付的请求参数
+//
+// 调起支付使用的应用ID为 sub_appid，未设置 sub_appid 时为 sp_appid；商户号为子商户号。
+// 缺少这些字段时不会下单；下单成功但生成调起支付的参数失败时，返回的 result 中包含下单的请求与应答
+func (a *AppApiService) PrepayWithRequestPayment(
+	ctx context.Context,
+	req PrepayRequest,
+) (resp *PrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	appid := req.SpAppid
+	if req.SubAppid != nil {
+		appid = req.SubAppid
+	}
+	if appid == nil {
+		return nil, nil, fmt.Errorf("field `SpAppid` or `SubAppid` is required and must be specified in PrepayRequest")
+	}
+	if req.SubMchid == nil {
+		return nil, nil, fmt.Errorf("field `SubMchid` is required and must be specified in PrepayRequest")
+	}
+
+	prepayResp, result, err := a.Prepay(ctx, req)
+	if err != nil {
+		return nil, result, err
+	}
+
+	resp, err = a.RequestPayment(ctx, *appid, *req.SubMchid, *prepayResp.PrepayId)
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
+// RequestPayment 为已有的预支付交易会话标识 prepayId 生成 APP 调起支付的参数
+//
+// appid 为 sp_appid 或 sub_appid，subMchid 为子商户号。
+// prepayId 有效期为 2 小时，用户在有效期内重新发起支付时无需重新下单
+func (a *AppApiService) RequestPayment(
+	ctx context.Context, appid, subMchid, prepayId string,
+) (resp *PrepayWithRequestPaymentResponse, err error) {
+	payment, err := (&requestpayment.Builder{Client: a.Client}).App(ctx, appid, subMchid, prepayId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrepayWithRequestPaymentResponse{
+		PrepayId:  payment.PrepayId,
+		PartnerId: payment.PartnerId,
+		TimeStamp: payment.TimeStamp,
+		NonceStr:  payment.NonceStr,
+		Package:   payment.Package,
+		Sign:      payment.Sign,
+	}, nil
+}
+
+func (o PrepayWithRequestPaymentResponse) String() string {
+	var ret string
+	if o.PrepayId == nil {
+		ret += "PrepayId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PrepayId:%v, ", *o.PrepayId)
+	}
+	if o.PartnerId == nil {
+		ret += "PartnerId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PartnerId:%v, ", *o.PartnerId)
+	}
+	if o.TimeStamp == nil {
+		ret += "TimeStamp:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TimeStamp:%v, ", *o.TimeStamp)
+	}
+	if o.NonceStr == nil {
+		ret += "NonceStr:<nil>, "
+	} else {
+		ret += fmt.Sprintf("NonceStr:%v, ", *o.NonceStr)
+	}
+	if o.Package == nil {
+		ret += "Package:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Package:%v, ", *o.Package)
+	}
+	if o.Sign == nil {
+		ret += "Sign:<nil>"
+	} else {
+		ret += fmt.Sprintf("Sign:%v", *o.Sign)
+	}
+
+	return fmt.Sprintf("PrepayResponse{%s}", ret)
+}
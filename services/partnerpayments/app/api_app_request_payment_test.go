@@ -0,0 +1,144 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/auth"
+	"github.com/jemuri/wechatpay-go/core/auth/signers"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments/app"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// limitedSigner 签名 limit 次后返回错误，用于模拟下单成功后生成调起支付参数失败
+type limitedSigner struct {
+	*signers.SHA256WithRSASigner
+	limit int
+}
+
+func (s *limitedSigner) Sign(ctx context.Context, message string) (*auth.SignatureResult, error) {
+	if s.limit <= 0 {
+		return nil, fmt.Errorf("signer unavailable")
+	}
+	s.limit--
+	return s.SHA256WithRSASigner.Sign(ctx, message)
+}
+
+func newTestService(t *testing.T, signLimit int, requested *int) *app.AppApiService {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*requested++
+		assert.Equal(t, "/v3/pay/partner/transactions/app", req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{consts.ContentType: []string{consts.ApplicationJSON}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"prepay_id":"wx201410272009395522657a690389285100"}`)),
+			Request:    req,
+		}, nil
+	})
+	signer := &limitedSigner{
+		SHA256WithRSASigner: &signers.SHA256WithRSASigner{
+			MchID: "1900000100", CertificateSerialNo: "SERIAL", PrivateKey: privateKey,
+		},
+		limit: signLimit,
+	}
+	client, err := core.NewClient(
+		context.Background(),
+		option.WithSigner(signer),
+		option.WithoutValidator(),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+	return &app.AppApiService{Client: client}
+}
+
+func newPrepayRequest() app.PrepayRequest {
+	return app.PrepayRequest{
+		SpAppid:     core.String("wx8888888888888888"),
+		SpMchid:     core.String("1900000100"),
+		SubAppid:    core.String("wxd678efh567hg6999"),
+		SubMchid:    core.String("1900000109"),
+		Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+		OutTradeNo:  core.String("1217752501201407033233368018"),
+		NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+		Amount:      &app.Amount{Total: core.Int64(100)},
+	}
+}
+
+func TestAppApiService_PrepayWithRequestPayment(t *testing.T) {
+	requested := 0
+	svc := newTestService(t, 2, &requested)
+
+	resp, result, err := svc.PrepayWithRequestPayment(context.Background(), newPrepayRequest())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.Equal(t, "wx201410272009395522657a690389285100", *resp.PrepayId)
+	assert.Equal(t, "1900000109", *resp.PartnerId)
+	assert.NotEmpty(t, *resp.Sign)
+}
+
+func TestAppApiService_PrepayWithRequestPaymentMissingField(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(req *app.PrepayRequest)
+		wantErr string
+	}{
+		{
+			name:    "missing appid",
+			modify:  func(req *app.PrepayRequest) { req.SpAppid, req.SubAppid = nil, nil },
+			wantErr: "field `SpAppid` or `SubAppid` is required and must be specified in PrepayRequest",
+		},
+		{
+			name:    "missing sub_mchid",
+			modify:  func(req *app.PrepayRequest) { req.SubMchid = nil },
+			wantErr: "field `SubMchid` is required and must be specified in PrepayRequest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := 0
+			svc := newTestService(t, 2, &requested)
+			req := newPrepayRequest()
+			tt.modify(&req)
+
+			resp, result, err := svc.PrepayWithRequestPayment(context.Background(), req)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, resp)
+			assert.Nil(t, result)
+			assert.Equal(t, 0, requested)
+		})
+	}
+}
+
+func TestAppApiService_PrepayWithRequestPaymentSignFailure(t *testing.T) {
+	requested := 0
+	svc := newTestService(t, 1, &requested)
+
+	// 下单成功后签名失败，仍返回下单的 result 以便排查与重新生成调起支付的参数
+	resp, result, err := svc.PrepayWithRequestPayment(context.Background(), newPrepayRequest())
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	require.NotNil(t, result)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.Equal(t, 1, requested)
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package apptest 提供 app 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package apptest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments/app"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeAppAPI app.AppAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeAppAPI struct {
+	servicetest.Recorder
+
+	CloseOrderFunc               func(context.Context, app.CloseOrderRequest) (*core.APIResult, error)
+	PrepayFunc                   func(context.Context, app.PrepayRequest) (*app.PrepayResponse, *core.APIResult, error)
+	PrepayWithRequestPaymentFunc func(context.Context, app.PrepayRequest) (*app.PrepayWithRequestPaymentResponse, *core.APIResult, error)
+	QueryOrderByIdFunc           func(context.Context, app.QueryOrderByIdRequest) (*partnerpayments.Transaction, *core.APIResult, error)
+	QueryOrderByOutTradeNoFunc   func(context.Context, app.QueryOrderByOutTradeNoRequest) (*partnerpayments.Transaction, *core.APIResult, error)
+	RequestPaymentFunc           func(context.Context, string, string, string) (*app.PrepayWithRequestPaymentResponse, error)
+}
+
+var _ app.AppAPI = (*FakeAppAPI)(nil)
+
+// CloseOrder 记录调用并返回 CloseOrderFunc 的结果
+func (f *FakeAppAPI) CloseOrder(ctx context.Context, req app.CloseOrderRequest) (result *core.APIResult, err error) {
+	f.Record("CloseOrder", req)
+	if f.CloseOrderFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "CloseOrder")
+		return
+	}
+	return f.CloseOrderFunc(ctx, req)
+}
+
+// CloseOrderReturns 设置 CloseOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAppAPI) CloseOrderReturns(err error) *FakeAppAPI {
+	f.CloseOrderFunc = func(context.Context, app.CloseOrderRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// Prepay 记录调用并返回 PrepayFunc 的结果
+func (f *FakeAppAPI) Prepay(ctx context.Context, req app.PrepayRequest) (resp *app.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("Prepay", req)
+	if f.PrepayFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "Prepay")
+		return
+	}
+	return f.PrepayFunc(ctx, req)
+}
+
+// PrepayReturns 设置 Prepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAppAPI) PrepayReturns(resp *app.PrepayResponse, err error) *FakeAppAPI {
+	f.PrepayFunc = func(context.Context, app.PrepayRequest) (*app.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PrepayWithRequestPayment 记录调用并返回 PrepayWithRequestPaymentFunc 的结果
+func (f *FakeAppAPI) PrepayWithRequestPayment(ctx context.Context, req app.PrepayRequest) (resp *app.PrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	f.Record("PrepayWithRequestPayment", req)
+	if f.PrepayWithRequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "PrepayWithRequestPayment")
+		return
+	}
+	return f.PrepayWithRequestPaymentFunc(ctx, req)
+}
+
+// PrepayWithRequestPaymentReturns 设置 PrepayWithRequestPayment 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAppAPI) PrepayWithRequestPaymentReturns(resp *app.PrepayWithRequestPaymentResponse, err error) *FakeAppAPI {
+	f.PrepayWithRequestPaymentFunc = func(context.Context, app.PrepayRequest) (*app.PrepayWithRequestPaymentResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderById 记录调用并返回 QueryOrderByIdFunc 的结果
+func (f *FakeAppAPI) QueryOrderById(ctx context.Context, req app.QueryOrderByIdRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderById", req)
+	if f.QueryOrderByIdFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "QueryOrderById")
+		return
+	}
+	return f.QueryOrderByIdFunc(ctx, req)
+}
+
+// QueryOrderByIdReturns 设置 QueryOrderById 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAppAPI) QueryOrderByIdReturns(resp *partnerpayments.Transaction, err error) *FakeAppAPI {
+	f.QueryOrderByIdFunc = func(context.Context, app.QueryOrderByIdRequest) (*partnerpayments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderByOutTradeNo 记录调用并返回 QueryOrderByOutTradeNoFunc 的结果
+func (f *FakeAppAPI) QueryOrderByOutTradeNo(ctx context.Context, req app.QueryOrderByOutTradeNoRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderByOutTradeNo", req)
+	if f.QueryOrderByOutTradeNoFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "QueryOrderByOutTradeNo")
+		return
+	}
+	return f.QueryOrderByOutTradeNoFunc(ctx, req)
+}
+
+// QueryOrderByOutTradeNoReturns 设置 QueryOrderByOutTradeNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAppAPI) QueryOrderByOutTradeNoReturns(resp *partnerpayments.Transaction, err error) *FakeAppAPI {
+	f.QueryOrderByOutTradeNoFunc = func(context.Context, app.QueryOrderByOutTradeNoRequest) (*partnerpayments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// RequestPayment 记录调用并返回 RequestPaymentFunc 的结果
+func (f *FakeAppAPI) RequestPayment(ctx context.Context, appid string, subMchid string, prepayId string) (resp *app.PrepayWithRequestPaymentResponse, err error) {
+	f.Record("RequestPayment", []interface{}{appid, subMchid, prepayId})
+	if f.RequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("AppAPI", "RequestPayment")
+		return
+	}
+	return f.RequestPaymentFunc(ctx, appid, subMchid, prepayId)
+}
+
+// RequestPaymentReturns 设置 RequestPayment 的返回值
+func (f *FakeAppAPI) RequestPaymentReturns(resp *app.PrepayWithRequestPaymentResponse, err error) *FakeAppAPI {
+	f.RequestPaymentFunc = func(context.Context, string, string, string) (*app.PrepayWithRequestPaymentResponse, error) {
+		return resp, err
+	}
+	return f
+}
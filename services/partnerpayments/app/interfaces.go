@@ -0,0 +1,37 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package app
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+)
+
+// AppAPI AppApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *AppApiService，即可在测试中使用 apptest.FakeAppAPI 替代
+type AppAPI interface {
+	// CloseOrder 关闭订单
+	CloseOrder(ctx context.Context, req CloseOrderRequest) (result *core.APIResult, err error)
+
+	// Prepay APP支付下单
+	Prepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error)
+
+	// PrepayWithRequestPayment APP支付下单，并返回调起支付的请求参数
+	PrepayWithRequestPayment(ctx context.Context, req PrepayRequest) (resp *PrepayWithRequestPaymentResponse, result *core.APIResult, err error)
+
+	// QueryOrderById 微信支付订单号查询订单
+	QueryOrderById(ctx context.Context, req QueryOrderByIdRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+
+	// QueryOrderByOutTradeNo 商户订单号查询订单
+	QueryOrderByOutTradeNo(ctx context.Context, req QueryOrderByOutTradeNoRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+
+	// RequestPayment 为已有的预支付交易会话标识 prepayId 生成 APP 调起支付的参数
+	RequestPayment(ctx context.Context, appid string, subMchid string, prepayId string) (resp *PrepayWithRequestPaymentResponse, err error)
+}
+
+var _ AppAPI = (*AppApiService)(nil)
@@ -38,6 +38,11 @@ func (a *H5ApiService) CloseOrder(ctx context.Context, req CloseOrderRequest) (r
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutTradeNo == nil {
 		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in CloseOrderRequest")
@@ -80,6 +85,11 @@ func (a *H5ApiService) Prepay(ctx context.Context, req PrepayRequest) (resp *Pre
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/pay/partner/transactions/h5"
 	// Make sure All Required Params are properly set
 
@@ -117,6 +127,11 @@ func (a *H5ApiService) QueryOrderById(ctx context.Context, req QueryOrderByIdReq
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.TransactionId == nil {
 		return nil, nil, fmt.Errorf("field `TransactionId` is required and must be specified in QueryOrderByIdRequest")
@@ -170,6 +185,11 @@ func (a *H5ApiService) QueryOrderByOutTradeNo(ctx context.Context, req QueryOrde
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutTradeNo == nil {
 		return nil, nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in QueryOrderByOutTradeNoRequest")
@@ -0,0 +1,31 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package h5
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+)
+
+// H5API H5ApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *H5ApiService，即可在测试中使用 h5test.FakeH5API 替代
+type H5API interface {
+	// CloseOrder 关闭订单
+	CloseOrder(ctx context.Context, req CloseOrderRequest) (result *core.APIResult, err error)
+
+	// Prepay H5支付下单
+	Prepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error)
+
+	// QueryOrderById 微信支付订单号查询订单
+	QueryOrderById(ctx context.Context, req QueryOrderByIdRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+
+	// QueryOrderByOutTradeNo 商户订单号查询订单
+	QueryOrderByOutTradeNo(ctx context.Context, req QueryOrderByOutTradeNoRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+}
+
+var _ H5API = (*H5ApiService)(nil)
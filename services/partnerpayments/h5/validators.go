@@ -0,0 +1,149 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package h5
+
+import (
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 Amount 的字段
+func (o Amount) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("Amount", opts...)
+	c.Required("total", o.Total != nil)
+	c.Range("total", o.Total, 1, 0)
+	c.Pattern("currency", o.Currency, "^[A-Z]{3}$")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CloseOrderRequest 的字段
+func (o CloseOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CloseOrderRequest", opts...)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("sp_mchid", o.SpMchid != nil)
+	c.Length("sp_mchid", o.SpMchid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 Detail 的字段
+func (o Detail) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("Detail", opts...)
+	for i, item := range o.GoodsDetail {
+		c.Nested(validation.Index("goods_detail", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GoodsDetail 的字段
+func (o GoodsDetail) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GoodsDetail", opts...)
+	c.Required("merchant_goods_id", o.MerchantGoodsId != nil)
+	c.Required("quantity", o.Quantity != nil)
+	c.Range("quantity", o.Quantity, 1, 0)
+	c.Required("unit_price", o.UnitPrice != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 H5Info 的字段
+func (o H5Info) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("H5Info", opts...)
+	c.Required("type", o.Type != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PrepayRequest 的字段
+func (o PrepayRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PrepayRequest", opts...)
+	c.Required("sp_appid", o.SpAppid != nil)
+	c.Length("sp_appid", o.SpAppid, 0, 32)
+	c.Required("sp_mchid", o.SpMchid != nil)
+	c.Length("sp_mchid", o.SpMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("description", o.Description != nil)
+	c.Length("description", o.Description, 1, 127)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Window("time_expire", o.TimeExpire, chinatime.Window{Min: time.Minute})
+	c.Length("attach", o.Attach, 0, 128)
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	c.Length("goods_tag", o.GoodsTag, 0, 32)
+	c.Required("amount", o.Amount != nil)
+	if o.Amount != nil {
+		c.Nested("amount", o.Amount.Validate(opts...))
+	}
+	if o.Detail != nil {
+		c.Nested("detail", o.Detail.Validate(opts...))
+	}
+	c.Required("scene_info", o.SceneInfo != nil)
+	if o.SceneInfo != nil {
+		c.Nested("scene_info", o.SceneInfo.Validate(opts...))
+	}
+	if o.SettleInfo != nil {
+		c.Nested("settle_info", o.SettleInfo.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryOrderByIdRequest 的字段
+func (o QueryOrderByIdRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryOrderByIdRequest", opts...)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.Required("sp_mchid", o.SpMchid != nil)
+	c.Length("sp_mchid", o.SpMchid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryOrderByOutTradeNoRequest 的字段
+func (o QueryOrderByOutTradeNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryOrderByOutTradeNoRequest", opts...)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("sp_mchid", o.SpMchid != nil)
+	c.Length("sp_mchid", o.SpMchid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SceneInfo 的字段
+func (o SceneInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SceneInfo", opts...)
+	c.Required("payer_client_ip", o.PayerClientIp != nil)
+	c.Length("payer_client_ip", o.PayerClientIp, 0, 45)
+	if o.StoreInfo != nil {
+		c.Nested("store_info", o.StoreInfo.Validate(opts...))
+	}
+	c.Required("h5_info", o.H5Info != nil)
+	if o.H5Info != nil {
+		c.Nested("h5_info", o.H5Info.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SettleInfo 的字段
+func (o SettleInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SettleInfo", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 StoreInfo 的字段
+func (o StoreInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("StoreInfo", opts...)
+	c.Required("id", o.Id != nil)
+	return c.Err()
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package jsapitest 提供 jsapi 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package jsapitest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeJsapiAPI jsapi.JsapiAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeJsapiAPI struct {
+	servicetest.Recorder
+
+	CloseOrderFunc               func(context.Context, jsapi.CloseOrderRequest) (*core.APIResult, error)
+	PrepayFunc                   func(context.Context, jsapi.PrepayRequest) (*jsapi.PrepayResponse, *core.APIResult, error)
+	PrepayWithRequestPaymentFunc func(context.Context, jsapi.PrepayRequest, string) (*jsapi.PrepayWithRequestPaymentResponse, *core.APIResult, error)
+	QueryOrderByIdFunc           func(context.Context, jsapi.QueryOrderByIdRequest) (*partnerpayments.Transaction, *core.APIResult, error)
+	QueryOrderByOutTradeNoFunc   func(context.Context, jsapi.QueryOrderByOutTradeNoRequest) (*partnerpayments.Transaction, *core.APIResult, error)
+	RequestPaymentFunc           func(context.Context, string, string) (*jsapi.PrepayWithRequestPaymentResponse, error)
+}
+
+var _ jsapi.JsapiAPI = (*FakeJsapiAPI)(nil)
+
+// CloseOrder 记录调用并返回 CloseOrderFunc 的结果
+func (f *FakeJsapiAPI) CloseOrder(ctx context.Context, req jsapi.CloseOrderRequest) (result *core.APIResult, err error) {
+	f.Record("CloseOrder", req)
+	if f.CloseOrderFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "CloseOrder")
+		return
+	}
+	return f.CloseOrderFunc(ctx, req)
+}
+
+// CloseOrderReturns 设置 CloseOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeJsapiAPI) CloseOrderReturns(err error) *FakeJsapiAPI {
+	f.CloseOrderFunc = func(context.Context, jsapi.CloseOrderRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// Prepay 记录调用并返回 PrepayFunc 的结果
+func (f *FakeJsapiAPI) Prepay(ctx context.Context, req jsapi.PrepayRequest) (resp *jsapi.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("Prepay", req)
+	if f.PrepayFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "Prepay")
+		return
+	}
+	return f.PrepayFunc(ctx, req)
+}
+
+// PrepayReturns 设置 Prepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeJsapiAPI) PrepayReturns(resp *jsapi.PrepayResponse, err error) *FakeJsapiAPI {
+	f.PrepayFunc = func(context.Context, jsapi.PrepayRequest) (*jsapi.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PrepayWithRequestPayment 记录调用并返回 PrepayWithRequestPaymentFunc 的结果
+func (f *FakeJsapiAPI) PrepayWithRequestPayment(ctx context.Context, req jsapi.PrepayRequest, requestPaymentAppid string) (resp *jsapi.PrepayWithRequestPaymentResponse, result *core.APIResult, err error) {
+	f.Record("PrepayWithRequestPayment", []interface{}{req, requestPaymentAppid})
+	if f.PrepayWithRequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "PrepayWithRequestPayment")
+		return
+	}
+	return f.PrepayWithRequestPaymentFunc(ctx, req, requestPaymentAppid)
+}
+
+// PrepayWithRequestPaymentReturns 设置 PrepayWithRequestPayment 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeJsapiAPI) PrepayWithRequestPaymentReturns(resp *jsapi.PrepayWithRequestPaymentResponse, err error) *FakeJsapiAPI {
+	f.PrepayWithRequestPaymentFunc = func(context.Context, jsapi.PrepayRequest, string) (*jsapi.PrepayWithRequestPaymentResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderById 记录调用并返回 QueryOrderByIdFunc 的结果
+func (f *FakeJsapiAPI) QueryOrderById(ctx context.Context, req jsapi.QueryOrderByIdRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderById", req)
+	if f.QueryOrderByIdFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "QueryOrderById")
+		return
+	}
+	return f.QueryOrderByIdFunc(ctx, req)
+}
+
+// QueryOrderByIdReturns 设置 QueryOrderById 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeJsapiAPI) QueryOrderByIdReturns(resp *partnerpayments.Transaction, err error) *FakeJsapiAPI {
+	f.QueryOrderByIdFunc = func(context.Context, jsapi.QueryOrderByIdRequest) (*partnerpayments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderByOutTradeNo 记录调用并返回 QueryOrderByOutTradeNoFunc 的结果
+func (f *FakeJsapiAPI) QueryOrderByOutTradeNo(ctx context.Context, req jsapi.QueryOrderByOutTradeNoRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderByOutTradeNo", req)
+	if f.QueryOrderByOutTradeNoFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "QueryOrderByOutTradeNo")
+		return
+	}
+	return f.QueryOrderByOutTradeNoFunc(ctx, req)
+}
+
+// QueryOrderByOutTradeNoReturns 设置 QueryOrderByOutTradeNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeJsapiAPI) QueryOrderByOutTradeNoReturns(resp *partnerpayments.Transaction, err error) *FakeJsapiAPI {
+	f.QueryOrderByOutTradeNoFunc = func(context.Context, jsapi.QueryOrderByOutTradeNoRequest) (*partnerpayments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// RequestPayment 记录调用并返回 RequestPaymentFunc 的结果
+func (f *FakeJsapiAPI) RequestPayment(ctx context.Context, appid string, prepayId string) (resp *jsapi.PrepayWithRequestPaymentResponse, err error) {
+	f.Record("RequestPayment", []interface{}{appid, prepayId})
+	if f.RequestPaymentFunc == nil {
+		err = servicetest.NotStubbed("JsapiAPI", "RequestPayment")
+		return
+	}
+	return f.RequestPaymentFunc(ctx, appid, prepayId)
+}
+
+// RequestPaymentReturns 设置 RequestPayment 的返回值
+func (f *FakeJsapiAPI) RequestPaymentReturns(resp *jsapi.PrepayWithRequestPaymentResponse, err error) *FakeJsapiAPI {
+	f.RequestPaymentFunc = func(context.Context, string, string) (*jsapi.PrepayWithRequestPaymentResponse, error) {
+		return resp, err
+	}
+	return f
+}
@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // Amount
@@ -497,7 +499,7 @@ func (o PrepayRequest) MarshalJSON() ([]byte, error) {
 	toSerialize["out_trade_no"] = o.OutTradeNo
 
 	if o.TimeExpire != nil {
-		toSerialize["time_expire"] = o.TimeExpire.Format(time.RFC3339)
+		toSerialize["time_expire"] = chinatime.Format(*o.TimeExpire)
 	}
 
 	if o.Attach != nil {
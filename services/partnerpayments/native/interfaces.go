@@ -0,0 +1,35 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package native
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+	"github.com/jemuri/wechatpay-go/utils/qrcode"
+)
+
+// NativeAPI NativeApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *NativeApiService，即可在测试中使用 nativetest.FakeNativeAPI 替代
+type NativeAPI interface {
+	// CloseOrder 关闭订单
+	CloseOrder(ctx context.Context, req CloseOrderRequest) (result *core.APIResult, err error)
+
+	// Prepay Native支付预下单
+	Prepay(ctx context.Context, req PrepayRequest) (resp *PrepayResponse, result *core.APIResult, err error)
+
+	// PrepayWithQRCode Native支付预下单，并将二维码链接渲染为 format 格式的二维码图片
+	PrepayWithQRCode(ctx context.Context, req PrepayRequest, format qrcode.Format, opts qrcode.Options) (resp *PrepayWithQRCodeResponse, result *core.APIResult, err error)
+
+	// QueryOrderById 微信支付订单号查询订单
+	QueryOrderById(ctx context.Context, req QueryOrderByIdRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+
+	// QueryOrderByOutTradeNo 商户订单号查询订单
+	QueryOrderByOutTradeNo(ctx context.Context, req QueryOrderByOutTradeNoRequest) (resp *partnerpayments.Transaction, result *core.APIResult, err error)
+}
+
+var _ NativeAPI = (*NativeApiService)(nil)
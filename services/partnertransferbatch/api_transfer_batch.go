@@ -47,6 +47,11 @@ func (a *TransferBatchApiService) GetTransferBatchByNo(ctx context.Context, req
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BatchId == nil {
 		return nil, nil, fmt.Errorf("field `BatchId` is required and must be specified in GetTransferBatchByNoRequest")
@@ -117,6 +122,11 @@ func (a *TransferBatchApiService) GetTransferBatchByOutNo(ctx context.Context, r
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutBatchNo == nil {
 		return nil, nil, fmt.Errorf("field `OutBatchNo` is required and must be specified in GetTransferBatchByOutNoRequest")
@@ -191,6 +201,11 @@ func (a *TransferBatchApiService) InitiateTransferBatch(ctx context.Context, req
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// 对请求中敏感字段进行加密
 	encReq := req.Clone()
 	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
@@ -0,0 +1,41 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package partnertransferbatch
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// TransferBatchAPI TransferBatchApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransferBatchApiService，即可在测试中使用 partnertransferbatchtest.FakeTransferBatchAPI 替代
+type TransferBatchAPI interface {
+	// GetTransferBatchByNo 微信支付批次单号查询批次单
+	GetTransferBatchByNo(ctx context.Context, req GetTransferBatchByNoRequest) (resp *TransferBatchEntity, result *core.APIResult, err error)
+
+	// GetTransferBatchByOutNo 商家批次单号查询批次单
+	GetTransferBatchByOutNo(ctx context.Context, req GetTransferBatchByOutNoRequest) (resp *TransferBatchEntity, result *core.APIResult, err error)
+
+	// InitiateTransferBatch 发起批量转账
+	InitiateTransferBatch(ctx context.Context, req InitiateTransferBatchRequest) (resp *InitiateTransferBatchResponse, result *core.APIResult, err error)
+}
+
+// TransferDetailAPI TransferDetailApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransferDetailApiService，即可在测试中使用 partnertransferbatchtest.FakeTransferDetailAPI 替代
+type TransferDetailAPI interface {
+	// GetTransferDetailByNo 微信支付明细单号查询明细单
+	GetTransferDetailByNo(ctx context.Context, req GetTransferDetailByNoRequest) (resp *TransferDetailEntity, result *core.APIResult, err error)
+
+	// GetTransferDetailByOutNo 商家明细单号查询明细单
+	GetTransferDetailByOutNo(ctx context.Context, req GetTransferDetailByOutNoRequest) (resp *TransferDetailEntity, result *core.APIResult, err error)
+}
+
+var (
+	_ TransferBatchAPI  = (*TransferBatchApiService)(nil)
+	_ TransferDetailAPI = (*TransferDetailApiService)(nil)
+)
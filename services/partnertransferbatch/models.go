@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // AuthType * `INFORMATION_AUTHORIZATION_TYPE` - 特约商户信息授权类型, 表示使用特约商户用户信息，出款方服务商 * `FUND_AUTHORIZATION_TYPE` - 特约商户资金授权类型, 表示使用特约商户的资金，出款方为特约商户，用户信息为服务商appid对应的openid * `INFORMATION_AND_FUND_AUTHORIZATION_TYPE` - 特约商户信息和资金授权类型, 表示使用特约商户的用户信息且出款方为特约商户
@@ -652,7 +654,7 @@ func (o InitiateTransferBatchResponse) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in InitiateTransferBatchResponse")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -817,11 +819,11 @@ func (o TransferBatchEntity) MarshalJSON() ([]byte, error) {
 	toSerialize["total_num"] = o.TotalNum
 
 	if o.CreateTime != nil {
-		toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+		toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 	}
 
 	if o.UpdateTime != nil {
-		toSerialize["update_time"] = o.UpdateTime.Format(time.RFC3339)
+		toSerialize["update_time"] = chinatime.Format(*o.UpdateTime)
 	}
 
 	if o.SuccessAmount != nil {
@@ -1290,12 +1292,12 @@ func (o TransferDetailEntity) MarshalJSON() ([]byte, error) {
 	if o.InitiateTime == nil {
 		return nil, fmt.Errorf("field `InitiateTime` is required and must be specified in TransferDetailEntity")
 	}
-	toSerialize["initiate_time"] = o.InitiateTime.Format(time.RFC3339)
+	toSerialize["initiate_time"] = chinatime.Format(*o.InitiateTime)
 
 	if o.UpdateTime == nil {
 		return nil, fmt.Errorf("field `UpdateTime` is required and must be specified in TransferDetailEntity")
 	}
-	toSerialize["update_time"] = o.UpdateTime.Format(time.RFC3339)
+	toSerialize["update_time"] = chinatime.Format(*o.UpdateTime)
 	return json.Marshal(toSerialize)
 }
 
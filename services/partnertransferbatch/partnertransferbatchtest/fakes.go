@@ -0,0 +1,131 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package partnertransferbatchtest 提供 partnertransferbatch 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package partnertransferbatchtest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/partnertransferbatch"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeTransferBatchAPI partnertransferbatch.TransferBatchAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransferBatchAPI struct {
+	servicetest.Recorder
+
+	GetTransferBatchByNoFunc    func(context.Context, partnertransferbatch.GetTransferBatchByNoRequest) (*partnertransferbatch.TransferBatchEntity, *core.APIResult, error)
+	GetTransferBatchByOutNoFunc func(context.Context, partnertransferbatch.GetTransferBatchByOutNoRequest) (*partnertransferbatch.TransferBatchEntity, *core.APIResult, error)
+	InitiateTransferBatchFunc   func(context.Context, partnertransferbatch.InitiateTransferBatchRequest) (*partnertransferbatch.InitiateTransferBatchResponse, *core.APIResult, error)
+}
+
+var _ partnertransferbatch.TransferBatchAPI = (*FakeTransferBatchAPI)(nil)
+
+// GetTransferBatchByNo 记录调用并返回 GetTransferBatchByNoFunc 的结果
+func (f *FakeTransferBatchAPI) GetTransferBatchByNo(ctx context.Context, req partnertransferbatch.GetTransferBatchByNoRequest) (resp *partnertransferbatch.TransferBatchEntity, result *core.APIResult, err error) {
+	f.Record("GetTransferBatchByNo", req)
+	if f.GetTransferBatchByNoFunc == nil {
+		err = servicetest.NotStubbed("TransferBatchAPI", "GetTransferBatchByNo")
+		return
+	}
+	return f.GetTransferBatchByNoFunc(ctx, req)
+}
+
+// GetTransferBatchByNoReturns 设置 GetTransferBatchByNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferBatchAPI) GetTransferBatchByNoReturns(resp *partnertransferbatch.TransferBatchEntity, err error) *FakeTransferBatchAPI {
+	f.GetTransferBatchByNoFunc = func(context.Context, partnertransferbatch.GetTransferBatchByNoRequest) (*partnertransferbatch.TransferBatchEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// GetTransferBatchByOutNo 记录调用并返回 GetTransferBatchByOutNoFunc 的结果
+func (f *FakeTransferBatchAPI) GetTransferBatchByOutNo(ctx context.Context, req partnertransferbatch.GetTransferBatchByOutNoRequest) (resp *partnertransferbatch.TransferBatchEntity, result *core.APIResult, err error) {
+	f.Record("GetTransferBatchByOutNo", req)
+	if f.GetTransferBatchByOutNoFunc == nil {
+		err = servicetest.NotStubbed("TransferBatchAPI", "GetTransferBatchByOutNo")
+		return
+	}
+	return f.GetTransferBatchByOutNoFunc(ctx, req)
+}
+
+// GetTransferBatchByOutNoReturns 设置 GetTransferBatchByOutNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferBatchAPI) GetTransferBatchByOutNoReturns(resp *partnertransferbatch.TransferBatchEntity, err error) *FakeTransferBatchAPI {
+	f.GetTransferBatchByOutNoFunc = func(context.Context, partnertransferbatch.GetTransferBatchByOutNoRequest) (*partnertransferbatch.TransferBatchEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// InitiateTransferBatch 记录调用并返回 InitiateTransferBatchFunc 的结果
+func (f *FakeTransferBatchAPI) InitiateTransferBatch(ctx context.Context, req partnertransferbatch.InitiateTransferBatchRequest) (resp *partnertransferbatch.InitiateTransferBatchResponse, result *core.APIResult, err error) {
+	f.Record("InitiateTransferBatch", req)
+	if f.InitiateTransferBatchFunc == nil {
+		err = servicetest.NotStubbed("TransferBatchAPI", "InitiateTransferBatch")
+		return
+	}
+	return f.InitiateTransferBatchFunc(ctx, req)
+}
+
+// InitiateTransferBatchReturns 设置 InitiateTransferBatch 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferBatchAPI) InitiateTransferBatchReturns(resp *partnertransferbatch.InitiateTransferBatchResponse, err error) *FakeTransferBatchAPI {
+	f.InitiateTransferBatchFunc = func(context.Context, partnertransferbatch.InitiateTransferBatchRequest) (*partnertransferbatch.InitiateTransferBatchResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeTransferDetailAPI partnertransferbatch.TransferDetailAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransferDetailAPI struct {
+	servicetest.Recorder
+
+	GetTransferDetailByNoFunc    func(context.Context, partnertransferbatch.GetTransferDetailByNoRequest) (*partnertransferbatch.TransferDetailEntity, *core.APIResult, error)
+	GetTransferDetailByOutNoFunc func(context.Context, partnertransferbatch.GetTransferDetailByOutNoRequest) (*partnertransferbatch.TransferDetailEntity, *core.APIResult, error)
+}
+
+var _ partnertransferbatch.TransferDetailAPI = (*FakeTransferDetailAPI)(nil)
+
+// GetTransferDetailByNo 记录调用并返回 GetTransferDetailByNoFunc 的结果
+func (f *FakeTransferDetailAPI) GetTransferDetailByNo(ctx context.Context, req partnertransferbatch.GetTransferDetailByNoRequest) (resp *partnertransferbatch.TransferDetailEntity, result *core.APIResult, err error) {
+	f.Record("GetTransferDetailByNo", req)
+	if f.GetTransferDetailByNoFunc == nil {
+		err = servicetest.NotStubbed("TransferDetailAPI", "GetTransferDetailByNo")
+		return
+	}
+	return f.GetTransferDetailByNoFunc(ctx, req)
+}
+
+// GetTransferDetailByNoReturns 设置 GetTransferDetailByNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferDetailAPI) GetTransferDetailByNoReturns(resp *partnertransferbatch.TransferDetailEntity, err error) *FakeTransferDetailAPI {
+	f.GetTransferDetailByNoFunc = func(context.Context, partnertransferbatch.GetTransferDetailByNoRequest) (*partnertransferbatch.TransferDetailEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// GetTransferDetailByOutNo 记录调用并返回 GetTransferDetailByOutNoFunc 的结果
+func (f *FakeTransferDetailAPI) GetTransferDetailByOutNo(ctx context.Context, req partnertransferbatch.GetTransferDetailByOutNoRequest) (resp *partnertransferbatch.TransferDetailEntity, result *core.APIResult, err error) {
+	f.Record("GetTransferDetailByOutNo", req)
+	if f.GetTransferDetailByOutNoFunc == nil {
+		err = servicetest.NotStubbed("TransferDetailAPI", "GetTransferDetailByOutNo")
+		return
+	}
+	return f.GetTransferDetailByOutNoFunc(ctx, req)
+}
+
+// GetTransferDetailByOutNoReturns 设置 GetTransferDetailByOutNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferDetailAPI) GetTransferDetailByOutNoReturns(resp *partnertransferbatch.TransferDetailEntity, err error) *FakeTransferDetailAPI {
+	f.GetTransferDetailByOutNoFunc = func(context.Context, partnertransferbatch.GetTransferDetailByOutNoRequest) (*partnertransferbatch.TransferDetailEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
@@ -5,11 +5,9 @@ package app
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/jemuri/wechatpay-go/core"
-	"github.com/jemuri/wechatpay-go/utils"
+	"github.com/jemuri/wechatpay-go/services/requestpayment"
 )
 
 // PrepayWithRequestPaymentResponse 预下单ID，并包含了调起支付的请求参数
@@ -38,23 +36,32 @@ func (a *AppApiService) PrepayWithRequestPayment(
 		return nil, result, err
 	}
 
-	resp = new(PrepayWithRequestPaymentResponse)
-	resp.PrepayId = prepayResp.PrepayId
-	resp.TimeStamp = core.String(strconv.FormatInt(time.Now().Unix(), 10))
-	nonce, err := utils.GenerateNonce()
+	resp, err = a.RequestPayment(ctx, *req.Appid, *prepayResp.PrepayId)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate request for payment err:%s", err.Error())
+		return nil, result, err
 	}
-	resp.NonceStr = core.String(nonce)
-	resp.Package = core.String("Sign=WXPay")
-	message := fmt.Sprintf("%s\n%s\n%s\n%s\n", *req.Appid, *resp.TimeStamp, *resp.NonceStr, *prepayResp.PrepayId)
-	signatureResult, err := a.Client.Sign(ctx, message)
+	return resp, result, nil
+}
+
+// RequestPayment 为已有的预支付交易会话标识 prepayId 生成 APP 调起支付的参数，商户号取自签名所用的商户号
+//
+// prepayId 有效期为 2 小时，用户在有效期内重新发起支付时无需重新下单
+func (a *AppApiService) RequestPayment(
+	ctx context.Context, appid, prepayId string,
+) (resp *PrepayWithRequestPaymentResponse, err error) {
+	payment, err := (&requestpayment.Builder{Client: a.Client}).App(ctx, appid, "", prepayId)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate sign for payment err:%s", err.Error())
+		return nil, err
 	}
-	resp.Sign = core.String(signatureResult.Signature)
-	resp.PartnerId = core.String(signatureResult.MchID)
-	return resp, result, nil
+
+	return &PrepayWithRequestPaymentResponse{
+		PrepayId:  payment.PrepayId,
+		PartnerId: payment.PartnerId,
+		TimeStamp: payment.TimeStamp,
+		NonceStr:  payment.NonceStr,
+		Package:   payment.Package,
+		Sign:      payment.Sign,
+	}, nil
 }
 
 func (o PrepayWithRequestPaymentResponse) String() string {
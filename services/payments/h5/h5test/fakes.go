@@ -0,0 +1,102 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package h5test 提供 h5 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package h5test
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/payments/h5"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeH5API h5.H5API 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeH5API struct {
+	servicetest.Recorder
+
+	CloseOrderFunc             func(context.Context, h5.CloseOrderRequest) (*core.APIResult, error)
+	PrepayFunc                 func(context.Context, h5.PrepayRequest) (*h5.PrepayResponse, *core.APIResult, error)
+	QueryOrderByIdFunc         func(context.Context, h5.QueryOrderByIdRequest) (*payments.Transaction, *core.APIResult, error)
+	QueryOrderByOutTradeNoFunc func(context.Context, h5.QueryOrderByOutTradeNoRequest) (*payments.Transaction, *core.APIResult, error)
+}
+
+var _ h5.H5API = (*FakeH5API)(nil)
+
+// CloseOrder 记录调用并返回 CloseOrderFunc 的结果
+func (f *FakeH5API) CloseOrder(ctx context.Context, req h5.CloseOrderRequest) (result *core.APIResult, err error) {
+	f.Record("CloseOrder", req)
+	if f.CloseOrderFunc == nil {
+		err = servicetest.NotStubbed("H5API", "CloseOrder")
+		return
+	}
+	return f.CloseOrderFunc(ctx, req)
+}
+
+// CloseOrderReturns 设置 CloseOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeH5API) CloseOrderReturns(err error) *FakeH5API {
+	f.CloseOrderFunc = func(context.Context, h5.CloseOrderRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// Prepay 记录调用并返回 PrepayFunc 的结果
+func (f *FakeH5API) Prepay(ctx context.Context, req h5.PrepayRequest) (resp *h5.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("Prepay", req)
+	if f.PrepayFunc == nil {
+		err = servicetest.NotStubbed("H5API", "Prepay")
+		return
+	}
+	return f.PrepayFunc(ctx, req)
+}
+
+// PrepayReturns 设置 Prepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeH5API) PrepayReturns(resp *h5.PrepayResponse, err error) *FakeH5API {
+	f.PrepayFunc = func(context.Context, h5.PrepayRequest) (*h5.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderById 记录调用并返回 QueryOrderByIdFunc 的结果
+func (f *FakeH5API) QueryOrderById(ctx context.Context, req h5.QueryOrderByIdRequest) (resp *payments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderById", req)
+	if f.QueryOrderByIdFunc == nil {
+		err = servicetest.NotStubbed("H5API", "QueryOrderById")
+		return
+	}
+	return f.QueryOrderByIdFunc(ctx, req)
+}
+
+// QueryOrderByIdReturns 设置 QueryOrderById 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeH5API) QueryOrderByIdReturns(resp *payments.Transaction, err error) *FakeH5API {
+	f.QueryOrderByIdFunc = func(context.Context, h5.QueryOrderByIdRequest) (*payments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderByOutTradeNo 记录调用并返回 QueryOrderByOutTradeNoFunc 的结果
+func (f *FakeH5API) QueryOrderByOutTradeNo(ctx context.Context, req h5.QueryOrderByOutTradeNoRequest) (resp *payments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderByOutTradeNo", req)
+	if f.QueryOrderByOutTradeNoFunc == nil {
+		err = servicetest.NotStubbed("H5API", "QueryOrderByOutTradeNo")
+		return
+	}
+	return f.QueryOrderByOutTradeNoFunc(ctx, req)
+}
+
+// QueryOrderByOutTradeNoReturns 设置 QueryOrderByOutTradeNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeH5API) QueryOrderByOutTradeNoReturns(resp *payments.Transaction, err error) *FakeH5API {
+	f.QueryOrderByOutTradeNoFunc = func(context.Context, h5.QueryOrderByOutTradeNoRequest) (*payments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
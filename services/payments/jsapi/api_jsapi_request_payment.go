@@ -5,11 +5,9 @@ package jsapi
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/jemuri/wechatpay-go/core"
-	"github.com/jemuri/wechatpay-go/utils"
+	"github.com/jemuri/wechatpay-go/services/requestpayment"
 )
 
 // PrepayWithRequestPaymentResponse 预下单ID，并包含了调起支付的请求参数
@@ -39,24 +37,33 @@ func (a *JsapiApiService) PrepayWithRequestPayment(
 		return nil, result, err
 	}
 
-	resp = new(PrepayWithRequestPaymentResponse)
-	resp.PrepayId = prepayResp.PrepayId
-	resp.SignType = core.String("RSA")
-	resp.Appid = req.Appid
-	resp.TimeStamp = core.String(strconv.FormatInt(time.Now().Unix(), 10))
-	nonce, err := utils.GenerateNonce()
+	resp, err = a.RequestPayment(ctx, *req.Appid, *prepayResp.PrepayId)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate request for payment err:%s", err.Error())
+		return nil, result, err
 	}
-	resp.NonceStr = core.String(nonce)
-	resp.Package = core.String("prepay_id=" + *prepayResp.PrepayId)
-	message := fmt.Sprintf("%s\n%s\n%s\n%s\n", *resp.Appid, *resp.TimeStamp, *resp.NonceStr, *resp.Package)
-	signatureResult, err := a.Client.Sign(ctx, message)
+	return resp, result, nil
+}
+
+// RequestPayment 为已有的预支付交易会话标识 prepayId 生成 JSAPI 或小程序调起支付的参数
+//
+// prepayId 有效期为 2 小时，用户在有效期内重新发起支付时无需重新下单
+func (a *JsapiApiService) RequestPayment(
+	ctx context.Context, appid, prepayId string,
+) (resp *PrepayWithRequestPaymentResponse, err error) {
+	payment, err := (&requestpayment.Builder{Client: a.Client}).Jsapi(ctx, appid, prepayId)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate sign for payment err:%s", err.Error())
+		return nil, err
 	}
-	resp.PaySign = core.String(signatureResult.Signature)
-	return resp, result, nil
+
+	return &PrepayWithRequestPaymentResponse{
+		PrepayId:  core.String(prepayId),
+		Appid:     payment.Appid,
+		TimeStamp: payment.TimeStamp,
+		NonceStr:  payment.NonceStr,
+		Package:   payment.Package,
+		SignType:  payment.SignType,
+		PaySign:   payment.PaySign,
+	}, nil
 }
 
 func (o PrepayWithRequestPaymentResponse) String() string {
@@ -0,0 +1,47 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package jsapitest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi/jsapitest"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// createOrder 依赖 jsapi.JsapiAPI 的业务代码
+func createOrder(ctx context.Context, api jsapi.JsapiAPI, outTradeNo string) (string, error) {
+	resp, _, err := api.Prepay(ctx, jsapi.PrepayRequest{OutTradeNo: core.String(outTradeNo)})
+	if err != nil {
+		return "", err
+	}
+	return *resp.PrepayId, nil
+}
+
+func TestFakeJsapiAPI(t *testing.T) {
+	fake := (&jsapitest.FakeJsapiAPI{}).
+		PrepayReturns(&jsapi.PrepayResponse{PrepayId: core.String("wx201410272009395522657a690389285100")}, nil)
+
+	prepayID, err := createOrder(context.Background(), fake, "1217752501201407033233368018")
+	require.NoError(t, err)
+	assert.Equal(t, "wx201410272009395522657a690389285100", prepayID)
+	fake.AssertCallCount(t, "Prepay", 1)
+	fake.AssertCalledWith(t, "Prepay", jsapi.PrepayRequest{OutTradeNo: core.String("1217752501201407033233368018")})
+
+	apiErr := &core.APIError{StatusCode: http.StatusBadRequest, Code: "ORDERPAID"}
+	fake.CloseOrderReturns(apiErr)
+	result, err := fake.CloseOrder(context.Background(), jsapi.CloseOrderRequest{})
+	assert.True(t, core.IsAPIError(err, "ORDERPAID"))
+	assert.Equal(t, http.StatusBadRequest, result.Response.StatusCode)
+
+	_, _, err = fake.QueryOrderById(context.Background(), jsapi.QueryOrderByIdRequest{})
+	assert.True(t, errors.Is(err, servicetest.ErrNotStubbed))
+	assert.Len(t, fake.Calls(), 3)
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package jsapi_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+)
+
+func TestPrepayRequest_MarshalJSONTimeExpire(t *testing.T) {
+	req := jsapi.PrepayRequest{
+		Appid:       core.String("wxd678efh567hg6787"),
+		Mchid:       core.String("1230000109"),
+		Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+		OutTradeNo:  core.String("1217752501201407033233368018"),
+		TimeExpire:  core.Time(time.Date(2018, 6, 8, 2, 34, 56, 0, time.UTC)),
+		NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+		Amount:      &jsapi.Amount{Total: core.Int64(100)},
+		Payer:       &jsapi.Payer{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+	}
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &body))
+	// UTC 时间同样以北京时间发送
+	assert.Equal(t, "2018-06-08T10:34:56+08:00", body["time_expire"])
+}
+
+func TestPrepayRequest_Validate(t *testing.T) {
+	req := jsapi.PrepayRequest{
+		Appid:       core.String("wxd678efh567hg6787"),
+		Mchid:       core.String("1230000109"),
+		Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+		OutTradeNo:  core.String("1217752501201407033233368018"),
+		Attach:      core.String("自定义数据"),
+		NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+		Amount:      &jsapi.Amount{Total: core.Int64(100), Currency: core.String("CNY")},
+		Payer:       &jsapi.Payer{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+		Detail: &jsapi.Detail{GoodsDetail: []jsapi.GoodsDetail{
+			{MerchantGoodsId: core.String("1246464644"), Quantity: core.Int64(1), UnitPrice: core.Int64(100)},
+		}},
+	}
+	assert.NoError(t, req.Validate())
+
+	req.Appid = nil
+	req.Description = core.String(strings.Repeat("商", 43))
+	req.OutTradeNo = core.String("order@123")
+	req.NotifyUrl = core.String("http://www.weixin.qq.com/wxpay/pay.php")
+	req.Amount.Total = core.Int64(0)
+	req.Amount.Currency = core.String("cny")
+	req.Detail.GoodsDetail = append(req.Detail.GoodsDetail, jsapi.GoodsDetail{
+		MerchantGoodsId: core.String("1246464645"), Quantity: core.Int64(0), UnitPrice: core.Int64(100),
+	})
+
+	err := req.Validate()
+	validationErr, ok := validation.AsError(err)
+	require.True(t, ok)
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{
+		"appid", "description", "out_trade_no", "notify_url",
+		"amount.total", "amount.currency", "detail.goods_detail[1].quantity",
+	}, fields)
+	assert.Contains(t, err.Error(), "description must be 1 to 127 bytes, got 129")
+}
+
+func TestPrepayRequest_ValidateTimeExpire(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeExpire *time.Time
+		wantErr    bool
+	}{
+		{"未设置", nil, false},
+		{"1 小时后", core.Time(time.Now().Add(time.Hour)), false},
+		{"1 天后", core.Time(time.Now().Add(24 * time.Hour)), false},
+		{"当前时间", core.Time(time.Now()), true},
+		{"已过期", core.Time(time.Now().Add(-time.Hour)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := jsapi.PrepayRequest{
+				Appid:       core.String("wxd678efh567hg6787"),
+				Mchid:       core.String("1230000109"),
+				Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+				OutTradeNo:  core.String("1217752501201407033233368018"),
+				TimeExpire:  tt.timeExpire,
+				NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+				Amount:      &jsapi.Amount{Total: core.Int64(100)},
+				Payer:       &jsapi.Payer{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+			}
+			err := req.Validate()
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			validationErr, ok := validation.AsError(err)
+			require.True(t, ok)
+			require.Len(t, validationErr.Fields, 1)
+			assert.Equal(t, "time_expire", validationErr.Fields[0].Field)
+			assert.Contains(t, validationErr.Fields[0].Reason, "must be at least 1m0s after")
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package native
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/utils/qrcode"
+)
+
+// PrepayWithQRCodeResponse 二维码链接，并包含了渲染后的二维码图片
+type PrepayWithQRCodeResponse struct {
+	// 此URL用于生成支付二维码，然后提供给用户扫码支付。
+	CodeUrl *string `json:"code_url"`
+	// 二维码图片
+	Image []byte `json:"-"`
+	// 二维码图片的 MIME 类型，如 image/png
+	ContentType string `json:"-"`
+}
+
+// PrepayWithQRCode Native支付预下单，并将二维码链接渲染为 format 格式的二维码图片
+func (a *NativeApiService) PrepayWithQRCode(
+	ctx context.Context, req PrepayRequest, format qrcode.Format, opts qrcode.Options,
+) (resp *PrepayWithQRCodeResponse, result *core.APIResult, err error) {
+	prepayResp, result, err := a.Prepay(ctx, req)
+	if err != nil {
+		return nil, result, err
+	}
+
+	image, err := qrcode.Render(*prepayResp.CodeUrl, format, opts)
+	if err != nil {
+		return nil, result, fmt.Errorf("generate QR code for payment err:%s", err.Error())
+	}
+	return &PrepayWithQRCodeResponse{
+		CodeUrl:     prepayResp.CodeUrl,
+		Image:       image,
+		ContentType: format.ContentType(),
+	}, result, nil
+}
+
+func (o PrepayWithQRCodeResponse) String() string {
+	var ret string
+	if o.CodeUrl == nil {
+		ret += "CodeUrl:<nil>, "
+	} else {
+		ret += fmt.Sprintf("CodeUrl:%v, ", *o.CodeUrl)
+	}
+	ret += fmt.Sprintf("ContentType:%v, ImageSize:%d", o.ContentType, len(o.Image))
+
+	return fmt.Sprintf("PrepayWithQRCodeResponse{%s}", ret)
+}
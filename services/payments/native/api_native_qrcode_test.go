@@ -0,0 +1,71 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package native_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/consts"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+	"github.com/jemuri/wechatpay-go/utils/qrcode"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNativeApiService_PrepayWithQRCode(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v3/pay/transactions/native", req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{consts.ContentType: []string{consts.ApplicationJSON}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`)),
+			Request:    req,
+		}, nil
+	})
+	client, err := core.NewClient(
+		context.Background(),
+		option.WithMerchantCredential("1900009191", "SERIAL", privateKey),
+		option.WithoutValidator(),
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+
+	svc := native.NativeApiService{Client: client}
+	resp, result, err := svc.PrepayWithQRCode(context.Background(),
+		native.PrepayRequest{
+			Appid:       core.String("wxd678efh567hg6787"),
+			Mchid:       core.String("1900009191"),
+			Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+			OutTradeNo:  core.String("1217752501201407033233368018"),
+			NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+			Amount:      &native.Amount{Total: core.Int64(100)},
+		},
+		qrcode.FormatPNG, qrcode.Options{Size: 200, Level: qrcode.LevelH},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.Equal(t, "weixin://wxpay/bizpayurl?pr=p4lpSuKzz", *resp.CodeUrl)
+	assert.Equal(t, "image/png", resp.ContentType)
+
+	img, err := png.Decode(bytes.NewReader(resp.Image))
+	require.NoError(t, err)
+	assert.Equal(t, 200, img.Bounds().Dx())
+}
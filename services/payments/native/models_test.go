@@ -0,0 +1,49 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package native_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+)
+
+func TestPrepayRequest_ValidateTimeExpire(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeExpire time.Time
+		wantReason string
+	}{
+		{"1 小时后", time.Now().Add(time.Hour), ""},
+		{"30 秒后", time.Now().Add(30 * time.Second), "must be at least 1m0s after"},
+		{"二维码有效期之后", time.Now().Add(3 * time.Hour), "must be at most 2h0m0s after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := native.PrepayRequest{
+				Appid:       core.String("wxd678efh567hg6787"),
+				Mchid:       core.String("1230000109"),
+				Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+				OutTradeNo:  core.String("1217752501201407033233368018"),
+				TimeExpire:  core.Time(tt.timeExpire),
+				NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+				Amount:      &native.Amount{Total: core.Int64(100)},
+			}
+			err := req.Validate()
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			validationErr, ok := validation.AsError(err)
+			require.True(t, ok)
+			require.Len(t, validationErr.Fields, 1)
+			assert.Equal(t, "time_expire", validationErr.Fields[0].Field)
+			assert.Contains(t, validationErr.Fields[0].Reason, tt.wantReason)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package nativetest 提供 native 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package nativetest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+	"github.com/jemuri/wechatpay-go/utils/qrcode"
+)
+
+// FakeNativeAPI native.NativeAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeNativeAPI struct {
+	servicetest.Recorder
+
+	CloseOrderFunc             func(context.Context, native.CloseOrderRequest) (*core.APIResult, error)
+	PrepayFunc                 func(context.Context, native.PrepayRequest) (*native.PrepayResponse, *core.APIResult, error)
+	PrepayWithQRCodeFunc       func(context.Context, native.PrepayRequest, qrcode.Format, qrcode.Options) (*native.PrepayWithQRCodeResponse, *core.APIResult, error)
+	QueryOrderByIdFunc         func(context.Context, native.QueryOrderByIdRequest) (*payments.Transaction, *core.APIResult, error)
+	QueryOrderByOutTradeNoFunc func(context.Context, native.QueryOrderByOutTradeNoRequest) (*payments.Transaction, *core.APIResult, error)
+}
+
+var _ native.NativeAPI = (*FakeNativeAPI)(nil)
+
+// CloseOrder 记录调用并返回 CloseOrderFunc 的结果
+func (f *FakeNativeAPI) CloseOrder(ctx context.Context, req native.CloseOrderRequest) (result *core.APIResult, err error) {
+	f.Record("CloseOrder", req)
+	if f.CloseOrderFunc == nil {
+		err = servicetest.NotStubbed("NativeAPI", "CloseOrder")
+		return
+	}
+	return f.CloseOrderFunc(ctx, req)
+}
+
+// CloseOrderReturns 设置 CloseOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeNativeAPI) CloseOrderReturns(err error) *FakeNativeAPI {
+	f.CloseOrderFunc = func(context.Context, native.CloseOrderRequest) (*core.APIResult, error) {
+		return servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// Prepay 记录调用并返回 PrepayFunc 的结果
+func (f *FakeNativeAPI) Prepay(ctx context.Context, req native.PrepayRequest) (resp *native.PrepayResponse, result *core.APIResult, err error) {
+	f.Record("Prepay", req)
+	if f.PrepayFunc == nil {
+		err = servicetest.NotStubbed("NativeAPI", "Prepay")
+		return
+	}
+	return f.PrepayFunc(ctx, req)
+}
+
+// PrepayReturns 设置 Prepay 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeNativeAPI) PrepayReturns(resp *native.PrepayResponse, err error) *FakeNativeAPI {
+	f.PrepayFunc = func(context.Context, native.PrepayRequest) (*native.PrepayResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PrepayWithQRCode 记录调用并返回 PrepayWithQRCodeFunc 的结果
+func (f *FakeNativeAPI) PrepayWithQRCode(ctx context.Context, req native.PrepayRequest, format qrcode.Format, opts qrcode.Options) (resp *native.PrepayWithQRCodeResponse, result *core.APIResult, err error) {
+	f.Record("PrepayWithQRCode", []interface{}{req, format, opts})
+	if f.PrepayWithQRCodeFunc == nil {
+		err = servicetest.NotStubbed("NativeAPI", "PrepayWithQRCode")
+		return
+	}
+	return f.PrepayWithQRCodeFunc(ctx, req, format, opts)
+}
+
+// PrepayWithQRCodeReturns 设置 PrepayWithQRCode 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeNativeAPI) PrepayWithQRCodeReturns(resp *native.PrepayWithQRCodeResponse, err error) *FakeNativeAPI {
+	f.PrepayWithQRCodeFunc = func(context.Context, native.PrepayRequest, qrcode.Format, qrcode.Options) (*native.PrepayWithQRCodeResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderById 记录调用并返回 QueryOrderByIdFunc 的结果
+func (f *FakeNativeAPI) QueryOrderById(ctx context.Context, req native.QueryOrderByIdRequest) (resp *payments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderById", req)
+	if f.QueryOrderByIdFunc == nil {
+		err = servicetest.NotStubbed("NativeAPI", "QueryOrderById")
+		return
+	}
+	return f.QueryOrderByIdFunc(ctx, req)
+}
+
+// QueryOrderByIdReturns 设置 QueryOrderById 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeNativeAPI) QueryOrderByIdReturns(resp *payments.Transaction, err error) *FakeNativeAPI {
+	f.QueryOrderByIdFunc = func(context.Context, native.QueryOrderByIdRequest) (*payments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrderByOutTradeNo 记录调用并返回 QueryOrderByOutTradeNoFunc 的结果
+func (f *FakeNativeAPI) QueryOrderByOutTradeNo(ctx context.Context, req native.QueryOrderByOutTradeNoRequest) (resp *payments.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryOrderByOutTradeNo", req)
+	if f.QueryOrderByOutTradeNoFunc == nil {
+		err = servicetest.NotStubbed("NativeAPI", "QueryOrderByOutTradeNo")
+		return
+	}
+	return f.QueryOrderByOutTradeNoFunc(ctx, req)
+}
+
+// QueryOrderByOutTradeNoReturns 设置 QueryOrderByOutTradeNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeNativeAPI) QueryOrderByOutTradeNoReturns(resp *payments.Transaction, err error) *FakeNativeAPI {
+	f.QueryOrderByOutTradeNoFunc = func(context.Context, native.QueryOrderByOutTradeNoRequest) (*payments.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
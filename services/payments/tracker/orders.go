@@ -0,0 +1,126 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package tracker
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/payments/app"
+	"github.com/jemuri/wechatpay-go/services/payments/h5"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+)
+
+// JsapiOrders 使用 JSAPI 支付接口查询与关闭直连商户 mchid 的订单
+func JsapiOrders(api jsapi.JsapiAPI, mchid string) Orders {
+	return &jsapiOrders{api: api, mchid: mchid}
+}
+
+type jsapiOrders struct {
+	api   jsapi.JsapiAPI
+	mchid string
+}
+
+// QueryOrder 使用商户订单号查询订单
+func (o *jsapiOrders) QueryOrder(ctx context.Context, outTradeNo string) (*payments.Transaction, error) {
+	tx, _, err := o.api.QueryOrderByOutTradeNo(ctx, jsapi.QueryOrderByOutTradeNoRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return tx, err
+}
+
+// CloseOrder 使用商户订单号关闭订单
+func (o *jsapiOrders) CloseOrder(ctx context.Context, outTradeNo string) error {
+	_, err := o.api.CloseOrder(ctx, jsapi.CloseOrderRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return err
+}
+
+// AppOrders 使用 APP 支付接口查询与关闭直连商户 mchid 的订单
+func AppOrders(api app.AppAPI, mchid string) Orders {
+	return &appOrders{api: api, mchid: mchid}
+}
+
+type appOrders struct {
+	api   app.AppAPI
+	mchid string
+}
+
+// QueryOrder 使用商户订单号查询订单
+func (o *appOrders) QueryOrder(ctx context.Context, outTradeNo string) (*payments.Transaction, error) {
+	tx, _, err := o.api.QueryOrderByOutTradeNo(ctx, app.QueryOrderByOutTradeNoRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return tx, err
+}
+
+// CloseOrder 使用商户订单号关闭订单
+func (o *appOrders) CloseOrder(ctx context.Context, outTradeNo string) error {
+	_, err := o.api.CloseOrder(ctx, app.CloseOrderRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return err
+}
+
+// H5Orders 使用 H5 支付接口查询与关闭直连商户 mchid 的订单
+func H5Orders(api h5.H5API, mchid string) Orders {
+	return &h5Orders{api: api, mchid: mchid}
+}
+
+type h5Orders struct {
+	api   h5.H5API
+	mchid string
+}
+
+// QueryOrder 使用商户订单号查询订单
+func (o *h5Orders) QueryOrder(ctx context.Context, outTradeNo string) (*payments.Transaction, error) {
+	tx, _, err := o.api.QueryOrderByOutTradeNo(ctx, h5.QueryOrderByOutTradeNoRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return tx, err
+}
+
+// CloseOrder 使用商户订单号关闭订单
+func (o *h5Orders) CloseOrder(ctx context.Context, outTradeNo string) error {
+	_, err := o.api.CloseOrder(ctx, h5.CloseOrderRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return err
+}
+
+// NativeOrders 使用 Native 支付接口查询与关闭直连商户 mchid 的订单
+func NativeOrders(api native.NativeAPI, mchid string) Orders {
+	return &nativeOrders{api: api, mchid: mchid}
+}
+
+type nativeOrders struct {
+	api   native.NativeAPI
+	mchid string
+}
+
+// QueryOrder 使用商户订单号查询订单
+func (o *nativeOrders) QueryOrder(ctx context.Context, outTradeNo string) (*payments.Transaction, error) {
+	tx, _, err := o.api.QueryOrderByOutTradeNo(ctx, native.QueryOrderByOutTradeNoRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return tx, err
+}
+
+// CloseOrder 使用商户订单号关闭订单
+func (o *nativeOrders) CloseOrder(ctx context.Context, outTradeNo string) error {
+	_, err := o.api.CloseOrder(ctx, native.CloseOrderRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(o.mchid),
+	})
+	return err
+}
@@ -0,0 +1,270 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package tracker 跟踪订单的支付状态
+//
+// 支付结果通知可能延迟或丢失，下单后需要主动查询订单直到订单进入终态，并在订单过期后关闭订单。
+// Tracker 以退避的间隔轮询订单，订单到达 time_expire 时自动关单；收到支付结果通知后调用 Tracker.Notify，
+// 正在跟踪该订单的 Track 会立即返回，不再继续轮询。
+//
+//	t := tracker.NewTracker(tracker.JsapiOrders(&jsapi.JsapiApiService{Client: client}, mchID), tracker.Config{})
+//	tx, err := t.Track(ctx, outTradeNo, timeExpire)
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/services/payments"
+)
+
+// 订单的交易状态
+const (
+	TradeStateSuccess    = "SUCCESS"    // 支付成功
+	TradeStateRefund     = "REFUND"     // 转入退款
+	TradeStateNotPay     = "NOTPAY"     // 未支付
+	TradeStateClosed     = "CLOSED"     // 已关闭
+	TradeStateRevoked    = "REVOKED"    // 已撤销（付款码支付）
+	TradeStateUserPaying = "USERPAYING" // 用户支付中（付款码支付）
+	TradeStatePayError   = "PAYERROR"   // 支付失败（其他原因，如银行返回失败）
+)
+
+// IsTerminal 判断订单是否已处于终态，终态的订单不会再发生支付
+func IsTerminal(tx *payments.Transaction) bool {
+	if tx == nil || tx.TradeState == nil {
+		return false
+	}
+	switch *tx.TradeState {
+	case TradeStateSuccess, TradeStateRefund, TradeStateClosed, TradeStateRevoked, TradeStatePayError:
+		return true
+	}
+	return false
+}
+
+// Orders 查询与关闭订单，各支付方式的实现见 JsapiOrders、AppOrders、H5Orders 与 NativeOrders
+type Orders interface {
+	// QueryOrder 使用商户订单号查询订单
+	QueryOrder(ctx context.Context, outTradeNo string) (*payments.Transaction, error)
+	// CloseOrder 使用商户订单号关闭订单
+	CloseOrder(ctx context.Context, outTradeNo string) error
+}
+
+// Config Tracker 配置
+type Config struct {
+	InitialInterval      time.Duration // 首次轮询的间隔，默认 2s
+	MaxInterval          time.Duration // 轮询间隔的上限，默认 30s
+	Multiplier           float64       // 每次轮询后间隔的增长倍数，默认 1.5
+	Jitter               float64       // 轮询间隔的随机抖动比例，取值 [0, 1)，默认 0.2，用于打散大量订单的查询
+	MaxConcurrentQueries int           // 同时进行的查询与关单请求数上限，<= 0 时不限制
+}
+
+// 默认配置
+const (
+	DefaultInitialInterval = 2 * time.Second
+	DefaultMaxInterval     = 30 * time.Second
+	DefaultMultiplier      = 1.5
+	DefaultJitter          = 0.2
+)
+
+func (c Config) withDefaults() Config {
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = DefaultInitialInterval
+	}
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = DefaultMaxInterval
+	}
+	if c.MaxInterval < c.InitialInterval {
+		c.MaxInterval = c.InitialInterval
+	}
+	if c.Multiplier < 1 {
+		c.Multiplier = DefaultMultiplier
+	}
+	if c.Jitter < 0 || c.Jitter >= 1 {
+		c.Jitter = DefaultJitter
+	}
+	return c
+}
+
+// Tracker 订单支付状态跟踪器，可以安全地并发跟踪大量订单
+type Tracker struct {
+	orders Orders
+	config Config
+	clock  clock.Clock
+	sem    chan struct{}
+
+	lock    sync.Mutex
+	waiters map[string]map[chan *payments.Transaction]struct{} // 商户订单号 -> 正在等待通知的 Track
+}
+
+// NewTracker 使用 orders 创建一个 Tracker
+func NewTracker(orders Orders, config Config) *Tracker {
+	t := &Tracker{
+		orders:  orders,
+		config:  config.withDefaults(),
+		waiters: make(map[string]map[chan *payments.Transaction]struct{}),
+	}
+	if t.config.MaxConcurrentQueries > 0 {
+		t.sem = make(chan struct{}, t.config.MaxConcurrentQueries)
+	}
+	return t
+}
+
+// SetClock 设置判断订单是否过期所使用的时钟，默认使用系统时钟
+//
+// 建议使用 core.Client.Clock()，使得订单过期时间以补偿了时钟偏差的时间为准
+func (t *Tracker) SetClock(c clock.Clock) *Tracker {
+	t.clock = c
+	return t
+}
+
+// Track 跟踪订单直到订单进入终态，返回订单的最终状态
+//
+// 订单未进入终态时，以退避的间隔轮询订单；到达 timeExpire 后关闭订单，并返回关单后的订单状态。
+// 期间收到该订单的终态通知（见 Notify）时立即返回通知中的订单。
+// ctx 结束时返回最后一次查询到的订单与 ctx.Err()
+func (t *Tracker) Track(ctx context.Context, outTradeNo string, timeExpire time.Time) (*payments.Transaction, error) {
+	notified := t.register(outTradeNo)
+	defer t.unregister(outTradeNo, notified)
+
+	var last *payments.Transaction
+	interval := t.config.InitialInterval
+	for {
+		tx, err := t.query(ctx, outTradeNo)
+		if err == nil {
+			if IsTerminal(tx) {
+				return tx, nil
+			}
+			last = tx
+		} else if ctx.Err() != nil {
+			return last, ctx.Err()
+		}
+
+		now := clock.OrSystem(t.clock).Now()
+		if !now.Before(timeExpire) {
+			return t.close(ctx, outTradeNo, last)
+		}
+
+		wait := t.jitter(interval)
+		if remaining := timeExpire.Sub(now); remaining < wait {
+			wait = remaining
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case tx := <-notified:
+			timer.Stop()
+			return tx, nil
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * t.config.Multiplier)
+		if interval > t.config.MaxInterval {
+			interval = t.config.MaxInterval
+		}
+	}
+}
+
+// close 关闭过期订单，并查询关单后的订单状态
+//
+// 用户可能恰好在关单前完成支付，此时关单失败，查询得到的订单状态为 SUCCESS
+func (t *Tracker) close(
+	ctx context.Context, outTradeNo string, last *payments.Transaction,
+) (*payments.Transaction, error) {
+	closeErr := t.do(ctx, func() error { return t.orders.CloseOrder(ctx, outTradeNo) })
+
+	tx, err := t.query(ctx, outTradeNo)
+	if err == nil && IsTerminal(tx) {
+		return tx, nil
+	}
+	if closeErr != nil {
+		return last, fmt.Errorf("close expired order %s failed: %w", outTradeNo, closeErr)
+	}
+	if err != nil {
+		return last, fmt.Errorf("query closed order %s failed: %w", outTradeNo, err)
+	}
+	return tx, nil
+}
+
+func (t *Tracker) query(ctx context.Context, outTradeNo string) (tx *payments.Transaction, err error) {
+	err = t.do(ctx, func() error {
+		tx, err = t.orders.QueryOrder(ctx, outTradeNo)
+		return err
+	})
+	return tx, err
+}
+
+// do 在查询并发数限制内执行 fn
+func (t *Tracker) do(ctx context.Context, fn func() error) error {
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+			defer func() { <-t.sem }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fn()
+}
+
+func (t *Tracker) jitter(interval time.Duration) time.Duration {
+	if t.config.Jitter == 0 {
+		return interval
+	}
+	delta := (rand.Float64()*2 - 1) * t.config.Jitter * float64(interval)
+	return interval + time.Duration(delta)
+}
+
+// Notify 将支付结果通知中的订单交给正在跟踪该订单的 Track，返回是否有 Track 在跟踪该订单
+//
+// 请在通知验签并解密后调用，非终态的订单会被忽略
+func (t *Tracker) Notify(tx *payments.Transaction) bool {
+	if !IsTerminal(tx) || tx.OutTradeNo == nil {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	waiters := t.waiters[*tx.OutTradeNo]
+	for notified := range waiters {
+		select {
+		case notified <- tx:
+		default:
+			// 已经收到过通知
+		}
+	}
+	return len(waiters) > 0
+}
+
+// Tracking 返回正在跟踪的订单数
+func (t *Tracker) Tracking() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.waiters)
+}
+
+func (t *Tracker) register(outTradeNo string) chan *payments.Transaction {
+	notified := make(chan *payments.Transaction, 1)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.waiters[outTradeNo] == nil {
+		t.waiters[outTradeNo] = make(map[chan *payments.Transaction]struct{})
+	}
+	t.waiters[outTradeNo][notified] = struct{}{}
+	return notified
+}
+
+func (t *Tracker) unregister(outTradeNo string, notified chan *payments.Transaction) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.waiters[outTradeNo], notified)
+	if len(t.waiters[outTradeNo]) == 0 {
+		delete(t.waiters, outTradeNo)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package tracker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi/jsapitest"
+	"github.com/jemuri/wechatpay-go/services/payments/tracker"
+)
+
+const testMchID = "1230000109"
+
+var fastConfig = tracker.Config{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func transaction(outTradeNo, state string) *payments.Transaction {
+	return &payments.Transaction{OutTradeNo: core.String(outTradeNo), TradeState: core.String(state)}
+}
+
+// fakeOrders 按订单依次返回 states 中的交易状态，最后一个状态保持不变
+func fakeOrders(states ...string) *jsapitest.FakeJsapiAPI {
+	var lock sync.Mutex
+	queried := make(map[string]int)
+	closed := make(map[string]bool)
+
+	fake := &jsapitest.FakeJsapiAPI{}
+	fake.QueryOrderByOutTradeNoFunc = func(
+		ctx context.Context, req jsapi.QueryOrderByOutTradeNoRequest,
+	) (*payments.Transaction, *core.APIResult, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if closed[*req.OutTradeNo] {
+			return transaction(*req.OutTradeNo, tracker.TradeStateClosed), nil, nil
+		}
+		i := queried[*req.OutTradeNo]
+		queried[*req.OutTradeNo]++
+		if i >= len(states) {
+			i = len(states) - 1
+		}
+		return transaction(*req.OutTradeNo, states[i]), nil, nil
+	}
+	fake.CloseOrderFunc = func(ctx context.Context, req jsapi.CloseOrderRequest) (*core.APIResult, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		closed[*req.OutTradeNo] = true
+		return nil, nil
+	}
+	return fake
+}
+
+func TestTracker_Track(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay, tracker.TradeStateUserPaying, tracker.TradeStateSuccess)
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), fastConfig)
+
+	tx, err := tr.Track(context.Background(), "1217752501201407033233368018", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, tracker.TradeStateSuccess, *tx.TradeState)
+	fake.AssertCallCount(t, "QueryOrderByOutTradeNo", 3)
+	fake.AssertCalledWith(t, "QueryOrderByOutTradeNo", jsapi.QueryOrderByOutTradeNoRequest{
+		OutTradeNo: core.String("1217752501201407033233368018"), Mchid: core.String(testMchID),
+	})
+	fake.AssertNotCalled(t, "CloseOrder")
+	assert.Equal(t, 0, tr.Tracking())
+}
+
+func TestTracker_TrackCloseExpiredOrder(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay)
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), fastConfig)
+
+	tx, err := tr.Track(context.Background(), "1217752501201407033233368018", time.Now().Add(20*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, tracker.TradeStateClosed, *tx.TradeState)
+	fake.AssertCallCount(t, "CloseOrder", 1)
+}
+
+func TestTracker_TrackPaidBeforeClose(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay, tracker.TradeStateSuccess)
+	fake.CloseOrderReturns(&core.APIError{StatusCode: http.StatusBadRequest, Code: "ORDERPAID"})
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), fastConfig)
+
+	// 订单已过期，关单失败后查询得到支付成功
+	tx, err := tr.Track(context.Background(), "1217752501201407033233368018", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, tracker.TradeStateSuccess, *tx.TradeState)
+	fake.AssertCallCount(t, "CloseOrder", 1)
+}
+
+func TestTracker_TrackCloseFailed(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay)
+	fake.CloseOrderReturns(&core.APIError{StatusCode: http.StatusInternalServerError, Code: "SYSTEM_ERROR"})
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), fastConfig)
+
+	tx, err := tr.Track(context.Background(), "1217752501201407033233368018", time.Now())
+	require.Error(t, err)
+	var apiErr *core.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "SYSTEM_ERROR", apiErr.Code)
+	assert.Equal(t, tracker.TradeStateNotPay, *tx.TradeState)
+}
+
+func TestTracker_Notify(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay)
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), tracker.Config{InitialInterval: time.Hour})
+
+	// 非终态或未在跟踪的订单不会被通知
+	assert.False(t, tr.Notify(transaction("1217752501201407033233368018", tracker.TradeStateSuccess)))
+
+	done := make(chan *payments.Transaction)
+	go func() {
+		tx, err := tr.Track(context.Background(), "1217752501201407033233368018", time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		done <- tx
+	}()
+	require.Eventually(t, func() bool { return tr.Tracking() == 1 }, time.Second, time.Millisecond)
+	assert.False(t, tr.Notify(transaction("1217752501201407033233368018", tracker.TradeStateNotPay)))
+
+	notification := transaction("1217752501201407033233368018", tracker.TradeStateSuccess)
+	assert.True(t, tr.Notify(notification))
+	select {
+	case tx := <-done:
+		assert.Same(t, notification, tx)
+	case <-time.After(time.Second):
+		t.Fatal("Track did not return after notification")
+	}
+	fake.AssertCallCount(t, "QueryOrderByOutTradeNo", 1)
+}
+
+func TestTracker_TrackContextCanceled(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay)
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), tracker.Config{InitialInterval: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	tx, err := tr.Track(ctx, "1217752501201407033233368018", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, tracker.TradeStateNotPay, *tx.TradeState)
+	assert.Equal(t, 0, tr.Tracking())
+}
+
+func TestTracker_Concurrent(t *testing.T) {
+	fake := fakeOrders(tracker.TradeStateNotPay, tracker.TradeStateNotPay, tracker.TradeStateSuccess)
+	config := fastConfig
+	config.MaxConcurrentQueries = 16
+	tr := tracker.NewTracker(tracker.JsapiOrders(fake, testMchID), config)
+
+	const orders = 1000
+	var succeeded int64
+	var wg sync.WaitGroup
+	for i := 0; i < orders; i++ {
+		wg.Add(1)
+		go func(outTradeNo string) {
+			defer wg.Done()
+			tx, err := tr.Track(context.Background(), outTradeNo, time.Now().Add(time.Minute))
+			if assert.NoError(t, err) && *tx.TradeState == tracker.TradeStateSuccess {
+				atomic.AddInt64(&succeeded, 1)
+			}
+		}(fmt.Sprintf("order-%d", i))
+		if i%2 == 0 {
+			// 部分订单通过通知提前结束
+			tr.Notify(transaction(fmt.Sprintf("order-%d", i), tracker.TradeStateSuccess))
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, int64(orders), succeeded)
+	assert.Equal(t, 0, tr.Tracking())
+}
@@ -35,6 +35,11 @@ func (a *AuthenticationsApiService) GetAuthentication(ctx context.Context, req G
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.AuthenticateNumber == nil {
 		return nil, nil, fmt.Errorf("field `AuthenticateNumber` is required and must be specified in GetAuthenticationRequest")
@@ -84,6 +89,11 @@ func (a *AuthenticationsApiService) ListAuthentications(ctx context.Context, req
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/payroll-card/authentications"
 	// Make sure All Required Params are properly set
 	if req.Openid == nil {
@@ -148,6 +158,11 @@ func (a *AuthenticationsApiService) PreOrderAuthentication(ctx context.Context,
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/payroll-card/authentications/pre-order"
 	// Make sure All Required Params are properly set
 
@@ -185,6 +200,11 @@ func (a *AuthenticationsApiService) PreOrderAuthenticationWithAuth(ctx context.C
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// 对请求中敏感字段进行加密
 	encReq := req.Clone()
 	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
@@ -35,6 +35,11 @@ func (a *RelationsApiService) GetRelation(ctx context.Context, req GetRelationRe
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.Openid == nil {
 		return nil, nil, fmt.Errorf("field `Openid` is required and must be specified in GetRelationRequest")
@@ -34,6 +34,11 @@ func (a *TokensApiService) CreateToken(ctx context.Context, req CreateTokenReque
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// 对请求中敏感字段进行加密
 	encReq := req.Clone()
 	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
@@ -0,0 +1,59 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package payrollcard
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// AuthenticationsAPI AuthenticationsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *AuthenticationsApiService，即可在测试中使用 payrollcardtest.FakeAuthenticationsAPI 替代
+type AuthenticationsAPI interface {
+	// GetAuthentication 获取核身结果
+	GetAuthentication(ctx context.Context, req GetAuthenticationRequest) (resp *AuthenticationEntity, result *core.APIResult, err error)
+
+	// ListAuthentications 查询核身记录
+	ListAuthentications(ctx context.Context, req ListAuthenticationsRequest) (resp *ListAuthenticationsResponse, result *core.APIResult, err error)
+
+	// PreOrderAuthentication 微工卡核身预下单
+	PreOrderAuthentication(ctx context.Context, req PreOrderAuthenticationRequest) (resp *PreOrderAuthenticationResponse, result *core.APIResult, err error)
+
+	// PreOrderAuthenticationWithAuth 微工卡核身预下单（流程中完成授权）
+	PreOrderAuthenticationWithAuth(ctx context.Context, req PreOrderAuthenticationWithAuthRequest) (resp *PreOrderAuthenticationWithAuthResponse, result *core.APIResult, err error)
+}
+
+// RelationsAPI RelationsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *RelationsApiService，即可在测试中使用 payrollcardtest.FakeRelationsAPI 替代
+type RelationsAPI interface {
+	// GetRelation 查询微工卡授权关系
+	GetRelation(ctx context.Context, req GetRelationRequest) (resp *RelationEntity, result *core.APIResult, err error)
+}
+
+// TokensAPI TokensApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TokensApiService，即可在测试中使用 payrollcardtest.FakeTokensAPI 替代
+type TokensAPI interface {
+	// CreateToken 生成授权token
+	CreateToken(ctx context.Context, req CreateTokenRequest) (resp *TokenEntity, result *core.APIResult, err error)
+}
+
+// TransferBatchAPI TransferBatchApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransferBatchApiService，即可在测试中使用 payrollcardtest.FakeTransferBatchAPI 替代
+type TransferBatchAPI interface {
+	// CreateTransferBatch 发起批量转账
+	CreateTransferBatch(ctx context.Context, req CreateTransferBatchRequest) (resp *TransferBatchEntity, result *core.APIResult, err error)
+}
+
+var (
+	_ AuthenticationsAPI = (*AuthenticationsApiService)(nil)
+	_ RelationsAPI       = (*RelationsApiService)(nil)
+	_ TokensAPI          = (*TokensApiService)(nil)
+	_ TransferBatchAPI   = (*TransferBatchApiService)(nil)
+)
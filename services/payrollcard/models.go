@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // AuthType
@@ -2095,7 +2097,7 @@ func (o TransferBatchEntity) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in TransferBatchEntity")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -0,0 +1,191 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package payrollcardtest 提供 payrollcard 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package payrollcardtest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payrollcard"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeAuthenticationsAPI payrollcard.AuthenticationsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeAuthenticationsAPI struct {
+	servicetest.Recorder
+
+	GetAuthenticationFunc              func(context.Context, payrollcard.GetAuthenticationRequest) (*payrollcard.AuthenticationEntity, *core.APIResult, error)
+	ListAuthenticationsFunc            func(context.Context, payrollcard.ListAuthenticationsRequest) (*payrollcard.ListAuthenticationsResponse, *core.APIResult, error)
+	PreOrderAuthenticationFunc         func(context.Context, payrollcard.PreOrderAuthenticationRequest) (*payrollcard.PreOrderAuthenticationResponse, *core.APIResult, error)
+	PreOrderAuthenticationWithAuthFunc func(context.Context, payrollcard.PreOrderAuthenticationWithAuthRequest) (*payrollcard.PreOrderAuthenticationWithAuthResponse, *core.APIResult, error)
+}
+
+var _ payrollcard.AuthenticationsAPI = (*FakeAuthenticationsAPI)(nil)
+
+// GetAuthentication 记录调用并返回 GetAuthenticationFunc 的结果
+func (f *FakeAuthenticationsAPI) GetAuthentication(ctx context.Context, req payrollcard.GetAuthenticationRequest) (resp *payrollcard.AuthenticationEntity, result *core.APIResult, err error) {
+	f.Record("GetAuthentication", req)
+	if f.GetAuthenticationFunc == nil {
+		err = servicetest.NotStubbed("AuthenticationsAPI", "GetAuthentication")
+		return
+	}
+	return f.GetAuthenticationFunc(ctx, req)
+}
+
+// GetAuthenticationReturns 设置 GetAuthentication 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAuthenticationsAPI) GetAuthenticationReturns(resp *payrollcard.AuthenticationEntity, err error) *FakeAuthenticationsAPI {
+	f.GetAuthenticationFunc = func(context.Context, payrollcard.GetAuthenticationRequest) (*payrollcard.AuthenticationEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListAuthentications 记录调用并返回 ListAuthenticationsFunc 的结果
+func (f *FakeAuthenticationsAPI) ListAuthentications(ctx context.Context, req payrollcard.ListAuthenticationsRequest) (resp *payrollcard.ListAuthenticationsResponse, result *core.APIResult, err error) {
+	f.Record("ListAuthentications", req)
+	if f.ListAuthenticationsFunc == nil {
+		err = servicetest.NotStubbed("AuthenticationsAPI", "ListAuthentications")
+		return
+	}
+	return f.ListAuthenticationsFunc(ctx, req)
+}
+
+// ListAuthenticationsReturns 设置 ListAuthentications 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAuthenticationsAPI) ListAuthenticationsReturns(resp *payrollcard.ListAuthenticationsResponse, err error) *FakeAuthenticationsAPI {
+	f.ListAuthenticationsFunc = func(context.Context, payrollcard.ListAuthenticationsRequest) (*payrollcard.ListAuthenticationsResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PreOrderAuthentication 记录调用并返回 PreOrderAuthenticationFunc 的结果
+func (f *FakeAuthenticationsAPI) PreOrderAuthentication(ctx context.Context, req payrollcard.PreOrderAuthenticationRequest) (resp *payrollcard.PreOrderAuthenticationResponse, result *core.APIResult, err error) {
+	f.Record("PreOrderAuthentication", req)
+	if f.PreOrderAuthenticationFunc == nil {
+		err = servicetest.NotStubbed("AuthenticationsAPI", "PreOrderAuthentication")
+		return
+	}
+	return f.PreOrderAuthenticationFunc(ctx, req)
+}
+
+// PreOrderAuthenticationReturns 设置 PreOrderAuthentication 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAuthenticationsAPI) PreOrderAuthenticationReturns(resp *payrollcard.PreOrderAuthenticationResponse, err error) *FakeAuthenticationsAPI {
+	f.PreOrderAuthenticationFunc = func(context.Context, payrollcard.PreOrderAuthenticationRequest) (*payrollcard.PreOrderAuthenticationResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// PreOrderAuthenticationWithAuth 记录调用并返回 PreOrderAuthenticationWithAuthFunc 的结果
+func (f *FakeAuthenticationsAPI) PreOrderAuthenticationWithAuth(ctx context.Context, req payrollcard.PreOrderAuthenticationWithAuthRequest) (resp *payrollcard.PreOrderAuthenticationWithAuthResponse, result *core.APIResult, err error) {
+	f.Record("PreOrderAuthenticationWithAuth", req)
+	if f.PreOrderAuthenticationWithAuthFunc == nil {
+		err = servicetest.NotStubbed("AuthenticationsAPI", "PreOrderAuthenticationWithAuth")
+		return
+	}
+	return f.PreOrderAuthenticationWithAuthFunc(ctx, req)
+}
+
+// PreOrderAuthenticationWithAuthReturns 设置 PreOrderAuthenticationWithAuth 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeAuthenticationsAPI) PreOrderAuthenticationWithAuthReturns(resp *payrollcard.PreOrderAuthenticationWithAuthResponse, err error) *FakeAuthenticationsAPI {
+	f.PreOrderAuthenticationWithAuthFunc = func(context.Context, payrollcard.PreOrderAuthenticationWithAuthRequest) (*payrollcard.PreOrderAuthenticationWithAuthResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeRelationsAPI payrollcard.RelationsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeRelationsAPI struct {
+	servicetest.Recorder
+
+	GetRelationFunc func(context.Context, payrollcard.GetRelationRequest) (*payrollcard.RelationEntity, *core.APIResult, error)
+}
+
+var _ payrollcard.RelationsAPI = (*FakeRelationsAPI)(nil)
+
+// GetRelation 记录调用并返回 GetRelationFunc 的结果
+func (f *FakeRelationsAPI) GetRelation(ctx context.Context, req payrollcard.GetRelationRequest) (resp *payrollcard.RelationEntity, result *core.APIResult, err error) {
+	f.Record("GetRelation", req)
+	if f.GetRelationFunc == nil {
+		err = servicetest.NotStubbed("RelationsAPI", "GetRelation")
+		return
+	}
+	return f.GetRelationFunc(ctx, req)
+}
+
+// GetRelationReturns 设置 GetRelation 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRelationsAPI) GetRelationReturns(resp *payrollcard.RelationEntity, err error) *FakeRelationsAPI {
+	f.GetRelationFunc = func(context.Context, payrollcard.GetRelationRequest) (*payrollcard.RelationEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeTokensAPI payrollcard.TokensAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTokensAPI struct {
+	servicetest.Recorder
+
+	CreateTokenFunc func(context.Context, payrollcard.CreateTokenRequest) (*payrollcard.TokenEntity, *core.APIResult, error)
+}
+
+var _ payrollcard.TokensAPI = (*FakeTokensAPI)(nil)
+
+// CreateToken 记录调用并返回 CreateTokenFunc 的结果
+func (f *FakeTokensAPI) CreateToken(ctx context.Context, req payrollcard.CreateTokenRequest) (resp *payrollcard.TokenEntity, result *core.APIResult, err error) {
+	f.Record("CreateToken", req)
+	if f.CreateTokenFunc == nil {
+		err = servicetest.NotStubbed("TokensAPI", "CreateToken")
+		return
+	}
+	return f.CreateTokenFunc(ctx, req)
+}
+
+// CreateTokenReturns 设置 CreateToken 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTokensAPI) CreateTokenReturns(resp *payrollcard.TokenEntity, err error) *FakeTokensAPI {
+	f.CreateTokenFunc = func(context.Context, payrollcard.CreateTokenRequest) (*payrollcard.TokenEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeTransferBatchAPI payrollcard.TransferBatchAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransferBatchAPI struct {
+	servicetest.Recorder
+
+	CreateTransferBatchFunc func(context.Context, payrollcard.CreateTransferBatchRequest) (*payrollcard.TransferBatchEntity, *core.APIResult, error)
+}
+
+var _ payrollcard.TransferBatchAPI = (*FakeTransferBatchAPI)(nil)
+
+// CreateTransferBatch 记录调用并返回 CreateTransferBatchFunc 的结果
+func (f *FakeTransferBatchAPI) CreateTransferBatch(ctx context.Context, req payrollcard.CreateTransferBatchRequest) (resp *payrollcard.TransferBatchEntity, result *core.APIResult, err error) {
+	f.Record("CreateTransferBatch", req)
+	if f.CreateTransferBatchFunc == nil {
+		err = servicetest.NotStubbed("TransferBatchAPI", "CreateTransferBatch")
+		return
+	}
+	return f.CreateTransferBatchFunc(ctx, req)
+}
+
+// CreateTransferBatchReturns 设置 CreateTransferBatch 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransferBatchAPI) CreateTransferBatchReturns(resp *payrollcard.TransferBatchEntity, err error) *FakeTransferBatchAPI {
+	f.CreateTransferBatchFunc = func(context.Context, payrollcard.CreateTransferBatchRequest) (*payrollcard.TransferBatchEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
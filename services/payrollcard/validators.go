@@ -0,0 +1,140 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package payrollcard
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 CreateTokenRequest 的字段
+func (o CreateTokenRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateTokenRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("user_name", o.UserName != nil)
+	c.Required("id_card_number", o.IdCardNumber != nil)
+	c.Enum("employment_type", (*string)(o.EmploymentType), "LONG_TERM_EMPLOYMENT", "SHORT_TERM_EMPLOYMENT", "COOPERATION_EMPLOYMENT")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateTransferBatchRequest 的字段
+func (o CreateTransferBatchRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateTransferBatchRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("authorization_type", o.AuthorizationType != nil)
+	c.Enum("authorization_type", (*string)(o.AuthorizationType), "INFORMATION_AUTHORIZATION_TYPE", "FUND_AUTHORIZATION_TYPE", "INFORMATION_AND_FUND_AUTHORIZATION_TYPE")
+	c.Required("out_batch_no", o.OutBatchNo != nil)
+	c.ID("out_batch_no", o.OutBatchNo, idgen.OutBatchNo)
+	c.Required("batch_name", o.BatchName != nil)
+	c.Length("batch_name", o.BatchName, 1, 32)
+	c.Required("batch_remark", o.BatchRemark != nil)
+	c.Length("batch_remark", o.BatchRemark, 1, 32)
+	c.Required("total_amount", o.TotalAmount != nil)
+	c.Range("total_amount", o.TotalAmount, 1, 0)
+	c.Required("total_num", o.TotalNum != nil)
+	c.Range("total_num", o.TotalNum, 1, 3000)
+	c.Required("transfer_detail_list", o.TransferDetailList != nil)
+	for i, item := range o.TransferDetailList {
+		c.Nested(validation.Index("transfer_detail_list", i), item.Validate(opts...))
+	}
+	c.Length("sp_appid", o.SpAppid, 0, 32)
+	c.Enum("employment_type", (*string)(o.EmploymentType), "LONG_TERM_EMPLOYMENT", "SHORT_TERM_EMPLOYMENT", "COOPERATION_EMPLOYMENT")
+	c.Enum("employment_scene", (*string)(o.EmploymentScene), "LOGISTICS", "MANUFACTURING", "HOTEL", "CATERING", "EVENT", "RETAIL", "OTHERS")
+	c.Enum("business_type", (*string)(o.BusinessType), "UNDEFINE", "PROMOTION")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetAuthenticationRequest 的字段
+func (o GetAuthenticationRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetAuthenticationRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("authenticate_number", o.AuthenticateNumber != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetRelationRequest 的字段
+func (o GetRelationRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetRelationRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListAuthenticationsRequest 的字段
+func (o ListAuthenticationsRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListAuthenticationsRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("authenticate_date", o.AuthenticateDate != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PreOrderAuthenticationRequest 的字段
+func (o PreOrderAuthenticationRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PreOrderAuthenticationRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("authenticate_number", o.AuthenticateNumber != nil)
+	c.Required("project_name", o.ProjectName != nil)
+	c.Required("employer_name", o.EmployerName != nil)
+	c.Enum("authenticate_type", (*string)(o.AuthenticateType), "NORMAL", "SIGN_IN", "INSURANCE", "CONTRACT")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 PreOrderAuthenticationWithAuthRequest 的字段
+func (o PreOrderAuthenticationWithAuthRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("PreOrderAuthenticationWithAuthRequest", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Required("authenticate_number", o.AuthenticateNumber != nil)
+	c.Required("project_name", o.ProjectName != nil)
+	c.Required("employer_name", o.EmployerName != nil)
+	c.Required("user_name", o.UserName != nil)
+	c.Required("id_card_number", o.IdCardNumber != nil)
+	c.Required("employment_type", o.EmploymentType != nil)
+	c.Enum("employment_type", (*string)(o.EmploymentType), "LONG_TERM_EMPLOYMENT", "SHORT_TERM_EMPLOYMENT", "COOPERATION_EMPLOYMENT")
+	c.Enum("authenticate_type", (*string)(o.AuthenticateType), "NORMAL", "SIGN_IN", "INSURANCE", "CONTRACT")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 TransferDetailInput 的字段
+func (o TransferDetailInput) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("TransferDetailInput", opts...)
+	c.Required("out_detail_no", o.OutDetailNo != nil)
+	c.ID("out_detail_no", o.OutDetailNo, idgen.OutDetailNo)
+	c.Required("transfer_amount", o.TransferAmount != nil)
+	c.Range("transfer_amount", o.TransferAmount, 1, 0)
+	c.Required("transfer_remark", o.TransferRemark != nil)
+	c.Length("transfer_remark", o.TransferRemark, 1, 32)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("user_name", o.UserName != nil)
+	return c.Err()
+}
@@ -54,6 +54,11 @@ func (a *BillShipmentApiService) SplitBill(ctx context.Context, req SplitBillReq
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/profitsharing/bills"
 	// Make sure All Required Params are properly set
 	if req.BillDate == nil {
@@ -48,6 +48,11 @@ func (a *OrdersApiService) CreateOrder(ctx context.Context, req CreateOrderReque
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// 对请求中敏感字段进行加密
 	encReq := req.Clone()
 	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
@@ -107,6 +112,11 @@ func (a *OrdersApiService) QueryOrder(ctx context.Context, req QueryOrderRequest
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutOrderNo == nil {
 		return nil, nil, fmt.Errorf("field `OutOrderNo` is required and must be specified in QueryOrderRequest")
@@ -172,6 +182,11 @@ func (a *OrdersApiService) UnfreezeOrder(ctx context.Context, req UnfreezeOrderR
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/profitsharing/orders/unfreeze"
 	// Make sure All Required Params are properly set
 
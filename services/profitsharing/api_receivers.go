@@ -44,6 +44,11 @@ func (a *ReceiversApiService) AddReceiver(ctx context.Context, req AddReceiverRe
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// 对请求中敏感字段进行加密
 	encReq := req.Clone()
 	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
@@ -110,6 +115,11 @@ func (a *ReceiversApiService) DeleteReceiver(ctx context.Context, req DeleteRece
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/profitsharing/receivers/delete"
 	// Make sure All Required Params are properly set
 
@@ -50,6 +50,11 @@ func (a *ReturnOrdersApiService) CreateReturnOrder(ctx context.Context, req Crea
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/profitsharing/return-orders"
 	// Make sure All Required Params are properly set
 
@@ -97,6 +102,11 @@ func (a *ReturnOrdersApiService) QueryReturnOrder(ctx context.Context, req Query
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutReturnNo == nil {
 		return nil, nil, fmt.Errorf("field `OutReturnNo` is required and must be specified in QueryReturnOrderRequest")
@@ -0,0 +1,80 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package profitsharing
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// BillShipmentAPI BillShipmentApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *BillShipmentApiService，即可在测试中使用 profitsharingtest.FakeBillShipmentAPI 替代
+type BillShipmentAPI interface {
+	// SplitBill 获取分账账单文件下载地址
+	SplitBill(ctx context.Context, req SplitBillRequest) (resp *SplitBillResponse, result *core.APIResult, err error)
+}
+
+// MerchantsAPI MerchantsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *MerchantsApiService，即可在测试中使用 profitsharingtest.FakeMerchantsAPI 替代
+type MerchantsAPI interface {
+	// QueryMerchantRatio 查询最大分账比例API
+	QueryMerchantRatio(ctx context.Context, req QueryMerchantRatioRequest) (resp *QueryMerchantRatioResponse, result *core.APIResult, err error)
+}
+
+// OrdersAPI OrdersApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *OrdersApiService，即可在测试中使用 profitsharingtest.FakeOrdersAPI 替代
+type OrdersAPI interface {
+	// CreateOrder 请求分账API
+	CreateOrder(ctx context.Context, req CreateOrderRequest) (resp *OrdersEntity, result *core.APIResult, err error)
+
+	// QueryOrder 查询分账结果API
+	QueryOrder(ctx context.Context, req QueryOrderRequest) (resp *OrdersEntity, result *core.APIResult, err error)
+
+	// UnfreezeOrder 解冻剩余资金API
+	UnfreezeOrder(ctx context.Context, req UnfreezeOrderRequest) (resp *OrdersEntity, result *core.APIResult, err error)
+}
+
+// ReceiversAPI ReceiversApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ReceiversApiService，即可在测试中使用 profitsharingtest.FakeReceiversAPI 替代
+type ReceiversAPI interface {
+	// AddReceiver 添加分账接收方API
+	AddReceiver(ctx context.Context, req AddReceiverRequest) (resp *AddReceiverResponse, result *core.APIResult, err error)
+
+	// DeleteReceiver 删除分账接收方API
+	DeleteReceiver(ctx context.Context, req DeleteReceiverRequest) (resp *DeleteReceiverResponse, result *core.APIResult, err error)
+}
+
+// ReturnOrdersAPI ReturnOrdersApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ReturnOrdersApiService，即可在测试中使用 profitsharingtest.FakeReturnOrdersAPI 替代
+type ReturnOrdersAPI interface {
+	// CreateReturnOrder 请求分账回退API
+	CreateReturnOrder(ctx context.Context, req CreateReturnOrderRequest) (resp *ReturnOrdersEntity, result *core.APIResult, err error)
+
+	// QueryReturnOrder 查询分账回退结果API
+	QueryReturnOrder(ctx context.Context, req QueryReturnOrderRequest) (resp *ReturnOrdersEntity, result *core.APIResult, err error)
+}
+
+// TransactionsAPI TransactionsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransactionsApiService，即可在测试中使用 profitsharingtest.FakeTransactionsAPI 替代
+type TransactionsAPI interface {
+	// QueryOrderAmount 查询剩余待分金额API
+	QueryOrderAmount(ctx context.Context, req QueryOrderAmountRequest) (resp *QueryOrderAmountResponse, result *core.APIResult, err error)
+}
+
+var (
+	_ BillShipmentAPI = (*BillShipmentApiService)(nil)
+	_ MerchantsAPI    = (*MerchantsApiService)(nil)
+	_ OrdersAPI       = (*OrdersApiService)(nil)
+	_ ReceiversAPI    = (*ReceiversApiService)(nil)
+	_ ReturnOrdersAPI = (*ReturnOrdersApiService)(nil)
+	_ TransactionsAPI = (*TransactionsApiService)(nil)
+)
@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // AddReceiverRequest
@@ -943,7 +945,7 @@ func (o OrderReceiverDetail) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in OrderReceiverDetail")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 
 	if o.Description == nil {
 		return nil, fmt.Errorf("field `Description` is required and must be specified in OrderReceiverDetail")
@@ -962,7 +964,7 @@ func (o OrderReceiverDetail) MarshalJSON() ([]byte, error) {
 	if o.FinishTime == nil {
 		return nil, fmt.Errorf("field `FinishTime` is required and must be specified in OrderReceiverDetail")
 	}
-	toSerialize["finish_time"] = o.FinishTime.Format(time.RFC3339)
+	toSerialize["finish_time"] = chinatime.Format(*o.FinishTime)
 
 	if o.Result == nil {
 		return nil, fmt.Errorf("field `Result` is required and must be specified in OrderReceiverDetail")
@@ -1660,7 +1662,7 @@ func (o ReturnOrdersEntity) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in ReturnOrdersEntity")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 
 	if o.Description == nil {
 		return nil, fmt.Errorf("field `Description` is required and must be specified in ReturnOrdersEntity")
@@ -1674,7 +1676,7 @@ func (o ReturnOrdersEntity) MarshalJSON() ([]byte, error) {
 	if o.FinishTime == nil {
 		return nil, fmt.Errorf("field `FinishTime` is required and must be specified in ReturnOrdersEntity")
 	}
-	toSerialize["finish_time"] = o.FinishTime.Format(time.RFC3339)
+	toSerialize["finish_time"] = chinatime.Format(*o.FinishTime)
 
 	if o.OrderId == nil {
 		return nil, fmt.Errorf("field `OrderId` is required and must be specified in ReturnOrdersEntity")
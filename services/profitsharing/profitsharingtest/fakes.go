@@ -0,0 +1,270 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package profitsharingtest 提供 profitsharing 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package profitsharingtest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/profitsharing"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeBillShipmentAPI profitsharing.BillShipmentAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeBillShipmentAPI struct {
+	servicetest.Recorder
+
+	SplitBillFunc func(context.Context, profitsharing.SplitBillRequest) (*profitsharing.SplitBillResponse, *core.APIResult, error)
+}
+
+var _ profitsharing.BillShipmentAPI = (*FakeBillShipmentAPI)(nil)
+
+// SplitBill 记录调用并返回 SplitBillFunc 的结果
+func (f *FakeBillShipmentAPI) SplitBill(ctx context.Context, req profitsharing.SplitBillRequest) (resp *profitsharing.SplitBillResponse, result *core.APIResult, err error) {
+	f.Record("SplitBill", req)
+	if f.SplitBillFunc == nil {
+		err = servicetest.NotStubbed("BillShipmentAPI", "SplitBill")
+		return
+	}
+	return f.SplitBillFunc(ctx, req)
+}
+
+// SplitBillReturns 设置 SplitBill 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeBillShipmentAPI) SplitBillReturns(resp *profitsharing.SplitBillResponse, err error) *FakeBillShipmentAPI {
+	f.SplitBillFunc = func(context.Context, profitsharing.SplitBillRequest) (*profitsharing.SplitBillResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeMerchantsAPI profitsharing.MerchantsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeMerchantsAPI struct {
+	servicetest.Recorder
+
+	QueryMerchantRatioFunc func(context.Context, profitsharing.QueryMerchantRatioRequest) (*profitsharing.QueryMerchantRatioResponse, *core.APIResult, error)
+}
+
+var _ profitsharing.MerchantsAPI = (*FakeMerchantsAPI)(nil)
+
+// QueryMerchantRatio 记录调用并返回 QueryMerchantRatioFunc 的结果
+func (f *FakeMerchantsAPI) QueryMerchantRatio(ctx context.Context, req profitsharing.QueryMerchantRatioRequest) (resp *profitsharing.QueryMerchantRatioResponse, result *core.APIResult, err error) {
+	f.Record("QueryMerchantRatio", req)
+	if f.QueryMerchantRatioFunc == nil {
+		err = servicetest.NotStubbed("MerchantsAPI", "QueryMerchantRatio")
+		return
+	}
+	return f.QueryMerchantRatioFunc(ctx, req)
+}
+
+// QueryMerchantRatioReturns 设置 QueryMerchantRatio 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeMerchantsAPI) QueryMerchantRatioReturns(resp *profitsharing.QueryMerchantRatioResponse, err error) *FakeMerchantsAPI {
+	f.QueryMerchantRatioFunc = func(context.Context, profitsharing.QueryMerchantRatioRequest) (*profitsharing.QueryMerchantRatioResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeOrdersAPI profitsharing.OrdersAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeOrdersAPI struct {
+	servicetest.Recorder
+
+	CreateOrderFunc   func(context.Context, profitsharing.CreateOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error)
+	QueryOrderFunc    func(context.Context, profitsharing.QueryOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error)
+	UnfreezeOrderFunc func(context.Context, profitsharing.UnfreezeOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error)
+}
+
+var _ profitsharing.OrdersAPI = (*FakeOrdersAPI)(nil)
+
+// CreateOrder 记录调用并返回 CreateOrderFunc 的结果
+func (f *FakeOrdersAPI) CreateOrder(ctx context.Context, req profitsharing.CreateOrderRequest) (resp *profitsharing.OrdersEntity, result *core.APIResult, err error) {
+	f.Record("CreateOrder", req)
+	if f.CreateOrderFunc == nil {
+		err = servicetest.NotStubbed("OrdersAPI", "CreateOrder")
+		return
+	}
+	return f.CreateOrderFunc(ctx, req)
+}
+
+// CreateOrderReturns 设置 CreateOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeOrdersAPI) CreateOrderReturns(resp *profitsharing.OrdersEntity, err error) *FakeOrdersAPI {
+	f.CreateOrderFunc = func(context.Context, profitsharing.CreateOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryOrder 记录调用并返回 QueryOrderFunc 的结果
+func (f *FakeOrdersAPI) QueryOrder(ctx context.Context, req profitsharing.QueryOrderRequest) (resp *profitsharing.OrdersEntity, result *core.APIResult, err error) {
+	f.Record("QueryOrder", req)
+	if f.QueryOrderFunc == nil {
+		err = servicetest.NotStubbed("OrdersAPI", "QueryOrder")
+		return
+	}
+	return f.QueryOrderFunc(ctx, req)
+}
+
+// QueryOrderReturns 设置 QueryOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeOrdersAPI) QueryOrderReturns(resp *profitsharing.OrdersEntity, err error) *FakeOrdersAPI {
+	f.QueryOrderFunc = func(context.Context, profitsharing.QueryOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// UnfreezeOrder 记录调用并返回 UnfreezeOrderFunc 的结果
+func (f *FakeOrdersAPI) UnfreezeOrder(ctx context.Context, req profitsharing.UnfreezeOrderRequest) (resp *profitsharing.OrdersEntity, result *core.APIResult, err error) {
+	f.Record("UnfreezeOrder", req)
+	if f.UnfreezeOrderFunc == nil {
+		err = servicetest.NotStubbed("OrdersAPI", "UnfreezeOrder")
+		return
+	}
+	return f.UnfreezeOrderFunc(ctx, req)
+}
+
+// UnfreezeOrderReturns 设置 UnfreezeOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeOrdersAPI) UnfreezeOrderReturns(resp *profitsharing.OrdersEntity, err error) *FakeOrdersAPI {
+	f.UnfreezeOrderFunc = func(context.Context, profitsharing.UnfreezeOrderRequest) (*profitsharing.OrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeReceiversAPI profitsharing.ReceiversAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeReceiversAPI struct {
+	servicetest.Recorder
+
+	AddReceiverFunc    func(context.Context, profitsharing.AddReceiverRequest) (*profitsharing.AddReceiverResponse, *core.APIResult, error)
+	DeleteReceiverFunc func(context.Context, profitsharing.DeleteReceiverRequest) (*profitsharing.DeleteReceiverResponse, *core.APIResult, error)
+}
+
+var _ profitsharing.ReceiversAPI = (*FakeReceiversAPI)(nil)
+
+// AddReceiver 记录调用并返回 AddReceiverFunc 的结果
+func (f *FakeReceiversAPI) AddReceiver(ctx context.Context, req profitsharing.AddReceiverRequest) (resp *profitsharing.AddReceiverResponse, result *core.APIResult, err error) {
+	f.Record("AddReceiver", req)
+	if f.AddReceiverFunc == nil {
+		err = servicetest.NotStubbed("ReceiversAPI", "AddReceiver")
+		return
+	}
+	return f.AddReceiverFunc(ctx, req)
+}
+
+// AddReceiverReturns 设置 AddReceiver 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeReceiversAPI) AddReceiverReturns(resp *profitsharing.AddReceiverResponse, err error) *FakeReceiversAPI {
+	f.AddReceiverFunc = func(context.Context, profitsharing.AddReceiverRequest) (*profitsharing.AddReceiverResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeleteReceiver 记录调用并返回 DeleteReceiverFunc 的结果
+func (f *FakeReceiversAPI) DeleteReceiver(ctx context.Context, req profitsharing.DeleteReceiverRequest) (resp *profitsharing.DeleteReceiverResponse, result *core.APIResult, err error) {
+	f.Record("DeleteReceiver", req)
+	if f.DeleteReceiverFunc == nil {
+		err = servicetest.NotStubbed("ReceiversAPI", "DeleteReceiver")
+		return
+	}
+	return f.DeleteReceiverFunc(ctx, req)
+}
+
+// DeleteReceiverReturns 设置 DeleteReceiver 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeReceiversAPI) DeleteReceiverReturns(resp *profitsharing.DeleteReceiverResponse, err error) *FakeReceiversAPI {
+	f.DeleteReceiverFunc = func(context.Context, profitsharing.DeleteReceiverRequest) (*profitsharing.DeleteReceiverResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeReturnOrdersAPI profitsharing.ReturnOrdersAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeReturnOrdersAPI struct {
+	servicetest.Recorder
+
+	CreateReturnOrderFunc func(context.Context, profitsharing.CreateReturnOrderRequest) (*profitsharing.ReturnOrdersEntity, *core.APIResult, error)
+	QueryReturnOrderFunc  func(context.Context, profitsharing.QueryReturnOrderRequest) (*profitsharing.ReturnOrdersEntity, *core.APIResult, error)
+}
+
+var _ profitsharing.ReturnOrdersAPI = (*FakeReturnOrdersAPI)(nil)
+
+// CreateReturnOrder 记录调用并返回 CreateReturnOrderFunc 的结果
+func (f *FakeReturnOrdersAPI) CreateReturnOrder(ctx context.Context, req profitsharing.CreateReturnOrderRequest) (resp *profitsharing.ReturnOrdersEntity, result *core.APIResult, err error) {
+	f.Record("CreateReturnOrder", req)
+	if f.CreateReturnOrderFunc == nil {
+		err = servicetest.NotStubbed("ReturnOrdersAPI", "CreateReturnOrder")
+		return
+	}
+	return f.CreateReturnOrderFunc(ctx, req)
+}
+
+// CreateReturnOrderReturns 设置 CreateReturnOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeReturnOrdersAPI) CreateReturnOrderReturns(resp *profitsharing.ReturnOrdersEntity, err error) *FakeReturnOrdersAPI {
+	f.CreateReturnOrderFunc = func(context.Context, profitsharing.CreateReturnOrderRequest) (*profitsharing.ReturnOrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryReturnOrder 记录调用并返回 QueryReturnOrderFunc 的结果
+func (f *FakeReturnOrdersAPI) QueryReturnOrder(ctx context.Context, req profitsharing.QueryReturnOrderRequest) (resp *profitsharing.ReturnOrdersEntity, result *core.APIResult, err error) {
+	f.Record("QueryReturnOrder", req)
+	if f.QueryReturnOrderFunc == nil {
+		err = servicetest.NotStubbed("ReturnOrdersAPI", "QueryReturnOrder")
+		return
+	}
+	return f.QueryReturnOrderFunc(ctx, req)
+}
+
+// QueryReturnOrderReturns 设置 QueryReturnOrder 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeReturnOrdersAPI) QueryReturnOrderReturns(resp *profitsharing.ReturnOrdersEntity, err error) *FakeReturnOrdersAPI {
+	f.QueryReturnOrderFunc = func(context.Context, profitsharing.QueryReturnOrderRequest) (*profitsharing.ReturnOrdersEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeTransactionsAPI profitsharing.TransactionsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransactionsAPI struct {
+	servicetest.Recorder
+
+	QueryOrderAmountFunc func(context.Context, profitsharing.QueryOrderAmountRequest) (*profitsharing.QueryOrderAmountResponse, *core.APIResult, error)
+}
+
+var _ profitsharing.TransactionsAPI = (*FakeTransactionsAPI)(nil)
+
+// QueryOrderAmount 记录调用并返回 QueryOrderAmountFunc 的结果
+func (f *FakeTransactionsAPI) QueryOrderAmount(ctx context.Context, req profitsharing.QueryOrderAmountRequest) (resp *profitsharing.QueryOrderAmountResponse, result *core.APIResult, err error) {
+	f.Record("QueryOrderAmount", req)
+	if f.QueryOrderAmountFunc == nil {
+		err = servicetest.NotStubbed("TransactionsAPI", "QueryOrderAmount")
+		return
+	}
+	return f.QueryOrderAmountFunc(ctx, req)
+}
+
+// QueryOrderAmountReturns 设置 QueryOrderAmount 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransactionsAPI) QueryOrderAmountReturns(resp *profitsharing.QueryOrderAmountResponse, err error) *FakeTransactionsAPI {
+	f.QueryOrderAmountFunc = func(context.Context, profitsharing.QueryOrderAmountRequest) (*profitsharing.QueryOrderAmountResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
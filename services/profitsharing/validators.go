@@ -0,0 +1,143 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package profitsharing
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 AddReceiverRequest 的字段
+func (o AddReceiverRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AddReceiverRequest", opts...)
+	c.Required("account", o.Account != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("relation_type", o.RelationType != nil)
+	c.Enum("relation_type", (*string)(o.RelationType), "SERVICE_PROVIDER", "STORE", "STAFF", "STORE_OWNER", "PARTNER", "HEADQUARTER", "BRAND", "DISTRIBUTOR", "USER", "SUPPLIER", "CUSTOM")
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("type", o.Type != nil)
+	c.Enum("type", (*string)(o.Type), "MERCHANT_ID", "PERSONAL_OPENID", "PERSONAL_SUB_OPENID")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateOrderReceiver 的字段
+func (o CreateOrderReceiver) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateOrderReceiver", opts...)
+	c.Required("account", o.Account != nil)
+	c.Required("amount", o.Amount != nil)
+	c.Range("amount", o.Amount, 1, 0)
+	c.Required("description", o.Description != nil)
+	c.Length("description", o.Description, 1, 80)
+	c.Required("type", o.Type != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateOrderRequest 的字段
+func (o CreateOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateOrderRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("out_order_no", o.OutOrderNo != nil)
+	c.ID("out_order_no", o.OutOrderNo, idgen.OutOrderNo)
+	for i, item := range o.Receivers {
+		c.Nested(validation.Index("receivers", i), item.Validate(opts...))
+	}
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.Required("unfreeze_unsplit", o.UnfreezeUnsplit != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateReturnOrderRequest 的字段
+func (o CreateReturnOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateReturnOrderRequest", opts...)
+	c.Required("amount", o.Amount != nil)
+	c.Range("amount", o.Amount, 1, 0)
+	c.Required("description", o.Description != nil)
+	c.Length("description", o.Description, 1, 80)
+	c.ID("out_order_no", o.OutOrderNo, idgen.OutOrderNo)
+	c.Required("out_return_no", o.OutReturnNo != nil)
+	c.ID("out_return_no", o.OutReturnNo, idgen.OutReturnNo)
+	c.Required("return_mchid", o.ReturnMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeleteReceiverRequest 的字段
+func (o DeleteReceiverRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeleteReceiverRequest", opts...)
+	c.Required("account", o.Account != nil)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("type", o.Type != nil)
+	c.Enum("type", (*string)(o.Type), "MERCHANT_ID", "PERSONAL_OPENID", "PERSONAL_SUB_OPENID")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryMerchantRatioRequest 的字段
+func (o QueryMerchantRatioRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryMerchantRatioRequest", opts...)
+	c.Required("sub_mchid", o.SubMchid != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryOrderAmountRequest 的字段
+func (o QueryOrderAmountRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryOrderAmountRequest", opts...)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryOrderRequest 的字段
+func (o QueryOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryOrderRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.Required("out_order_no", o.OutOrderNo != nil)
+	c.ID("out_order_no", o.OutOrderNo, idgen.OutOrderNo)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryReturnOrderRequest 的字段
+func (o QueryReturnOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryReturnOrderRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("out_return_no", o.OutReturnNo != nil)
+	c.ID("out_return_no", o.OutReturnNo, idgen.OutReturnNo)
+	c.Required("out_order_no", o.OutOrderNo != nil)
+	c.ID("out_order_no", o.OutOrderNo, idgen.OutOrderNo)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 SplitBillRequest 的字段
+func (o SplitBillRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("SplitBillRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("bill_date", o.BillDate != nil)
+	c.Enum("tar_type", (*string)(o.TarType), "GZIP")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 UnfreezeOrderRequest 的字段
+func (o UnfreezeOrderRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("UnfreezeOrderRequest", opts...)
+	c.Required("description", o.Description != nil)
+	c.Length("description", o.Description, 1, 80)
+	c.Required("out_order_no", o.OutOrderNo != nil)
+	c.ID("out_order_no", o.OutOrderNo, idgen.OutOrderNo)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("transaction_id", o.TransactionId != nil)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	return c.Err()
+}
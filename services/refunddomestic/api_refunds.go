@@ -24,6 +24,91 @@ import (
 
 type RefundsApiService services.Service
 
+// ApplyAbnormalRefund 发起异常退款
+//
+// # 应用场景
+// 提交退款申请后，查询退款确认状态为退款异常，可调用此接口发起异常退款处理。支持退款至用户、退款至交易商户银行账户两种处理方式。
+//
+// 注意：
+// 1、退款至用户时，仅支持以下银行的借记卡：招行、交通银行、农行、建行、工商、中行、平安、浦发、中信、光大、民生、兴业、广发、邮储、宁波银行
+// 2、请求频率限制：150qps，即每秒钟正常的申请退款请求次数不超过150次
+//
+//
+// # 错误码
+// |名称|描述|原因|解决方案|
+// |-|-|-|-|
+// |SYSTEM_ERROR|接口返回错误|系统超时等|请不要更换商户退款单号，请使用相同参数再次调用API。|
+// |PARAM_ERROR|参数错误|请求参数未按指引进行填写|请求参数错误，请重新检查再调用异常退款|
+// |INVALID_REQUEST|请求参数符合参数格式，但不符合业务规则|退款单状态不是退款异常|请确认退款单状态为ABNORMAL后再发起异常退款|
+// |RESOURCE_NOT_EXISTS|退款单不存在|退款单号错误|请检查退款单号是否正确|
+// |SIGN_ERROR|签名错误|参数签名结果不正确|请检查签名参数和方法是否都符合签名算法要求|
+// |NO_AUTH|没有权限|没有此单的退款权限|请检查是否有处理这笔退款的权限|
+func (a *RefundsApiService) ApplyAbnormalRefund(ctx context.Context, req ApplyAbnormalRefundRequest) (resp *Refund, result *core.APIResult, err error) {
+	var (
+		localVarHTTPMethod   = nethttp.MethodPost
+		localVarPostBody     interface{}
+		localVarQueryParams  neturl.Values
+		localVarHeaderParams = nethttp.Header{}
+	)
+
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	// Make sure Path Params are properly set
+	if req.RefundId == nil {
+		return nil, nil, fmt.Errorf("field `RefundId` is required and must be specified in ApplyAbnormalRefundRequest")
+	}
+
+	// 对请求中敏感字段进行加密
+	encReq := req.Clone()
+	encryptCertificate, err := a.Client.EncryptRequest(ctx, encReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypt request failed: %v", err)
+	}
+
+	if encryptCertificate != "" {
+		localVarHeaderParams.Set(consts.WechatPaySerial, encryptCertificate)
+	}
+	req = *encReq
+
+	localVarPath := consts.WechatPayAPIServer + "/v3/refund/domestic/refunds/{refund_id}/apply-abnormal-refund"
+	// Build Path with Path Params
+	localVarPath = strings.Replace(localVarPath, "{"+"refund_id"+"}", neturl.PathEscape(core.ParameterToString(*req.RefundId, "")), -1)
+
+	// Make sure All Required Params are properly set
+
+	// Setup Body Params
+	localVarPostBody = &ApplyAbnormalRefundBody{
+		SubMchid:    req.SubMchid,
+		OutRefundNo: req.OutRefundNo,
+		Type:        req.Type,
+		BankType:    req.BankType,
+		BankAccount: req.BankAccount,
+		RealName:    req.RealName,
+	}
+
+	// Determine the Content-Type Header
+	localVarHTTPContentTypes := []string{"application/json"}
+	// Setup Content-Type
+	localVarHTTPContentType := core.SelectHeaderContentType(localVarHTTPContentTypes)
+
+	// Perform Http Request
+	result, err = a.Client.Request(ctx, localVarHTTPMethod, localVarPath, localVarHeaderParams, localVarQueryParams, localVarPostBody, localVarHTTPContentType)
+	if err != nil {
+		return nil, result, err
+	}
+
+	// Extract Refund from Http Response
+	resp = new(Refund)
+	err = core.UnMarshalResponse(result.Response, resp)
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
 // Create 退款申请
 //
 // # 应用场景
@@ -60,6 +145,11 @@ func (a *RefundsApiService) Create(ctx context.Context, req CreateRequest) (resp
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/refund/domestic/refunds"
 	// Make sure All Required Params are properly set
 
@@ -106,6 +196,11 @@ func (a *RefundsApiService) QueryByOutRefundNo(ctx context.Context, req QueryByO
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.OutRefundNo == nil {
 		return nil, nil, fmt.Errorf("field `OutRefundNo` is required and must be specified in QueryByOutRefundNoRequest")
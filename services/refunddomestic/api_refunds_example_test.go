@@ -121,3 +121,49 @@ func ExampleRefundsApiService_QueryByOutRefundNo() {
 		log.Printf("status=%d resp=%s", result.Response.StatusCode, resp)
 	}
 }
+
+func ExampleRefundsApiService_ApplyAbnormalRefund() {
+	var (
+		mchID                      string = "190000****"                               // 商户号
+		mchCertificateSerialNumber string = "3775************************************" // 商户证书序列号
+		mchAPIv3Key                string = "2ab9****************************"         // 商户APIv3密钥
+	)
+
+	// 使用 utils 提供的函数从本地文件中加载商户私钥，商户私钥会用来生成请求的签名
+	mchPrivateKey, err := utils.LoadPrivateKeyWithPath("/path/to/merchant/apiclient_key.pem")
+	if err != nil {
+		log.Print("load merchant private key error")
+	}
+
+	ctx := context.Background()
+	// 使用商户私钥等初始化 client，并使它具有自动定时获取微信支付平台证书的能力
+	opts := []core.ClientOption{
+		option.WithWechatPayAutoAuthCipher(mchID, mchCertificateSerialNumber, mchPrivateKey, mchAPIv3Key),
+	}
+	client, err := core.NewClient(ctx, opts...)
+	if err != nil {
+		log.Printf("new wechat pay client err:%s", err)
+	}
+
+	svc := refunddomestic.RefundsApiService{Client: client}
+	// BankAccount 与 RealName 传入明文，发出请求前会使用微信支付平台证书加密
+	resp, result, err := svc.ApplyAbnormalRefund(ctx,
+		refunddomestic.ApplyAbnormalRefundRequest{
+			RefundId:    core.String("50000000382019052709732678859"),
+			SubMchid:    core.String("1900000109"),
+			OutRefundNo: core.String("1217752501201407033233368018"),
+			Type:        refunddomestic.ABNORMALREFUNDTYPE_USER_BANK_CARD.Ptr(),
+			BankType:    core.String("ICBC_DEBIT"),
+			BankAccount: core.String("6222020000000000000"),
+			RealName:    core.String("张三"),
+		},
+	)
+
+	if err != nil {
+		// 处理错误
+		log.Printf("call ApplyAbnormalRefund err:%s", err)
+	} else {
+		// 处理返回结果
+		log.Printf("status=%d resp=%s", result.Response.StatusCode, resp)
+	}
+}
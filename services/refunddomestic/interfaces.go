@@ -0,0 +1,27 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package refunddomestic
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// RefundsAPI RefundsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *RefundsApiService，即可在测试中使用 refunddomestictest.FakeRefundsAPI 替代
+type RefundsAPI interface {
+	// ApplyAbnormalRefund 发起异常退款
+	ApplyAbnormalRefund(ctx context.Context, req ApplyAbnormalRefundRequest) (resp *Refund, result *core.APIResult, err error)
+
+	// Create 退款申请
+	Create(ctx context.Context, req CreateRequest) (resp *Refund, result *core.APIResult, err error)
+
+	// QueryByOutRefundNo 查询单笔退款（通过商户退款单号）
+	QueryByOutRefundNo(ctx context.Context, req QueryByOutRefundNoRequest) (resp *Refund, result *core.APIResult, err error)
+}
+
+var _ RefundsAPI = (*RefundsApiService)(nil)
@@ -14,6 +14,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+)
+
+// AbnormalRefundType * `USER_BANK_CARD` - 退款到用户银行卡, 异常退款处理方式 * `MERCHANT_BANK_CARD` - 退款至交易商户银行账户, 异常退款处理方式
+type AbnormalRefundType string
+
+func (e AbnormalRefundType) Ptr() *AbnormalRefundType {
+	return &e
+}
+
+// Enums of AbnormalRefundType
+const (
+	ABNORMALREFUNDTYPE_USER_BANK_CARD     AbnormalRefundType = "USER_BANK_CARD"
+	ABNORMALREFUNDTYPE_MERCHANT_BANK_CARD AbnormalRefundType = "MERCHANT_BANK_CARD"
 )
 
 // Account * `AVAILABLE` - 可用余额, 多账户资金准备退款可用余额出资账户类型 * `UNAVAILABLE` - 不可用余额, 多账户资金准备退款不可用余额出资账户类型
@@ -297,6 +312,272 @@ func (o AmountReq) Clone() *AmountReq {
 	return &ret
 }
 
+// ApplyAbnormalRefundBody
+type ApplyAbnormalRefundBody struct {
+	// 子商户的商户号，由微信支付生成并下发。服务商模式下必须传递此参数
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 商户系统内部的退款单号
+	OutRefundNo *string `json:"out_refund_no"`
+	// 异常退款处理方式
+	Type *AbnormalRefundType `json:"type"`
+	// 银行类型，采用字符串类型的银行标识
+	BankType *string `json:"bank_type,omitempty"`
+	// 退款至用户银行卡的银行账号，已加密
+	BankAccount *string `json:"bank_account,omitempty" encryption:"EM_APIV3"`
+	// 收款用户姓名，已加密
+	RealName *string `json:"real_name,omitempty" encryption:"EM_APIV3"`
+}
+
+func (o ApplyAbnormalRefundBody) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.OutRefundNo == nil {
+		return nil, fmt.Errorf("field `OutRefundNo` is required and must be specified in ApplyAbnormalRefundBody")
+	}
+	toSerialize["out_refund_no"] = o.OutRefundNo
+
+	if o.Type == nil {
+		return nil, fmt.Errorf("field `Type` is required and must be specified in ApplyAbnormalRefundBody")
+	}
+	toSerialize["type"] = o.Type
+
+	if o.BankType != nil {
+		toSerialize["bank_type"] = o.BankType
+	}
+
+	if o.BankAccount != nil {
+		toSerialize["bank_account"] = o.BankAccount
+	}
+
+	if o.RealName != nil {
+		toSerialize["real_name"] = o.RealName
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o ApplyAbnormalRefundBody) String() string {
+	var ret string
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.OutRefundNo == nil {
+		ret += "OutRefundNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutRefundNo:%v, ", *o.OutRefundNo)
+	}
+
+	if o.Type == nil {
+		ret += "Type:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Type:%v, ", *o.Type)
+	}
+
+	if o.BankType == nil {
+		ret += "BankType:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BankType:%v, ", *o.BankType)
+	}
+
+	if o.BankAccount == nil {
+		ret += "BankAccount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BankAccount:%v, ", *o.BankAccount)
+	}
+
+	if o.RealName == nil {
+		ret += "RealName:<nil>"
+	} else {
+		ret += fmt.Sprintf("RealName:%v", *o.RealName)
+	}
+
+	return fmt.Sprintf("ApplyAbnormalRefundBody{%s}", ret)
+}
+
+func (o ApplyAbnormalRefundBody) Clone() *ApplyAbnormalRefundBody {
+	ret := ApplyAbnormalRefundBody{}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.OutRefundNo != nil {
+		ret.OutRefundNo = new(string)
+		*ret.OutRefundNo = *o.OutRefundNo
+	}
+
+	if o.Type != nil {
+		ret.Type = new(AbnormalRefundType)
+		*ret.Type = *o.Type
+	}
+
+	if o.BankType != nil {
+		ret.BankType = new(string)
+		*ret.BankType = *o.BankType
+	}
+
+	if o.BankAccount != nil {
+		ret.BankAccount = new(string)
+		*ret.BankAccount = *o.BankAccount
+	}
+
+	if o.RealName != nil {
+		ret.RealName = new(string)
+		*ret.RealName = *o.RealName
+	}
+
+	return &ret
+}
+
+// ApplyAbnormalRefundRequest
+type ApplyAbnormalRefundRequest struct {
+	// 微信支付退款单号
+	RefundId *string `json:"refund_id"`
+	// 子商户的商户号，由微信支付生成并下发。服务商模式下必须传递此参数
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 商户系统内部的退款单号
+	OutRefundNo *string `json:"out_refund_no"`
+	// 异常退款处理方式
+	Type *AbnormalRefundType `json:"type"`
+	// 银行类型，采用字符串类型的银行标识，值列表详见银行类型。仅支持招行、交通银行、农行、建行、工商、中行、平安、浦发、中信、光大、民生、兴业、广发、邮储、宁波银行的借记卡。若退款至用户此字段必填
+	BankType *string `json:"bank_type,omitempty"`
+	// 退款至用户银行卡的银行账号，该字段需进行加密处理。若退款至用户此字段必填
+	BankAccount *string `json:"bank_account,omitempty" encryption:"EM_APIV3"`
+	// 收款用户姓名，该字段需进行加密处理。若退款至用户此字段必填
+	RealName *string `json:"real_name,omitempty" encryption:"EM_APIV3"`
+}
+
+func (o ApplyAbnormalRefundRequest) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.RefundId == nil {
+		return nil, fmt.Errorf("field `RefundId` is required and must be specified in ApplyAbnormalRefundRequest")
+	}
+	toSerialize["refund_id"] = o.RefundId
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.OutRefundNo == nil {
+		return nil, fmt.Errorf("field `OutRefundNo` is required and must be specified in ApplyAbnormalRefundRequest")
+	}
+	toSerialize["out_refund_no"] = o.OutRefundNo
+
+	if o.Type == nil {
+		return nil, fmt.Errorf("field `Type` is required and must be specified in ApplyAbnormalRefundRequest")
+	}
+	toSerialize["type"] = o.Type
+
+	if o.BankType != nil {
+		toSerialize["bank_type"] = o.BankType
+	}
+
+	if o.BankAccount != nil {
+		toSerialize["bank_account"] = o.BankAccount
+	}
+
+	if o.RealName != nil {
+		toSerialize["real_name"] = o.RealName
+	}
+	return json.Marshal(toSerialize)
+}
+
+func (o ApplyAbnormalRefundRequest) String() string {
+	var ret string
+	if o.RefundId == nil {
+		ret += "RefundId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("RefundId:%v, ", *o.RefundId)
+	}
+
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.OutRefundNo == nil {
+		ret += "OutRefundNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutRefundNo:%v, ", *o.OutRefundNo)
+	}
+
+	if o.Type == nil {
+		ret += "Type:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Type:%v, ", *o.Type)
+	}
+
+	if o.BankType == nil {
+		ret += "BankType:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BankType:%v, ", *o.BankType)
+	}
+
+	if o.BankAccount == nil {
+		ret += "BankAccount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("BankAccount:%v, ", *o.BankAccount)
+	}
+
+	if o.RealName == nil {
+		ret += "RealName:<nil>"
+	} else {
+		ret += fmt.Sprintf("RealName:%v", *o.RealName)
+	}
+
+	return fmt.Sprintf("ApplyAbnormalRefundRequest{%s}", ret)
+}
+
+func (o ApplyAbnormalRefundRequest) Clone() *ApplyAbnormalRefundRequest {
+	ret := ApplyAbnormalRefundRequest{}
+
+	if o.RefundId != nil {
+		ret.RefundId = new(string)
+		*ret.RefundId = *o.RefundId
+	}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.OutRefundNo != nil {
+		ret.OutRefundNo = new(string)
+		*ret.OutRefundNo = *o.OutRefundNo
+	}
+
+	if o.Type != nil {
+		ret.Type = new(AbnormalRefundType)
+		*ret.Type = *o.Type
+	}
+
+	if o.BankType != nil {
+		ret.BankType = new(string)
+		*ret.BankType = *o.BankType
+	}
+
+	if o.BankAccount != nil {
+		ret.BankAccount = new(string)
+		*ret.BankAccount = *o.BankAccount
+	}
+
+	if o.RealName != nil {
+		ret.RealName = new(string)
+		*ret.RealName = *o.RealName
+	}
+
+	return &ret
+}
+
 // Channel * `ORIGINAL` - 原路退款, 退款渠道 * `BALANCE` - 退回到余额, 退款渠道 * `OTHER_BALANCE` - 原账户异常退到其他余额账户, 退款渠道 * `OTHER_BANKCARD` - 原银行卡异常退到其他银行卡, 退款渠道
 type Channel string
 
@@ -920,13 +1201,13 @@ func (o Refund) MarshalJSON() ([]byte, error) {
 	toSerialize["user_received_account"] = o.UserReceivedAccount
 
 	if o.SuccessTime != nil {
-		toSerialize["success_time"] = o.SuccessTime.Format(time.RFC3339)
+		toSerialize["success_time"] = chinatime.Format(*o.SuccessTime)
 	}
 
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in Refund")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 
 	if o.Status == nil {
 		return nil, fmt.Errorf("field `Status` is required and must be specified in Refund")
@@ -1084,6 +1365,307 @@ func (o Refund) Clone() *Refund {
 	return &ret
 }
 
+// RefundNotification 退款结果通知的资源内容，对应 REFUND.SUCCESS、REFUND.ABNORMAL 与 REFUND.CLOSED 通知
+type RefundNotification struct {
+	// 直连商户的商户号
+	Mchid *string `json:"mchid,omitempty"`
+	// 服务商模式下服务商的商户号
+	SpMchid *string `json:"sp_mchid,omitempty"`
+	// 服务商模式下子商户的商户号
+	SubMchid *string `json:"sub_mchid,omitempty"`
+	// 微信支付订单号
+	TransactionId *string `json:"transaction_id"`
+	// 原支付交易对应的商户订单号
+	OutTradeNo *string `json:"out_trade_no"`
+	// 微信支付退款单号
+	RefundId *string `json:"refund_id"`
+	// 商户系统内部的退款单号
+	OutRefundNo *string `json:"out_refund_no"`
+	// 退款状态，枚举值：SUCCESS、CLOSED、ABNORMAL
+	RefundStatus *Status `json:"refund_status"`
+	// 退款成功时间，退款状态为SUCCESS时返回
+	SuccessTime *time.Time `json:"success_time,omitempty"`
+	// 退款入账账户
+	UserReceivedAccount *string `json:"user_received_account"`
+	// 金额信息
+	Amount *RefundNotificationAmount `json:"amount"`
+}
+
+func (o RefundNotification) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Mchid != nil {
+		toSerialize["mchid"] = o.Mchid
+	}
+
+	if o.SpMchid != nil {
+		toSerialize["sp_mchid"] = o.SpMchid
+	}
+
+	if o.SubMchid != nil {
+		toSerialize["sub_mchid"] = o.SubMchid
+	}
+
+	if o.TransactionId == nil {
+		return nil, fmt.Errorf("field `TransactionId` is required and must be specified in RefundNotification")
+	}
+	toSerialize["transaction_id"] = o.TransactionId
+
+	if o.OutTradeNo == nil {
+		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in RefundNotification")
+	}
+	toSerialize["out_trade_no"] = o.OutTradeNo
+
+	if o.RefundId == nil {
+		return nil, fmt.Errorf("field `RefundId` is required and must be specified in RefundNotification")
+	}
+	toSerialize["refund_id"] = o.RefundId
+
+	if o.OutRefundNo == nil {
+		return nil, fmt.Errorf("field `OutRefundNo` is required and must be specified in RefundNotification")
+	}
+	toSerialize["out_refund_no"] = o.OutRefundNo
+
+	if o.RefundStatus == nil {
+		return nil, fmt.Errorf("field `RefundStatus` is required and must be specified in RefundNotification")
+	}
+	toSerialize["refund_status"] = o.RefundStatus
+
+	if o.SuccessTime != nil {
+		toSerialize["success_time"] = chinatime.Format(*o.SuccessTime)
+	}
+
+	if o.UserReceivedAccount == nil {
+		return nil, fmt.Errorf("field `UserReceivedAccount` is required and must be specified in RefundNotification")
+	}
+	toSerialize["user_received_account"] = o.UserReceivedAccount
+
+	if o.Amount == nil {
+		return nil, fmt.Errorf("field `Amount` is required and must be specified in RefundNotification")
+	}
+	toSerialize["amount"] = o.Amount
+	return json.Marshal(toSerialize)
+}
+
+func (o RefundNotification) String() string {
+	var ret string
+	if o.Mchid == nil {
+		ret += "Mchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Mchid:%v, ", *o.Mchid)
+	}
+
+	if o.SpMchid == nil {
+		ret += "SpMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SpMchid:%v, ", *o.SpMchid)
+	}
+
+	if o.SubMchid == nil {
+		ret += "SubMchid:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SubMchid:%v, ", *o.SubMchid)
+	}
+
+	if o.TransactionId == nil {
+		ret += "TransactionId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("TransactionId:%v, ", *o.TransactionId)
+	}
+
+	if o.OutTradeNo == nil {
+		ret += "OutTradeNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutTradeNo:%v, ", *o.OutTradeNo)
+	}
+
+	if o.RefundId == nil {
+		ret += "RefundId:<nil>, "
+	} else {
+		ret += fmt.Sprintf("RefundId:%v, ", *o.RefundId)
+	}
+
+	if o.OutRefundNo == nil {
+		ret += "OutRefundNo:<nil>, "
+	} else {
+		ret += fmt.Sprintf("OutRefundNo:%v, ", *o.OutRefundNo)
+	}
+
+	if o.RefundStatus == nil {
+		ret += "RefundStatus:<nil>, "
+	} else {
+		ret += fmt.Sprintf("RefundStatus:%v, ", *o.RefundStatus)
+	}
+
+	if o.SuccessTime == nil {
+		ret += "SuccessTime:<nil>, "
+	} else {
+		ret += fmt.Sprintf("SuccessTime:%v, ", *o.SuccessTime)
+	}
+
+	if o.UserReceivedAccount == nil {
+		ret += "UserReceivedAccount:<nil>, "
+	} else {
+		ret += fmt.Sprintf("UserReceivedAccount:%v, ", *o.UserReceivedAccount)
+	}
+
+	ret += fmt.Sprintf("Amount:%v", o.Amount)
+
+	return fmt.Sprintf("RefundNotification{%s}", ret)
+}
+
+func (o RefundNotification) Clone() *RefundNotification {
+	ret := RefundNotification{}
+
+	if o.Mchid != nil {
+		ret.Mchid = new(string)
+		*ret.Mchid = *o.Mchid
+	}
+
+	if o.SpMchid != nil {
+		ret.SpMchid = new(string)
+		*ret.SpMchid = *o.SpMchid
+	}
+
+	if o.SubMchid != nil {
+		ret.SubMchid = new(string)
+		*ret.SubMchid = *o.SubMchid
+	}
+
+	if o.TransactionId != nil {
+		ret.TransactionId = new(string)
+		*ret.TransactionId = *o.TransactionId
+	}
+
+	if o.OutTradeNo != nil {
+		ret.OutTradeNo = new(string)
+		*ret.OutTradeNo = *o.OutTradeNo
+	}
+
+	if o.RefundId != nil {
+		ret.RefundId = new(string)
+		*ret.RefundId = *o.RefundId
+	}
+
+	if o.OutRefundNo != nil {
+		ret.OutRefundNo = new(string)
+		*ret.OutRefundNo = *o.OutRefundNo
+	}
+
+	if o.RefundStatus != nil {
+		ret.RefundStatus = new(Status)
+		*ret.RefundStatus = *o.RefundStatus
+	}
+
+	if o.SuccessTime != nil {
+		ret.SuccessTime = new(time.Time)
+		*ret.SuccessTime = *o.SuccessTime
+	}
+
+	if o.UserReceivedAccount != nil {
+		ret.UserReceivedAccount = new(string)
+		*ret.UserReceivedAccount = *o.UserReceivedAccount
+	}
+
+	if o.Amount != nil {
+		ret.Amount = o.Amount.Clone()
+	}
+
+	return &ret
+}
+
+// RefundNotificationAmount 退款结果通知中的金额信息
+type RefundNotificationAmount struct {
+	// 订单总金额，单位为分
+	Total *int64 `json:"total"`
+	// 退款金额，单位为分
+	Refund *int64 `json:"refund"`
+	// 用户实际支付金额，单位为分
+	PayerTotal *int64 `json:"payer_total"`
+	// 退款给用户的金额，单位为分，不包含所有优惠券金额
+	PayerRefund *int64 `json:"payer_refund"`
+}
+
+func (o RefundNotificationAmount) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+
+	if o.Total == nil {
+		return nil, fmt.Errorf("field `Total` is required and must be specified in RefundNotificationAmount")
+	}
+	toSerialize["total"] = o.Total
+
+	if o.Refund == nil {
+		return nil, fmt.Errorf("field `Refund` is required and must be specified in RefundNotificationAmount")
+	}
+	toSerialize["refund"] = o.Refund
+
+	if o.PayerTotal == nil {
+		return nil, fmt.Errorf("field `PayerTotal` is required and must be specified in RefundNotificationAmount")
+	}
+	toSerialize["payer_total"] = o.PayerTotal
+
+	if o.PayerRefund == nil {
+		return nil, fmt.Errorf("field `PayerRefund` is required and must be specified in RefundNotificationAmount")
+	}
+	toSerialize["payer_refund"] = o.PayerRefund
+	return json.Marshal(toSerialize)
+}
+
+func (o RefundNotificationAmount) String() string {
+	var ret string
+	if o.Total == nil {
+		ret += "Total:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Total:%v, ", *o.Total)
+	}
+
+	if o.Refund == nil {
+		ret += "Refund:<nil>, "
+	} else {
+		ret += fmt.Sprintf("Refund:%v, ", *o.Refund)
+	}
+
+	if o.PayerTotal == nil {
+		ret += "PayerTotal:<nil>, "
+	} else {
+		ret += fmt.Sprintf("PayerTotal:%v, ", *o.PayerTotal)
+	}
+
+	if o.PayerRefund == nil {
+		ret += "PayerRefund:<nil>"
+	} else {
+		ret += fmt.Sprintf("PayerRefund:%v", *o.PayerRefund)
+	}
+
+	return fmt.Sprintf("RefundNotificationAmount{%s}", ret)
+}
+
+func (o RefundNotificationAmount) Clone() *RefundNotificationAmount {
+	ret := RefundNotificationAmount{}
+
+	if o.Total != nil {
+		ret.Total = new(int64)
+		*ret.Total = *o.Total
+	}
+
+	if o.Refund != nil {
+		ret.Refund = new(int64)
+		*ret.Refund = *o.Refund
+	}
+
+	if o.PayerTotal != nil {
+		ret.PayerTotal = new(int64)
+		*ret.PayerTotal = *o.PayerTotal
+	}
+
+	if o.PayerRefund != nil {
+		ret.PayerRefund = new(int64)
+		*ret.PayerRefund = *o.PayerRefund
+	}
+
+	return &ret
+}
+
 // ReqFundsAccount * `AVAILABLE` - 可用余额, 仅对老资金流商户适用，指定从可用余额账户出资
 type ReqFundsAccount string
 
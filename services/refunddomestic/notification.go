@@ -0,0 +1,10 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package refunddomestic
+
+// 退款结果通知的事件类型，通知内容为 RefundNotification
+const (
+	EventTypeRefundSuccess  = "REFUND.SUCCESS"  // 退款成功
+	EventTypeRefundAbnormal = "REFUND.ABNORMAL" // 退款异常
+	EventTypeRefundClosed   = "REFUND.CLOSED"   // 退款关闭
+)
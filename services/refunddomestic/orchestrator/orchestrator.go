@@ -0,0 +1,559 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package orchestrator 编排境内退款：防止超额退款，并跟踪退款直到进入终态
+//
+// 同一笔订单可以分多次退款，但退款总额不能超过订单金额。Orchestrator 以订单为单位记录已预占的退款金额，
+// 并发发起的退款在申请前即完成额度检查，超出剩余可退金额时返回 *OverRefundError，不会发出请求。
+// 相同的商户退款单号视为同一笔退款，可以安全地重试。
+//
+//	o := orchestrator.NewOrchestrator(&refunddomestic.RefundsApiService{Client: client}, orchestrator.Config{})
+//	refund, err := o.Refund(ctx, orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R001", Amount: 100})
+//	refund, err = o.Track(ctx, "R001")
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// IsTerminal 判断退款是否已处于终态
+//
+// 退款异常（ABNORMAL）同样视为终态：需要商户发起异常退款处理后，退款才会继续进行
+func IsTerminal(status refunddomestic.Status) bool {
+	switch status {
+	case refunddomestic.STATUS_SUCCESS, refunddomestic.STATUS_CLOSED, refunddomestic.STATUS_ABNORMAL:
+		return true
+	}
+	return false
+}
+
+// Refundable 根据订单与该订单已有的退款计算剩余可退金额，单位为分
+//
+// 退款关闭（CLOSED）的退款不占用可退金额，其余状态的退款均按退款金额扣除
+func Refundable(tx *payments.Transaction, refunds []refunddomestic.Refund) (int64, error) {
+	if tx == nil || tx.Amount == nil || tx.Amount.Total == nil {
+		return 0, fmt.Errorf("transaction amount total is required")
+	}
+
+	remaining := *tx.Amount.Total
+	for _, refund := range refunds {
+		if refund.Status != nil && *refund.Status == refunddomestic.STATUS_CLOSED {
+			continue
+		}
+		if refund.Amount == nil || refund.Amount.Refund == nil {
+			return 0, fmt.Errorf("refund amount of %s is required", stringOf(refund.OutRefundNo))
+		}
+		remaining -= *refund.Amount.Refund
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+// OverRefundError 退款金额超过了订单剩余可退金额，退款申请未发出
+type OverRefundError struct {
+	OutTradeNo  string // 商户订单号
+	OutRefundNo string // 商户退款单号
+	Amount      int64  // 本次退款金额
+	Refundable  int64  // 剩余可退金额
+}
+
+// Error 输出 OverRefundError
+func (e *OverRefundError) Error() string {
+	return fmt.Sprintf(
+		"refund %s of %d exceeds refundable amount %d of order %s",
+		e.OutRefundNo, e.Amount, e.Refundable, e.OutTradeNo,
+	)
+}
+
+// AsOverRefundError 判断当前 error 是否为 *OverRefundError（包括被包装的情况），是则返回该错误
+func AsOverRefundError(err error) (*OverRefundError, bool) {
+	var overRefundErr *OverRefundError
+	if errors.As(err, &overRefundErr) {
+		return overRefundErr, true
+	}
+	return nil, false
+}
+
+// IsOverRefundError 判断当前 error 是否为 *OverRefundError（包括被包装的情况）
+func IsOverRefundError(err error) bool {
+	_, ok := AsOverRefundError(err)
+	return ok
+}
+
+// AbnormalHandler 为退款异常的退款提供异常退款处理方式
+//
+// 返回 nil 时不处理，Track 直接返回异常的退款；RefundId、OutRefundNo 与 SubMchid 未设置时由 Orchestrator 填充
+type AbnormalHandler func(
+	ctx context.Context, refund *refunddomestic.Refund,
+) (*refunddomestic.ApplyAbnormalRefundRequest, error)
+
+// Config Orchestrator 配置
+type Config struct {
+	SubMchid        string          // 服务商模式下的子商户号，直连模式下留空
+	InitialInterval time.Duration   // 首次查询退款的间隔，默认 5s
+	MaxInterval     time.Duration   // 查询间隔的上限，默认 5min
+	Multiplier      float64         // 每次查询后间隔的增长倍数，默认 2
+	AbnormalHandler AbnormalHandler // 退款异常时的处理方式，为空时 Track 在退款异常时返回
+}
+
+// 默认配置
+const (
+	DefaultInitialInterval = 5 * time.Second
+	DefaultMaxInterval     = 5 * time.Minute
+	DefaultMultiplier      = 2
+)
+
+func (c Config) withDefaults() Config {
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = DefaultInitialInterval
+	}
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = DefaultMaxInterval
+	}
+	if c.MaxInterval < c.InitialInterval {
+		c.MaxInterval = c.InitialInterval
+	}
+	if c.Multiplier < 1 {
+		c.Multiplier = DefaultMultiplier
+	}
+	return c
+}
+
+// RefundRequest 发起一笔退款所需的信息
+type RefundRequest struct {
+	Transaction  *payments.Transaction           // 原支付订单，需包含 Amount.Total 与 OutTradeNo，包含 TransactionId 时以其申请退款
+	PriorRefunds []refunddomestic.Refund         // 该订单在本 Orchestrator 之外已发起的退款，用于计算剩余可退金额
+	OutRefundNo  string                          // 商户退款单号，相同的退款单号只退一笔
+	Amount       int64                           // 退款金额，单位为分
+	Reason       string                          // 退款原因，选填
+	NotifyUrl    string                          // 退款结果通知地址，选填
+	FundsAccount *refunddomestic.ReqFundsAccount // 退款出资账户，选填
+}
+
+// attempt 一次进行中的退款申请，并发的相同退款共享申请结果
+type attempt struct {
+	done   chan struct{}
+	refund *refunddomestic.Refund
+	err    error
+}
+
+// reservation 一笔退款预占的金额
+type reservation struct {
+	amount  int64
+	attempt *attempt // 非空时退款申请正在进行
+}
+
+// ledger 一笔订单的退款台账
+type ledger struct {
+	total        int64
+	reservations map[string]*reservation // 商户退款单号 -> 预占
+}
+
+func (l *ledger) refundable() int64 {
+	remaining := l.total
+	for _, r := range l.reservations {
+		remaining -= r.amount
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
+// Orchestrator 退款编排器，可以安全地并发使用
+type Orchestrator struct {
+	api    refunddomestic.RefundsAPI
+	config Config
+
+	lock    sync.Mutex
+	ledgers map[string]*ledger                                              // 商户订单号 -> 台账
+	orders  map[string]string                                               // 商户退款单号 -> 商户订单号
+	waiters map[string]map[chan *refunddomestic.RefundNotification]struct{} // 商户退款单号 -> 正在等待通知的 Track
+}
+
+// NewOrchestrator 使用 api 创建一个 Orchestrator
+func NewOrchestrator(api refunddomestic.RefundsAPI, config Config) *Orchestrator {
+	return &Orchestrator{
+		api:     api,
+		config:  config.withDefaults(),
+		ledgers: make(map[string]*ledger),
+		orders:  make(map[string]string),
+		waiters: make(map[string]map[chan *refunddomestic.RefundNotification]struct{}),
+	}
+}
+
+// Refund 在剩余可退金额内发起退款
+//
+// 退款金额超过剩余可退金额时返回 *OverRefundError；相同的 OutRefundNo 重复调用时会以相同参数再次申请，
+// 微信支付保证只退一笔，但金额或订单不同时返回错误。并发的相同退款只发出一次申请并共享结果。
+// 申请被明确拒绝（4xx 且不是 SYSTEM_ERROR）时释放预占的金额；结果不确定时保留预占，请使用相同的 OutRefundNo 重试
+func (o *Orchestrator) Refund(ctx context.Context, req RefundRequest) (*refunddomestic.Refund, error) {
+	if err := idgen.OutRefundNo.Validate(req.OutRefundNo); err != nil {
+		return nil, err
+	}
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("refund amount must be positive, got %d", req.Amount)
+	}
+	outTradeNo, err := orderKey(req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	o.lock.Lock()
+	if err := o.checkOrder(req.OutRefundNo, outTradeNo); err != nil {
+		o.lock.Unlock()
+		return nil, err
+	}
+	l, err := o.ledgerOf(outTradeNo, req.Transaction, req.PriorRefunds)
+	if err != nil {
+		o.lock.Unlock()
+		return nil, err
+	}
+	r, ok := l.reservations[req.OutRefundNo]
+	switch {
+	case ok && r.amount != req.Amount:
+		o.lock.Unlock()
+		return nil, fmt.Errorf(
+			"refund %s already requested with amount %d, got %d", req.OutRefundNo, r.amount, req.Amount,
+		)
+	case ok && r.attempt != nil:
+		a := r.attempt
+		o.lock.Unlock()
+		return a.wait(ctx)
+	case !ok:
+		if refundable := l.refundable(); req.Amount > refundable {
+			o.lock.Unlock()
+			return nil, &OverRefundError{
+				OutTradeNo: outTradeNo, OutRefundNo: req.OutRefundNo, Amount: req.Amount, Refundable: refundable,
+			}
+		}
+		r = &reservation{amount: req.Amount}
+		l.reservations[req.OutRefundNo] = r
+		o.orders[req.OutRefundNo] = outTradeNo
+	}
+	a := &attempt{done: make(chan struct{})}
+	r.attempt = a
+	o.lock.Unlock()
+
+	a.refund, _, a.err = o.api.Create(ctx, o.createRequest(req, l.total))
+
+	o.lock.Lock()
+	r.attempt = nil
+	if isRejected(a.err) || (a.refund != nil && statusOf(a.refund) == refunddomestic.STATUS_CLOSED) {
+		o.release(req.OutRefundNo)
+	}
+	o.lock.Unlock()
+	close(a.done)
+
+	return a.refund, a.err
+}
+
+func (a *attempt) wait(ctx context.Context) (*refunddomestic.Refund, error) {
+	select {
+	case <-a.done:
+		return a.refund, a.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (o *Orchestrator) createRequest(req RefundRequest, total int64) refunddomestic.CreateRequest {
+	tx := req.Transaction
+	create := refunddomestic.CreateRequest{
+		TransactionId: tx.TransactionId,
+		OutRefundNo:   core.String(req.OutRefundNo),
+		FundsAccount:  req.FundsAccount,
+		Amount: &refunddomestic.AmountReq{
+			Refund:   core.Int64(req.Amount),
+			Total:    core.Int64(total),
+			Currency: core.String("CNY"),
+		},
+	}
+	if create.TransactionId == nil {
+		create.OutTradeNo = tx.OutTradeNo
+	}
+	if tx.Amount.Currency != nil {
+		create.Amount.Currency = tx.Amount.Currency
+	}
+	if o.config.SubMchid != "" {
+		create.SubMchid = core.String(o.config.SubMchid)
+	}
+	if req.Reason != "" {
+		create.Reason = core.String(req.Reason)
+	}
+	if req.NotifyUrl != "" {
+		create.NotifyUrl = core.String(req.NotifyUrl)
+	}
+	return create
+}
+
+// ledgerOf 返回订单的台账，并以 refunds 更新台账：新出现的退款计入预占，已关闭的退款释放预占
+//
+// 调用方需持有 o.lock
+func (o *Orchestrator) ledgerOf(
+	outTradeNo string, tx *payments.Transaction, refunds []refunddomestic.Refund,
+) (*ledger, error) {
+	l, ok := o.ledgers[outTradeNo]
+	if !ok {
+		if _, err := Refundable(tx, nil); err != nil {
+			return nil, err
+		}
+		l = &ledger{total: *tx.Amount.Total, reservations: make(map[string]*reservation)}
+		o.ledgers[outTradeNo] = l
+	}
+
+	for _, refund := range refunds {
+		if refund.OutRefundNo == nil {
+			return nil, fmt.Errorf("out_refund_no of prior refund is required")
+		}
+		outRefundNo := *refund.OutRefundNo
+		if err := o.checkOrder(outRefundNo, outTradeNo); err != nil {
+			return nil, err
+		}
+		if statusOf(&refund) == refunddomestic.STATUS_CLOSED {
+			if r, ok := l.reservations[outRefundNo]; ok && r.attempt == nil {
+				o.release(outRefundNo)
+			}
+			continue
+		}
+		if _, ok := l.reservations[outRefundNo]; ok {
+			continue
+		}
+		if refund.Amount == nil || refund.Amount.Refund == nil {
+			return nil, fmt.Errorf("refund amount of %s is required", outRefundNo)
+		}
+		l.reservations[outRefundNo] = &reservation{amount: *refund.Amount.Refund}
+		o.orders[outRefundNo] = outTradeNo
+	}
+	return l, nil
+}
+
+// checkOrder 校验商户退款单号没有被用于其他订单，调用方需持有 o.lock
+//
+// 商户退款单号在商户号下唯一，若允许其属于两笔订单，释放预占时会释放错误的台账
+func (o *Orchestrator) checkOrder(outRefundNo, outTradeNo string) error {
+	if owner, ok := o.orders[outRefundNo]; ok && owner != outTradeNo {
+		return fmt.Errorf("refund %s already belongs to order %s, got order %s", outRefundNo, owner, outTradeNo)
+	}
+	return nil
+}
+
+// release 释放退款预占的金额，调用方需持有 o.lock
+func (o *Orchestrator) release(outRefundNo string) {
+	outTradeNo, ok := o.orders[outRefundNo]
+	if !ok {
+		return
+	}
+	delete(o.orders, outRefundNo)
+	if l, ok := o.ledgers[outTradeNo]; ok {
+		delete(l.reservations, outRefundNo)
+	}
+}
+
+// Refundable 返回订单在本 Orchestrator 中记录的剩余可退金额，订单尚无台账时返回 false
+func (o *Orchestrator) Refundable(outTradeNo string) (int64, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	l, ok := o.ledgers[outTradeNo]
+	if !ok {
+		return 0, false
+	}
+	return l.refundable(), true
+}
+
+// Forget 移除订单的台账，例如订单的退款均已完成时
+//
+// 移除后再次对该订单发起退款，需要通过 RefundRequest.PriorRefunds 提供已有的退款
+func (o *Orchestrator) Forget(outTradeNo string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	l, ok := o.ledgers[outTradeNo]
+	if !ok {
+		return
+	}
+	for outRefundNo := range l.reservations {
+		delete(o.orders, outRefundNo)
+	}
+	delete(o.ledgers, outTradeNo)
+}
+
+// Track 跟踪退款直到进入终态，返回退款的最终状态
+//
+// 以退避的间隔查询退款；期间收到该退款的终态通知（见 Notify）时立即重新查询。
+// 退款关闭时释放预占的金额。退款异常且配置了 AbnormalHandler 时，发起异常退款处理并继续跟踪，
+// 此后退款仍可能短暂地处于异常状态，请为 ctx 设置超时。ctx 结束时返回最后一次查询到的退款与 ctx.Err()
+func (o *Orchestrator) Track(ctx context.Context, outRefundNo string) (*refunddomestic.Refund, error) {
+	notified := o.register(outRefundNo)
+	defer o.unregister(outRefundNo, notified)
+
+	var last *refunddomestic.Refund
+	applied := false
+	interval := o.config.InitialInterval
+	for {
+		refund, err := o.query(ctx, outRefundNo)
+		if err == nil {
+			last = refund
+			status := statusOf(refund)
+			switch {
+			case status == refunddomestic.STATUS_CLOSED:
+				o.lock.Lock()
+				o.release(outRefundNo)
+				o.lock.Unlock()
+				return refund, nil
+			case status == refunddomestic.STATUS_ABNORMAL && o.config.AbnormalHandler != nil:
+				if !applied {
+					handled, err := o.applyAbnormal(ctx, refund)
+					if err != nil || !handled {
+						return refund, err
+					}
+					applied = true
+				}
+			case IsTerminal(status):
+				return refund, nil
+			}
+		} else if ctx.Err() != nil {
+			return last, ctx.Err()
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-notified:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+			interval = time.Duration(float64(interval) * o.config.Multiplier)
+			if interval > o.config.MaxInterval {
+				interval = o.config.MaxInterval
+			}
+		}
+	}
+}
+
+// applyAbnormal 使用 AbnormalHandler 发起异常退款处理，返回是否已发起
+func (o *Orchestrator) applyAbnormal(ctx context.Context, refund *refunddomestic.Refund) (bool, error) {
+	req, err := o.config.AbnormalHandler(ctx, refund)
+	if err != nil || req == nil {
+		return false, err
+	}
+
+	apply := *req
+	if apply.RefundId == nil {
+		apply.RefundId = refund.RefundId
+	}
+	if apply.OutRefundNo == nil {
+		apply.OutRefundNo = refund.OutRefundNo
+	}
+	if apply.SubMchid == nil && o.config.SubMchid != "" {
+		apply.SubMchid = core.String(o.config.SubMchid)
+	}
+	if _, _, err = o.api.ApplyAbnormalRefund(ctx, apply); err != nil {
+		return false, fmt.Errorf("apply abnormal refund %s failed: %w", stringOf(refund.OutRefundNo), err)
+	}
+	return true, nil
+}
+
+func (o *Orchestrator) query(ctx context.Context, outRefundNo string) (*refunddomestic.Refund, error) {
+	req := refunddomestic.QueryByOutRefundNoRequest{OutRefundNo: core.String(outRefundNo)}
+	if o.config.SubMchid != "" {
+		req.SubMchid = core.String(o.config.SubMchid)
+	}
+	refund, _, err := o.api.QueryByOutRefundNo(ctx, req)
+	return refund, err
+}
+
+// Notify 将退款结果通知交给正在跟踪该退款的 Track，返回是否有 Track 在跟踪该退款
+//
+// 请在通知验签并解密后调用。退款关闭的通知会立即释放预占的金额
+func (o *Orchestrator) Notify(n *refunddomestic.RefundNotification) bool {
+	if n == nil || n.OutRefundNo == nil || n.RefundStatus == nil || !IsTerminal(*n.RefundStatus) {
+		return false
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if *n.RefundStatus == refunddomestic.STATUS_CLOSED {
+		o.release(*n.OutRefundNo)
+	}
+	waiters := o.waiters[*n.OutRefundNo]
+	for notified := range waiters {
+		select {
+		case notified <- n:
+		default:
+			// 已经收到过通知
+		}
+	}
+	return len(waiters) > 0
+}
+
+func (o *Orchestrator) register(outRefundNo string) chan *refunddomestic.RefundNotification {
+	notified := make(chan *refunddomestic.RefundNotification, 1)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.waiters[outRefundNo] == nil {
+		o.waiters[outRefundNo] = make(map[chan *refunddomestic.RefundNotification]struct{})
+	}
+	o.waiters[outRefundNo][notified] = struct{}{}
+	return notified
+}
+
+func (o *Orchestrator) unregister(outRefundNo string, notified chan *refunddomestic.RefundNotification) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.waiters[outRefundNo], notified)
+	if len(o.waiters[outRefundNo]) == 0 {
+		delete(o.waiters, outRefundNo)
+	}
+}
+
+// isRejected 判断退款申请是否被明确拒绝，被拒绝的申请不会产生退款
+func isRejected(err error) bool {
+	var apiErr *core.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= http.StatusBadRequest && apiErr.StatusCode < http.StatusInternalServerError &&
+		apiErr.Code != "SYSTEM_ERROR"
+}
+
+// orderKey 返回订单台账的键，即商户订单号
+//
+// 台账只以商户订单号为键：若允许以微信支付订单号代替，同一笔订单分别以两种单号退款时会产生两份独立的台账，
+// 超额退款检查随之失效
+func orderKey(tx *payments.Transaction) (string, error) {
+	if tx == nil {
+		return "", fmt.Errorf("transaction is required")
+	}
+	if tx.OutTradeNo == nil || *tx.OutTradeNo == "" {
+		return "", fmt.Errorf("out_trade_no of transaction is required")
+	}
+	return *tx.OutTradeNo, nil
+}
+
+func statusOf(refund *refunddomestic.Refund) refunddomestic.Status {
+	if refund == nil || refund.Status == nil {
+		return ""
+	}
+	return *refund.Status
+}
+
+func stringOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
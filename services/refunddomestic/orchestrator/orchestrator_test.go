@@ -0,0 +1,404 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package orchestrator_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic/orchestrator"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic/refunddomestictest"
+)
+
+var fastConfig = orchestrator.Config{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func transaction(total int64) *payments.Transaction {
+	return &payments.Transaction{
+		OutTradeNo:    core.String("1217752501201407033233368018"),
+		TransactionId: core.String("4200000000000000000000000001"),
+		Amount:        &payments.TransactionAmount{Total: core.Int64(total), Currency: core.String("CNY")},
+	}
+}
+
+func refund(outRefundNo string, amount int64, status refunddomestic.Status) refunddomestic.Refund {
+	return refunddomestic.Refund{
+		RefundId:    core.String("50000000382019052709732678859"),
+		OutRefundNo: core.String(outRefundNo),
+		OutTradeNo:  core.String("1217752501201407033233368018"),
+		Status:      status.Ptr(),
+		Amount:      &refunddomestic.Amount{Refund: core.Int64(amount)},
+	}
+}
+
+// acceptingAPI 受理所有退款申请，退款状态为 PROCESSING
+func acceptingAPI() *refunddomestictest.FakeRefundsAPI {
+	fake := &refunddomestictest.FakeRefundsAPI{}
+	fake.CreateFunc = func(
+		ctx context.Context, req refunddomestic.CreateRequest,
+	) (*refunddomestic.Refund, *core.APIResult, error) {
+		r := refund(*req.OutRefundNo, *req.Amount.Refund, refunddomestic.STATUS_PROCESSING)
+		return &r, nil, nil
+	}
+	return fake
+}
+
+func TestRefundable(t *testing.T) {
+	remaining, err := orchestrator.Refundable(transaction(100), []refunddomestic.Refund{
+		refund("R1", 30, refunddomestic.STATUS_SUCCESS),
+		refund("R2", 20, refunddomestic.STATUS_PROCESSING),
+		refund("R3", 50, refunddomestic.STATUS_CLOSED),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), remaining)
+
+	_, err = orchestrator.Refundable(&payments.Transaction{}, nil)
+	assert.Error(t, err)
+}
+
+func TestOrchestrator_Refund(t *testing.T) {
+	fake := acceptingAPI()
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{SubMchid: "1900000109"})
+	tx := transaction(100)
+
+	r, err := o.Refund(context.Background(), orchestrator.RefundRequest{
+		Transaction:  tx,
+		PriorRefunds: []refunddomestic.Refund{refund("R0", 40, refunddomestic.STATUS_SUCCESS)},
+		OutRefundNo:  "R1",
+		Amount:       50,
+		Reason:       "商品已售完",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, refunddomestic.STATUS_PROCESSING, *r.Status)
+	fake.AssertCalledWith(t, "Create", refunddomestic.CreateRequest{
+		SubMchid:      core.String("1900000109"),
+		TransactionId: core.String("4200000000000000000000000001"),
+		OutRefundNo:   core.String("R1"),
+		Reason:        core.String("商品已售完"),
+		Amount:        &refunddomestic.AmountReq{Refund: core.Int64(50), Total: core.Int64(100), Currency: core.String("CNY")},
+	})
+
+	remaining, ok := o.Refundable(*tx.OutTradeNo)
+	require.True(t, ok)
+	assert.Equal(t, int64(10), remaining)
+
+	// 超出剩余可退金额时不发出请求
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R2", Amount: 20})
+	overRefundErr, ok := orchestrator.AsOverRefundError(err)
+	require.True(t, ok)
+	assert.Equal(t, int64(10), overRefundErr.Refundable)
+	fake.AssertCallCount(t, "Create", 1)
+
+	// 相同退款单号可以重试，金额不同时拒绝
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 50})
+	require.NoError(t, err)
+	fake.AssertCallCount(t, "Create", 2)
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 10})
+	assert.Error(t, err)
+	assert.False(t, orchestrator.IsOverRefundError(err))
+
+	// 退款单号不符合格式要求时不发出请求
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "退款1", Amount: 1})
+	assert.Error(t, err)
+	fake.AssertCallCount(t, "Create", 2)
+
+	// 已有退款关闭后释放可退金额
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{
+		Transaction:  tx,
+		PriorRefunds: []refunddomestic.Refund{refund("R0", 40, refunddomestic.STATUS_CLOSED)},
+		OutRefundNo:  "R2",
+		Amount:       20,
+	})
+	require.NoError(t, err)
+	remaining, _ = o.Refundable(*tx.OutTradeNo)
+	assert.Equal(t, int64(30), remaining)
+}
+
+func TestOrchestrator_RefundSameOrderByDifferentIDs(t *testing.T) {
+	fake := acceptingAPI()
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{})
+	tx := transaction(100)
+
+	// 只有微信支付订单号的订单无法与以商户订单号发起的退款共用台账，直接拒绝
+	byTransactionID := &payments.Transaction{TransactionId: tx.TransactionId, Amount: tx.Amount}
+	_, err := o.Refund(context.Background(), orchestrator.RefundRequest{
+		Transaction: byTransactionID, OutRefundNo: "R1", Amount: 80,
+	})
+	assert.EqualError(t, err, "out_trade_no of transaction is required")
+	fake.AssertCallCount(t, "Create", 0)
+
+	// 同一笔订单以完整信息与仅商户订单号发起的退款共用一份台账
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 80})
+	require.NoError(t, err)
+	byOutTradeNo := &payments.Transaction{OutTradeNo: tx.OutTradeNo, Amount: tx.Amount}
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{
+		Transaction: byOutTradeNo, OutRefundNo: "R2", Amount: 80,
+	})
+	overRefundErr, ok := orchestrator.AsOverRefundError(err)
+	require.True(t, ok)
+	assert.Equal(t, int64(20), overRefundErr.Refundable)
+	fake.AssertCallCount(t, "Create", 1)
+}
+
+func TestOrchestrator_RefundConcurrently(t *testing.T) {
+	fake := acceptingAPI()
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{})
+	tx := transaction(100)
+
+	var wg sync.WaitGroup
+	var succeeded, overRefunded int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := o.Refund(context.Background(), orchestrator.RefundRequest{
+				Transaction: tx, OutRefundNo: "R" + string(rune('A'+i)), Amount: 30,
+			})
+			if err == nil {
+				atomic.AddInt32(&succeeded, 1)
+			} else if orchestrator.IsOverRefundError(err) {
+				atomic.AddInt32(&overRefunded, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), succeeded)
+	assert.Equal(t, int32(17), overRefunded)
+	fake.AssertCallCount(t, "Create", 3)
+}
+
+func TestOrchestrator_RefundSameOutRefundNoConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	fake := &refunddomestictest.FakeRefundsAPI{}
+	fake.CreateFunc = func(
+		ctx context.Context, req refunddomestic.CreateRequest,
+	) (*refunddomestic.Refund, *core.APIResult, error) {
+		<-release
+		r := refund(*req.OutRefundNo, *req.Amount.Refund, refunddomestic.STATUS_PROCESSING)
+		return &r, nil, nil
+	}
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := o.Refund(context.Background(), orchestrator.RefundRequest{
+				Transaction: transaction(100), OutRefundNo: "R1", Amount: 60,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "R1", *r.OutRefundNo)
+		}()
+	}
+	assert.Eventually(t, func() bool { return fake.CallCount("Create") == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	fake.AssertCallCount(t, "Create", 1)
+}
+
+func TestOrchestrator_RefundSameOutRefundNoForDifferentOrders(t *testing.T) {
+	fake := acceptingAPI()
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{})
+	tx := transaction(100)
+	other := &payments.Transaction{
+		OutTradeNo: core.String("1217752501201407033233368019"), Amount: tx.Amount,
+	}
+
+	_, err := o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 60})
+	require.NoError(t, err)
+
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: other, OutRefundNo: "R1", Amount: 60})
+	assert.EqualError(
+		t, err, "refund R1 already belongs to order 1217752501201407033233368018, got order 1217752501201407033233368019",
+	)
+	_, err = o.Refund(context.Background(), orchestrator.RefundRequest{
+		Transaction:  other,
+		PriorRefunds: []refunddomestic.Refund{refund("R1", 60, refunddomestic.STATUS_CLOSED)},
+		OutRefundNo:  "R2",
+		Amount:       10,
+	})
+	assert.Error(t, err)
+	fake.AssertCallCount(t, "Create", 1)
+
+	// 原订单的预占不受影响
+	remaining, _ := o.Refundable(*tx.OutTradeNo)
+	assert.Equal(t, int64(40), remaining)
+}
+
+func TestOrchestrator_RefundFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		released bool
+	}{
+		{"rejected", &core.APIError{StatusCode: http.StatusForbidden, Code: "NOT_ENOUGH"}, true},
+		{"system error", &core.APIError{StatusCode: http.StatusInternalServerError, Code: "SYSTEM_ERROR"}, false},
+		{"network error", context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := (&refunddomestictest.FakeRefundsAPI{}).CreateReturns(nil, tt.err)
+			o := orchestrator.NewOrchestrator(fake, orchestrator.Config{})
+			tx := transaction(100)
+
+			_, err := o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 100})
+			assert.Equal(t, tt.err, err)
+
+			remaining, _ := o.Refundable(*tx.OutTradeNo)
+			if tt.released {
+				assert.Equal(t, int64(100), remaining)
+			} else {
+				assert.Equal(t, int64(0), remaining)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_Forget(t *testing.T) {
+	o := orchestrator.NewOrchestrator(acceptingAPI(), orchestrator.Config{})
+	tx := transaction(100)
+
+	_, err := o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 100})
+	require.NoError(t, err)
+	o.Forget(*tx.OutTradeNo)
+
+	_, ok := o.Refundable(*tx.OutTradeNo)
+	assert.False(t, ok)
+}
+
+// queryReturns 依次返回 statuses 中的退款状态，最后一个状态保持不变
+func queryReturns(fake *refunddomestictest.FakeRefundsAPI, statuses ...refunddomestic.Status) {
+	var count int32
+	fake.QueryByOutRefundNoFunc = func(
+		ctx context.Context, req refunddomestic.QueryByOutRefundNoRequest,
+	) (*refunddomestic.Refund, *core.APIResult, error) {
+		i := int(atomic.AddInt32(&count, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		r := refund(*req.OutRefundNo, 100, statuses[i])
+		return &r, nil, nil
+	}
+}
+
+func TestOrchestrator_Track(t *testing.T) {
+	fake := acceptingAPI()
+	queryReturns(fake, refunddomestic.STATUS_PROCESSING, refunddomestic.STATUS_PROCESSING, refunddomestic.STATUS_SUCCESS)
+	o := orchestrator.NewOrchestrator(fake, fastConfig)
+
+	r, err := o.Track(context.Background(), "R1")
+	require.NoError(t, err)
+	assert.Equal(t, refunddomestic.STATUS_SUCCESS, *r.Status)
+	fake.AssertCallCount(t, "QueryByOutRefundNo", 3)
+}
+
+func TestOrchestrator_TrackClosed(t *testing.T) {
+	fake := acceptingAPI()
+	queryReturns(fake, refunddomestic.STATUS_CLOSED)
+	o := orchestrator.NewOrchestrator(fake, fastConfig)
+	tx := transaction(100)
+
+	_, err := o.Refund(context.Background(), orchestrator.RefundRequest{Transaction: tx, OutRefundNo: "R1", Amount: 100})
+	require.NoError(t, err)
+
+	r, err := o.Track(context.Background(), "R1")
+	require.NoError(t, err)
+	assert.Equal(t, refunddomestic.STATUS_CLOSED, *r.Status)
+
+	remaining, _ := o.Refundable(*tx.OutTradeNo)
+	assert.Equal(t, int64(100), remaining)
+}
+
+func TestOrchestrator_TrackAbnormal(t *testing.T) {
+	fake := acceptingAPI()
+	queryReturns(fake, refunddomestic.STATUS_ABNORMAL)
+	o := orchestrator.NewOrchestrator(fake, fastConfig)
+
+	// 未配置 AbnormalHandler 时直接返回
+	r, err := o.Track(context.Background(), "R1")
+	require.NoError(t, err)
+	assert.Equal(t, refunddomestic.STATUS_ABNORMAL, *r.Status)
+
+	fake = acceptingAPI()
+	queryReturns(fake, refunddomestic.STATUS_ABNORMAL, refunddomestic.STATUS_PROCESSING, refunddomestic.STATUS_SUCCESS)
+	fake.ApplyAbnormalRefundReturns(nil, nil)
+	config := fastConfig
+	config.SubMchid = "1900000109"
+	config.AbnormalHandler = func(
+		ctx context.Context, refund *refunddomestic.Refund,
+	) (*refunddomestic.ApplyAbnormalRefundRequest, error) {
+		return &refunddomestic.ApplyAbnormalRefundRequest{
+			Type:        refunddomestic.ABNORMALREFUNDTYPE_USER_BANK_CARD.Ptr(),
+			BankType:    core.String("ICBC_DEBIT"),
+			BankAccount: core.String("d+xT+MQCvrLHUVDWv/8MR/dB7TkXLVfSrUxMPZy6jWWYzpRrEEaYQE8ZRGYoeorwC+w=="),
+			RealName:    core.String("UPgQcZSdq3zOayJwZ5XLrHY2dZU1W2Cd"),
+		}, nil
+	}
+	o = orchestrator.NewOrchestrator(fake, config)
+
+	r, err = o.Track(context.Background(), "R1")
+	require.NoError(t, err)
+	assert.Equal(t, refunddomestic.STATUS_SUCCESS, *r.Status)
+	fake.AssertCallCount(t, "ApplyAbnormalRefund", 1)
+	req, _ := fake.LastRequest("ApplyAbnormalRefund")
+	apply := req.(refunddomestic.ApplyAbnormalRefundRequest)
+	assert.Equal(t, "50000000382019052709732678859", *apply.RefundId)
+	assert.Equal(t, "R1", *apply.OutRefundNo)
+	assert.Equal(t, "1900000109", *apply.SubMchid)
+}
+
+func TestOrchestrator_Notify(t *testing.T) {
+	fake := acceptingAPI()
+	var terminal int32
+	fake.QueryByOutRefundNoFunc = func(
+		ctx context.Context, req refunddomestic.QueryByOutRefundNoRequest,
+	) (*refunddomestic.Refund, *core.APIResult, error) {
+		status := refunddomestic.STATUS_PROCESSING
+		if atomic.LoadInt32(&terminal) == 1 {
+			status = refunddomestic.STATUS_SUCCESS
+		}
+		r := refund(*req.OutRefundNo, 100, status)
+		return &r, nil, nil
+	}
+	o := orchestrator.NewOrchestrator(fake, orchestrator.Config{InitialInterval: time.Hour})
+
+	done := make(chan *refunddomestic.Refund)
+	go func() {
+		r, err := o.Track(context.Background(), "R1")
+		assert.NoError(t, err)
+		done <- r
+	}()
+
+	notification := &refunddomestic.RefundNotification{
+		OutRefundNo:  core.String("R1"),
+		RefundStatus: refunddomestic.STATUS_SUCCESS.Ptr(),
+	}
+	assert.Eventually(t, func() bool {
+		atomic.StoreInt32(&terminal, 1)
+		return o.Notify(notification)
+	}, time.Second, time.Millisecond)
+
+	select {
+	case r := <-done:
+		assert.Equal(t, refunddomestic.STATUS_SUCCESS, *r.Status)
+	case <-time.After(time.Second):
+		t.Fatal("Track is not woken up by Notify")
+	}
+
+	// 非终态的通知被忽略
+	assert.False(t, o.Notify(&refunddomestic.RefundNotification{
+		OutRefundNo:  core.String("R1"),
+		RefundStatus: refunddomestic.STATUS_PROCESSING.Ptr(),
+	}))
+}
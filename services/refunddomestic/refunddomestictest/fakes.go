@@ -0,0 +1,82 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package refunddomestictest 提供 refunddomestic 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package refunddomestictest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeRefundsAPI refunddomestic.RefundsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeRefundsAPI struct {
+	servicetest.Recorder
+
+	ApplyAbnormalRefundFunc func(context.Context, refunddomestic.ApplyAbnormalRefundRequest) (*refunddomestic.Refund, *core.APIResult, error)
+	CreateFunc              func(context.Context, refunddomestic.CreateRequest) (*refunddomestic.Refund, *core.APIResult, error)
+	QueryByOutRefundNoFunc  func(context.Context, refunddomestic.QueryByOutRefundNoRequest) (*refunddomestic.Refund, *core.APIResult, error)
+}
+
+var _ refunddomestic.RefundsAPI = (*FakeRefundsAPI)(nil)
+
+// ApplyAbnormalRefund 记录调用并返回 ApplyAbnormalRefundFunc 的结果
+func (f *FakeRefundsAPI) ApplyAbnormalRefund(ctx context.Context, req refunddomestic.ApplyAbnormalRefundRequest) (resp *refunddomestic.Refund, result *core.APIResult, err error) {
+	f.Record("ApplyAbnormalRefund", req)
+	if f.ApplyAbnormalRefundFunc == nil {
+		err = servicetest.NotStubbed("RefundsAPI", "ApplyAbnormalRefund")
+		return
+	}
+	return f.ApplyAbnormalRefundFunc(ctx, req)
+}
+
+// ApplyAbnormalRefundReturns 设置 ApplyAbnormalRefund 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRefundsAPI) ApplyAbnormalRefundReturns(resp *refunddomestic.Refund, err error) *FakeRefundsAPI {
+	f.ApplyAbnormalRefundFunc = func(context.Context, refunddomestic.ApplyAbnormalRefundRequest) (*refunddomestic.Refund, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// Create 记录调用并返回 CreateFunc 的结果
+func (f *FakeRefundsAPI) Create(ctx context.Context, req refunddomestic.CreateRequest) (resp *refunddomestic.Refund, result *core.APIResult, err error) {
+	f.Record("Create", req)
+	if f.CreateFunc == nil {
+		err = servicetest.NotStubbed("RefundsAPI", "Create")
+		return
+	}
+	return f.CreateFunc(ctx, req)
+}
+
+// CreateReturns 设置 Create 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRefundsAPI) CreateReturns(resp *refunddomestic.Refund, err error) *FakeRefundsAPI {
+	f.CreateFunc = func(context.Context, refunddomestic.CreateRequest) (*refunddomestic.Refund, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryByOutRefundNo 记录调用并返回 QueryByOutRefundNoFunc 的结果
+func (f *FakeRefundsAPI) QueryByOutRefundNo(ctx context.Context, req refunddomestic.QueryByOutRefundNoRequest) (resp *refunddomestic.Refund, result *core.APIResult, err error) {
+	f.Record("QueryByOutRefundNo", req)
+	if f.QueryByOutRefundNoFunc == nil {
+		err = servicetest.NotStubbed("RefundsAPI", "QueryByOutRefundNo")
+		return
+	}
+	return f.QueryByOutRefundNoFunc(ctx, req)
+}
+
+// QueryByOutRefundNoReturns 设置 QueryByOutRefundNo 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRefundsAPI) QueryByOutRefundNoReturns(resp *refunddomestic.Refund, err error) *FakeRefundsAPI {
+	f.QueryByOutRefundNoFunc = func(context.Context, refunddomestic.QueryByOutRefundNoRequest) (*refunddomestic.Refund, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package refunddomestic
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 AmountReq 的字段
+func (o AmountReq) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AmountReq", opts...)
+	c.Required("refund", o.Refund != nil)
+	c.Range("refund", o.Refund, 1, 0)
+	for i, item := range o.From {
+		c.Nested(validation.Index("from", i), item.Validate(opts...))
+	}
+	c.Required("total", o.Total != nil)
+	c.Range("total", o.Total, 1, 0)
+	c.Required("currency", o.Currency != nil)
+	c.Pattern("currency", o.Currency, "^[A-Z]{3}$")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ApplyAbnormalRefundRequest 的字段
+func (o ApplyAbnormalRefundRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ApplyAbnormalRefundRequest", opts...)
+	c.Required("refund_id", o.RefundId != nil)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("out_refund_no", o.OutRefundNo != nil)
+	c.ID("out_refund_no", o.OutRefundNo, idgen.OutRefundNo)
+	c.Required("type", o.Type != nil)
+	c.Enum("type", (*string)(o.Type), "USER_BANK_CARD", "MERCHANT_BANK_CARD")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateRequest 的字段
+func (o CreateRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Length("transaction_id", o.TransactionId, 0, 32)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("out_refund_no", o.OutRefundNo != nil)
+	c.ID("out_refund_no", o.OutRefundNo, idgen.OutRefundNo)
+	c.Length("reason", o.Reason, 0, 80)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	c.Enum("funds_account", (*string)(o.FundsAccount), "AVAILABLE")
+	c.Required("amount", o.Amount != nil)
+	if o.Amount != nil {
+		c.Nested("amount", o.Amount.Validate(opts...))
+	}
+	for i, item := range o.GoodsDetail {
+		c.Nested(validation.Index("goods_detail", i), item.Validate(opts...))
+	}
+	c.ExactlyOne([]string{"transaction_id", "out_trade_no"}, o.TransactionId != nil, o.OutTradeNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 FundsFromItem 的字段
+func (o FundsFromItem) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("FundsFromItem", opts...)
+	c.Required("account", o.Account != nil)
+	c.Enum("account", (*string)(o.Account), "AVAILABLE", "UNAVAILABLE")
+	c.Required("amount", o.Amount != nil)
+	c.Range("amount", o.Amount, 1, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GoodsDetail 的字段
+func (o GoodsDetail) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GoodsDetail", opts...)
+	c.Required("merchant_goods_id", o.MerchantGoodsId != nil)
+	c.Required("unit_price", o.UnitPrice != nil)
+	c.Required("refund_amount", o.RefundAmount != nil)
+	c.Required("refund_quantity", o.RefundQuantity != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryByOutRefundNoRequest 的字段
+func (o QueryByOutRefundNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryByOutRefundNoRequest", opts...)
+	c.Required("out_refund_no", o.OutRefundNo != nil)
+	c.ID("out_refund_no", o.OutRefundNo, idgen.OutRefundNo)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
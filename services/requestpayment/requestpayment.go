@@ -0,0 +1,165 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package requestpayment 生成客户端调起支付所需的参数
+//
+// 预支付交易会话标识（prepay_id）、H5 支付跳转链接与 Native 二维码链接的有效期均为 2 小时，用户在有效期内重新发起支付时，
+// 无需重新下单，使用本包为已有的 prepay_id 重新签名即可。直连商户与服务商均使用 core.Client 中配置的商户私钥签名。
+//
+//	builder := requestpayment.Builder{Client: client}
+//	params, err := builder.Jsapi(ctx, appid, prepayId)
+package requestpayment
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/utils"
+)
+
+// JsapiRequestPayment JSAPI 与小程序调起支付的参数，JSON 字段名与 WeixinJSBridge.invoke("getBrandWCPayRequest") 及
+// wx.requestPayment 的参数一致
+type JsapiRequestPayment struct {
+	// 应用ID
+	Appid *string `json:"appId"`
+	// 时间戳
+	TimeStamp *string `json:"timeStamp"`
+	// 随机字符串
+	NonceStr *string `json:"nonceStr"`
+	// 订单详情扩展字符串
+	Package *string `json:"package"`
+	// 签名方式
+	SignType *string `json:"signType"`
+	// 签名
+	PaySign *string `json:"paySign"`
+}
+
+// AppRequestPayment APP 调起支付的参数，JSON 字段名与 OpenSDK 中 PayReq 的参数一致
+type AppRequestPayment struct {
+	// 应用ID
+	Appid *string `json:"appid"`
+	// 商户号
+	PartnerId *string `json:"partnerid"` // revive:disable-line:var-naming
+	// 预支付交易会话标识
+	PrepayId *string `json:"prepayid"` // revive:disable-line:var-naming
+	// 订单详情扩展字符串
+	Package *string `json:"package"`
+	// 随机字符串
+	NonceStr *string `json:"noncestr"`
+	// 时间戳
+	TimeStamp *string `json:"timestamp"`
+	// 签名
+	Sign *string `json:"sign"`
+}
+
+// Builder 调起支付参数生成器
+type Builder struct {
+	Client *core.Client
+}
+
+// Jsapi 生成 JSAPI 或小程序调起支付的参数
+//
+// appid 为下单时的 appid；服务商模式下为 sp_appid 或 sub_appid，需与用户 openid 所属的应用一致
+func (b *Builder) Jsapi(ctx context.Context, appid, prepayId string) (*JsapiRequestPayment, error) {
+	if appid == "" || prepayId == "" {
+		return nil, fmt.Errorf("appid and prepayId are required to generate request for payment")
+	}
+
+	timeStamp, nonce, err := b.timeStampAndNonce()
+	if err != nil {
+		return nil, err
+	}
+	pkg := "prepay_id=" + prepayId
+	message := fmt.Sprintf("%s\n%s\n%s\n%s\n", appid, timeStamp, nonce, pkg)
+	signatureResult, err := b.Client.Sign(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("generate sign for payment err:%s", err.Error())
+	}
+
+	return &JsapiRequestPayment{
+		Appid:     core.String(appid),
+		TimeStamp: core.String(timeStamp),
+		NonceStr:  core.String(nonce),
+		Package:   core.String(pkg),
+		SignType:  core.String("RSA"),
+		PaySign:   core.String(signatureResult.Signature),
+	}, nil
+}
+
+// App 生成 APP 调起支付的参数
+//
+// appid 为下单时的 appid；服务商模式下为 sp_appid 或 sub_appid。
+// partnerId 在直连模式下为商户号，服务商模式下为子商户号；为空时使用签名所用的商户号
+func (b *Builder) App(ctx context.Context, appid, partnerId, prepayId string) (*AppRequestPayment, error) {
+	if appid == "" || prepayId == "" {
+		return nil, fmt.Errorf("appid and prepayId are required to generate request for payment")
+	}
+
+	timeStamp, nonce, err := b.timeStampAndNonce()
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("%s\n%s\n%s\n%s\n", appid, timeStamp, nonce, prepayId)
+	signatureResult, err := b.Client.Sign(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("generate sign for payment err:%s", err.Error())
+	}
+	if partnerId == "" {
+		partnerId = signatureResult.MchID
+	}
+
+	return &AppRequestPayment{
+		Appid:     core.String(appid),
+		PartnerId: core.String(partnerId),
+		PrepayId:  core.String(prepayId),
+		Package:   core.String("Sign=WXPay"),
+		NonceStr:  core.String(nonce),
+		TimeStamp: core.String(timeStamp),
+		Sign:      core.String(signatureResult.Signature),
+	}, nil
+}
+
+func (b *Builder) timeStampAndNonce() (timeStamp, nonce string, err error) {
+	nonce, err = utils.GenerateNonce()
+	if err != nil {
+		return "", "", fmt.Errorf("generate request for payment err:%s", err.Error())
+	}
+	return strconv.FormatInt(b.Client.Clock().Now().Unix(), 10), nonce, nil
+}
+
+// H5RedirectUrl 在 H5 支付跳转链接 h5Url 后拼接支付完成后返回的页面 redirectUrl
+//
+// redirectUrl 会被 URL 编码，其域名需与商户在商户平台配置的 H5 支付域名一致
+func H5RedirectUrl(h5Url, redirectUrl string) (string, error) {
+	u, err := url.Parse(h5Url)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid h5_url `%s`", h5Url)
+	}
+	r, err := url.Parse(redirectUrl)
+	if err != nil || (r.Scheme != "http" && r.Scheme != "https") || r.Host == "" {
+		return "", fmt.Errorf("invalid redirect_url `%s`", redirectUrl)
+	}
+	if u.Query().Get("redirect_url") != "" {
+		return "", fmt.Errorf("h5_url `%s` already contains redirect_url", h5Url)
+	}
+
+	separator := "?"
+	if strings.Contains(h5Url, "?") {
+		separator = "&"
+	}
+	return h5Url + separator + "redirect_url=" + url.QueryEscape(redirectUrl), nil
+}
+
+// NativeCodeUrl 校验 Native 支付的二维码链接 codeUrl，并原样返回
+//
+// 二维码链接无需签名，有效期内可以直接重新生成二维码供用户扫码支付
+func NativeCodeUrl(codeUrl string) (string, error) {
+	u, err := url.Parse(codeUrl)
+	if err != nil || u.Scheme != "weixin" || u.Host != "wxpay" {
+		return "", fmt.Errorf("invalid code_url `%s`", codeUrl)
+	}
+	return codeUrl, nil
+}
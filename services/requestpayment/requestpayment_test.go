@@ -0,0 +1,110 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package requestpayment_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/core/option"
+	"github.com/jemuri/wechatpay-go/services/requestpayment"
+)
+
+func newTestBuilder(t *testing.T) (*requestpayment.Builder, *rsa.PublicKey) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	client, err := core.NewClient(
+		context.Background(),
+		option.WithMerchantCredential("1900009191", "SERIAL", privateKey),
+		option.WithoutValidator(),
+		option.WithClock(clock.Fixed(time.Unix(1414561699, 0))),
+	)
+	require.NoError(t, err)
+	return &requestpayment.Builder{Client: client}, &privateKey.PublicKey
+}
+
+func assertSignature(t *testing.T, publicKey *rsa.PublicKey, message, signature string) {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(message))
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], sig))
+}
+
+func TestBuilder_Jsapi(t *testing.T) {
+	builder, publicKey := newTestBuilder(t)
+
+	payment, err := builder.Jsapi(context.Background(), "wx8888888888888888", "wx201410272009395522657a690389285100")
+	require.NoError(t, err)
+	assert.Equal(t, "1414561699", *payment.TimeStamp)
+	assert.Equal(t, "prepay_id=wx201410272009395522657a690389285100", *payment.Package)
+	assert.Equal(t, "RSA", *payment.SignType)
+	assertSignature(t, publicKey,
+		fmt.Sprintf("wx8888888888888888\n1414561699\n%s\nprepay_id=wx201410272009395522657a690389285100\n", *payment.NonceStr),
+		*payment.PaySign,
+	)
+
+	data, err := json.Marshal(payment)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"appId":"wx8888888888888888"`)
+	assert.Contains(t, string(data), `"paySign":`)
+
+	_, err = builder.Jsapi(context.Background(), "wx8888888888888888", "")
+	assert.Error(t, err)
+}
+
+func TestBuilder_App(t *testing.T) {
+	builder, publicKey := newTestBuilder(t)
+
+	payment, err := builder.App(context.Background(), "wx8888888888888888", "", "WX1217752501201407033233368018")
+	require.NoError(t, err)
+	assert.Equal(t, "1900009191", *payment.PartnerId)
+	assert.Equal(t, "Sign=WXPay", *payment.Package)
+	assertSignature(t, publicKey,
+		fmt.Sprintf("wx8888888888888888\n1414561699\n%s\nWX1217752501201407033233368018\n", *payment.NonceStr),
+		*payment.Sign,
+	)
+
+	// 服务商模式使用子商户号
+	payment, err = builder.App(context.Background(), "wx8888888888888888", "1900000109", "WX1217752501201407033233368018")
+	require.NoError(t, err)
+	assert.Equal(t, "1900000109", *payment.PartnerId)
+}
+
+func TestH5RedirectUrl(t *testing.T) {
+	h5Url := "https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb?prepay_id=wx2016121516420242444321ca0631331346&package=1405458241"
+	redirectUrl, err := requestpayment.H5RedirectUrl(h5Url, "https://www.wechatpay.com.cn/result?order=1&from=h5")
+	require.NoError(t, err)
+	assert.Equal(t,
+		h5Url+"&redirect_url=https%3A%2F%2Fwww.wechatpay.com.cn%2Fresult%3Forder%3D1%26from%3Dh5",
+		redirectUrl,
+	)
+
+	_, err = requestpayment.H5RedirectUrl(h5Url, "/result")
+	assert.Error(t, err)
+	_, err = requestpayment.H5RedirectUrl("http://wx.tenpay.com/checkmweb", "https://www.wechatpay.com.cn")
+	assert.Error(t, err)
+	_, err = requestpayment.H5RedirectUrl(redirectUrl, "https://www.wechatpay.com.cn")
+	assert.Error(t, err)
+}
+
+func TestNativeCodeUrl(t *testing.T) {
+	codeUrl, err := requestpayment.NativeCodeUrl("weixin://wxpay/bizpayurl?pr=p4lpSuKzz")
+	require.NoError(t, err)
+	assert.Equal(t, "weixin://wxpay/bizpayurl?pr=p4lpSuKzz", codeUrl)
+
+	_, err = requestpayment.NativeCodeUrl("https://wxpay/bizpayurl?pr=p4lpSuKzz")
+	assert.Error(t, err)
+}
@@ -36,6 +36,11 @@ func (a *ActivityApiService) ApplyActivity(ctx context.Context, req ApplyActivit
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in ApplyActivityRequest")
@@ -85,6 +90,11 @@ func (a *ActivityApiService) ListActsByArea(ctx context.Context, req ListActsByA
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/goods-subsidy-activity/activities"
 	// Make sure All Required Params are properly set
 	if req.CityId == nil {
@@ -34,6 +34,11 @@ func (a *QualificationApiService) LockQualification(ctx context.Context, req Loc
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/goods-subsidy-activity/qualification/lock"
 	// Make sure All Required Params are properly set
 
@@ -72,6 +77,11 @@ func (a *QualificationApiService) UnlockQualification(ctx context.Context, req U
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/marketing/goods-subsidy-activity/qualification/unlock"
 	// Make sure All Required Params are properly set
 
@@ -35,6 +35,11 @@ func (a *RetailStoreActApiService) AddRepresentative(ctx context.Context, req Ad
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in AddRepresentativeRequest")
@@ -84,6 +89,11 @@ func (a *RetailStoreActApiService) AddStores(ctx context.Context, req AddStoresR
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in AddStoresRequest")
@@ -133,6 +143,11 @@ func (a *RetailStoreActApiService) CreateMaterials(ctx context.Context, req Crea
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in CreateMaterialsRequest")
@@ -181,6 +196,11 @@ func (a *RetailStoreActApiService) DeleteRepresentative(ctx context.Context, req
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in DeleteRepresentativeRequest")
@@ -230,6 +250,11 @@ func (a *RetailStoreActApiService) DeleteStores(ctx context.Context, req DeleteS
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in DeleteStoresRequest")
@@ -279,6 +304,11 @@ func (a *RetailStoreActApiService) GetStore(ctx context.Context, req GetStoreReq
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in GetStoreRequest")
@@ -325,6 +355,11 @@ func (a *RetailStoreActApiService) ListRepresentative(ctx context.Context, req L
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ActivityId == nil {
 		return nil, nil, fmt.Errorf("field `ActivityId` is required and must be specified in ListRepresentativeRequest")
@@ -378,6 +413,11 @@ func (a *RetailStoreActApiService) ListStore(ctx context.Context, req ListStoreR
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.BrandId == nil {
 		return nil, nil, fmt.Errorf("field `BrandId` is required and must be specified in ListStoreRequest")
@@ -0,0 +1,68 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package retailstore
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// ActivityAPI ActivityApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ActivityApiService，即可在测试中使用 retailstoretest.FakeActivityAPI 替代
+type ActivityAPI interface {
+	// ApplyActivity 门店报名品牌加价购活动
+	ApplyActivity(ctx context.Context, req ApplyActivityRequest) (resp *ApplyActivityResponse, result *core.APIResult, err error)
+
+	// ListActsByArea 按区域查询品牌加价购活动
+	ListActsByArea(ctx context.Context, req ListActsByAreaRequest) (resp *ListActsByAreaResponse, result *core.APIResult, err error)
+}
+
+// QualificationAPI QualificationApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *QualificationApiService，即可在测试中使用 retailstoretest.FakeQualificationAPI 替代
+type QualificationAPI interface {
+	// LockQualification 锁定品牌加价购活动资格
+	LockQualification(ctx context.Context, req LockQualificationRequest) (resp *LockQualificationResponse, result *core.APIResult, err error)
+
+	// UnlockQualification 解锁品牌加价购活动资格
+	UnlockQualification(ctx context.Context, req UnlockQualificationRequest) (resp *UnlockQualificationResponse, result *core.APIResult, err error)
+}
+
+// RetailStoreActAPI RetailStoreActApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *RetailStoreActApiService，即可在测试中使用 retailstoretest.FakeRetailStoreActAPI 替代
+type RetailStoreActAPI interface {
+	// AddRepresentative 添加零售小店活动业务代理
+	AddRepresentative(ctx context.Context, req AddRepresentativeRequest) (resp *AddRepresentativesResponse, result *core.APIResult, err error)
+
+	// AddStores 添加小店活动门店
+	AddStores(ctx context.Context, req AddStoresRequest) (resp *AddStoresResponse, result *core.APIResult, err error)
+
+	// CreateMaterials 生成小店活动物料码
+	CreateMaterials(ctx context.Context, req CreateMaterialsRequest) (resp *Materials, result *core.APIResult, err error)
+
+	// DeleteRepresentative 删除零售小店活动业务代理
+	DeleteRepresentative(ctx context.Context, req DeleteRepresentativeRequest) (resp *DeleteRepresentativeResponse, result *core.APIResult, err error)
+
+	// DeleteStores 删除小店活动门店
+	DeleteStores(ctx context.Context, req DeleteStoresRequest) (resp *DeleteStoresResponse, result *core.APIResult, err error)
+
+	// GetStore 查询小店活动门店详情
+	GetStore(ctx context.Context, req GetStoreRequest) (resp *RetailStoreInfo, result *core.APIResult, err error)
+
+	// ListRepresentative 查询零售小店活动业务代理
+	ListRepresentative(ctx context.Context, req ListRepresentativeRequest) (resp *ListRepresentativeResponse, result *core.APIResult, err error)
+
+	// ListStore 查询小店活动门店列表
+	ListStore(ctx context.Context, req ListStoreRequest) (resp *ListStoreResponse, result *core.APIResult, err error)
+}
+
+var (
+	_ ActivityAPI       = (*ActivityApiService)(nil)
+	_ QualificationAPI  = (*QualificationApiService)(nil)
+	_ RetailStoreActAPI = (*RetailStoreActApiService)(nil)
+)
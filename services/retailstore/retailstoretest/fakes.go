@@ -0,0 +1,275 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package retailstoretest 提供 retailstore 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package retailstoretest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/retailstore"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+// FakeActivityAPI retailstore.ActivityAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeActivityAPI struct {
+	servicetest.Recorder
+
+	ApplyActivityFunc  func(context.Context, retailstore.ApplyActivityRequest) (*retailstore.ApplyActivityResponse, *core.APIResult, error)
+	ListActsByAreaFunc func(context.Context, retailstore.ListActsByAreaRequest) (*retailstore.ListActsByAreaResponse, *core.APIResult, error)
+}
+
+var _ retailstore.ActivityAPI = (*FakeActivityAPI)(nil)
+
+// ApplyActivity 记录调用并返回 ApplyActivityFunc 的结果
+func (f *FakeActivityAPI) ApplyActivity(ctx context.Context, req retailstore.ApplyActivityRequest) (resp *retailstore.ApplyActivityResponse, result *core.APIResult, err error) {
+	f.Record("ApplyActivity", req)
+	if f.ApplyActivityFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "ApplyActivity")
+		return
+	}
+	return f.ApplyActivityFunc(ctx, req)
+}
+
+// ApplyActivityReturns 设置 ApplyActivity 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) ApplyActivityReturns(resp *retailstore.ApplyActivityResponse, err error) *FakeActivityAPI {
+	f.ApplyActivityFunc = func(context.Context, retailstore.ApplyActivityRequest) (*retailstore.ApplyActivityResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListActsByArea 记录调用并返回 ListActsByAreaFunc 的结果
+func (f *FakeActivityAPI) ListActsByArea(ctx context.Context, req retailstore.ListActsByAreaRequest) (resp *retailstore.ListActsByAreaResponse, result *core.APIResult, err error) {
+	f.Record("ListActsByArea", req)
+	if f.ListActsByAreaFunc == nil {
+		err = servicetest.NotStubbed("ActivityAPI", "ListActsByArea")
+		return
+	}
+	return f.ListActsByAreaFunc(ctx, req)
+}
+
+// ListActsByAreaReturns 设置 ListActsByArea 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeActivityAPI) ListActsByAreaReturns(resp *retailstore.ListActsByAreaResponse, err error) *FakeActivityAPI {
+	f.ListActsByAreaFunc = func(context.Context, retailstore.ListActsByAreaRequest) (*retailstore.ListActsByAreaResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeQualificationAPI retailstore.QualificationAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeQualificationAPI struct {
+	servicetest.Recorder
+
+	LockQualificationFunc   func(context.Context, retailstore.LockQualificationRequest) (*retailstore.LockQualificationResponse, *core.APIResult, error)
+	UnlockQualificationFunc func(context.Context, retailstore.UnlockQualificationRequest) (*retailstore.UnlockQualificationResponse, *core.APIResult, error)
+}
+
+var _ retailstore.QualificationAPI = (*FakeQualificationAPI)(nil)
+
+// LockQualification 记录调用并返回 LockQualificationFunc 的结果
+func (f *FakeQualificationAPI) LockQualification(ctx context.Context, req retailstore.LockQualificationRequest) (resp *retailstore.LockQualificationResponse, result *core.APIResult, err error) {
+	f.Record("LockQualification", req)
+	if f.LockQualificationFunc == nil {
+		err = servicetest.NotStubbed("QualificationAPI", "LockQualification")
+		return
+	}
+	return f.LockQualificationFunc(ctx, req)
+}
+
+// LockQualificationReturns 设置 LockQualification 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeQualificationAPI) LockQualificationReturns(resp *retailstore.LockQualificationResponse, err error) *FakeQualificationAPI {
+	f.LockQualificationFunc = func(context.Context, retailstore.LockQualificationRequest) (*retailstore.LockQualificationResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// UnlockQualification 记录调用并返回 UnlockQualificationFunc 的结果
+func (f *FakeQualificationAPI) UnlockQualification(ctx context.Context, req retailstore.UnlockQualificationRequest) (resp *retailstore.UnlockQualificationResponse, result *core.APIResult, err error) {
+	f.Record("UnlockQualification", req)
+	if f.UnlockQualificationFunc == nil {
+		err = servicetest.NotStubbed("QualificationAPI", "UnlockQualification")
+		return
+	}
+	return f.UnlockQualificationFunc(ctx, req)
+}
+
+// UnlockQualificationReturns 设置 UnlockQualification 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeQualificationAPI) UnlockQualificationReturns(resp *retailstore.UnlockQualificationResponse, err error) *FakeQualificationAPI {
+	f.UnlockQualificationFunc = func(context.Context, retailstore.UnlockQualificationRequest) (*retailstore.UnlockQualificationResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeRetailStoreActAPI retailstore.RetailStoreActAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeRetailStoreActAPI struct {
+	servicetest.Recorder
+
+	AddRepresentativeFunc    func(context.Context, retailstore.AddRepresentativeRequest) (*retailstore.AddRepresentativesResponse, *core.APIResult, error)
+	AddStoresFunc            func(context.Context, retailstore.AddStoresRequest) (*retailstore.AddStoresResponse, *core.APIResult, error)
+	CreateMaterialsFunc      func(context.Context, retailstore.CreateMaterialsRequest) (*retailstore.Materials, *core.APIResult, error)
+	DeleteRepresentativeFunc func(context.Context, retailstore.DeleteRepresentativeRequest) (*retailstore.DeleteRepresentativeResponse, *core.APIResult, error)
+	DeleteStoresFunc         func(context.Context, retailstore.DeleteStoresRequest) (*retailstore.DeleteStoresResponse, *core.APIResult, error)
+	GetStoreFunc             func(context.Context, retailstore.GetStoreRequest) (*retailstore.RetailStoreInfo, *core.APIResult, error)
+	ListRepresentativeFunc   func(context.Context, retailstore.ListRepresentativeRequest) (*retailstore.ListRepresentativeResponse, *core.APIResult, error)
+	ListStoreFunc            func(context.Context, retailstore.ListStoreRequest) (*retailstore.ListStoreResponse, *core.APIResult, error)
+}
+
+var _ retailstore.RetailStoreActAPI = (*FakeRetailStoreActAPI)(nil)
+
+// AddRepresentative 记录调用并返回 AddRepresentativeFunc 的结果
+func (f *FakeRetailStoreActAPI) AddRepresentative(ctx context.Context, req retailstore.AddRepresentativeRequest) (resp *retailstore.AddRepresentativesResponse, result *core.APIResult, err error) {
+	f.Record("AddRepresentative", req)
+	if f.AddRepresentativeFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "AddRepresentative")
+		return
+	}
+	return f.AddRepresentativeFunc(ctx, req)
+}
+
+// AddRepresentativeReturns 设置 AddRepresentative 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) AddRepresentativeReturns(resp *retailstore.AddRepresentativesResponse, err error) *FakeRetailStoreActAPI {
+	f.AddRepresentativeFunc = func(context.Context, retailstore.AddRepresentativeRequest) (*retailstore.AddRepresentativesResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// AddStores 记录调用并返回 AddStoresFunc 的结果
+func (f *FakeRetailStoreActAPI) AddStores(ctx context.Context, req retailstore.AddStoresRequest) (resp *retailstore.AddStoresResponse, result *core.APIResult, err error) {
+	f.Record("AddStores", req)
+	if f.AddStoresFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "AddStores")
+		return
+	}
+	return f.AddStoresFunc(ctx, req)
+}
+
+// AddStoresReturns 设置 AddStores 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) AddStoresReturns(resp *retailstore.AddStoresResponse, err error) *FakeRetailStoreActAPI {
+	f.AddStoresFunc = func(context.Context, retailstore.AddStoresRequest) (*retailstore.AddStoresResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// CreateMaterials 记录调用并返回 CreateMaterialsFunc 的结果
+func (f *FakeRetailStoreActAPI) CreateMaterials(ctx context.Context, req retailstore.CreateMaterialsRequest) (resp *retailstore.Materials, result *core.APIResult, err error) {
+	f.Record("CreateMaterials", req)
+	if f.CreateMaterialsFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "CreateMaterials")
+		return
+	}
+	return f.CreateMaterialsFunc(ctx, req)
+}
+
+// CreateMaterialsReturns 设置 CreateMaterials 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) CreateMaterialsReturns(resp *retailstore.Materials, err error) *FakeRetailStoreActAPI {
+	f.CreateMaterialsFunc = func(context.Context, retailstore.CreateMaterialsRequest) (*retailstore.Materials, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeleteRepresentative 记录调用并返回 DeleteRepresentativeFunc 的结果
+func (f *FakeRetailStoreActAPI) DeleteRepresentative(ctx context.Context, req retailstore.DeleteRepresentativeRequest) (resp *retailstore.DeleteRepresentativeResponse, result *core.APIResult, err error) {
+	f.Record("DeleteRepresentative", req)
+	if f.DeleteRepresentativeFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "DeleteRepresentative")
+		return
+	}
+	return f.DeleteRepresentativeFunc(ctx, req)
+}
+
+// DeleteRepresentativeReturns 设置 DeleteRepresentative 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) DeleteRepresentativeReturns(resp *retailstore.DeleteRepresentativeResponse, err error) *FakeRetailStoreActAPI {
+	f.DeleteRepresentativeFunc = func(context.Context, retailstore.DeleteRepresentativeRequest) (*retailstore.DeleteRepresentativeResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// DeleteStores 记录调用并返回 DeleteStoresFunc 的结果
+func (f *FakeRetailStoreActAPI) DeleteStores(ctx context.Context, req retailstore.DeleteStoresRequest) (resp *retailstore.DeleteStoresResponse, result *core.APIResult, err error) {
+	f.Record("DeleteStores", req)
+	if f.DeleteStoresFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "DeleteStores")
+		return
+	}
+	return f.DeleteStoresFunc(ctx, req)
+}
+
+// DeleteStoresReturns 设置 DeleteStores 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) DeleteStoresReturns(resp *retailstore.DeleteStoresResponse, err error) *FakeRetailStoreActAPI {
+	f.DeleteStoresFunc = func(context.Context, retailstore.DeleteStoresRequest) (*retailstore.DeleteStoresResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// GetStore 记录调用并返回 GetStoreFunc 的结果
+func (f *FakeRetailStoreActAPI) GetStore(ctx context.Context, req retailstore.GetStoreRequest) (resp *retailstore.RetailStoreInfo, result *core.APIResult, err error) {
+	f.Record("GetStore", req)
+	if f.GetStoreFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "GetStore")
+		return
+	}
+	return f.GetStoreFunc(ctx, req)
+}
+
+// GetStoreReturns 设置 GetStore 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) GetStoreReturns(resp *retailstore.RetailStoreInfo, err error) *FakeRetailStoreActAPI {
+	f.GetStoreFunc = func(context.Context, retailstore.GetStoreRequest) (*retailstore.RetailStoreInfo, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListRepresentative 记录调用并返回 ListRepresentativeFunc 的结果
+func (f *FakeRetailStoreActAPI) ListRepresentative(ctx context.Context, req retailstore.ListRepresentativeRequest) (resp *retailstore.ListRepresentativeResponse, result *core.APIResult, err error) {
+	f.Record("ListRepresentative", req)
+	if f.ListRepresentativeFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "ListRepresentative")
+		return
+	}
+	return f.ListRepresentativeFunc(ctx, req)
+}
+
+// ListRepresentativeReturns 设置 ListRepresentative 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) ListRepresentativeReturns(resp *retailstore.ListRepresentativeResponse, err error) *FakeRetailStoreActAPI {
+	f.ListRepresentativeFunc = func(context.Context, retailstore.ListRepresentativeRequest) (*retailstore.ListRepresentativeResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// ListStore 记录调用并返回 ListStoreFunc 的结果
+func (f *FakeRetailStoreActAPI) ListStore(ctx context.Context, req retailstore.ListStoreRequest) (resp *retailstore.ListStoreResponse, result *core.APIResult, err error) {
+	f.Record("ListStore", req)
+	if f.ListStoreFunc == nil {
+		err = servicetest.NotStubbed("RetailStoreActAPI", "ListStore")
+		return
+	}
+	return f.ListStoreFunc(ctx, req)
+}
+
+// ListStoreReturns 设置 ListStore 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeRetailStoreActAPI) ListStoreReturns(resp *retailstore.ListStoreResponse, err error) *FakeRetailStoreActAPI {
+	f.ListStoreFunc = func(context.Context, retailstore.ListStoreRequest) (*retailstore.ListStoreResponse, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
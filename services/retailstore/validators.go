@@ -0,0 +1,185 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package retailstore
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 ActApplyInfo 的字段
+func (o ActApplyInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ActApplyInfo", opts...)
+	c.Required("store_info", o.StoreInfo != nil)
+	if o.StoreInfo != nil {
+		c.Nested("store_info", o.StoreInfo.Validate(opts...))
+	}
+	c.Required("goods_original_price", o.GoodsOriginalPrice != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AddRepresentativeRequest 的字段
+func (o AddRepresentativeRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AddRepresentativeRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("representative_info_list", o.RepresentativeInfoList != nil)
+	for i, item := range o.RepresentativeInfoList {
+		c.Nested(validation.Index("representative_info_list", i), item.Validate(opts...))
+	}
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 AddStoresRequest 的字段
+func (o AddStoresRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("AddStoresRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("add_time", o.AddTime != nil)
+	c.Required("stores", o.Stores != nil)
+	for i, item := range o.Stores {
+		c.Nested(validation.Index("stores", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ApplyActivityRequest 的字段
+func (o ApplyActivityRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ApplyActivityRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("caller_merchant_id", o.CallerMerchantId != nil)
+	for i, item := range o.ApplyInfos {
+		c.Nested(validation.Index("apply_infos", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateMaterialsRequest 的字段
+func (o CreateMaterialsRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateMaterialsRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("material_num", o.MaterialNum != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeleteRepresentativeRequest 的字段
+func (o DeleteRepresentativeRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeleteRepresentativeRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("representative_info_list", o.RepresentativeInfoList != nil)
+	for i, item := range o.RepresentativeInfoList {
+		c.Nested(validation.Index("representative_info_list", i), item.Validate(opts...))
+	}
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("delete_time", o.DeleteTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 DeleteStoresRequest 的字段
+func (o DeleteStoresRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("DeleteStoresRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("out_request_no", o.OutRequestNo != nil)
+	c.Required("delete_time", o.DeleteTime != nil)
+	c.Required("stores", o.Stores != nil)
+	for i, item := range o.Stores {
+		c.Nested(validation.Index("stores", i), item.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetStoreRequest 的字段
+func (o GetStoreRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetStoreRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("store_code", o.StoreCode != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListActsByAreaRequest 的字段
+func (o ListActsByAreaRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListActsByAreaRequest", opts...)
+	c.Required("city_id", o.CityId != nil)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListRepresentativeRequest 的字段
+func (o ListRepresentativeRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListRepresentativeRequest", opts...)
+	c.Required("activity_id", o.ActivityId != nil)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ListStoreRequest 的字段
+func (o ListStoreRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ListStoreRequest", opts...)
+	c.Required("brand_id", o.BrandId != nil)
+	c.Required("offset", o.Offset != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	c.Required("limit", o.Limit != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 LockQualificationRequest 的字段
+func (o LockQualificationRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("LockQualificationRequest", opts...)
+	c.Required("order_information", o.OrderInformation != nil)
+	if o.OrderInformation != nil {
+		c.Nested("order_information", o.OrderInformation.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 OrderInfo 的字段
+func (o OrderInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("OrderInfo", opts...)
+	c.Required("payer_openid", o.PayerOpenid != nil)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("total_fee", o.TotalFee != nil)
+	c.Required("store_id", o.StoreId != nil)
+	c.Required("store_merchant_id", o.StoreMerchantId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 RepresentativeInfo 的字段
+func (o RepresentativeInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("RepresentativeInfo", opts...)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 RetailStoreInfo 的字段
+func (o RetailStoreInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("RetailStoreInfo", opts...)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 StoreInfo 的字段
+func (o StoreInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("StoreInfo", opts...)
+	c.Required("store_id", o.StoreId != nil)
+	c.Required("accounting_merchant_id", o.AccountingMerchantId != nil)
+	c.Required("merchant_id", o.MerchantId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 UnlockQualificationRequest 的字段
+func (o UnlockQualificationRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("UnlockQualificationRequest", opts...)
+	c.Required("order_information", o.OrderInformation != nil)
+	if o.OrderInformation != nil {
+		c.Nested("order_information", o.OrderInformation.Validate(opts...))
+	}
+	return c.Err()
+}
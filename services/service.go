@@ -3,6 +3,9 @@
 // Package services 微信支付 API v3 Go SDK 服务列表
 package services
 
+//go:generate go run ../cmd/wechatpay_gen_fakes -d .
+//go:generate go run ../cmd/wechatpay_gen_validators -d .
+
 import (
 	"github.com/jemuri/wechatpay-go/core"
 )
@@ -0,0 +1,170 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package servicetest 为各服务的 Fake 实现提供调用记录、返回值构造与断言工具
+//
+// 每个服务包下的 <包名>test 子包（如 services/payments/jsapi/jsapitest）提供该包所有服务接口的 Fake 实现，
+// Fake 均内嵌 Recorder，因此可以直接调用 Calls、AssertCalled 等方法检查业务代码发起的调用。
+//
+//	fake := (&jsapitest.FakeJsapiAPI{}).PrepayReturns(&jsapi.PrepayResponse{PrepayId: core.String("wx201410272009395522657a690389285100")}, nil)
+//	svc := NewOrderService(fake) // 业务代码依赖 jsapi.JsapiAPI 接口
+//	...
+//	fake.AssertCallCount(t, "Prepay", 1)
+package servicetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// ErrNotStubbed 调用了未设置返回值的 Fake 接口
+var ErrNotStubbed = errors.New("fake method is not stubbed")
+
+// NotStubbed 返回包装了 ErrNotStubbed 的错误，可以使用 errors.Is 判断
+func NotStubbed(service, method string) error {
+	return fmt.Errorf("%w: %s.%s", ErrNotStubbed, service, method)
+}
+
+// NewResult 根据 err 构造一个 *core.APIResult，用作 Fake 接口的返回值
+//
+// err 为 *core.APIError 时，Response 的状态码、Header 与 Body 取自该错误，否则状态码为 200。
+// 返回结果的 Request 为 nil
+func NewResult(err error) *core.APIResult {
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+
+	var apiErr *core.APIError
+	if errors.As(err, &apiErr) {
+		response.StatusCode = apiErr.StatusCode
+		if apiErr.Header != nil {
+			response.Header = apiErr.Header
+		}
+		response.Body = ioutil.NopCloser(bytes.NewBufferString(apiErr.Body))
+	}
+	response.Status = fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	return &core.APIResult{Response: response}
+}
+
+// Call 一次接口调用
+type Call struct {
+	Method  string      // 接口名，如 Prepay
+	Request interface{} // 调用时传入的请求（不包含 context.Context），没有参数时为 nil，有多个参数时为 []interface{}
+}
+
+// TestingT 断言所需的 *testing.T 方法
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Recorder 记录 Fake 接口的调用，可以安全地并发使用
+type Recorder struct {
+	lock  sync.Mutex
+	calls []Call
+}
+
+// Record 记录一次调用
+func (r *Recorder) Record(method string, request interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Request: request})
+}
+
+// Calls 按调用顺序返回全部调用
+func (r *Recorder) Calls() []Call {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo 按调用顺序返回指定接口的全部调用
+func (r *Recorder) CallsTo(method string) []Call {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount 返回指定接口的调用次数
+func (r *Recorder) CallCount(method string) int {
+	return len(r.CallsTo(method))
+}
+
+// LastRequest 返回指定接口最后一次调用的请求，接口未被调用时 ok 为 false
+func (r *Recorder) LastRequest(method string) (request interface{}, ok bool) {
+	calls := r.CallsTo(method)
+	if len(calls) == 0 {
+		return nil, false
+	}
+	return calls[len(calls)-1].Request, true
+}
+
+// Reset 清空调用记录
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled 断言指定接口至少被调用过一次
+func (r *Recorder) AssertCalled(t TestingT, method string) bool {
+	t.Helper()
+	if r.CallCount(method) == 0 {
+		t.Errorf("expected %s to be called, but it was not", method)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled 断言指定接口未被调用
+func (r *Recorder) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+	if count := r.CallCount(method); count != 0 {
+		t.Errorf("expected %s not to be called, but it was called %d time(s)", method, count)
+		return false
+	}
+	return true
+}
+
+// AssertCallCount 断言指定接口的调用次数
+func (r *Recorder) AssertCallCount(t TestingT, method string, expected int) bool {
+	t.Helper()
+	if count := r.CallCount(method); count != expected {
+		t.Errorf("expected %s to be called %d time(s), but it was called %d time(s)", method, expected, count)
+		return false
+	}
+	return true
+}
+
+// AssertCalledWith 断言指定接口至少有一次调用的请求与 request 相等（使用 reflect.DeepEqual 比较）
+func (r *Recorder) AssertCalledWith(t TestingT, method string, request interface{}) bool {
+	t.Helper()
+	calls := r.CallsTo(method)
+	for _, call := range calls {
+		if reflect.DeepEqual(call.Request, request) {
+			return true
+		}
+	}
+
+	if len(calls) == 0 {
+		t.Errorf("expected %s to be called with %+v, but it was not called", method, request)
+	} else {
+		t.Errorf("expected %s to be called with %+v, but it was called with %+v", method, request, calls[len(calls)-1].Request)
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package servicetest_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	r := &servicetest.Recorder{}
+	r.Record("Prepay", "order-1")
+	r.Record("CloseOrder", "order-1")
+	r.Record("Prepay", "order-2")
+
+	assert.Len(t, r.Calls(), 3)
+	assert.Equal(t, []servicetest.Call{
+		{Method: "Prepay", Request: "order-1"}, {Method: "Prepay", Request: "order-2"},
+	}, r.CallsTo("Prepay"))
+	request, ok := r.LastRequest("Prepay")
+	assert.True(t, ok)
+	assert.Equal(t, "order-2", request)
+
+	mockT := &recordingT{}
+	assert.True(t, r.AssertCalled(mockT, "CloseOrder"))
+	assert.True(t, r.AssertCallCount(mockT, "Prepay", 2))
+	assert.True(t, r.AssertCalledWith(mockT, "Prepay", "order-1"))
+	assert.True(t, r.AssertNotCalled(mockT, "QueryOrderById"))
+	assert.Empty(t, mockT.errors)
+
+	assert.False(t, r.AssertCalled(mockT, "QueryOrderById"))
+	assert.False(t, r.AssertCallCount(mockT, "Prepay", 1))
+	assert.False(t, r.AssertCalledWith(mockT, "Prepay", "order-3"))
+	assert.False(t, r.AssertNotCalled(mockT, "Prepay"))
+	assert.Equal(t, []string{
+		"expected QueryOrderById to be called, but it was not",
+		"expected Prepay to be called 1 time(s), but it was called 2 time(s)",
+		"expected Prepay to be called with order-3, but it was called with order-2",
+		"expected Prepay not to be called, but it was called 2 time(s)",
+	}, mockT.errors)
+
+	r.Reset()
+	assert.Empty(t, r.Calls())
+	_, ok = r.LastRequest("Prepay")
+	assert.False(t, ok)
+}
+
+func TestNewResult(t *testing.T) {
+	result := servicetest.NewResult(nil)
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+
+	apiErr := &core.APIError{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Request-Id": []string{"request-id"}},
+		Body:       `{"code":"ORDER_NOT_EXIST","message":"订单不存在"}`,
+		Code:       "ORDER_NOT_EXIST",
+	}
+	result = servicetest.NewResult(fmt.Errorf("wrapped: %w", apiErr))
+	assert.Equal(t, http.StatusNotFound, result.Response.StatusCode)
+	assert.Equal(t, "request-id", result.Response.Header.Get("Request-Id"))
+	body, err := ioutil.ReadAll(result.Response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, apiErr.Body, string(body))
+}
+
+func TestNotStubbed(t *testing.T) {
+	err := servicetest.NotStubbed("JsapiAPI", "Prepay")
+	assert.True(t, errors.Is(err, servicetest.ErrNotStubbed))
+	assert.Equal(t, "fake method is not stubbed: JsapiAPI.Prepay", err.Error())
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package transferbatch
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// TransferBatchAPI TransferBatchApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransferBatchApiService，即可在测试中使用 transferbatchtest.FakeTransferBatchAPI 替代
+type TransferBatchAPI interface {
+	// GetTransferBatchByNo 通过微信批次单号查询批次单
+	GetTransferBatchByNo(ctx context.Context, req GetTransferBatchByNoRequest) (resp *TransferBatchEntity, result *core.APIResult, err error)
+
+	// GetTransferBatchByOutNo 通过商家批次单号查询批次单
+	GetTransferBatchByOutNo(ctx context.Context, req GetTransferBatchByOutNoRequest) (resp *TransferBatchEntity, result *core.APIResult, err error)
+
+	// InitiateBatchTransfer 发起商家转账
+	InitiateBatchTransfer(ctx context.Context, req InitiateBatchTransferRequest) (resp *InitiateBatchTransferResponse, result *core.APIResult, err error)
+}
+
+// TransferDetailAPI TransferDetailApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransferDetailApiService，即可在测试中使用 transferbatchtest.FakeTransferDetailAPI 替代
+type TransferDetailAPI interface {
+	// GetTransferDetailByNo 通过微信明细单号查询明细单
+	GetTransferDetailByNo(ctx context.Context, req GetTransferDetailByNoRequest) (resp *TransferDetailEntity, result *core.APIResult, err error)
+
+	// GetTransferDetailByOutNo 通过商家明细单号查询明细单
+	GetTransferDetailByOutNo(ctx context.Context, req GetTransferDetailByOutNoRequest) (resp *TransferDetailEntity, result *core.APIResult, err error)
+}
+
+var (
+	_ TransferBatchAPI  = (*TransferBatchApiService)(nil)
+	_ TransferDetailAPI = (*TransferDetailApiService)(nil)
+)
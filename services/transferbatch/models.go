@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // CloseReasonType
@@ -596,7 +598,7 @@ func (o InitiateBatchTransferResponse) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in InitiateBatchTransferResponse")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 
 	if o.BatchStatus != nil {
 		toSerialize["batch_status"] = o.BatchStatus
@@ -805,11 +807,11 @@ func (o TransferBatchGet) MarshalJSON() ([]byte, error) {
 	toSerialize["total_num"] = o.TotalNum
 
 	if o.CreateTime != nil {
-		toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+		toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 	}
 
 	if o.UpdateTime != nil {
-		toSerialize["update_time"] = o.UpdateTime.Format(time.RFC3339)
+		toSerialize["update_time"] = chinatime.Format(*o.UpdateTime)
 	}
 
 	if o.SuccessAmount != nil {
@@ -1213,12 +1215,12 @@ func (o TransferDetailEntity) MarshalJSON() ([]byte, error) {
 	if o.InitiateTime == nil {
 		return nil, fmt.Errorf("field `InitiateTime` is required and must be specified in TransferDetailEntity")
 	}
-	toSerialize["initiate_time"] = o.InitiateTime.Format(time.RFC3339)
+	toSerialize["initiate_time"] = chinatime.Format(*o.InitiateTime)
 
 	if o.UpdateTime == nil {
 		return nil, fmt.Errorf("field `UpdateTime` is required and must be specified in TransferDetailEntity")
 	}
-	toSerialize["update_time"] = o.UpdateTime.Format(time.RFC3339)
+	toSerialize["update_time"] = chinatime.Format(*o.UpdateTime)
 	return json.Marshal(toSerialize)
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package transferbatch
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 GetTransferBatchByNoRequest 的字段
+func (o GetTransferBatchByNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetTransferBatchByNoRequest", opts...)
+	c.Required("batch_id", o.BatchId != nil)
+	c.Required("need_query_detail", o.NeedQueryDetail != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetTransferBatchByOutNoRequest 的字段
+func (o GetTransferBatchByOutNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetTransferBatchByOutNoRequest", opts...)
+	c.Required("out_batch_no", o.OutBatchNo != nil)
+	c.ID("out_batch_no", o.OutBatchNo, idgen.OutBatchNo)
+	c.Required("need_query_detail", o.NeedQueryDetail != nil)
+	c.Range("offset", o.Offset, 0, 0)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetTransferDetailByNoRequest 的字段
+func (o GetTransferDetailByNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetTransferDetailByNoRequest", opts...)
+	c.Required("batch_id", o.BatchId != nil)
+	c.Required("detail_id", o.DetailId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 GetTransferDetailByOutNoRequest 的字段
+func (o GetTransferDetailByOutNoRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("GetTransferDetailByOutNoRequest", opts...)
+	c.Required("out_detail_no", o.OutDetailNo != nil)
+	c.ID("out_detail_no", o.OutDetailNo, idgen.OutDetailNo)
+	c.Required("out_batch_no", o.OutBatchNo != nil)
+	c.ID("out_batch_no", o.OutBatchNo, idgen.OutBatchNo)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 InitiateBatchTransferRequest 的字段
+func (o InitiateBatchTransferRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("InitiateBatchTransferRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Required("out_batch_no", o.OutBatchNo != nil)
+	c.ID("out_batch_no", o.OutBatchNo, idgen.OutBatchNo)
+	c.Required("batch_name", o.BatchName != nil)
+	c.Length("batch_name", o.BatchName, 1, 32)
+	c.Required("batch_remark", o.BatchRemark != nil)
+	c.Length("batch_remark", o.BatchRemark, 1, 32)
+	c.Required("total_amount", o.TotalAmount != nil)
+	c.Range("total_amount", o.TotalAmount, 1, 0)
+	c.Required("total_num", o.TotalNum != nil)
+	c.Range("total_num", o.TotalNum, 1, 3000)
+	c.Required("transfer_detail_list", o.TransferDetailList != nil)
+	for i, item := range o.TransferDetailList {
+		c.Nested(validation.Index("transfer_detail_list", i), item.Validate(opts...))
+	}
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 TransferDetailInput 的字段
+func (o TransferDetailInput) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("TransferDetailInput", opts...)
+	c.Required("out_detail_no", o.OutDetailNo != nil)
+	c.ID("out_detail_no", o.OutDetailNo, idgen.OutDetailNo)
+	c.Required("transfer_amount", o.TransferAmount != nil)
+	c.Range("transfer_amount", o.TransferAmount, 1, 0)
+	c.Required("transfer_remark", o.TransferRemark != nil)
+	c.Length("transfer_remark", o.TransferRemark, 1, 32)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	return c.Err()
+}
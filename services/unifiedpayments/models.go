@@ -0,0 +1,218 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package unifiedpayments
+
+import (
+	"time"
+
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+	"github.com/jemuri/wechatpay-go/services/payments"
+)
+
+// TradeType 交易类型
+type TradeType string
+
+// 支持的交易类型，取值与订单查询结果中的 trade_type 一致
+const (
+	TradeTypeJsapi  TradeType = "JSAPI"  // JSAPI 支付（含小程序支付）
+	TradeTypeApp    TradeType = "APP"    // APP 支付
+	TradeTypeH5     TradeType = "MWEB"   // H5 支付
+	TradeTypeNative TradeType = "NATIVE" // Native 支付
+)
+
+// Merchant 下单商户信息
+//
+// SubMchid 为空时使用直连模式，否则使用服务商模式
+type Merchant struct {
+	// 直连模式下为直连商户号；服务商模式下为服务商商户号
+	Mchid string
+	// 直连模式下为应用ID；服务商模式下为服务商应用ID
+	Appid string
+	// 子商户号，服务商模式必填
+	SubMchid string
+	// 子商户应用ID，服务商模式选填
+	SubAppid string
+}
+
+// IsPartner 是否为服务商模式
+func (m Merchant) IsPartner() bool {
+	return m.SubMchid != ""
+}
+
+// Order 统一的下单参数，字段含义与各支付方式的 PrepayRequest 一致
+type Order struct {
+	// 商品描述
+	Description *string `json:"description"`
+	// 商户系统内部订单号，只能是数字、大小写字母_-*且在同一个商户号下唯一
+	OutTradeNo *string `json:"out_trade_no"`
+	// 订单失效时间，格式为rfc3339格式
+	TimeExpire *time.Time `json:"time_expire,omitempty"`
+	// 附加数据，在查询API和支付通知中原样返回
+	Attach *string `json:"attach,omitempty"`
+	// 有效性：1. HTTPS；2. 不允许携带查询串。
+	NotifyUrl *string `json:"notify_url"`
+	// 商品标记，代金券或立减优惠功能的参数。
+	GoodsTag *string `json:"goods_tag,omitempty"`
+	// 指定支付方式
+	LimitPay []string `json:"limit_pay,omitempty"`
+	// 传入true时，支付成功消息和支付详情页将出现开票入口。
+	SupportFapiao *bool   `json:"support_fapiao,omitempty"`
+	Amount        *Amount `json:"amount"`
+	// 支付者信息，仅 JSAPI 支付使用且必填
+	Payer      *Payer      `json:"payer,omitempty"`
+	Detail     *Detail     `json:"detail,omitempty"`
+	SceneInfo  *SceneInfo  `json:"scene_info,omitempty"`
+	SettleInfo *SettleInfo `json:"settle_info,omitempty"`
+}
+
+// Amount 订单金额
+type Amount struct {
+	// 订单总金额，单位为分
+	Total *int64 `json:"total"`
+	// CNY：人民币，境内商户号仅支持人民币。
+	Currency *string `json:"currency,omitempty"`
+}
+
+// Payer 支付者
+//
+// 直连模式使用 Openid；服务商模式使用 SpOpenid 或 SubOpenid。
+// 服务商模式下只设置了 Openid 时，若商户设置了 SubAppid 则视为 SubOpenid，否则视为 SpOpenid
+type Payer struct {
+	// 用户在直连商户appid下的唯一标识
+	Openid *string `json:"openid,omitempty"`
+	// 用户在服务商appid下的唯一标识
+	SpOpenid *string `json:"sp_openid,omitempty"`
+	// 用户在子商户appid下的唯一标识
+	SubOpenid *string `json:"sub_openid,omitempty"`
+}
+
+// Detail 优惠功能
+type Detail struct {
+	// 1.商户侧一张小票订单可能被分多次支付，订单原价用于记录整张小票的交易金额。 2.当订单原价与支付金额不相等，则不享受优惠。 3.该字段主要用于防止同一张小票分多次支付，以享受多次优惠的情况，正常支付订单不必上传此参数。
+	CostPrice *int64 `json:"cost_price,omitempty"`
+	// 商家小票ID。
+	InvoiceId   *string       `json:"invoice_id,omitempty"`
+	GoodsDetail []GoodsDetail `json:"goods_detail,omitempty"`
+}
+
+// GoodsDetail 单品列表信息
+type GoodsDetail struct {
+	// 由半角的大小写字母、数字、中划线、下划线中的一种或几种组成。
+	MerchantGoodsId *string `json:"merchant_goods_id"`
+	// 微信支付定义的统一商品编号（没有可不传）。
+	WechatpayGoodsId *string `json:"wechatpay_goods_id,omitempty"`
+	// 商品的实际名称。
+	GoodsName *string `json:"goods_name,omitempty"`
+	// 用户购买的数量。
+	Quantity *int64 `json:"quantity"`
+	// 商品单价，单位为分。
+	UnitPrice *int64 `json:"unit_price"`
+}
+
+// SceneInfo 支付场景描述
+type SceneInfo struct {
+	// 用户终端IP
+	PayerClientIp *string `json:"payer_client_ip"`
+	// 商户端设备号
+	DeviceId  *string    `json:"device_id,omitempty"`
+	StoreInfo *StoreInfo `json:"store_info,omitempty"`
+	// H5 场景信息，仅 H5 支付使用且必填
+	H5Info *H5Info `json:"h5_info,omitempty"`
+}
+
+// StoreInfo 商户门店信息
+type StoreInfo struct {
+	// 商户侧门店编号
+	Id *string `json:"id"`
+	// 商户侧门店名称
+	Name *string `json:"name,omitempty"`
+	// 地区编码，详细请见微信支付提供的文档
+	AreaCode *string `json:"area_code,omitempty"`
+	// 详细的商户门店地址
+	Address *string `json:"address,omitempty"`
+}
+
+// H5Info H5 场景信息
+type H5Info struct {
+	// 场景类型
+	Type *string `json:"type"`
+	// 应用名称
+	AppName *string `json:"app_name,omitempty"`
+	// 网站URL
+	AppUrl *string `json:"app_url,omitempty"`
+	// iOS 平台 BundleID
+	BundleId *string `json:"bundle_id,omitempty"`
+	// Android 平台 PackageName
+	PackageName *string `json:"package_name,omitempty"`
+}
+
+// SettleInfo 结算信息
+type SettleInfo struct {
+	// 是否指定分账
+	ProfitSharing *bool `json:"profit_sharing,omitempty"`
+}
+
+// PrepayResult 下单结果，根据交易类型设置其中一个字段
+type PrepayResult struct {
+	TradeType TradeType
+	// 预支付交易会话标识，JSAPI 与 APP 支付返回
+	PrepayId *string
+	// 支付跳转链接，H5 支付返回
+	H5Url *string
+	// 二维码链接，Native 支付返回
+	CodeUrl *string
+}
+
+// Transaction 订单查询结果
+//
+// 直连模式下 Direct 不为 nil，服务商模式下 Partner 不为 nil
+type Transaction struct {
+	Direct  *payments.Transaction
+	Partner *partnerpayments.Transaction
+}
+
+// TradeState 交易状态，未返回时为空字符串
+func (t *Transaction) TradeState() string {
+	switch {
+	case t == nil:
+		return ""
+	case t.Direct != nil:
+		return stringValue(t.Direct.TradeState)
+	case t.Partner != nil:
+		return stringValue(t.Partner.TradeState)
+	}
+	return ""
+}
+
+// OutTradeNo 商户订单号，未返回时为空字符串
+func (t *Transaction) OutTradeNo() string {
+	switch {
+	case t == nil:
+		return ""
+	case t.Direct != nil:
+		return stringValue(t.Direct.OutTradeNo)
+	case t.Partner != nil:
+		return stringValue(t.Partner.OutTradeNo)
+	}
+	return ""
+}
+
+// TransactionId 微信支付订单号，未返回时为空字符串
+func (t *Transaction) TransactionId() string {
+	switch {
+	case t == nil:
+		return ""
+	case t.Direct != nil:
+		return stringValue(t.Direct.TransactionId)
+	case t.Partner != nil:
+		return stringValue(t.Partner.TransactionId)
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
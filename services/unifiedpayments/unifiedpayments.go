@@ -0,0 +1,339 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package unifiedpayments 统一 JSAPI、APP、H5、Native 四种支付方式在直连与服务商模式下的下单、查单与关单
+//
+// 使用同一个 Order 描述订单，Service 根据交易类型调用对应的下单接口，根据 Merchant 是否设置了子商户号
+// 选择直连或服务商模式的接口。四种支付方式的查单与关单是同一个接口，因此无需指定交易类型。
+//
+//	svc := unifiedpayments.NewService(client, unifiedpayments.Merchant{Mchid: mchID, Appid: appID})
+//	result, _, err := svc.Prepay(ctx, unifiedpayments.TradeTypeNative, order)
+//	tx, _, err := svc.QueryOrderByOutTradeNo(ctx, *order.OutTradeNo)
+package unifiedpayments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	partnerapp "github.com/jemuri/wechatpay-go/services/partnerpayments/app"
+	partnerh5 "github.com/jemuri/wechatpay-go/services/partnerpayments/h5"
+	partnerjsapi "github.com/jemuri/wechatpay-go/services/partnerpayments/jsapi"
+	partnernative "github.com/jemuri/wechatpay-go/services/partnerpayments/native"
+	"github.com/jemuri/wechatpay-go/services/payments/app"
+	"github.com/jemuri/wechatpay-go/services/payments/h5"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// DirectAPIs 直连模式下各支付方式的接口
+type DirectAPIs struct {
+	Jsapi  jsapi.JsapiAPI
+	App    app.AppAPI
+	H5     h5.H5API
+	Native native.NativeAPI
+}
+
+// PartnerAPIs 服务商模式下各支付方式的接口
+type PartnerAPIs struct {
+	Jsapi  partnerjsapi.JsapiAPI
+	App    partnerapp.AppAPI
+	H5     partnerh5.H5API
+	Native partnernative.NativeAPI
+}
+
+// Service 统一支付服务
+//
+// Direct 与 Partner 中的接口可以替换为 Fake 实现（见各包下的 <包名>test 子包）以便测试
+type Service struct {
+	Merchant Merchant
+	Direct   DirectAPIs
+	Partner  PartnerAPIs
+	Clock    clock.Clock // 校验订单失效时间所使用的时钟，为空时使用系统时钟
+}
+
+// NewService 使用 client 为 merchant 创建统一支付服务
+func NewService(client *core.Client, merchant Merchant) *Service {
+	return &Service{
+		Merchant: merchant,
+		Clock:    client.Clock(),
+		Direct: DirectAPIs{
+			Jsapi:  &jsapi.JsapiApiService{Client: client},
+			App:    &app.AppApiService{Client: client},
+			H5:     &h5.H5ApiService{Client: client},
+			Native: &native.NativeApiService{Client: client},
+		},
+		Partner: PartnerAPIs{
+			Jsapi:  &partnerjsapi.JsapiApiService{Client: client},
+			App:    &partnerapp.AppApiService{Client: client},
+			H5:     &partnerh5.H5ApiService{Client: client},
+			Native: &partnernative.NativeApiService{Client: client},
+		},
+	}
+}
+
+// TimeExpireWindow 返回支付方式对应的订单失效时间的取值范围
+//
+// 订单失效时间需在下单时间 1 分钟之后；Native 支付的二维码链接有效期为 2 小时，失效时间不能晚于 2 小时之后
+func TimeExpireWindow(tradeType TradeType) chinatime.Window {
+	if tradeType == TradeTypeNative {
+		return chinatime.Window{Min: time.Minute, Max: 2 * time.Hour}
+	}
+	return chinatime.Window{Min: time.Minute}
+}
+
+// Prepay 使用 tradeType 对应的支付方式下单
+func (s *Service) Prepay(
+	ctx context.Context, tradeType TradeType, order Order,
+) (resp *PrepayResult, result *core.APIResult, err error) {
+	if err = s.checkMerchant(); err != nil {
+		return nil, nil, err
+	}
+	if order.OutTradeNo != nil {
+		if err = idgen.OutTradeNo.Validate(*order.OutTradeNo); err != nil {
+			return nil, nil, err
+		}
+	}
+	if order.TimeExpire != nil {
+		now := clock.OrSystem(s.Clock).Now()
+		if err = TimeExpireWindow(tradeType).Validate("time_expire", *order.TimeExpire, now); err != nil {
+			return nil, nil, err
+		}
+	}
+	if s.Merchant.IsPartner() {
+		return s.partnerPrepay(ctx, tradeType, order)
+	}
+	return s.directPrepay(ctx, tradeType, order)
+}
+
+func (s *Service) directPrepay(
+	ctx context.Context, tradeType TradeType, order Order,
+) (resp *PrepayResult, result *core.APIResult, err error) {
+	appid, mchid := core.String(s.Merchant.Appid), core.String(s.Merchant.Mchid)
+	resp = &PrepayResult{TradeType: tradeType}
+
+	switch tradeType {
+	case TradeTypeJsapi:
+		var req jsapi.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.Appid, req.Mchid = appid, mchid
+		var prepayResp *jsapi.PrepayResponse
+		if prepayResp, result, err = s.Direct.Jsapi.Prepay(ctx, req); err == nil {
+			resp.PrepayId = prepayResp.PrepayId
+		}
+	case TradeTypeApp:
+		var req app.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.Appid, req.Mchid = appid, mchid
+		var prepayResp *app.PrepayResponse
+		if prepayResp, result, err = s.Direct.App.Prepay(ctx, req); err == nil {
+			resp.PrepayId = prepayResp.PrepayId
+		}
+	case TradeTypeH5:
+		var req h5.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.Appid, req.Mchid = appid, mchid
+		var prepayResp *h5.PrepayResponse
+		if prepayResp, result, err = s.Direct.H5.Prepay(ctx, req); err == nil {
+			resp.H5Url = prepayResp.H5Url
+		}
+	case TradeTypeNative:
+		var req native.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.Appid, req.Mchid = appid, mchid
+		var prepayResp *native.PrepayResponse
+		if prepayResp, result, err = s.Direct.Native.Prepay(ctx, req); err == nil {
+			resp.CodeUrl = prepayResp.CodeUrl
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported trade type `%s`", tradeType)
+	}
+
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
+func (s *Service) partnerPrepay(
+	ctx context.Context, tradeType TradeType, order Order,
+) (resp *PrepayResult, result *core.APIResult, err error) {
+	spAppid, spMchid := core.String(s.Merchant.Appid), core.String(s.Merchant.Mchid)
+	subAppid, subMchid := s.subAppid(), core.String(s.Merchant.SubMchid)
+	resp = &PrepayResult{TradeType: tradeType}
+
+	switch tradeType {
+	case TradeTypeJsapi:
+		var req partnerjsapi.PrepayRequest
+		if err = convertOrder(s.partnerOrder(order), &req); err != nil {
+			return nil, nil, err
+		}
+		req.SpAppid, req.SpMchid, req.SubAppid, req.SubMchid = spAppid, spMchid, subAppid, subMchid
+		var prepayResp *partnerjsapi.PrepayResponse
+		if prepayResp, result, err = s.Partner.Jsapi.Prepay(ctx, req); err == nil {
+			resp.PrepayId = prepayResp.PrepayId
+		}
+	case TradeTypeApp:
+		var req partnerapp.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.SpAppid, req.SpMchid, req.SubAppid, req.SubMchid = spAppid, spMchid, subAppid, subMchid
+		var prepayResp *partnerapp.PrepayResponse
+		if prepayResp, result, err = s.Partner.App.Prepay(ctx, req); err == nil {
+			resp.PrepayId = prepayResp.PrepayId
+		}
+	case TradeTypeH5:
+		var req partnerh5.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.SpAppid, req.SpMchid, req.SubAppid, req.SubMchid = spAppid, spMchid, subAppid, subMchid
+		var prepayResp *partnerh5.PrepayResponse
+		if prepayResp, result, err = s.Partner.H5.Prepay(ctx, req); err == nil {
+			resp.H5Url = prepayResp.H5Url
+		}
+	case TradeTypeNative:
+		var req partnernative.PrepayRequest
+		if err = convertOrder(order, &req); err != nil {
+			return nil, nil, err
+		}
+		req.SpAppid, req.SpMchid, req.SubAppid, req.SubMchid = spAppid, spMchid, subAppid, subMchid
+		var prepayResp *partnernative.PrepayResponse
+		if prepayResp, result, err = s.Partner.Native.Prepay(ctx, req); err == nil {
+			resp.CodeUrl = prepayResp.CodeUrl
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported trade type `%s`", tradeType)
+	}
+
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
+// partnerOrder 将只设置了 Openid 的支付者转换为服务商模式的 SubOpenid 或 SpOpenid
+func (s *Service) partnerOrder(order Order) Order {
+	payer := order.Payer
+	if payer == nil || payer.Openid == nil || payer.SpOpenid != nil || payer.SubOpenid != nil {
+		return order
+	}
+	if s.Merchant.SubAppid != "" {
+		order.Payer = &Payer{SubOpenid: payer.Openid}
+	} else {
+		order.Payer = &Payer{SpOpenid: payer.Openid}
+	}
+	return order
+}
+
+// QueryOrderByOutTradeNo 商户订单号查询订单
+func (s *Service) QueryOrderByOutTradeNo(
+	ctx context.Context, outTradeNo string,
+) (resp *Transaction, result *core.APIResult, err error) {
+	if err = s.checkMerchant(); err != nil {
+		return nil, nil, err
+	}
+
+	resp = new(Transaction)
+	if s.Merchant.IsPartner() {
+		resp.Partner, result, err = s.Partner.Jsapi.QueryOrderByOutTradeNo(ctx, partnerjsapi.QueryOrderByOutTradeNoRequest{
+			OutTradeNo: core.String(outTradeNo),
+			SpMchid:    core.String(s.Merchant.Mchid),
+			SubMchid:   core.String(s.Merchant.SubMchid),
+		})
+	} else {
+		resp.Direct, result, err = s.Direct.Jsapi.QueryOrderByOutTradeNo(ctx, jsapi.QueryOrderByOutTradeNoRequest{
+			OutTradeNo: core.String(outTradeNo),
+			Mchid:      core.String(s.Merchant.Mchid),
+		})
+	}
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
+// QueryOrderById 微信支付订单号查询订单
+func (s *Service) QueryOrderById(
+	ctx context.Context, transactionId string,
+) (resp *Transaction, result *core.APIResult, err error) {
+	if err = s.checkMerchant(); err != nil {
+		return nil, nil, err
+	}
+
+	resp = new(Transaction)
+	if s.Merchant.IsPartner() {
+		resp.Partner, result, err = s.Partner.Jsapi.QueryOrderById(ctx, partnerjsapi.QueryOrderByIdRequest{
+			TransactionId: core.String(transactionId),
+			SpMchid:       core.String(s.Merchant.Mchid),
+			SubMchid:      core.String(s.Merchant.SubMchid),
+		})
+	} else {
+		resp.Direct, result, err = s.Direct.Jsapi.QueryOrderById(ctx, jsapi.QueryOrderByIdRequest{
+			TransactionId: core.String(transactionId),
+			Mchid:         core.String(s.Merchant.Mchid),
+		})
+	}
+	if err != nil {
+		return nil, result, err
+	}
+	return resp, result, nil
+}
+
+// CloseOrder 关闭订单
+func (s *Service) CloseOrder(ctx context.Context, outTradeNo string) (result *core.APIResult, err error) {
+	if err = s.checkMerchant(); err != nil {
+		return nil, err
+	}
+
+	if s.Merchant.IsPartner() {
+		return s.Partner.Jsapi.CloseOrder(ctx, partnerjsapi.CloseOrderRequest{
+			OutTradeNo: core.String(outTradeNo),
+			SpMchid:    core.String(s.Merchant.Mchid),
+			SubMchid:   core.String(s.Merchant.SubMchid),
+		})
+	}
+	return s.Direct.Jsapi.CloseOrder(ctx, jsapi.CloseOrderRequest{
+		OutTradeNo: core.String(outTradeNo),
+		Mchid:      core.String(s.Merchant.Mchid),
+	})
+}
+
+func (s *Service) checkMerchant() error {
+	if s.Merchant.Mchid == "" {
+		return fmt.Errorf("field `Mchid` is required and must be specified in Merchant")
+	}
+	return nil
+}
+
+func (s *Service) subAppid() *string {
+	if s.Merchant.SubAppid == "" {
+		return nil
+	}
+	return core.String(s.Merchant.SubAppid)
+}
+
+// convertOrder 将 order 转换为各支付方式的 PrepayRequest，各支付方式不支持的字段会被忽略
+func convertOrder(order Order, req interface{}) error {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("marshal order err:%s", err.Error())
+	}
+	if err = json.Unmarshal(data, req); err != nil {
+		return fmt.Errorf("convert order to %T err:%s", req, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package unifiedpayments_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments"
+	partnerh5 "github.com/jemuri/wechatpay-go/services/partnerpayments/h5"
+	"github.com/jemuri/wechatpay-go/services/partnerpayments/h5/h5test"
+	partnerjsapi "github.com/jemuri/wechatpay-go/services/partnerpayments/jsapi"
+	partnerjsapitest "github.com/jemuri/wechatpay-go/services/partnerpayments/jsapi/jsapitest"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi"
+	"github.com/jemuri/wechatpay-go/services/payments/jsapi/jsapitest"
+	"github.com/jemuri/wechatpay-go/services/payments/native"
+	"github.com/jemuri/wechatpay-go/services/payments/native/nativetest"
+	"github.com/jemuri/wechatpay-go/services/unifiedpayments"
+)
+
+var timeExpire = time.Date(2018, 6, 8, 10, 34, 56, 0, time.FixedZone("CST", 8*3600))
+
+// orderTime 下单时间，早于 timeExpire 一小时
+var orderTime = clock.Fixed(timeExpire.Add(-time.Hour))
+
+func newOrder() unifiedpayments.Order {
+	return unifiedpayments.Order{
+		Description: core.String("Image形象店-深圳腾大-QQ公仔"),
+		OutTradeNo:  core.String("1217752501201407033233368018"),
+		TimeExpire:  &timeExpire,
+		NotifyUrl:   core.String("https://www.weixin.qq.com/wxpay/pay.php"),
+		Amount:      &unifiedpayments.Amount{Total: core.Int64(100), Currency: core.String("CNY")},
+		Payer:       &unifiedpayments.Payer{Openid: core.String("oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")},
+		Detail: &unifiedpayments.Detail{
+			GoodsDetail: []unifiedpayments.GoodsDetail{{
+				MerchantGoodsId: core.String("ABC"), Quantity: core.Int64(1), UnitPrice: core.Int64(100),
+			}},
+		},
+		SceneInfo: &unifiedpayments.SceneInfo{
+			PayerClientIp: core.String("14.23.150.211"),
+			H5Info:        &unifiedpayments.H5Info{Type: core.String("iOS")},
+		},
+	}
+}
+
+func TestService_PrepayDirect(t *testing.T) {
+	jsapiFake := (&jsapitest.FakeJsapiAPI{}).PrepayReturns(
+		&jsapi.PrepayResponse{PrepayId: core.String("wx201410272009395522657a690389285100")}, nil,
+	)
+	nativeFake := (&nativetest.FakeNativeAPI{}).PrepayReturns(
+		&native.PrepayResponse{CodeUrl: core.String("weixin://wxpay/bizpayurl?pr=p4lpSuKzz")}, nil,
+	)
+	svc := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{Mchid: "1230000109", Appid: "wxd678efh567hg6787"},
+		Clock:    orderTime,
+		Direct:   unifiedpayments.DirectAPIs{Jsapi: jsapiFake, Native: nativeFake},
+	}
+
+	resp, _, err := svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, newOrder())
+	require.NoError(t, err)
+	assert.Equal(t, "wx201410272009395522657a690389285100", *resp.PrepayId)
+	assert.Nil(t, resp.CodeUrl)
+
+	request, ok := jsapiFake.LastRequest("Prepay")
+	require.True(t, ok)
+	req := request.(jsapi.PrepayRequest)
+	assert.Equal(t, "wxd678efh567hg6787", *req.Appid)
+	assert.Equal(t, "1230000109", *req.Mchid)
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", *req.Payer.Openid)
+	assert.True(t, timeExpire.Equal(*req.TimeExpire))
+	assert.Equal(t, int64(100), *req.Amount.Total)
+	assert.Equal(t, "ABC", *req.Detail.GoodsDetail[0].MerchantGoodsId)
+	assert.Equal(t, "14.23.150.211", *req.SceneInfo.PayerClientIp)
+
+	resp, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeNative, newOrder())
+	require.NoError(t, err)
+	assert.Equal(t, unifiedpayments.TradeTypeNative, resp.TradeType)
+	assert.Equal(t, "weixin://wxpay/bizpayurl?pr=p4lpSuKzz", *resp.CodeUrl)
+	nativeFake.AssertCallCount(t, "Prepay", 1)
+}
+
+func TestService_PrepayPartner(t *testing.T) {
+	jsapiFake := (&partnerjsapitest.FakeJsapiAPI{}).PrepayReturns(
+		&partnerjsapi.PrepayResponse{PrepayId: core.String("wx201410272009395522657a690389285100")}, nil,
+	)
+	h5Fake := (&h5test.FakeH5API{}).PrepayReturns(
+		&partnerh5.PrepayResponse{H5Url: core.String("https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb")}, nil,
+	)
+	svc := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{
+			Mchid: "1230000109", Appid: "wx8888888888888888", SubMchid: "1900000109", SubAppid: "wxd678efh567hg6999",
+		},
+		Clock:   orderTime,
+		Partner: unifiedpayments.PartnerAPIs{Jsapi: jsapiFake, H5: h5Fake},
+	}
+
+	resp, _, err := svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, newOrder())
+	require.NoError(t, err)
+	assert.Equal(t, "wx201410272009395522657a690389285100", *resp.PrepayId)
+
+	request, _ := jsapiFake.LastRequest("Prepay")
+	req := request.(partnerjsapi.PrepayRequest)
+	assert.Equal(t, "wx8888888888888888", *req.SpAppid)
+	assert.Equal(t, "1230000109", *req.SpMchid)
+	assert.Equal(t, "wxd678efh567hg6999", *req.SubAppid)
+	assert.Equal(t, "1900000109", *req.SubMchid)
+	// 设置了子商户应用ID时，Openid 视为子商户下的 sub_openid
+	assert.Nil(t, req.Payer.SpOpenid)
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", *req.Payer.SubOpenid)
+
+	resp, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeH5, newOrder())
+	require.NoError(t, err)
+	assert.Equal(t, "https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb", *resp.H5Url)
+	h5Request, _ := h5Fake.LastRequest("Prepay")
+	assert.Equal(t, "iOS", *h5Request.(partnerh5.PrepayRequest).SceneInfo.H5Info.Type)
+}
+
+func TestService_PrepayPartnerSpOpenid(t *testing.T) {
+	jsapiFake := (&partnerjsapitest.FakeJsapiAPI{}).PrepayReturns(
+		&partnerjsapi.PrepayResponse{PrepayId: core.String("wx201410272009395522657a690389285100")}, nil,
+	)
+	svc := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{Mchid: "1230000109", Appid: "wx8888888888888888", SubMchid: "1900000109"},
+		Clock:    orderTime,
+		Partner:  unifiedpayments.PartnerAPIs{Jsapi: jsapiFake},
+	}
+
+	_, _, err := svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, newOrder())
+	require.NoError(t, err)
+	request, _ := jsapiFake.LastRequest("Prepay")
+	req := request.(partnerjsapi.PrepayRequest)
+	assert.Nil(t, req.SubAppid)
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", *req.Payer.SpOpenid)
+	assert.Nil(t, req.Payer.SubOpenid)
+}
+
+func TestService_PrepayError(t *testing.T) {
+	apiErr := &core.APIError{StatusCode: http.StatusBadRequest, Code: "PARAM_ERROR"}
+	svc := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{Mchid: "1230000109", Appid: "wxd678efh567hg6787"},
+		Clock:    orderTime,
+		Direct:   unifiedpayments.DirectAPIs{Jsapi: (&jsapitest.FakeJsapiAPI{}).PrepayReturns(nil, apiErr)},
+	}
+
+	resp, result, err := svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, newOrder())
+	assert.Nil(t, resp)
+	assert.Equal(t, apiErr, err)
+	assert.Equal(t, http.StatusBadRequest, result.Response.StatusCode)
+
+	_, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeType("MICROPAY"), newOrder())
+	assert.EqualError(t, err, "unsupported trade type `MICROPAY`")
+
+	// 商户订单号不符合格式要求时不发起请求
+	order := newOrder()
+	order.OutTradeNo = core.String("order@1")
+	_, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, order)
+	assert.EqualError(t, err, `out_trade_no contains invalid character "@" at 5`)
+
+	// 订单失效时间不在取值范围内时不发起请求
+	order = newOrder()
+	order.TimeExpire = core.Time(timeExpire.Add(-59*time.Minute - 30*time.Second))
+	_, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, order)
+	assert.EqualError(t, err,
+		"time_expire 2018-06-08T09:35:26+08:00 must be at least 1m0s after 2018-06-08T09:34:56+08:00")
+	order.TimeExpire = core.Time(timeExpire.Add(2 * time.Hour))
+	_, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeNative, order)
+	assert.Error(t, err)
+	svc.Direct.Jsapi.(*jsapitest.FakeJsapiAPI).AssertCallCount(t, "Prepay", 1)
+
+	svc.Merchant.Mchid = ""
+	_, _, err = svc.Prepay(context.Background(), unifiedpayments.TradeTypeJsapi, newOrder())
+	assert.Error(t, err)
+}
+
+func TestService_QueryAndClose(t *testing.T) {
+	directFake := (&jsapitest.FakeJsapiAPI{}).QueryOrderByOutTradeNoReturns(&payments.Transaction{
+		OutTradeNo: core.String("1217752501201407033233368018"), TradeState: core.String("SUCCESS"),
+	}, nil).CloseOrderReturns(nil)
+	partnerFake := (&partnerjsapitest.FakeJsapiAPI{}).QueryOrderByIdReturns(&partnerpayments.Transaction{
+		TransactionId: core.String("1217752501201407033233368018"), TradeState: core.String("NOTPAY"),
+	}, nil).CloseOrderReturns(nil)
+
+	direct := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{Mchid: "1230000109"},
+		Direct:   unifiedpayments.DirectAPIs{Jsapi: directFake},
+	}
+	tx, _, err := direct.QueryOrderByOutTradeNo(context.Background(), "1217752501201407033233368018")
+	require.NoError(t, err)
+	require.NotNil(t, tx.Direct)
+	assert.Nil(t, tx.Partner)
+	assert.Equal(t, "SUCCESS", tx.TradeState())
+	assert.Equal(t, "1217752501201407033233368018", tx.OutTradeNo())
+	assert.Equal(t, "", tx.TransactionId())
+
+	_, err = direct.CloseOrder(context.Background(), "1217752501201407033233368018")
+	require.NoError(t, err)
+	directFake.AssertCalledWith(t, "CloseOrder", jsapi.CloseOrderRequest{
+		OutTradeNo: core.String("1217752501201407033233368018"), Mchid: core.String("1230000109"),
+	})
+
+	partner := &unifiedpayments.Service{
+		Merchant: unifiedpayments.Merchant{Mchid: "1230000109", SubMchid: "1900000109"},
+		Partner:  unifiedpayments.PartnerAPIs{Jsapi: partnerFake},
+	}
+	tx, _, err = partner.QueryOrderById(context.Background(), "1217752501201407033233368018")
+	require.NoError(t, err)
+	require.NotNil(t, tx.Partner)
+	assert.Nil(t, tx.Direct)
+	assert.Equal(t, "NOTPAY", tx.TradeState())
+	partnerFake.AssertCalledWith(t, "QueryOrderById", partnerjsapi.QueryOrderByIdRequest{
+		TransactionId: core.String("1217752501201407033233368018"),
+		SpMchid:       core.String("1230000109"),
+		SubMchid:      core.String("1900000109"),
+	})
+
+	_, err = partner.CloseOrder(context.Background(), "1217752501201407033233368018")
+	require.NoError(t, err)
+	partnerFake.AssertCalledWith(t, "CloseOrder", partnerjsapi.CloseOrderRequest{
+		OutTradeNo: core.String("1217752501201407033233368018"),
+		SpMchid:    core.String("1230000109"),
+		SubMchid:   core.String("1900000109"),
+	})
+}
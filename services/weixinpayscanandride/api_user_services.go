@@ -35,6 +35,11 @@ func (a *UserServicesApiService) QueryUserService(ctx context.Context, req Query
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	// Make sure Path Params are properly set
 	if req.ContractId == nil {
 		return nil, nil, fmt.Errorf("field `ContractId` is required and must be specified in QueryUserServiceRequest")
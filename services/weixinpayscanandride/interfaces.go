@@ -0,0 +1,35 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package weixinpayscanandride
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// TransactionsAPI TransactionsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransactionsApiService，即可在测试中使用 weixinpayscanandridetest.FakeTransactionsAPI 替代
+type TransactionsAPI interface {
+	// CreateTransaction 扣费受理
+	CreateTransaction(ctx context.Context, req CreateTransactionRequest) (resp *TransactionsEntity, result *core.APIResult, err error)
+
+	// QueryTransaction 查询订单
+	QueryTransaction(ctx context.Context, req QueryTransactionRequest) (resp *TransactionsEntity, result *core.APIResult, err error)
+}
+
+// UserServicesAPI UserServicesApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *UserServicesApiService，即可在测试中使用 weixinpayscanandridetest.FakeUserServicesAPI 替代
+type UserServicesAPI interface {
+	// QueryUserService 查询用户服务可用信息
+	QueryUserService(ctx context.Context, req QueryUserServiceRequest) (resp *UserServiceEntity, result *core.APIResult, err error)
+}
+
+var (
+	_ TransactionsAPI = (*TransactionsApiService)(nil)
+	_ UserServicesAPI = (*UserServicesApiService)(nil)
+)
@@ -0,0 +1,84 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package weixinpayscanandride
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 BusSceneInfo 的字段
+func (o BusSceneInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("BusSceneInfo", opts...)
+	c.Required("start_time", o.StartTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateTransactionRequest 的字段
+func (o CreateTransactionRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateTransactionRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("description", o.Description != nil)
+	c.Length("attach", o.Attach, 0, 128)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("trade_scene", o.TradeScene != nil)
+	c.Enum("trade_scene", (*string)(o.TradeScene), "BUS", "METRO")
+	c.Length("goods_tag", o.GoodsTag, 0, 32)
+	c.Required("contract_id", o.ContractId != nil)
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	c.Required("amount", o.Amount != nil)
+	if o.Amount != nil {
+		c.Nested("amount", o.Amount.Validate(opts...))
+	}
+	if o.BusInfo != nil {
+		c.Nested("bus_info", o.BusInfo.Validate(opts...))
+	}
+	if o.MetroInfo != nil {
+		c.Nested("metro_info", o.MetroInfo.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 MetroSceneInfo 的字段
+func (o MetroSceneInfo) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("MetroSceneInfo", opts...)
+	c.Required("start_time", o.StartTime != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 OrderAmount 的字段
+func (o OrderAmount) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("OrderAmount", opts...)
+	c.Required("total", o.Total != nil)
+	c.Range("total", o.Total, 1, 0)
+	c.Pattern("currency", o.Currency, "^[A-Z]{3}$")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryTransactionRequest 的字段
+func (o QueryTransactionRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryTransactionRequest", opts...)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryUserServiceRequest 的字段
+func (o QueryUserServiceRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryUserServiceRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("contract_id", o.ContractId != nil)
+	return c.Err()
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package weixinpayscanandridetest 提供 weixinpayscanandride 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package weixinpayscanandridetest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+	"github.com/jemuri/wechatpay-go/services/weixinpayscanandride"
+)
+
+// FakeTransactionsAPI weixinpayscanandride.TransactionsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransactionsAPI struct {
+	servicetest.Recorder
+
+	CreateTransactionFunc func(context.Context, weixinpayscanandride.CreateTransactionRequest) (*weixinpayscanandride.TransactionsEntity, *core.APIResult, error)
+	QueryTransactionFunc  func(context.Context, weixinpayscanandride.QueryTransactionRequest) (*weixinpayscanandride.TransactionsEntity, *core.APIResult, error)
+}
+
+var _ weixinpayscanandride.TransactionsAPI = (*FakeTransactionsAPI)(nil)
+
+// CreateTransaction 记录调用并返回 CreateTransactionFunc 的结果
+func (f *FakeTransactionsAPI) CreateTransaction(ctx context.Context, req weixinpayscanandride.CreateTransactionRequest) (resp *weixinpayscanandride.TransactionsEntity, result *core.APIResult, err error) {
+	f.Record("CreateTransaction", req)
+	if f.CreateTransactionFunc == nil {
+		err = servicetest.NotStubbed("TransactionsAPI", "CreateTransaction")
+		return
+	}
+	return f.CreateTransactionFunc(ctx, req)
+}
+
+// CreateTransactionReturns 设置 CreateTransaction 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransactionsAPI) CreateTransactionReturns(resp *weixinpayscanandride.TransactionsEntity, err error) *FakeTransactionsAPI {
+	f.CreateTransactionFunc = func(context.Context, weixinpayscanandride.CreateTransactionRequest) (*weixinpayscanandride.TransactionsEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryTransaction 记录调用并返回 QueryTransactionFunc 的结果
+func (f *FakeTransactionsAPI) QueryTransaction(ctx context.Context, req weixinpayscanandride.QueryTransactionRequest) (resp *weixinpayscanandride.TransactionsEntity, result *core.APIResult, err error) {
+	f.Record("QueryTransaction", req)
+	if f.QueryTransactionFunc == nil {
+		err = servicetest.NotStubbed("TransactionsAPI", "QueryTransaction")
+		return
+	}
+	return f.QueryTransactionFunc(ctx, req)
+}
+
+// QueryTransactionReturns 设置 QueryTransaction 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransactionsAPI) QueryTransactionReturns(resp *weixinpayscanandride.TransactionsEntity, err error) *FakeTransactionsAPI {
+	f.QueryTransactionFunc = func(context.Context, weixinpayscanandride.QueryTransactionRequest) (*weixinpayscanandride.TransactionsEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeUserServicesAPI weixinpayscanandride.UserServicesAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeUserServicesAPI struct {
+	servicetest.Recorder
+
+	QueryUserServiceFunc func(context.Context, weixinpayscanandride.QueryUserServiceRequest) (*weixinpayscanandride.UserServiceEntity, *core.APIResult, error)
+}
+
+var _ weixinpayscanandride.UserServicesAPI = (*FakeUserServicesAPI)(nil)
+
+// QueryUserService 记录调用并返回 QueryUserServiceFunc 的结果
+func (f *FakeUserServicesAPI) QueryUserService(ctx context.Context, req weixinpayscanandride.QueryUserServiceRequest) (resp *weixinpayscanandride.UserServiceEntity, result *core.APIResult, err error) {
+	f.Record("QueryUserService", req)
+	if f.QueryUserServiceFunc == nil {
+		err = servicetest.NotStubbed("UserServicesAPI", "QueryUserService")
+		return
+	}
+	return f.QueryUserServiceFunc(ctx, req)
+}
+
+// QueryUserServiceReturns 设置 QueryUserService 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeUserServicesAPI) QueryUserServiceReturns(resp *weixinpayscanandride.UserServiceEntity, err error) *FakeUserServicesAPI {
+	f.QueryUserServiceFunc = func(context.Context, weixinpayscanandride.QueryUserServiceRequest) (*weixinpayscanandride.UserServiceEntity, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
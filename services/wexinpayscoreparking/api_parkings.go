@@ -33,6 +33,11 @@ func (a *ParkingsApiService) CreateParking(ctx context.Context, req CreateParkin
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/vehicle/parking/parkings"
 	// Make sure All Required Params are properly set
 
@@ -34,6 +34,11 @@ func (a *ServicesApiService) QueryPlateService(ctx context.Context, req QueryPla
 		localVarHeaderParams = nethttp.Header{}
 	)
 
+	// Validate Request Params against Schema Constraints if enabled
+	if err = a.Client.ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
 	localVarPath := consts.WechatPayAPIServer + "/v3/vehicle/parking/services/find"
 	// Make sure All Required Params are properly set
 	if req.Appid == nil {
@@ -0,0 +1,44 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+package wexinpayscoreparking
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+)
+
+// ParkingsAPI ParkingsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ParkingsApiService，即可在测试中使用 wexinpayscoreparkingtest.FakeParkingsAPI 替代
+type ParkingsAPI interface {
+	// CreateParking 创建停车入场
+	CreateParking(ctx context.Context, req CreateParkingRequest) (resp *Parking, result *core.APIResult, err error)
+}
+
+// ServicesAPI ServicesApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *ServicesApiService，即可在测试中使用 wexinpayscoreparkingtest.FakeServicesAPI 替代
+type ServicesAPI interface {
+	// QueryPlateService 查询车牌服务开通信息
+	QueryPlateService(ctx context.Context, req QueryPlateServiceRequest) (resp *PlateService, result *core.APIResult, err error)
+}
+
+// TransactionsAPI TransactionsApiService 提供的接口
+//
+// 业务代码依赖该接口而非 *TransactionsApiService，即可在测试中使用 wexinpayscoreparkingtest.FakeTransactionsAPI 替代
+type TransactionsAPI interface {
+	// CreateTransaction 扣费受理
+	CreateTransaction(ctx context.Context, req CreateTransactionRequest) (resp *Transaction, result *core.APIResult, err error)
+
+	// QueryTransaction 查询订单
+	QueryTransaction(ctx context.Context, req QueryTransactionRequest) (resp *Transaction, result *core.APIResult, err error)
+}
+
+var (
+	_ ParkingsAPI     = (*ParkingsApiService)(nil)
+	_ ServicesAPI     = (*ServicesApiService)(nil)
+	_ TransactionsAPI = (*TransactionsApiService)(nil)
+)
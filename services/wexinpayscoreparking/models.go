@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
 )
 
 // CreateParkingRequest
@@ -66,7 +68,7 @@ func (o CreateParkingRequest) MarshalJSON() ([]byte, error) {
 	if o.StartTime == nil {
 		return nil, fmt.Errorf("field `StartTime` is required and must be specified in CreateParkingRequest")
 	}
-	toSerialize["start_time"] = o.StartTime.Format(time.RFC3339)
+	toSerialize["start_time"] = chinatime.Format(*o.StartTime)
 
 	if o.ParkingName == nil {
 		return nil, fmt.Errorf("field `ParkingName` is required and must be specified in CreateParkingRequest")
@@ -676,12 +678,12 @@ func (o ParkingTradeScene) MarshalJSON() ([]byte, error) {
 	if o.StartTime == nil {
 		return nil, fmt.Errorf("field `StartTime` is required and must be specified in ParkingTradeScene")
 	}
-	toSerialize["start_time"] = o.StartTime.Format(time.RFC3339)
+	toSerialize["start_time"] = chinatime.Format(*o.StartTime)
 
 	if o.EndTime == nil {
 		return nil, fmt.Errorf("field `EndTime` is required and must be specified in ParkingTradeScene")
 	}
-	toSerialize["end_time"] = o.EndTime.Format(time.RFC3339)
+	toSerialize["end_time"] = chinatime.Format(*o.EndTime)
 
 	if o.ParkingName == nil {
 		return nil, fmt.Errorf("field `ParkingName` is required and must be specified in ParkingTradeScene")
@@ -899,7 +901,7 @@ func (o PlateService) MarshalJSON() ([]byte, error) {
 	toSerialize["plate_color"] = o.PlateColor
 
 	if o.ServiceOpenTime != nil {
-		toSerialize["service_open_time"] = o.ServiceOpenTime.Format(time.RFC3339)
+		toSerialize["service_open_time"] = chinatime.Format(*o.ServiceOpenTime)
 	}
 
 	if o.Openid == nil {
@@ -1496,7 +1498,7 @@ func (o Transaction) MarshalJSON() ([]byte, error) {
 	if o.CreateTime == nil {
 		return nil, fmt.Errorf("field `CreateTime` is required and must be specified in Transaction")
 	}
-	toSerialize["create_time"] = o.CreateTime.Format(time.RFC3339)
+	toSerialize["create_time"] = chinatime.Format(*o.CreateTime)
 
 	if o.OutTradeNo == nil {
 		return nil, fmt.Errorf("field `OutTradeNo` is required and must be specified in Transaction")
@@ -1517,7 +1519,7 @@ func (o Transaction) MarshalJSON() ([]byte, error) {
 	}
 
 	if o.SuccessTime != nil {
-		toSerialize["success_time"] = o.SuccessTime.Format(time.RFC3339)
+		toSerialize["success_time"] = chinatime.Format(*o.SuccessTime)
 	}
 
 	if o.BankType != nil {
@@ -0,0 +1,100 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_validators; DO NOT EDIT.
+
+package wexinpayscoreparking
+
+import (
+	"github.com/jemuri/wechatpay-go/core/validation"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+// Validate 按接口文档中的约束校验 CreateParkingRequest 的字段
+func (o CreateParkingRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateParkingRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("out_parking_no", o.OutParkingNo != nil)
+	c.Required("plate_number", o.PlateNumber != nil)
+	c.Required("plate_color", o.PlateColor != nil)
+	c.Enum("plate_color", (*string)(o.PlateColor), "BLUE", "GREEN", "YELLOW", "BLACK", "WHITE", "LIMEGREEN")
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	c.Required("start_time", o.StartTime != nil)
+	c.Required("parking_name", o.ParkingName != nil)
+	c.Required("free_duration", o.FreeDuration != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 CreateTransactionRequest 的字段
+func (o CreateTransactionRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("CreateTransactionRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_appid", o.SubAppid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("description", o.Description != nil)
+	c.Length("attach", o.Attach, 0, 128)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	c.Required("trade_scene", o.TradeScene != nil)
+	c.Length("goods_tag", o.GoodsTag, 0, 32)
+	c.Required("notify_url", o.NotifyUrl != nil)
+	c.Length("notify_url", o.NotifyUrl, 0, 256)
+	c.Pattern("notify_url", o.NotifyUrl, "^https://")
+	c.Required("amount", o.Amount != nil)
+	if o.Amount != nil {
+		c.Nested("amount", o.Amount.Validate(opts...))
+	}
+	if o.ParkingInfo != nil {
+		c.Nested("parking_info", o.ParkingInfo.Validate(opts...))
+	}
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 OrderAmount 的字段
+func (o OrderAmount) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("OrderAmount", opts...)
+	c.Required("total", o.Total != nil)
+	c.Range("total", o.Total, 1, 0)
+	c.Pattern("currency", o.Currency, "^[A-Z]{3}$")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 ParkingTradeScene 的字段
+func (o ParkingTradeScene) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("ParkingTradeScene", opts...)
+	c.Required("parking_id", o.ParkingId != nil)
+	c.Required("plate_number", o.PlateNumber != nil)
+	c.Required("plate_color", o.PlateColor != nil)
+	c.Enum("plate_color", (*string)(o.PlateColor), "BLUE", "GREEN", "YELLOW", "BLACK", "WHITE", "LIMEGREEN")
+	c.Required("start_time", o.StartTime != nil)
+	c.Required("end_time", o.EndTime != nil)
+	c.Required("parking_name", o.ParkingName != nil)
+	c.Required("charging_duration", o.ChargingDuration != nil)
+	c.Required("device_id", o.DeviceId != nil)
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryPlateServiceRequest 的字段
+func (o QueryPlateServiceRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryPlateServiceRequest", opts...)
+	c.Required("appid", o.Appid != nil)
+	c.Length("appid", o.Appid, 0, 32)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("plate_number", o.PlateNumber != nil)
+	c.Required("openid", o.Openid != nil)
+	c.Length("openid", o.Openid, 0, 128)
+	c.Required("plate_color", o.PlateColor != nil)
+	c.Enum("plate_color", (*string)(o.PlateColor), "BLUE", "GREEN", "YELLOW", "BLACK", "WHITE", "LIMEGREEN")
+	return c.Err()
+}
+
+// Validate 按接口文档中的约束校验 QueryTransactionRequest 的字段
+func (o QueryTransactionRequest) Validate(opts ...validation.Option) error {
+	c := validation.NewCollector("QueryTransactionRequest", opts...)
+	c.Length("sub_mchid", o.SubMchid, 0, 32)
+	c.Required("out_trade_no", o.OutTradeNo != nil)
+	c.ID("out_trade_no", o.OutTradeNo, idgen.OutTradeNo)
+	return c.Err()
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Code generated by wechatpay_gen_fakes; DO NOT EDIT.
+
+// Package wexinpayscoreparkingtest 提供 wexinpayscoreparking 包中各服务接口的 Fake 实现，用于在不发出 HTTP 请求的情况下测试业务代码
+package wexinpayscoreparkingtest
+
+import (
+	"context"
+
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/servicetest"
+	"github.com/jemuri/wechatpay-go/services/wexinpayscoreparking"
+)
+
+// FakeParkingsAPI wexinpayscoreparking.ParkingsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeParkingsAPI struct {
+	servicetest.Recorder
+
+	CreateParkingFunc func(context.Context, wexinpayscoreparking.CreateParkingRequest) (*wexinpayscoreparking.Parking, *core.APIResult, error)
+}
+
+var _ wexinpayscoreparking.ParkingsAPI = (*FakeParkingsAPI)(nil)
+
+// CreateParking 记录调用并返回 CreateParkingFunc 的结果
+func (f *FakeParkingsAPI) CreateParking(ctx context.Context, req wexinpayscoreparking.CreateParkingRequest) (resp *wexinpayscoreparking.Parking, result *core.APIResult, err error) {
+	f.Record("CreateParking", req)
+	if f.CreateParkingFunc == nil {
+		err = servicetest.NotStubbed("ParkingsAPI", "CreateParking")
+		return
+	}
+	return f.CreateParkingFunc(ctx, req)
+}
+
+// CreateParkingReturns 设置 CreateParking 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeParkingsAPI) CreateParkingReturns(resp *wexinpayscoreparking.Parking, err error) *FakeParkingsAPI {
+	f.CreateParkingFunc = func(context.Context, wexinpayscoreparking.CreateParkingRequest) (*wexinpayscoreparking.Parking, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeServicesAPI wexinpayscoreparking.ServicesAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeServicesAPI struct {
+	servicetest.Recorder
+
+	QueryPlateServiceFunc func(context.Context, wexinpayscoreparking.QueryPlateServiceRequest) (*wexinpayscoreparking.PlateService, *core.APIResult, error)
+}
+
+var _ wexinpayscoreparking.ServicesAPI = (*FakeServicesAPI)(nil)
+
+// QueryPlateService 记录调用并返回 QueryPlateServiceFunc 的结果
+func (f *FakeServicesAPI) QueryPlateService(ctx context.Context, req wexinpayscoreparking.QueryPlateServiceRequest) (resp *wexinpayscoreparking.PlateService, result *core.APIResult, err error) {
+	f.Record("QueryPlateService", req)
+	if f.QueryPlateServiceFunc == nil {
+		err = servicetest.NotStubbed("ServicesAPI", "QueryPlateService")
+		return
+	}
+	return f.QueryPlateServiceFunc(ctx, req)
+}
+
+// QueryPlateServiceReturns 设置 QueryPlateService 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeServicesAPI) QueryPlateServiceReturns(resp *wexinpayscoreparking.PlateService, err error) *FakeServicesAPI {
+	f.QueryPlateServiceFunc = func(context.Context, wexinpayscoreparking.QueryPlateServiceRequest) (*wexinpayscoreparking.PlateService, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// FakeTransactionsAPI wexinpayscoreparking.TransactionsAPI 的 Fake 实现
+//
+// 通过 XxxFunc 字段或 XxxReturns 方法设置接口的返回值，未设置的接口返回 servicetest.ErrNotStubbed；
+// 所有调用均会被记录，可以使用内嵌的 servicetest.Recorder 进行检查
+type FakeTransactionsAPI struct {
+	servicetest.Recorder
+
+	CreateTransactionFunc func(context.Context, wexinpayscoreparking.CreateTransactionRequest) (*wexinpayscoreparking.Transaction, *core.APIResult, error)
+	QueryTransactionFunc  func(context.Context, wexinpayscoreparking.QueryTransactionRequest) (*wexinpayscoreparking.Transaction, *core.APIResult, error)
+}
+
+var _ wexinpayscoreparking.TransactionsAPI = (*FakeTransactionsAPI)(nil)
+
+// CreateTransaction 记录调用并返回 CreateTransactionFunc 的结果
+func (f *FakeTransactionsAPI) CreateTransaction(ctx context.Context, req wexinpayscoreparking.CreateTransactionRequest) (resp *wexinpayscoreparking.Transaction, result *core.APIResult, err error) {
+	f.Record("CreateTransaction", req)
+	if f.CreateTransactionFunc == nil {
+		err = servicetest.NotStubbed("TransactionsAPI", "CreateTransaction")
+		return
+	}
+	return f.CreateTransactionFunc(ctx, req)
+}
+
+// CreateTransactionReturns 设置 CreateTransaction 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransactionsAPI) CreateTransactionReturns(resp *wexinpayscoreparking.Transaction, err error) *FakeTransactionsAPI {
+	f.CreateTransactionFunc = func(context.Context, wexinpayscoreparking.CreateTransactionRequest) (*wexinpayscoreparking.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
+
+// QueryTransaction 记录调用并返回 QueryTransactionFunc 的结果
+func (f *FakeTransactionsAPI) QueryTransaction(ctx context.Context, req wexinpayscoreparking.QueryTransactionRequest) (resp *wexinpayscoreparking.Transaction, result *core.APIResult, err error) {
+	f.Record("QueryTransaction", req)
+	if f.QueryTransactionFunc == nil {
+		err = servicetest.NotStubbed("TransactionsAPI", "QueryTransaction")
+		return
+	}
+	return f.QueryTransactionFunc(ctx, req)
+}
+
+// QueryTransactionReturns 设置 QueryTransaction 的返回值，*core.APIResult 由 servicetest.NewResult 根据 err 生成
+func (f *FakeTransactionsAPI) QueryTransactionReturns(resp *wexinpayscoreparking.Transaction, err error) *FakeTransactionsAPI {
+	f.QueryTransactionFunc = func(context.Context, wexinpayscoreparking.QueryTransactionRequest) (*wexinpayscoreparking.Transaction, *core.APIResult, error) {
+		return resp, servicetest.NewResult(err), err
+	}
+	return f
+}
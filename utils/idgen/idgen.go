@@ -0,0 +1,108 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package idgen 生成与校验商户侧单号
+//
+// 商户订单号、商户退款单号、商家批次单号等由商户生成，需在商户号下唯一，且长度与可用字符各有限制。
+// Generator 使用可替换的生成策略（见 TimeNodeSequence 与 Random）生成单号，并保证结果符合字段的格式要求；
+// 各字段的格式要求见 Field，发出请求前可使用 Field.Validate 校验外部传入的单号。
+//
+//	seq, _ := idgen.NewTimeNodeSequence(nodeID)
+//	g := idgen.NewGenerator(seq)
+//	outTradeNo, err := g.Generate(idgen.OutTradeNo)
+package idgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 单号可用的字符集
+const (
+	// CharsetAlphanumeric 数字与大小写字母
+	CharsetAlphanumeric = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// CharsetTradeNo 数字、大小写字母与 _-|*
+	CharsetTradeNo = CharsetAlphanumeric + "_-|*"
+	// CharsetRefundNo 数字、大小写字母与 _-|*@
+	CharsetRefundNo = CharsetAlphanumeric + "_-|*@"
+)
+
+// Field 商户侧单号字段的格式要求
+type Field struct {
+	Name      string // 字段名，用于错误信息
+	MinLength int    // 最小长度
+	MaxLength int    // 最大长度
+	Charset   string // 可用字符
+}
+
+// 各商户侧单号字段
+var (
+	// OutTradeNo 商户订单号，6-32 个字符，只能是数字、大小写字母_-|*
+	OutTradeNo = Field{Name: "out_trade_no", MinLength: 6, MaxLength: 32, Charset: CharsetTradeNo}
+	// CombineOutTradeNo 合单支付总订单号与子单商户订单号，1-32 个字符，只能是数字、大小写字母_-|*@
+	CombineOutTradeNo = Field{Name: "combine_out_trade_no", MinLength: 1, MaxLength: 32, Charset: CharsetRefundNo}
+	// OutRefundNo 商户退款单号，1-64 个字符，只能是数字、大小写字母_-|*@
+	OutRefundNo = Field{Name: "out_refund_no", MinLength: 1, MaxLength: 64, Charset: CharsetRefundNo}
+	// OutBatchNo 商家批次单号，5-32 个字符，只能是数字、大小写字母
+	OutBatchNo = Field{Name: "out_batch_no", MinLength: 5, MaxLength: 32, Charset: CharsetAlphanumeric}
+	// OutDetailNo 商家明细单号，1-32 个字符，只能是数字、大小写字母
+	OutDetailNo = Field{Name: "out_detail_no", MinLength: 1, MaxLength: 32, Charset: CharsetAlphanumeric}
+	// OutOrderNo 商户分账单号，1-64 个字符，只能是数字、大小写字母_-|*@
+	OutOrderNo = Field{Name: "out_order_no", MinLength: 1, MaxLength: 64, Charset: CharsetRefundNo}
+	// OutReturnNo 商户分账回退单号，1-64 个字符，只能是数字、大小写字母_-|*@
+	OutReturnNo = Field{Name: "out_return_no", MinLength: 1, MaxLength: 64, Charset: CharsetRefundNo}
+)
+
+// Validate 校验 value 是否符合字段的长度与字符要求
+func (f Field) Validate(value string) error {
+	if len(value) < f.MinLength || len(value) > f.MaxLength {
+		return fmt.Errorf(
+			"%s must be %d to %d characters, got %d", f.Name, f.MinLength, f.MaxLength, len(value),
+		)
+	}
+	if i := strings.IndexFunc(value, func(r rune) bool { return !strings.ContainsRune(f.Charset, r) }); i >= 0 {
+		return fmt.Errorf("%s contains invalid character %q at %d", f.Name, value[i:][:1], i)
+	}
+	return nil
+}
+
+// Strategy 单号生成策略
+//
+// Next 返回的单号只能包含数字与大小写字母，长度不超过 32，从而可以用于任意字段；实现需要可以安全地并发使用
+type Strategy interface {
+	Next() (string, error)
+}
+
+// Generator 单号生成器，可以安全地并发使用
+type Generator struct {
+	strategy Strategy
+	prefix   string
+}
+
+// NewGenerator 使用 strategy 创建一个 Generator
+func NewGenerator(strategy Strategy) *Generator {
+	return &Generator{strategy: strategy}
+}
+
+// SetPrefix 设置单号前缀，例如以 "R" 区分退款单号，前缀需要符合所生成字段的字符要求
+func (g *Generator) SetPrefix(prefix string) *Generator {
+	g.prefix = prefix
+	return g
+}
+
+// Generate 生成一个符合 field 格式要求的单号
+//
+// 单号短于字段的最小长度时在前缀后补 0；加上前缀后超过最大长度时返回错误，不会截断以免产生重复的单号
+func (g *Generator) Generate(field Field) (string, error) {
+	id, err := g.strategy.Next()
+	if err != nil {
+		return "", fmt.Errorf("generate %s failed: %w", field.Name, err)
+	}
+	if padding := field.MinLength - len(g.prefix) - len(id); padding > 0 {
+		id = strings.Repeat("0", padding) + id
+	}
+	id = g.prefix + id
+	if err = field.Validate(id); err != nil {
+		return "", fmt.Errorf("generate %s failed: %w", field.Name, err)
+	}
+	return id, nil
+}
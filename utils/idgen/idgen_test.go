@@ -0,0 +1,164 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package idgen_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core/clock"
+	"github.com/jemuri/wechatpay-go/utils/idgen"
+)
+
+func TestField_Validate(t *testing.T) {
+	tests := []struct {
+		field idgen.Field
+		value string
+		valid bool
+	}{
+		{idgen.OutTradeNo, "1217752501201407033233368018", true},
+		{idgen.OutTradeNo, "P_2021-08|06*1", true},
+		{idgen.OutTradeNo, "12345", false},
+		{idgen.OutTradeNo, "123456789012345678901234567890123", false},
+		{idgen.OutTradeNo, "order@123", false},
+		{idgen.OutRefundNo, "refund@123", true},
+		{idgen.OutBatchNo, "plfk2020042013", true},
+		{idgen.OutBatchNo, "plfk-2020042013", false},
+		{idgen.OutDetailNo, "x23zy545Bd5436", true},
+		{idgen.CombineOutTradeNo, "P@2021", true},
+		{idgen.CombineOutTradeNo, "1217752501201407033233368018@01", true},
+		{idgen.CombineOutTradeNo, "1217752501201407033233368018@0123", false},
+		{idgen.OutOrderNo, "P20150806125346", true},
+		{idgen.OutReturnNo, "R20190516001", true},
+		{idgen.OutReturnNo, "退款单号", false},
+	}
+	for _, tt := range tests {
+		err := tt.field.Validate(tt.value)
+		if tt.valid {
+			assert.NoError(t, err, "%s %q", tt.field.Name, tt.value)
+		} else {
+			assert.Error(t, err, "%s %q", tt.field.Name, tt.value)
+		}
+	}
+
+	err := idgen.OutTradeNo.Validate("order@123")
+	assert.EqualError(t, err, `out_trade_no contains invalid character "@" at 5`)
+}
+
+func TestTimeNodeSequence(t *testing.T) {
+	_, err := idgen.NewTimeNodeSequence(1000)
+	assert.Error(t, err)
+
+	now := time.Date(2021, 8, 6, 4, 53, 46, 0, time.UTC)
+	seq, err := idgen.NewTimeNodeSequence(7)
+	require.NoError(t, err)
+	seq.SetClock(clock.Func(func() time.Time { return now }))
+
+	id, err := seq.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "2021080612534600700000", id)
+	id, err = seq.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "2021080612534600700001", id)
+
+	// 时钟回拨时继续递增
+	now = now.Add(-time.Hour)
+	id, err = seq.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "2021080612534600700002", id)
+
+	// 序号用尽后借用下一秒
+	for i := 3; i <= idgen.MaxSequence; i++ {
+		_, err = seq.Next()
+		require.NoError(t, err)
+	}
+	id, err = seq.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "2021080612534700700000", id)
+}
+
+func TestRandom(t *testing.T) {
+	id, err := idgen.Random{}.Next()
+	require.NoError(t, err)
+	assert.Len(t, id, idgen.DefaultRandomLength)
+	assert.NoError(t, idgen.OutDetailNo.Validate(id))
+
+	id, err = idgen.Random{Length: 8}.Next()
+	require.NoError(t, err)
+	assert.Len(t, id, 8)
+
+	_, err = idgen.Random{Length: 33}.Next()
+	assert.Error(t, err)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	g := idgen.NewGenerator(idgen.Random{Length: 3}).SetPrefix("R")
+
+	id, err := g.Generate(idgen.OutTradeNo)
+	require.NoError(t, err)
+	assert.Len(t, id, 6)
+	assert.Equal(t, "R00", id[:3])
+
+	id, err = g.Generate(idgen.OutRefundNo)
+	require.NoError(t, err)
+	assert.Len(t, id, 4)
+
+	_, err = idgen.NewGenerator(idgen.Random{}).SetPrefix("REFUND").Generate(idgen.OutTradeNo)
+	assert.Error(t, err)
+	_, err = idgen.NewGenerator(idgen.Random{}).SetPrefix("R-").Generate(idgen.OutBatchNo)
+	assert.Error(t, err)
+}
+
+// generateConcurrently 在 instances 个实例上并发生成单号，返回重复的单号数
+func generateConcurrently(t *testing.T, instances []*idgen.Generator, perGoroutine int) int {
+	var (
+		wg         sync.WaitGroup
+		lock       sync.Mutex
+		seen       = make(map[string]struct{})
+		duplicates int
+	)
+	for _, g := range instances {
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(g *idgen.Generator) {
+				defer wg.Done()
+				for j := 0; j < perGoroutine; j++ {
+					id, err := g.Generate(idgen.OutTradeNo)
+					if !assert.NoError(t, err) {
+						return
+					}
+					lock.Lock()
+					if _, ok := seen[id]; ok {
+						duplicates++
+					}
+					seen[id] = struct{}{}
+					lock.Unlock()
+				}
+			}(g)
+		}
+	}
+	wg.Wait()
+	return duplicates
+}
+
+func TestGenerator_Concurrently(t *testing.T) {
+	t.Run("time node sequence", func(t *testing.T) {
+		// 固定的时钟使所有实例落在同一秒内，依赖节点号与序号区分
+		fixed := clock.Fixed(time.Date(2021, 8, 6, 4, 53, 46, 0, time.UTC))
+		var instances []*idgen.Generator
+		for node := 0; node < 4; node++ {
+			seq, err := idgen.NewTimeNodeSequence(node)
+			require.NoError(t, err)
+			instances = append(instances, idgen.NewGenerator(seq.SetClock(fixed)))
+		}
+		assert.Zero(t, generateConcurrently(t, instances, 30000))
+	})
+
+	t.Run("random", func(t *testing.T) {
+		g := idgen.NewGenerator(idgen.Random{})
+		assert.Zero(t, generateConcurrently(t, []*idgen.Generator{g, g}, 10000))
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jemuri/wechatpay-go/core/chinatime"
+	"github.com/jemuri/wechatpay-go/core/clock"
+)
+
+// 时间+节点+序号策略的参数
+const (
+	MaxNode     = 999   // 节点号的上限
+	MaxSequence = 99999 // 每秒内序号的上限
+	timeLayout  = "20060102150405"
+)
+
+// TimeNodeSequence 时间+节点+序号策略，生成形如 20210806125346 001 00042 的 22 位数字单号
+//
+// 单号中的时间为北京时间，便于与商户平台的账单对照。
+// 多实例部署时，各实例需使用不同的节点号；同一实例内每秒最多生成 MaxSequence+1 个单号，超出后借用下一秒。
+// 系统时钟回拨时继续使用已用过的最大时间，不会生成重复的单号。单号按生成顺序递增，便于排查与分库
+type TimeNodeSequence struct {
+	node  int
+	clock clock.Clock
+
+	lock     sync.Mutex
+	second   int64 // 当前使用的时间，Unix 秒
+	sequence int   // 当前秒内已使用的序号
+}
+
+// NewTimeNodeSequence 使用节点号 node 创建一个 TimeNodeSequence，node 的取值为 [0, MaxNode]
+func NewTimeNodeSequence(node int) (*TimeNodeSequence, error) {
+	if node < 0 || node > MaxNode {
+		return nil, fmt.Errorf("node must be between 0 and %d, got %d", MaxNode, node)
+	}
+	return &TimeNodeSequence{node: node, second: -1}, nil
+}
+
+// SetClock 设置生成单号所使用的时钟，默认使用系统时钟
+func (s *TimeNodeSequence) SetClock(c clock.Clock) *TimeNodeSequence {
+	s.clock = c
+	return s
+}
+
+// Next 生成下一个单号
+func (s *TimeNodeSequence) Next() (string, error) {
+	now := clock.OrSystem(s.clock).Now().Unix()
+
+	s.lock.Lock()
+	switch {
+	case now > s.second:
+		s.second, s.sequence = now, 0
+	case s.sequence < MaxSequence:
+		s.sequence++
+	default:
+		s.second, s.sequence = s.second+1, 0
+	}
+	second, sequence := s.second, s.sequence
+	s.lock.Unlock()
+
+	return fmt.Sprintf(
+		"%s%03d%05d", chinatime.In(time.Unix(second, 0)).Format(timeLayout), s.node, sequence,
+	), nil
+}
+
+// DefaultRandomLength Random 策略默认的单号长度
+const DefaultRandomLength = 32
+
+// Random 随机策略，生成只包含数字与大小写字母的随机单号
+//
+// 长度为 32 时随机空间约为 2^190，无需协调各实例即可认为不会重复，但单号不具有顺序
+type Random struct {
+	Length int // 单号长度，取值为 (0, 32]，默认为 DefaultRandomLength
+}
+
+// Next 生成下一个单号
+func (r Random) Next() (string, error) {
+	length := r.Length
+	if length == 0 {
+		length = DefaultRandomLength
+	}
+	if length < 0 || length > DefaultRandomLength {
+		return "", fmt.Errorf("random length must be between 1 and %d, got %d", DefaultRandomLength, length)
+	}
+
+	// 丢弃超出字符集整数倍的字节，使每个字符等概率出现
+	limit := byte(256 - 256%len(CharsetAlphanumeric))
+	id := make([]byte, 0, length)
+	buf := make([]byte, length*2)
+	for len(id) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if b < limit && len(id) < length {
+				id = append(id, CharsetAlphanumeric[int(b)%len(CharsetAlphanumeric)])
+			}
+		}
+	}
+	return string(id), nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package money 金额与币种
+//
+// 微信支付 API v3 的金额均为以最小货币单位（人民币为分）计的整数，账单与部分文档中的金额则为带小数的元。
+// Money 以最小货币单位保存金额，运算不经过浮点数，并提供元字符串的解析与格式化、与模型字段之间的转换，
+// 以及退款金额等微信支付要求的金额约束校验。
+//
+//	total, _ := money.FromFields(tx.Amount.Total, tx.Amount.Currency)
+//	refund, _ := money.ParseYuan("12.34")
+//	if err := money.ValidateRefund(refund, total); err != nil { ... }
+//	req.Amount = &refunddomestic.AmountReq{Refund: refund.FenPtr(), Total: total.FenPtr(), Currency: refund.CurrencyPtr()}
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency 符合 ISO 4217 标准的三位字母币种代码
+type Currency string
+
+// 常用币种
+const (
+	CNY Currency = "CNY" // 人民币
+	HKD Currency = "HKD" // 港币
+	USD Currency = "USD" // 美元
+	EUR Currency = "EUR" // 欧元
+	GBP Currency = "GBP" // 英镑
+	JPY Currency = "JPY" // 日元
+	KRW Currency = "KRW" // 韩元
+)
+
+// zeroDecimalCurrencies 最小货币单位即为主单位的币种，其余币种的最小货币单位为主单位的百分之一
+var zeroDecimalCurrencies = map[Currency]bool{JPY: true, KRW: true}
+
+// Decimals 返回币种主单位的小数位数，人民币为 2
+func (c Currency) Decimals() int {
+	if zeroDecimalCurrencies[c] {
+		return 0
+	}
+	return 2
+}
+
+// Money 以最小货币单位计的金额，零值为 0 元人民币
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New 创建以最小货币单位计的金额，currency 为空时为人民币
+func New(amount int64, currency Currency) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+// Fen 创建以分计的人民币金额
+func Fen(fen int64) Money {
+	return Money{amount: fen, currency: CNY}
+}
+
+// Amount 返回以最小货币单位计的金额
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency 返回币种，未指定时为人民币
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return CNY
+	}
+	return m.currency
+}
+
+// IsZero 判断金额是否为 0
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative 判断金额是否小于 0
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Neg 返回相反数
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Add 返回 m + o，币种不同或溢出时返回错误
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + o.amount
+	if (o.amount > 0 && sum < m.amount) || (o.amount < 0 && sum > m.amount) {
+		return Money{}, fmt.Errorf("money overflow: %s + %s", m, o)
+	}
+	return Money{amount: sum, currency: m.Currency()}, nil
+}
+
+// Sub 返回 m - o，币种不同或溢出时返回错误
+func (m Money) Sub(o Money) (Money, error) {
+	if o.amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("money overflow: %s - %s", m, o)
+	}
+	return m.Add(o.Neg())
+}
+
+// Mul 返回 m * n，溢出时返回错误
+func (m Money) Mul(n int64) (Money, error) {
+	if m.amount != 0 && n != 0 {
+		product := m.amount * n
+		if product/n != m.amount || (m.amount == -1 && n == math.MinInt64) || (n == -1 && m.amount == math.MinInt64) {
+			return Money{}, fmt.Errorf("money overflow: %s * %d", m, n)
+		}
+		return Money{amount: product, currency: m.currency}, nil
+	}
+	return Money{currency: m.currency}, nil
+}
+
+// Cmp 比较 m 与 o，m 小于、等于、大于 o 时分别返回 -1、0、1，币种不同时返回错误
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Sum 返回各金额之和，没有金额时返回 0 元人民币
+func Sum(ms ...Money) (Money, error) {
+	if len(ms) == 0 {
+		return Fen(0), nil
+	}
+	sum := Money{currency: ms[0].Currency()}
+	for _, m := range ms {
+		var err error
+		if sum, err = sum.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+func (m Money) checkCurrency(o Money) error {
+	if m.Currency() != o.Currency() {
+		return fmt.Errorf("currency mismatch: %s and %s", m.Currency(), o.Currency())
+	}
+	return nil
+}
+
+// Format 将金额格式化为主单位的十进制字符串，如 1234 分格式化为 "12.34"
+func (m Money) Format() string {
+	decimals := m.Currency().Decimals()
+	if decimals == 0 {
+		return strconv.FormatInt(m.amount, 10)
+	}
+
+	sign := ""
+	abs := uint64(m.amount)
+	if m.amount < 0 {
+		sign, abs = "-", uint64(-(m.amount+1))+1
+	}
+	unit := uint64(math.Pow10(decimals))
+	return fmt.Sprintf("%s%d.%0*d", sign, abs/unit, decimals, abs%unit)
+}
+
+// String 输出金额与币种，如 "12.34 CNY"
+func (m Money) String() string {
+	return m.Format() + " " + string(m.Currency())
+}
+
+// ParseYuan 解析以元计的人民币金额，见 Parse
+func ParseYuan(s string) (Money, error) {
+	return Parse(s, CNY)
+}
+
+// Parse 解析以主单位计的十进制金额字符串，如 "12.34"、"-0.01"
+//
+// 兼容账单中的格式：忽略首尾空白、账单用于防止科学计数法的前缀 "`"、货币符号 "¥"/"￥" 与千分位逗号。
+// 小数位数超过币种的精度时返回错误，不会舍入
+func Parse(s string, currency Currency) (Money, error) {
+	str := strings.TrimSpace(s)
+	str = strings.TrimPrefix(str, "`")
+	sign := int64(1)
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		if str[0] == '-' {
+			sign = -1
+		}
+		str = str[1:]
+	}
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "¥"), "￥")
+	str = strings.ReplaceAll(str, ",", "")
+
+	integer, fraction := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		integer, fraction = str[:i], str[i+1:]
+	}
+	decimals := New(0, currency).Currency().Decimals()
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(fraction) > decimals {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places", s, decimals)
+	}
+
+	digits := integer + fraction + strings.Repeat("0", decimals-len(fraction))
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	return Money{amount: sign * amount, currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FromFields 使用模型中的金额与币种字段创建金额，币种为 nil 时为人民币
+//
+// 适用于 payments.TransactionAmount 的 Total/Currency、refunddomestic.Amount 的 PayerRefund/PayerCurrency 等字段
+func FromFields(amount *int64, currency *string) (Money, error) {
+	if amount == nil {
+		return Money{}, fmt.Errorf("amount is required")
+	}
+	m := Money{amount: *amount, currency: CNY}
+	if currency != nil && *currency != "" {
+		m.currency = Currency(*currency)
+	}
+	return m, nil
+}
+
+// FromFen 使用模型中以分计的人民币金额字段创建金额
+func FromFen(fen *int64) (Money, error) {
+	return FromFields(fen, nil)
+}
+
+// FenPtr 返回以最小货币单位计的金额指针，用于设置模型中的金额字段
+func (m Money) FenPtr() *int64 {
+	amount := m.amount
+	return &amount
+}
+
+// CurrencyPtr 返回币种指针，用于设置模型中的币种字段
+func (m Money) CurrencyPtr() *string {
+	currency := string(m.Currency())
+	return &currency
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package money_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/jemuri/wechatpay-go/core"
+	"github.com/jemuri/wechatpay-go/services/payments"
+	"github.com/jemuri/wechatpay-go/services/refunddomestic"
+	"github.com/jemuri/wechatpay-go/utils/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency money.Currency
+		amount   int64
+		wantErr  bool
+	}{
+		{"12.34", money.CNY, 1234, false},
+		{"12.3", money.CNY, 1230, false},
+		{"12", money.CNY, 1200, false},
+		{"0.01", money.CNY, 1, false},
+		{"-0.01", money.CNY, -1, false},
+		{"`100.00", money.CNY, 10000, false},
+		{" ¥1,234.56 ", money.CNY, 123456, false},
+		{"-￥5.00", money.CNY, -500, false},
+		{"1200", money.JPY, 1200, false},
+		{"12.345", money.CNY, 0, true},
+		{"12.5", money.JPY, 0, true},
+		{"", money.CNY, 0, true},
+		{".5", money.CNY, 0, true},
+		{"1e3", money.CNY, 0, true},
+		{"99999999999999999999", money.CNY, 0, true},
+	}
+	for _, tt := range tests {
+		m, err := money.Parse(tt.input, tt.currency)
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+			continue
+		}
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.amount, m.Amount(), tt.input)
+		assert.Equal(t, tt.currency, m.Currency(), tt.input)
+	}
+}
+
+func TestMoney_Format(t *testing.T) {
+	assert.Equal(t, "12.34", money.Fen(1234).Format())
+	assert.Equal(t, "0.05", money.Fen(5).Format())
+	assert.Equal(t, "-0.05", money.Fen(-5).Format())
+	assert.Equal(t, "-92233720368547758.08", money.Fen(math.MinInt64).Format())
+	assert.Equal(t, "1200", money.New(1200, money.JPY).Format())
+	assert.Equal(t, "12.34 CNY", money.Fen(1234).String())
+	assert.Equal(t, "0.00 CNY", money.Money{}.String())
+
+	// 格式化与解析互逆
+	for _, fen := range []int64{0, 1, 99, 100, 123456789, -42} {
+		m, err := money.ParseYuan(money.Fen(fen).Format())
+		require.NoError(t, err)
+		assert.Equal(t, fen, m.Amount())
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	sum, err := money.Fen(10).Add(money.Fen(20))
+	require.NoError(t, err)
+	assert.Equal(t, money.Fen(30), sum)
+
+	diff, err := money.Fen(10).Sub(money.Fen(20))
+	require.NoError(t, err)
+	assert.True(t, diff.IsNegative())
+
+	product, err := money.Fen(25).Mul(4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), product.Amount())
+
+	_, err = money.Fen(math.MaxInt64).Add(money.Fen(1))
+	assert.Error(t, err)
+	_, err = money.Fen(math.MaxInt64 / 2).Mul(3)
+	assert.Error(t, err)
+	_, err = money.Fen(1).Add(money.New(1, money.USD))
+	assert.EqualError(t, err, "currency mismatch: CNY and USD")
+
+	// 零值为人民币
+	sum, err = money.Money{}.Add(money.Fen(1))
+	require.NoError(t, err)
+	assert.Equal(t, money.CNY, sum.Currency())
+
+	cmp, err := money.Fen(1).Cmp(money.Fen(2))
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	total, err := money.Sum(money.Fen(1), money.Fen(2), money.Fen(3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), total.Amount())
+}
+
+func TestFromFields(t *testing.T) {
+	tx := payments.Transaction{Amount: &payments.TransactionAmount{
+		Total: core.Int64(888), Currency: core.String("CNY"), PayerTotal: core.Int64(800), PayerCurrency: core.String("CNY"),
+	}}
+	total, err := money.FromFields(tx.Amount.Total, tx.Amount.Currency)
+	require.NoError(t, err)
+	assert.Equal(t, "8.88 CNY", total.String())
+
+	refund, err := money.ParseYuan("1.50")
+	require.NoError(t, err)
+	req := refunddomestic.AmountReq{Refund: refund.FenPtr(), Total: total.FenPtr(), Currency: refund.CurrencyPtr()}
+	assert.Equal(t, int64(150), *req.Refund)
+	assert.Equal(t, int64(888), *req.Total)
+	assert.Equal(t, "CNY", *req.Currency)
+
+	_, err = money.FromFen(nil)
+	assert.Error(t, err)
+
+	hkd, err := money.FromFields(core.Int64(100), core.String("HKD"))
+	require.NoError(t, err)
+	assert.Equal(t, money.HKD, hkd.Currency())
+}
+
+func TestValidateRefund(t *testing.T) {
+	assert.NoError(t, money.ValidateRefund(money.Fen(100), money.Fen(100)))
+	assert.NoError(t, money.ValidateRefund(money.Fen(100), money.Fen(200), money.Fen(60), money.Fen(40)))
+
+	assert.EqualError(t, money.ValidateRefund(money.Fen(201), money.Fen(200)),
+		"refund amount 2.01 CNY exceeds total amount 2.00 CNY")
+	assert.Error(t, money.ValidateRefund(money.Fen(0), money.Fen(200)))
+	assert.Error(t, money.ValidateRefund(money.Fen(100), money.Fen(0)))
+	assert.Error(t, money.ValidateRefund(money.New(100, money.USD), money.Fen(200)))
+	assert.EqualError(t, money.ValidateRefund(money.Fen(100), money.Fen(200), money.Fen(60)),
+		"funds from: sum of parts 0.60 CNY does not match total 1.00 CNY")
+}
+
+func TestValidateSum(t *testing.T) {
+	assert.NoError(t, money.ValidateSum(money.Fen(300), money.Fen(100), money.Fen(200)))
+	assert.Error(t, money.ValidateSum(money.Fen(300), money.Fen(100)))
+	assert.Error(t, money.ValidateSum(money.Fen(0), money.Fen(100), money.Fen(-100)))
+
+	assert.NoError(t, money.ValidateNotExceed(money.Fen(300), money.Fen(100), money.Fen(200)))
+	assert.NoError(t, money.ValidateNotExceed(money.Fen(300)))
+	assert.EqualError(t, money.ValidateNotExceed(money.Fen(300), money.Fen(200), money.Fen(101)),
+		"sum of parts 3.01 CNY exceeds limit 3.00 CNY")
+	assert.Error(t, money.ValidateNotExceed(money.Fen(300), money.New(1, money.HKD)))
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package money
+
+import (
+	"fmt"
+)
+
+// ValidateRefund 校验退款金额：退款金额与订单金额大于 0、币种相同且退款金额不超过订单金额；
+// 指定了退款出资账户的金额 from 时，出资金额之和需等于退款金额
+func ValidateRefund(refund, total Money, from ...Money) error {
+	if refund.amount <= 0 {
+		return fmt.Errorf("refund amount must be positive, got %s", refund)
+	}
+	if total.amount <= 0 {
+		return fmt.Errorf("total amount must be positive, got %s", total)
+	}
+	cmp, err := refund.Cmp(total)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("refund amount %s exceeds total amount %s", refund, total)
+	}
+	if len(from) == 0 {
+		return nil
+	}
+	if err = ValidateSum(refund, from...); err != nil {
+		return fmt.Errorf("funds from: %w", err)
+	}
+	return nil
+}
+
+// ValidateSum 校验各部分金额之和等于 total，如转账批次总金额与各明细金额、退款金额与各出资账户金额
+func ValidateSum(total Money, parts ...Money) error {
+	sum, err := sumWithCurrency(total, parts)
+	if err != nil {
+		return err
+	}
+	if sum.amount != total.amount {
+		return fmt.Errorf("sum of parts %s does not match total %s", sum, total)
+	}
+	return nil
+}
+
+// ValidateNotExceed 校验各部分金额之和不超过 limit，如多次退款之和不超过订单金额、分账金额之和不超过待分账金额
+func ValidateNotExceed(limit Money, parts ...Money) error {
+	sum, err := sumWithCurrency(limit, parts)
+	if err != nil {
+		return err
+	}
+	if sum.amount > limit.amount {
+		return fmt.Errorf("sum of parts %s exceeds limit %s", sum, limit)
+	}
+	return nil
+}
+
+// sumWithCurrency 以 base 的币种对 parts 求和，parts 中的金额需与 base 币种相同
+func sumWithCurrency(base Money, parts []Money) (Money, error) {
+	sum := Money{currency: base.Currency()}
+	for _, part := range parts {
+		if part.amount < 0 {
+			return Money{}, fmt.Errorf("amount must not be negative, got %s", part)
+		}
+		var err error
+		if sum, err = sum.Add(part); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,566 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+// Package qrcode 不依赖第三方库的二维码编码器，用于将 Native 支付的 code_url 渲染为 PNG 或 SVG 图片
+//
+// 编码器实现了 ISO/IEC 18004 中的 8 位字节模式，支持版本 1-40 与 L、M、Q、H 四种纠错等级，并自动选择最小的版本与最优的掩模。
+//
+//	png, err := qrcode.PNG(*resp.CodeUrl, qrcode.Options{Size: 300, Level: qrcode.LevelQ})
+package qrcode
+
+import (
+	"fmt"
+)
+
+// Level 纠错等级，等级越高可恢复的污损面积越大，二维码也越密集
+type Level string
+
+// 支持的纠错等级
+const (
+	LevelL Level = "L" // 约可恢复 7% 的码字
+	LevelM Level = "M" // 约可恢复 15% 的码字，默认值
+	LevelQ Level = "Q" // 约可恢复 25% 的码字
+	LevelH Level = "H" // 约可恢复 30% 的码字
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// index 纠错等级在码表中的下标，空值视为 LevelM
+func (l Level) index() (int, error) {
+	switch l {
+	case LevelL:
+		return 0, nil
+	case LevelM, "":
+		return 1, nil
+	case LevelQ:
+		return 2, nil
+	case LevelH:
+		return 3, nil
+	}
+	return 0, fmt.Errorf("unsupported error correction level `%s`", string(l))
+}
+
+// formatBits 格式信息中纠错等级的编码，下标与 index 一致
+var formatBits = [4]int{1, 0, 3, 2}
+
+// eccCodewordsPerBlock 各纠错等级、各版本每个块的纠错码字数
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numErrorCorrectionBlocks 各纠错等级、各版本的纠错块数
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// QRCode 编码后的二维码
+type QRCode struct {
+	// 版本，取值 1-40，边长为 4*Version+17 个模块
+	Version int
+	// 纠错等级
+	Level Level
+	// 掩模编号，取值 0-7
+	Mask int
+
+	size       int
+	modules    [][]bool // modules[y][x] 为 true 表示深色模块
+	isFunction [][]bool // 定位、校正、时序图形与格式、版本信息所在的模块，不参与数据填充与掩模
+}
+
+// Encode 使用纠错等级 level 以 8 位字节模式编码 content，并选择能容纳内容的最小版本
+func Encode(content string, level Level) (*QRCode, error) {
+	ecl, err := level.index()
+	if err != nil {
+		return nil, err
+	}
+	if level == "" {
+		level = LevelM
+	}
+
+	data := []byte(content)
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		usedBits := 4 + charCountBits(version) + 8*len(data)
+		if usedBits <= numDataCodewords(version, ecl)*8 {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, fmt.Errorf("content too long to encode in a QR code: %d bytes", len(data))
+	}
+
+	// 模式指示符、字符计数与数据
+	var bb bitBuffer
+	bb.appendBits(0x4, 4)
+	bb.appendBits(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.appendBits(int(b), 8)
+	}
+
+	// 终止符与填充
+	capacityBits := numDataCodewords(version, ecl) * 8
+	terminator := capacityBits - len(bb)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bb.appendBits(0, terminator)
+	bb.appendBits(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacityBits; pad ^= 0xEC ^ 0x11 {
+		bb.appendBits(pad, 8)
+	}
+
+	q := newQRCode(version, level)
+	q.drawFunctionPatterns(ecl)
+	q.drawCodewords(addEccAndInterleave(bb.bytes(), version, ecl))
+
+	// 选择惩罚分最低的掩模
+	minPenalty := -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(ecl, mask)
+		if penalty := q.penaltyScore(); minPenalty < 0 || penalty < minPenalty {
+			minPenalty = penalty
+			q.Mask = mask
+		}
+		q.applyMask(mask) // 掩模为异或操作，再次应用即可撤销
+	}
+	q.applyMask(q.Mask)
+	q.drawFormatBits(ecl, q.Mask)
+	return q, nil
+}
+
+func newQRCode(version int, level Level) *QRCode {
+	size := version*4 + 17
+	q := &QRCode{Version: version, Level: level, size: size}
+	q.modules = make([][]bool, size)
+	q.isFunction = make([][]bool, size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+	return q
+}
+
+// Size 二维码边长（模块数），不包含静区
+func (q *QRCode) Size() int {
+	return q.size
+}
+
+// Dark 坐标 (x, y) 处的模块是否为深色，左上角为 (0, 0)，超出范围时返回 false
+func (q *QRCode) Dark(x, y int) bool {
+	return x >= 0 && x < q.size && y >= 0 && y < q.size && q.modules[y][x]
+}
+
+func (q *QRCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns(ecl int) {
+	// 时序图形
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	// 定位图形及分隔符
+	q.drawFinderPattern(3, 3)
+	q.drawFinderPattern(q.size-4, 3)
+	q.drawFinderPattern(3, q.size-4)
+
+	// 校正图形，跳过与定位图形重叠的三个角
+	positions := alignmentPatternPositions(q.Version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// 先占位格式信息，掩模确定后再绘制
+	q.drawFormatBits(ecl, 0)
+	q.drawVersion()
+}
+
+func (q *QRCode) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= q.size || yy < 0 || yy >= q.size {
+				continue
+			}
+			dist := maxInt(absInt(dx), absInt(dy))
+			q.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *QRCode) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(x+dx, y+dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits 绘制两份格式信息（纠错等级与掩模编号，经 BCH(15,5) 编码）
+func (q *QRCode) drawFormatBits(ecl, mask int) {
+	bits := formatInfo(ecl, mask)
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(bits, i))
+	}
+	q.setFunction(8, 7, bit(bits, 6))
+	q.setFunction(8, 8, bit(bits, 7))
+	q.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(bits, i))
+	}
+	q.setFunction(8, q.size-8, true) // 固定的深色模块
+}
+
+func formatInfo(ecl, mask int) int {
+	data := formatBits[ecl]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawVersion 绘制两份版本信息（经 BCH(18,6) 编码），仅版本 7 及以上需要
+func (q *QRCode) drawVersion() {
+	if q.Version < 7 {
+		return
+	}
+	rem := q.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := q.Version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := bit(bits, i)
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords 自右下角起，以两列为单位上下往复填充数据与纠错码字
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// 跳过垂直时序图形所在的列
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.isFunction[y][x] && masked(mask, x, y) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// 惩罚分规则的权重
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// penaltyScore 计算当前模块的惩罚分，用于选择掩模
+func (q *QRCode) penaltyScore() int {
+	result := 0
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			// 规则 1：行（列）中连续 5 个及以上同色模块
+			run := 1
+			for x := 1; x < q.size; x++ {
+				if at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += penaltyN1 + run - 5
+				}
+				run = 1
+			}
+			if run >= 5 {
+				result += penaltyN1 + run - 5
+			}
+
+			// 规则 3：行（列）中出现一侧带 4 个浅色模块的 1:1:3:1:1 定位图形样式
+			for x := 0; x+7 <= q.size; x++ {
+				if !finderLike(func(i int) bool { return at(x+i, y, vertical) }) {
+					continue
+				}
+				if lightRun(func(i int) bool { return at(i, y, vertical) }, x-4, x, q.size) ||
+					lightRun(func(i int) bool { return at(i, y, vertical) }, x+7, x+11, q.size) {
+					result += penaltyN3
+				}
+			}
+		}
+	}
+
+	// 规则 2：2x2 的同色模块块
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					result += penaltyN2
+				}
+			}
+		}
+	}
+
+	// 规则 4：深色模块占比偏离 50% 的程度
+	total := q.size * q.size
+	k := absInt(dark*100/total-50) / 5
+	result += k * penaltyN4
+	return result
+}
+
+// finderLike 判断自某位置起的 7 个模块是否为 深:浅:深深深:浅:深
+func finderLike(at func(i int) bool) bool {
+	return at(0) && !at(1) && at(2) && at(3) && at(4) && !at(5) && at(6)
+}
+
+// lightRun 判断 [from, to) 内的模块是否均为浅色，超出二维码范围的部分视为浅色的静区
+func lightRun(at func(i int) bool, from, to, size int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < size && at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// addEccAndInterleave 将数据码字分块并计算各块的纠错码字，再按标准交错排列
+func addEccAndInterleave(data []byte, version, ecl int) []byte {
+	numBlocks := numErrorCorrectionBlocks[ecl][version]
+	blockEccLen := eccCodewordsPerBlock[ecl][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockEccLen)
+	blocks := make([][]byte, 0, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - blockEccLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, data[k:k+dataLen]...)
+		k += dataLen
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			// 短块补一个占位码字以便与长块对齐，交错时跳过
+			block = append(block, 0)
+		}
+		blocks = append(blocks, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i < shortBlockLen+1; i++ {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor 返回 degree 次 Reed-Solomon 生成多项式的系数（省略最高次项的系数 1），高次在前
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder 计算 data 除以生成多项式的余式，即纠错码字
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply GF(2^8) 上以 0x11D 为模的乘法
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>uint(i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// alignmentPatternPositions 校正图形中心在行、列上的坐标
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+// numRawDataModules 可用于填充数据与纠错码字的模块数
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// numDataCodewords 可容纳的数据码字数
+func numDataCodewords(version, ecl int) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[ecl][version]*numErrorCorrectionBlocks[ecl][version]
+}
+
+// charCountBits 字节模式下字符计数指示符的位数
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+type bitBuffer []bool
+
+func (bb *bitBuffer) appendBits(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*bb = append(*bb, bit(value, i))
+	}
+}
+
+func (bb bitBuffer) bytes() []byte {
+	result := make([]byte, len(bb)/8)
+	for i, b := range bb {
+		if b {
+			result[i>>3] |= 1 << uint(7-i&7)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return (value>>uint(i))&1 != 0
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// ISO/IEC 18004 附录 I 中 "01234567" 1-M 的示例
+	data := []byte{16, 32, 12, 86, 97, 128, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17}
+	assert.Equal(t,
+		[]byte{165, 36, 212, 193, 237, 54, 199, 135, 44, 85},
+		reedSolomonRemainder(data, reedSolomonDivisor(10)),
+	)
+}
+
+func TestFormatInfo(t *testing.T) {
+	assert.Equal(t, 0x5412, formatInfo(1, 0)) // M，掩模 0
+	assert.Equal(t, 0x77C4, formatInfo(0, 0)) // L，掩模 0
+}
+
+func TestCapacity(t *testing.T) {
+	for ecl := 0; ecl < 4; ecl++ {
+		for version := minVersion; version <= maxVersion; version++ {
+			// 每个块至少包含 1 个数据码字
+			blocks := numErrorCorrectionBlocks[ecl][version]
+			assert.Greater(t, numRawDataModules(version)/8/blocks, eccCodewordsPerBlock[ecl][version])
+		}
+	}
+	assert.Equal(t, 2956, numDataCodewords(40, 0))
+	assert.Equal(t, 1276, numDataCodewords(40, 3))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, alignmentPatternPositions(32))
+}
+
+// decode 读取二维码中的数据：校验格式信息，撤销掩模，校验各块的纠错码字，并解析字节模式的数据
+func decode(t *testing.T, q *QRCode) string {
+	ecl, err := q.Level.index()
+	require.NoError(t, err)
+
+	var format1, format2 int
+	for i := 0; i <= 5; i++ {
+		format1 |= boolBit(q.Dark(8, i)) << uint(i)
+	}
+	format1 |= boolBit(q.Dark(8, 7))<<6 | boolBit(q.Dark(8, 8))<<7 | boolBit(q.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		format1 |= boolBit(q.Dark(14-i, 8)) << uint(i)
+	}
+	for i := 0; i < 8; i++ {
+		format2 |= boolBit(q.Dark(q.size-1-i, 8)) << uint(i)
+	}
+	for i := 8; i < 15; i++ {
+		format2 |= boolBit(q.Dark(8, q.size-15+i)) << uint(i)
+	}
+	require.Equal(t, formatInfo(ecl, q.Mask), format1)
+	require.Equal(t, format1, format2)
+
+	// 在新的二维码上重新生成功能图形，用于定位数据模块
+	empty := newQRCode(q.Version, q.Level)
+	empty.drawFunctionPatterns(ecl)
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			// 格式信息所在的第 8 行与第 8 列已在上面校验
+			if empty.isFunction[y][x] && x != 8 && y != 8 {
+				require.Equal(t, empty.modules[y][x], q.modules[y][x])
+			}
+		}
+	}
+
+	var bits []bool
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !empty.isFunction[y][x] {
+					bits = append(bits, q.modules[y][x] != masked(q.Mask, x, y))
+				}
+			}
+		}
+	}
+	codewords := bitBuffer(bits[:len(bits)/8*8]).bytes()
+	require.Len(t, codewords, numRawDataModules(q.Version)/8)
+
+	// 解交错并校验纠错码字
+	numBlocks := numErrorCorrectionBlocks[ecl][q.Version]
+	blockEccLen := eccCodewordsPerBlock[ecl][q.Version]
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	shortDataLen := len(codewords)/numBlocks - blockEccLen
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortDataLen+1; i++ {
+		for j := range blocks {
+			if i < shortDataLen || j >= numShortBlocks {
+				blocks[j] = append(blocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for j := range blocks {
+		data = append(data, blocks[j]...)
+	}
+	for i := 0; i < blockEccLen; i++ {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+	divisor := reedSolomonDivisor(blockEccLen)
+	for _, block := range blocks {
+		dataLen := len(block) - blockEccLen
+		require.Equal(t, block[dataLen:], reedSolomonRemainder(block[:dataLen], divisor))
+	}
+
+	// 字节模式
+	require.Equal(t, byte(0x4), data[0]>>4)
+	var reader bitBuffer
+	for _, b := range data {
+		reader.appendBits(int(b), 8)
+	}
+	readBits := func(from, length int) int {
+		v := 0
+		for i := from; i < from+length; i++ {
+			v = v<<1 | boolBit(reader[i])
+		}
+		return v
+	}
+	countBits := charCountBits(q.Version)
+	count := readBits(4, countBits)
+	content := make([]byte, count)
+	for i := range content {
+		content[i] = byte(readBits(4+countBits+8*i, 8))
+	}
+	return string(content)
+}
+
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		content string
+		level   Level
+		version int
+	}{
+		{"weixin://wxpay/bizpayurl?pr=p4lpSuKzz", LevelM, 3},
+		{"weixin://wxpay/bizpayurl?pr=p4lpSuKzz", LevelH, 5},
+		{"https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb?prepay_id=wx2016121516420242444321ca0631331346", LevelL, 5},
+		{"", LevelQ, 1},
+		{"微信支付", "", 1},
+		{strings.Repeat("0123456789", 30), LevelQ, 0},
+		{strings.Repeat("abcdefghij", 295), LevelL, 40},
+	}
+	for _, tt := range tests {
+		q, err := Encode(tt.content, tt.level)
+		require.NoError(t, err)
+		if tt.version > 0 {
+			assert.Equal(t, tt.version, q.Version, tt.content)
+		}
+		assert.Equal(t, q.Version*4+17, q.Size())
+		assert.Equal(t, tt.content, decode(t, q))
+	}
+
+	_, err := Encode(strings.Repeat("a", 2954), LevelL)
+	assert.Error(t, err)
+	_, err = Encode("weixin://wxpay/bizpayurl?pr=p4lpSuKzz", Level("X"))
+	assert.EqualError(t, err, "unsupported error correction level `X`")
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG("weixin://wxpay/bizpayurl?pr=p4lpSuKzz", Options{Size: 300})
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 300, img.Bounds().Dx())
+
+	// 版本 3 的二维码为 29 个模块，含静区共 37 个模块，每个模块 8 像素，左右各留 2 像素边距
+	isDark := func(x, y int) bool {
+		r, _, _, _ := img.At(x, y).RGBA()
+		return r == 0
+	}
+	assert.False(t, isDark(2+4*8-1, 2+4*8-1))
+	assert.True(t, isDark(2+4*8, 2+4*8))
+	assert.True(t, isDark(2+4*8+7, 2+4*8+7))
+
+	// 尺寸过小时每个模块 1 像素
+	data, err = PNG("weixin://wxpay/bizpayurl?pr=p4lpSuKzz", Options{Size: 10, QuietZone: -1})
+	require.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 29, img.Bounds().Dx())
+}
+
+func TestSVG(t *testing.T) {
+	data, err := Render("weixin://wxpay/bizpayurl?pr=p4lpSuKzz", FormatSVG, Options{Size: 37, QuietZone: 4})
+	require.NoError(t, err)
+	svg := string(data)
+	assert.Contains(t, svg, `width="37" height="37" viewBox="0 0 37 37"`)
+	assert.Contains(t, svg, `d="M4,4h1v1h-1z`)
+	assert.Equal(t, "image/svg+xml", FormatSVG.ContentType())
+
+	_, err = Render("weixin://wxpay/bizpayurl?pr=p4lpSuKzz", Format("gif"), Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 Tencent Inc. All rights reserved.
+
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Format 图片格式
+type Format string
+
+// 支持的图片格式
+const (
+	FormatPNG Format = "png" // 默认值
+	FormatSVG Format = "svg"
+)
+
+// ContentType 图片格式对应的 MIME 类型
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// 默认渲染参数
+const (
+	DefaultSize      = 256
+	DefaultQuietZone = 4
+)
+
+// Options 渲染参数
+type Options struct {
+	// 图片边长（像素），默认 256。每个模块占用相同的整数像素，多余的部分作为边距；
+	// 小于二维码（含静区）的模块数时，每个模块占用 1 像素
+	Size int
+	// 纠错等级，默认 LevelM
+	Level Level
+	// 静区宽度（模块数），默认 4；小于 0 时不留静区
+	QuietZone int
+}
+
+func (o Options) withDefaults() Options {
+	if o.Size <= 0 {
+		o.Size = DefaultSize
+	}
+	if o.QuietZone == 0 {
+		o.QuietZone = DefaultQuietZone
+	} else if o.QuietZone < 0 {
+		o.QuietZone = 0
+	}
+	return o
+}
+
+// Render 将 content 编码为二维码，并渲染为 format 格式的图片
+func Render(content string, format Format, opts Options) ([]byte, error) {
+	switch format {
+	case FormatPNG, "":
+		return PNG(content, opts)
+	case FormatSVG:
+		return SVG(content, opts)
+	}
+	return nil, fmt.Errorf("unsupported QR code image format `%s`", string(format))
+}
+
+// PNG 将 content 编码为二维码，并渲染为 PNG 图片
+func PNG(content string, opts Options) ([]byte, error) {
+	q, err := Encode(content, opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err = encoder.Encode(&buf, q.Image(opts)); err != nil {
+		return nil, fmt.Errorf("encode QR code png err:%s", err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG 将 content 编码为二维码，并渲染为 SVG 图片
+func SVG(content string, opts Options) ([]byte, error) {
+	q, err := Encode(content, opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	return q.SVG(opts), nil
+}
+
+// layout 计算每个模块的像素数与图片边长
+func (q *QRCode) layout(opts Options) (scale, margin, size int) {
+	modules := q.size + 2*opts.QuietZone
+	scale = opts.Size / modules
+	if scale < 1 {
+		scale = 1
+	}
+	size = opts.Size
+	if size < modules*scale {
+		size = modules * scale
+	}
+	margin = (size-modules*scale)/2 + opts.QuietZone*scale
+	return scale, margin, size
+}
+
+// Image 按 opts 渲染二维码，opts.Level 被忽略
+func (q *QRCode) Image(opts Options) image.Image {
+	opts = opts.withDefaults()
+	scale, margin, size := q.layout(opts)
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				row := img.Pix[(margin+y*scale+dy)*img.Stride:]
+				for dx := 0; dx < scale; dx++ {
+					row[margin+x*scale+dx] = 1
+				}
+			}
+		}
+	}
+	return img
+}
+
+// SVG 按 opts 渲染二维码为 SVG 图片，opts.Level 被忽略
+//
+// 图片使用模块坐标作为 viewBox，可以无损缩放
+func (q *QRCode) SVG(opts Options) []byte {
+	opts = opts.withDefaults()
+	scale, margin, size := q.layout(opts)
+	viewBox := float64(size) / float64(scale)
+	offset := float64(margin) / float64(scale)
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %g %g" shape-rendering="crispEdges">`+"\n",
+		size, size, viewBox, viewBox,
+	)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="#FFFFFF"/>`+"\n")
+	buf.WriteString(`<path fill="#000000" d="`)
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				fmt.Fprintf(&buf, "M%g,%gh1v1h-1z", float64(x)+offset, float64(y)+offset)
+			}
+		}
+	}
+	buf.WriteString(`"/>` + "\n</svg>\n")
+	return buf.Bytes()
+}
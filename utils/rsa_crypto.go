@@ -3,6 +3,7 @@
 package utils
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
@@ -77,6 +78,24 @@ func DecryptOAEP(ciphertext string, privateKey *rsa.PrivateKey) (message string,
 	return string(messageBytes), nil
 }
 
+// DecryptOAEPWithDecrypter 使用 crypto.Decrypter 对OAEP-sha1加密的字符串进行解密
+//
+// 私钥可以保存在 KMS/HSM 等外部服务中，只需要提供实现了 crypto.Decrypter 的 RSA 私钥句柄
+func DecryptOAEPWithDecrypter(ciphertext string, decrypter crypto.Decrypter) (message string, err error) {
+	if decrypter == nil {
+		return "", fmt.Errorf("you should input crypto.Decrypter")
+	}
+	decodedCiphertext, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("base64 decode failed, error=%s", err.Error())
+	}
+	messageBytes, err := decrypter.Decrypt(rand.Reader, decodedCiphertext, &rsa.OAEPOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return "", fmt.Errorf("decrypt ciphertext with decrypter err:%s", err)
+	}
+	return string(messageBytes), nil
+}
+
 // DecryptPKCS1v15 使用私钥对PKCS1 padding方式加密的字符串进行解密
 func DecryptPKCS1v15(ciphertext string, privateKey *rsa.PrivateKey) (message string, err error) {
 	if privateKey == nil {
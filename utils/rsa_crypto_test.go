@@ -117,6 +117,11 @@ func TestOAEPCrypto(t *testing.T) {
 	decryptMessage, err := DecryptOAEP(ciphertext, testRSACryptoUtilPrivateKey)
 	require.NoError(t, err)
 	assert.Equal(t, message, decryptMessage)
+
+	// 使用OAEP padding方式通过 crypto.Decrypter 解密
+	decryptMessage, err = DecryptOAEPWithDecrypter(ciphertext, testRSACryptoUtilPrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, message, decryptMessage)
 }
 
 func TestPKCS1v15Crypto(t *testing.T) {
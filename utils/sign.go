@@ -6,6 +6,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 )
@@ -27,3 +28,21 @@ func SignSHA256WithRSA(source string, privateKey *rsa.PrivateKey) (signature str
 	}
 	return base64.StdEncoding.EncodeToString(signatureByte), nil
 }
+
+// SignSHA256WithSigner 通过 crypto.Signer 对字符串以 SHA256WithRSA 算法生成签名信息
+//
+// 私钥可以保存在 KMS/HSM 等外部服务中，只需要提供实现了 crypto.Signer 的 RSA 私钥句柄
+func SignSHA256WithSigner(source string, signer crypto.Signer) (signature string, err error) {
+	if signer == nil {
+		return "", fmt.Errorf("signer should not be nil")
+	}
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", fmt.Errorf("signer should hold a rsa private key")
+	}
+	hashed := sha256.Sum256([]byte(source))
+	signatureByte, err := signer.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signatureByte), nil
+}
@@ -106,3 +106,17 @@ func TestSha256WithRsa(t *testing.T) {
 		})
 	}
 }
+
+func TestSignSHA256WithSigner(t *testing.T) {
+	gotSignature, err := SignSHA256WithSigner("source", testAlgorithmPrivateKey)
+	if err != nil {
+		t.Fatalf("SignSHA256WithSigner() error = %v", err)
+	}
+	if gotSignature != testAlgorithmExpectSignature {
+		t.Errorf("SignSHA256WithSigner() gotSignature = %v, want %v", gotSignature, testAlgorithmExpectSignature)
+	}
+
+	if _, err = SignSHA256WithSigner("source", nil); err == nil {
+		t.Errorf("SignSHA256WithSigner() with nil signer should return error")
+	}
+}